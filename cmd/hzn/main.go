@@ -27,14 +27,18 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/config"
 	"github.com/hashicorp/horizon/pkg/control"
+	"github.com/hashicorp/horizon/pkg/data"
 	"github.com/hashicorp/horizon/pkg/discovery"
 	"github.com/hashicorp/horizon/pkg/grpc/lz4"
 	grpctoken "github.com/hashicorp/horizon/pkg/grpc/token"
 	"github.com/hashicorp/horizon/pkg/hub"
+	"github.com/hashicorp/horizon/pkg/netloc"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/periodic"
+	"github.com/hashicorp/horizon/pkg/proxyproto"
 	"github.com/hashicorp/horizon/pkg/tlsmanage"
 	"github.com/hashicorp/horizon/pkg/utils"
+	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/horizon/pkg/workq"
 	"github.com/hashicorp/vault/api"
 	"github.com/jinzhu/gorm"
@@ -239,11 +243,23 @@ func (c *controlServer) Run(args []string) int {
 
 	sess := session.New()
 
+	storeType := os.Getenv("OBJECT_STORE")
+
 	bucket := os.Getenv("S3_BUCKET")
-	if bucket == "" {
+	if bucket == "" && (storeType == "" || storeType == control.ObjectStoreS3) {
 		log.Fatal("S3_BUCKET not set")
 	}
 
+	store, err := control.NewObjectStore(control.ObjectStoreConfig{
+		Type:    storeType,
+		Session: sess,
+		Bucket:  bucket,
+		Path:    os.Getenv("OBJECT_STORE_PATH"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	domain := os.Getenv("HUB_DOMAIN")
 	if domain == "" {
 		log.Fatal("missing HUB_DOMAIN")
@@ -282,6 +298,7 @@ func (c *controlServer) Run(args []string) int {
 	}
 
 	asnDB := os.Getenv("ASN_DB_PATH")
+	geoDB := os.Getenv("GEO_DB_PATH")
 
 	hubAccess := os.Getenv("HUB_ACCESS_KEY")
 	hubSecret := os.Getenv("HUB_SECRET_KEY")
@@ -303,6 +320,25 @@ func (c *controlServer) Run(args []string) int {
 		log.Fatal(err)
 	}
 
+	var peers []string
+
+	if str := os.Getenv("ACTIVITY_PEERS"); str != "" {
+		peers = strings.Split(str, ",")
+	}
+
+	bus, err := control.NewActivityBus(ctx, control.ActivityBusConfig{
+		Type:     os.Getenv("ACTIVITY_BUS"),
+		DB:       db,
+		ConnInfo: url,
+		Peers:    peers,
+		Token:    os.Getenv("ACTIVITY_TOKEN"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer bus.Close()
+
 	s, err := control.NewServer(control.ServerConfig{
 		Logger: L,
 		DB:     db,
@@ -314,25 +350,38 @@ func (c *controlServer) Run(args []string) int {
 		VaultPath:   "hzn-k1",
 		KeyId:       "k1",
 
-		AwsSession: sess,
-		Bucket:     bucket,
+		ObjectStore: store,
+		AwsSession:  sess,
+		Bucket:      bucket,
 
 		ASNDB: asnDB,
+		GeoDB: geoDB,
 
 		HubAccessKey: hubAccess,
 		HubSecretKey: hubSecret,
 		HubImageTag:  hubTag,
 		LockManager:  lm,
+		ActivityBus:  bus,
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	err = s.StartActivityReader(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Setup cleanup activities
-	lc := &control.LogCleaner{DB: config.DB()}
+	lc := &control.LogCleaner{Bus: bus}
 	workq.RegisterHandler("cleanup-activity-log", lc.CleanupActivityLog)
 	workq.RegisterPeriodicJob("cleanup-activity-log", "default", "cleanup-activity-log", nil, time.Hour)
 
+	workq.RegisterHandler(control.WebhookJobType, s.DeliverWebhook)
+
+	workq.RegisterHandler(control.AgentSweepJobType, s.SweepAgents)
+	workq.RegisterPeriodicJob(control.AgentSweepJobType, "default", control.AgentSweepJobType, nil, time.Minute)
+
 	hubDomain := domain
 	if strings.HasPrefix(hubDomain, "*.") {
 		hubDomain = hubDomain[2:]
@@ -445,6 +494,16 @@ func (h *hubRunner) Run(args []string) int {
 
 	httpPort := os.Getenv("HTTP_PORT")
 
+	// Set when the hub is behind a load balancer that sends PROXY protocol
+	// headers, so that the real address of clients can be passed on.
+	proxyProtocol := os.Getenv("PROXY_PROTOCOL") != ""
+
+	adminPort := os.Getenv("ADMIN_PORT")
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	if adminPort != "" && adminToken == "" {
+		log.Fatal("missing ADMIN_TOKEN, required when ADMIN_PORT is set")
+	}
+
 	ctx := hclog.WithContext(context.Background(), L)
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -525,6 +584,50 @@ func (h *hubRunner) Run(args []string) int {
 		}
 	}
 
+	// By default the hub reads routing data from the S3 bucket that
+	// control advertises, but it can be pointed at another store, such as a
+	// directory shared with a single node control server.
+	var store control.ObjectStore
+
+	if storeType := os.Getenv("OBJECT_STORE"); storeType != "" && storeType != control.ObjectStoreS3 {
+		store, err = control.NewObjectStore(control.ObjectStoreConfig{
+			Type: storeType,
+			Path: os.Getenv("OBJECT_STORE_PATH"),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Persisting the routing data lets the hub restart and continue to route
+	// traffic while central is unavailable.
+	var (
+		snapshots   control.SnapshotStore
+		secretsPath string
+	)
+
+	if dataPath := os.Getenv("DATA_PATH"); dataPath != "" {
+		L.Info("persisting routing snapshots", "path", dataPath)
+
+		bolt, err := data.NewBolt(dataPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		snapshots = bolt
+
+		// The config holds the hub's TLS key and credentials, so it's kept
+		// out of the data file, in one only the hub can read.
+		secretsPath = dataPath + ".secrets"
+	}
+
+	// Which cloud metadata to learn the hub's locations from, such as gcp
+	// or file=/etc/hzn/location.env. Detected automatically if not set.
+	providers, err := netloc.ParseProviders(os.Getenv("CLOUD_METADATA"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	client, err := control.NewClient(ctx, control.ClientConfig{
 		Id:           id,
 		InstanceId:   instanceId,
@@ -534,6 +637,13 @@ func (h *hubRunner) Run(args []string) int {
 		WorkDir:      tmpdir,
 		K8Deployment: deployment,
 		FilterRoute:  filter,
+		ObjectStore:  store,
+		Snapshots:    snapshots,
+		SecretsPath:  secretsPath,
+
+		ProxyProtocol: proxyProtocol,
+
+		LocationProviders: providers,
 	})
 
 	if deployment != "" {
@@ -598,13 +708,42 @@ func (h *hubRunner) Run(args []string) int {
 		log.Fatal(err)
 	}
 
+	if str := os.Getenv("COMPRESSION"); str != "" {
+		cs, err := wire.ParseCompression(str)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		hb.SetCompression(cs)
+	}
+
 	for _, loc := range locs {
 		L.Info("learned network location", "labels", loc.Labels, "addresses", loc.Addresses)
 	}
 
 	if httpPort != "" {
 		L.Info("listen on http", "port", httpPort)
-		go hb.ListenHTTP(":" + httpPort)
+
+		hln, err := net.Listen("tcp", ":"+httpPort)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if proxyProtocol {
+			hln = proxyproto.NewListener(hln)
+		}
+
+		go hb.ServeCleartext(hln)
+	}
+
+	if adminPort != "" {
+		L.Info("listen on admin", "port", adminPort)
+		go func() {
+			err := hb.ListenAdmin(":"+adminPort, adminToken)
+			if err != nil {
+				L.Error("error serving admin api", "error", err)
+			}
+		}()
 	}
 
 	go StartHealthz(L)
@@ -661,22 +800,41 @@ func (c *devServer) Run(args []string) int {
 		log.Fatal(err)
 	}
 
-	sess := session.New(aws.NewConfig().
-		WithEndpoint("http://localhost:4566").
-		WithRegion("us-east-1").
-		WithCredentials(credentials.NewStaticCredentials("hzn", "hzn", "hzn")).
-		WithS3ForcePathStyle(true),
-	)
+	var store control.ObjectStore
+
+	storeType := os.Getenv("OBJECT_STORE")
+	switch storeType {
+	case "", control.ObjectStoreMemory:
+		L.Info("using in-memory object store")
+		store = control.NewMemObjectStore()
+	case control.ObjectStoreS3:
+		sess := session.New(aws.NewConfig().
+			WithEndpoint("http://localhost:4566").
+			WithRegion("us-east-1").
+			WithCredentials(credentials.NewStaticCredentials("hzn", "hzn", "hzn")).
+			WithS3ForcePathStyle(true),
+		)
+
+		bucket := os.Getenv("S3_BUCKET")
+		if bucket == "" {
+			bucket = "hzn-dev"
+			L.Info("using hzn-dev as the S3 bucket")
+		}
 
-	bucket := os.Getenv("S3_BUCKET")
-	if bucket == "" {
-		bucket = "hzn-dev"
-		L.Info("using hzn-dev as the S3 bucket")
-	}
+		s3.New(sess).CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
 
-	s3.New(sess).CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucket),
-	})
+		store = control.NewS3ObjectStore(sess, bucket)
+	default:
+		store, err = control.NewObjectStore(control.ObjectStoreConfig{
+			Type: storeType,
+			Path: os.Getenv("OBJECT_STORE_PATH"),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	domain := os.Getenv("HUB_DOMAIN")
 	if domain == "" {
@@ -710,8 +868,8 @@ func (c *devServer) Run(args []string) int {
 		VaultPath:   "hzn-dev",
 		KeyId:       "dev",
 
-		AwsSession: sess,
-		Bucket:     bucket,
+		ObjectStore: store,
+		ActivityBus: control.NewMemActivityBus(0),
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -764,6 +922,11 @@ func (c *devServer) Run(args []string) int {
 
 	L.Info("starting background worker")
 
+	workq.RegisterHandler(control.WebhookJobType, s.DeliverWebhook)
+
+	workq.RegisterHandler(control.AgentSweepJobType, s.SweepAgents)
+	workq.RegisterPeriodicJob(control.AgentSweepJobType, "default", control.AgentSweepJobType, nil, time.Minute)
+
 	workq.GlobalRegistry.PrintHandlers(L)
 
 	worker := workq.NewWorker(L, db, []string{"default"})
@@ -829,7 +992,7 @@ func (c *devServer) Run(args []string) int {
 	ioutil.WriteFile("dev-agent-id.txt", []byte(accountId.String()), 0644)
 	ioutil.WriteFile("dev-agent-token.txt", []byte(agentToken.Token), 0644)
 
-	go c.RunHub(ctx, ctr.Token, "localhost:24401", sess, bucket)
+	go c.RunHub(ctx, ctr.Token, "localhost:24401", store)
 	err = hs.ListenAndServe()
 	if err != nil {
 		log.Fatal(err)
@@ -840,7 +1003,7 @@ func (c *devServer) Run(args []string) int {
 
 const devHub = "01ECNJBS294ESNMG913SVX893F"
 
-func (h *devServer) RunHub(ctx context.Context, token, addr string, sess *session.Session, bucket string) int {
+func (h *devServer) RunHub(ctx context.Context, token, addr string, store control.ObjectStore) int {
 	L := hclog.L().Named("hub")
 
 	if os.Getenv("DEBUG") != "" {
@@ -886,14 +1049,13 @@ func (h *devServer) RunHub(ctx context.Context, token, addr string, sess *sessio
 	gClient := pb.NewControlServicesClient(gcc)
 
 	client, err := control.NewClient(ctx, control.ClientConfig{
-		Id:       id,
-		Token:    token,
-		Version:  "test",
-		Client:   gClient,
-		WorkDir:  tmpdir,
-		Session:  sess,
-		S3Bucket: bucket,
-		Insecure: true,
+		Id:          id,
+		Token:       token,
+		Version:     "test",
+		Client:      gClient,
+		WorkDir:     tmpdir,
+		ObjectStore: store,
+		Insecure:    true,
 	})
 
 	defer client.Close(ctx)
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/agent"
+	"github.com/hashicorp/horizon/pkg/agentconfig"
+	"github.com/hashicorp/horizon/pkg/discovery"
+)
+
+// runConfig runs an agent declared by the configuration file at path. On
+// SIGHUP the file is read again and the services are updated to match it.
+func (a *agentRunner) runConfig(ctx context.Context, L hclog.Logger, path string) int {
+	cfg, err := agentconfig.Parse(path)
+	if err != nil {
+		L.Error("error loading config", "error", err)
+		return 1
+	}
+
+	g, err := agent.NewAgent(L.Named("agent"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	token := *a.fToken
+	if token == "" {
+		token, err = cfg.ReadToken()
+		if err != nil {
+			L.Error("error loading token", "error", err)
+			return 1
+		}
+	}
+
+	g.Token = Token(&token)
+	g.Labels = cfg.Labels
+
+	set := agentconfig.NewServiceSet(L, g)
+
+	err = set.Apply(cfg.Services)
+	if err != nil {
+		L.Error("error configuring services", "error", err)
+		return 1
+	}
+
+	if len(cfg.Services) == 0 {
+		L.Warn("no services defined, waiting for them to be added by a reload")
+	}
+
+	var hcp discovery.HubConfigProvider
+
+	if len(cfg.Hubs) > 0 {
+		var hubs []discovery.HubConfig
+
+		for _, hub := range cfg.Hubs {
+			hubs = append(hubs, discovery.HubConfig{
+				Addr:     hub.Address,
+				Name:     hub.Name,
+				Insecure: hub.Insecure,
+			})
+		}
+
+		hcp = discovery.HubConfigs(hubs...)
+	} else {
+		control := cfg.Control
+		if control == "" {
+			control = *a.fControl
+		}
+
+		L.Debug("discovering hubs")
+
+		dc, err := discovery.NewClient(control)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		dc.PublicKey, err = cfg.PublicKey()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if dc.PublicKey == nil {
+			dc.PublicKey = ControlKey()
+		}
+
+		L.Debug("refreshing data")
+
+		err = dc.Refresh(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		hcp = dc
+	}
+
+	err = g.Start(ctx, hcp)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+
+	go func() {
+		for range sigs {
+			L.Info("reloading config", "path", path)
+
+			newcfg, err := agentconfig.Parse(path)
+			if err != nil {
+				L.Error("error loading config, keeping current config", "error", err)
+				continue
+			}
+
+			// These are part of the session preamble or the hub discovery,
+			// so they can't be changed on a running agent.
+			if newcfg.Control != cfg.Control ||
+				newcfg.Token != cfg.Token ||
+				newcfg.TokenFile != cfg.TokenFile ||
+				!reflect.DeepEqual(newcfg.Hubs, cfg.Hubs) ||
+				!reflect.DeepEqual(newcfg.Labels, cfg.Labels) {
+				L.Warn("changes to hubs, token or agent labels require a restart, ignoring them")
+			}
+
+			err = set.Apply(newcfg.Services)
+			if err != nil {
+				L.Error("error applying services", "error", err)
+			}
+		}
+	}()
+
+	L.Info("agent running", "services", len(cfg.Services))
+
+	err = g.Wait(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return 0
+}
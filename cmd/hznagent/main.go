@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,11 +13,13 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/agent"
 	"github.com/hashicorp/horizon/pkg/discovery"
 	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/mitchellh/cli"
 	"github.com/spf13/pflag"
 )
@@ -78,6 +82,22 @@ func main() {
 	os.Exit(exitStatus)
 }
 
+// ControlKey returns the key the control plane signs tokens with, read from
+// HORIZON_CONTROL_KEY. The discovery data is only verified if it's set.
+func ControlKey() ed25519.PublicKey {
+	str := os.Getenv("HORIZON_CONTROL_KEY")
+	if str == "" {
+		return nil
+	}
+
+	key, err := hex.DecodeString(str)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		log.Fatalln("invalid key in HORIZON_CONTROL_KEY")
+	}
+
+	return key
+}
+
 func Token(flag *string) string {
 	token := *flag
 	if token != "" {
@@ -164,6 +184,8 @@ func (c *proxyRunner) Run(args []string) int {
 		log.Fatal(err)
 	}
 
+	dc.PublicKey = ControlKey()
+
 	L.Debug("refreshing data")
 
 	ctx := context.Background()
@@ -276,20 +298,50 @@ type agentRunner struct {
 	flags    *pflag.FlagSet
 	fControl *string
 	fToken   *string
+	fConfig  *string
 	fHTTP    *string
+	fH2C     *bool
 	fLabels  *string
 	fTCP     *string
+	fUnix    *string
+	fStatic  *string
 	fVerbose *int
+
+	fCompression *string
+
+	fHTTPCA              *string
+	fHTTPCert            *string
+	fHTTPKey             *string
+	fHTTPServerName      *string
+	fHTTPInsecure        *bool
+	fHTTPMaxIdle         *int
+	fHTTPIdleTimeout     *time.Duration
+	fHTTPDialTimeout     *time.Duration
+	fHTTPResponseTimeout *time.Duration
 }
 
 func (a *agentRunner) init() error {
 	a.flags = pflag.NewFlagSet("agent", pflag.ExitOnError)
 	a.fControl = a.flags.String("control", "control.alpha.hzn.network", "address of control plane")
 	a.fToken = a.flags.String("token", "", "authentication token")
+	a.fConfig = a.flags.StringP("config", "c", "", "configuration file declaring the services to advertise")
 	a.fLabels = a.flags.StringP("labels", "l", "", "labels to associate with service")
 	a.fTCP = a.flags.String("tcp", "", "address of tcp server to advertise")
 	a.fHTTP = a.flags.String("http", "", "address to forward http traffic to")
+	a.fUnix = a.flags.String("unix", "", "path of a unix socket to forward http traffic and connections to")
+	a.fStatic = a.flags.String("static", "", "directory of files to serve over http")
+	a.fH2C = a.flags.Bool("h2c", false, "use HTTP/2 without TLS to talk to the http service")
+	a.fHTTPCA = a.flags.String("http-ca", "", "PEM file of CAs to verify an https service with")
+	a.fHTTPCert = a.flags.String("http-cert", "", "client certificate to present to an https service")
+	a.fHTTPKey = a.flags.String("http-key", "", "key for the client certificate")
+	a.fHTTPServerName = a.flags.String("http-server-name", "", "server name to use for SNI and verification of an https service")
+	a.fHTTPInsecure = a.flags.Bool("http-insecure", false, "don't verify the certificate of an https service (dev only)")
+	a.fHTTPMaxIdle = a.flags.Int("http-max-idle", 100, "maximum idle connections to keep to the http service")
+	a.fHTTPIdleTimeout = a.flags.Duration("http-idle-timeout", 90*time.Second, "how long to keep idle connections to the http service")
+	a.fHTTPDialTimeout = a.flags.Duration("http-dial-timeout", 30*time.Second, "how long to wait to connect to the http service")
+	a.fHTTPResponseTimeout = a.flags.Duration("http-response-timeout", 0, "how long to wait for response headers from the http service")
 	a.fVerbose = a.flags.CountP("verbose", "v", "increase verbosity of output")
+	a.fCompression = a.flags.String("compression", "", "compression codecs to offer the hub, most preferred first, with optional levels (ie, zstd:3,lz4)")
 
 	return nil
 }
@@ -323,6 +375,10 @@ func (a *agentRunner) Run(args []string) int {
 
 	ctx := hclog.WithContext(context.Background(), L)
 
+	if *a.fConfig != "" {
+		return a.runConfig(ctx, L, *a.fConfig)
+	}
+
 	L.Debug("starting agent")
 
 	g, err := agent.NewAgent(L.Named("agent"))
@@ -332,24 +388,63 @@ func (a *agentRunner) Run(args []string) int {
 
 	g.Token = Token(a.fToken)
 
+	if *a.fCompression != "" {
+		g.Compression, err = wire.ParseCompression(*a.fCompression)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	var setup bool
 
 	if *a.fHTTP != "" {
 		target := *a.fHTTP
+
+		scheme := "http://"
+
+		switch {
+		case strings.HasPrefix(target, "https://"):
+			scheme = "https://"
+			target = strings.TrimPrefix(target, scheme)
+		case strings.HasPrefix(target, "http://"):
+			target = strings.TrimPrefix(target, scheme)
+		}
+
 		if strings.IndexByte(target, ':') == -1 {
 			_, err := strconv.Atoi(target)
 			if err == nil {
 				target = "127.0.0.1:" + target
+			} else if scheme == "https://" {
+				target = target + ":443"
 			} else {
 				target = target + ":80"
 			}
 		}
 
-		L.Info("registered http service", "address", target)
+		target = scheme + target
+
+		handler, err := agent.NewHTTPHandler(agent.HTTPConfig{
+			URL:                   target,
+			H2C:                   *a.fH2C,
+			CACertFile:            *a.fHTTPCA,
+			ClientCertFile:        *a.fHTTPCert,
+			ClientKeyFile:         *a.fHTTPKey,
+			ServerName:            *a.fHTTPServerName,
+			InsecureSkipVerify:    *a.fHTTPInsecure,
+			MaxIdleConns:          *a.fHTTPMaxIdle,
+			IdleConnTimeout:       *a.fHTTPIdleTimeout,
+			DialTimeout:           *a.fHTTPDialTimeout,
+			ResponseHeaderTimeout: *a.fHTTPResponseTimeout,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		L.Info("registered http service", "address", target, "h2c", *a.fH2C)
 		_, err = g.AddService(&agent.Service{
 			Type:    "http",
 			Labels:  pb.ParseLabelSet(*a.fLabels),
-			Handler: agent.HTTPHandler("http://" + target),
+			Handler: handler,
 		})
 
 		if err != nil {
@@ -385,6 +480,36 @@ func (a *agentRunner) Run(args []string) int {
 		setup = true
 	}
 
+	if *a.fUnix != "" {
+		L.Info("registered unix service", "path", *a.fUnix)
+		_, err = g.AddService(&agent.Service{
+			Type:    "unix",
+			Labels:  pb.ParseLabelSet(*a.fLabels),
+			Handler: agent.UnixHandler(*a.fUnix),
+		})
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		setup = true
+	}
+
+	if *a.fStatic != "" {
+		L.Info("registered static service", "dir", *a.fStatic)
+		_, err = g.AddService(&agent.Service{
+			Type:    "http",
+			Labels:  pb.ParseLabelSet(*a.fLabels),
+			Handler: agent.StaticHandler(*a.fStatic),
+		})
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		setup = true
+	}
+
 	if !setup {
 		L.Error("no services defined therefore no reason to run")
 		return 1
@@ -397,6 +522,8 @@ func (a *agentRunner) Run(args []string) int {
 		log.Fatal(err)
 	}
 
+	dc.PublicKey = ControlKey()
+
 	L.Debug("refreshing data")
 
 	err = dc.Refresh(ctx)
@@ -88,6 +88,8 @@ func (c *pipeRunner) Run(args []string) int {
 		log.Fatal(err)
 	}
 
+	dc.PublicKey = ControlKey()
+
 	L.Debug("refreshing data")
 
 	ctx := context.Background()
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -45,6 +46,9 @@ func main() {
 		"create-agent-token": func() (cli.Command, error) {
 			return &agentTokenCreate{}, nil
 		},
+		"watch-services": func() (cli.Command, error) {
+			return &servicesWatch{}, nil
+		},
 	}
 
 	exitStatus, err := c.Run()
@@ -336,3 +340,70 @@ func (h *agentTokenCreate) Run(args []string) int {
 
 	return 0
 }
+
+type servicesWatch struct{}
+
+func (h *servicesWatch) Help() string {
+	return "Print services and label-links as they're added and removed"
+}
+
+func (h *servicesWatch) Synopsis() string {
+	return "Watch for service changes"
+}
+
+func (h *servicesWatch) Run(args []string) int {
+	fs := pflag.NewFlagSet("hznctl", pflag.ExitOnError)
+
+	addr := fs.String("control-addr", "127.0.0.1:24001", "Address of control server")
+	insecure := fs.Bool("insecure", false, "Whether or not to secure the grpc connection")
+	token := fs.String("token", "", "Token to authenticate with control server")
+	resume := fs.String("resume-token", "", "Resume token of the last event seen")
+
+	err := fs.Parse(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithPerRPCCredentials(grpctoken.Token(*token)),
+		grpc.WithDefaultCallOptions(grpc.UseCompressor(lz4.Name)),
+	}
+
+	if *insecure {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		creds := credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true,
+		})
+
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	}
+
+	gcc, err := grpc.Dial(*addr, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := pb.NewControlManagementClient(gcc)
+
+	stream, err := s.WatchServices(context.Background(), &pb.WatchServicesRequest{
+		ResumeToken: *resume,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		data, err := json.Marshal(ev)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(data))
+	}
+}
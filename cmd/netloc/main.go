@@ -10,8 +10,13 @@ import (
 )
 
 func main() {
+	providers, err := netloc.ParseProviders(os.Getenv("CLOUD_METADATA"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Fprintf(os.Stderr, "Gathering locations...\n")
-	locs, err := netloc.Locate(nil)
+	locs, err := netloc.LocateWith(nil, providers)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -19,6 +19,7 @@ require (
 	github.com/hashicorp/go-multierror v1.1.0
 	github.com/hashicorp/go-uuid v1.0.2 // indirect
 	github.com/hashicorp/golang-lru v0.5.3
+	github.com/hashicorp/hcl v1.0.0
 	github.com/hashicorp/vault/api v1.0.5-0.20190909201928-35325e2c3262
 	github.com/hashicorp/yamux v0.0.0-20190923154419-df201c70410d
 	github.com/imdario/mergo v0.3.8 // indirect
@@ -51,4 +52,5 @@ require (
 	gortc.io/stun v1.22.2
 	k8s.io/apimachinery v0.18.0
 	k8s.io/client-go v0.18.0
+	sigs.k8s.io/yaml v1.2.0
 )
@@ -5,7 +5,6 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
-	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -18,7 +17,7 @@ import (
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/yamux"
 	"github.com/oklog/ulid"
-	"github.com/pierrec/lz4/v3"
+	"github.com/pkg/errors"
 )
 
 type ServiceContext interface {
@@ -46,6 +45,12 @@ type serviceContext struct {
 	fr         *wire.FramingReader
 	stream     io.Writer
 
+	// The underlying stream and the agent that opened it, used by
+	// listeners.
+	ystream       *yamux.Stream
+	sourceAccount *pb.Account
+	sourceAgent   *pb.ULID
+
 	readHijack  bool
 	writeHijack bool
 }
@@ -107,6 +112,11 @@ type Service struct {
 
 	// The handler to invoke when the service is called.
 	Handler ServiceHandler
+
+	// The codec to compress connections to the service with, such as
+	// wire.CompressionNone for payloads that are already compressed. If not
+	// set, the codec agreed with the hub is used.
+	Compression string
 }
 
 type Agent struct {
@@ -123,14 +133,59 @@ type Agent struct {
 	// is not set, the defaults are used.
 	RootCAs *x509.CertPool
 
-	mu         sync.RWMutex
-	services   map[string]*Service
-	sessions   []*yamux.Session
-	activeHubs map[string]discovery.HubConfig
-	hcp        discovery.HubConfigProvider
+	// The compression codecs offered to hubs, most preferred first, and the
+	// level the agent compresses at with each. Defaults to
+	// wire.DefaultCompression.
+	Compression []wire.Compression
+
+	// How often the agent checks whether a hub with lower latency than
+	// the ones it's connected to has appeared, when the hub config
+	// provider can rank hubs. Zero disables rebalancing.
+	RebalanceInterval time.Duration
+
+	mu          sync.RWMutex
+	services    map[string]*Service
+	servicesGen int
+	sessions    []*yamux.Session
+	activeHubs  map[string]discovery.HubConfig
+	hcp         discovery.HubConfigProvider
+
+	// What was agreed with the hub of each session.
+	hubs map[*yamux.Session]*hubSession
 
 	statuses chan hubStatus
 	active   int
+
+	// Hubs being connected to in order to replace another, keyed by the
+	// address of the new hub, and the hubs that are being disconnected
+	// from once their replacement connected.
+	rebalanceTicker *time.Ticker
+	rebalancing     map[string]string
+	retiring        map[string]struct{}
+
+	// RPCs that hubs can make to the agent.
+	rpc wire.RPCServer
+}
+
+// hubSession is what the agent agreed with the hub of a session.
+type hubSession struct {
+	cfg         discovery.HubConfig
+	features    wire.FeatureSet
+	compression wire.Compression
+}
+
+// openCompressed prepares a stream opened on the session to be compressed
+// with the session's codec, or with codec if it's set and the hub can be
+// told about it.
+func (h *hubSession) openCompressed(stream *yamux.Stream, codec string) (*wire.CompressedStream, error) {
+	c := h.compression
+	header := h.features.Has(wire.FeatureStreamCompression)
+
+	if header && codec != "" {
+		c = c.WithCodec(codec)
+	}
+
+	return wire.OpenCompressedStream(stream, c, header)
 }
 
 type hubStatus struct {
@@ -149,11 +204,17 @@ func NewAgent(L hclog.Logger) (*Agent, error) {
 	cfg.LogOutput = nil
 
 	agent := &Agent{
-		L:          L,
-		cfg:        cfg,
-		services:   make(map[string]*Service),
-		statuses:   make(chan hubStatus),
-		activeHubs: make(map[string]discovery.HubConfig),
+		L:           L,
+		cfg:         cfg,
+		services:    make(map[string]*Service),
+		statuses:    make(chan hubStatus),
+		activeHubs:  make(map[string]discovery.HubConfig),
+		hubs:        make(map[*yamux.Session]*hubSession),
+		Compression: wire.DefaultCompression,
+
+		RebalanceInterval: time.Minute,
+		rebalancing:       make(map[string]string),
+		retiring:          make(map[string]struct{}),
 	}
 
 	return agent, nil
@@ -161,14 +222,159 @@ func NewAgent(L hclog.Logger) (*Agent, error) {
 
 var mread = ulid.Monotonic(rand.Reader, 1)
 
+// AddService begins advertising serv. If the agent is already connected
+// to hubs, they are told about the service over the existing sessions.
 func (a *Agent) AddService(serv *Service) (*pb.ULID, error) {
+	if serv.Compression != "" && !wire.KnownCodec(serv.Compression) {
+		return nil, errors.Wrapf(wire.ErrUnknownCodec, "codec: %s", serv.Compression)
+	}
+
 	a.mu.Lock()
-	defer a.mu.Unlock()
 
 	serv.Id = pb.NewULID()
 
 	a.services[serv.Id.SpecString()] = serv
-	return serv.Id, nil
+	a.servicesGen++
+
+	a.mu.Unlock()
+
+	err := a.updateServices(&pb.ServicesUpdate{
+		Added: []*pb.ServiceInfo{serv.info()},
+	})
+
+	return serv.Id, err
+}
+
+// RemoveService stops advertising the service with the given id. If the
+// agent is already connected to hubs, they are told to stop routing to the
+// service over the existing sessions.
+func (a *Agent) RemoveService(id *pb.ULID) error {
+	a.mu.Lock()
+
+	key := id.SpecString()
+
+	if _, ok := a.services[key]; !ok {
+		a.mu.Unlock()
+		return errors.Wrapf(ErrUnknownService, "service: %s", key)
+	}
+
+	delete(a.services, key)
+	a.servicesGen++
+
+	a.mu.Unlock()
+
+	return a.updateServices(&pb.ServicesUpdate{
+		Removed: []*pb.ULID{id},
+	})
+}
+
+func (s *Service) info() *pb.ServiceInfo {
+	var md []*pb.KVPair
+
+	for k, v := range s.Metadata {
+		md = append(md, &pb.KVPair{Key: k, Value: v})
+	}
+
+	return &pb.ServiceInfo{
+		ServiceId:   s.Id,
+		Type:        s.Type,
+		Metadata:    md,
+		Labels:      s.Labels,
+		Compression: s.Compression,
+	}
+}
+
+// updateServices sends upd to every hub the agent is connected to. A session
+// that can't be updated, including those to hubs that don't support
+// services updates, is closed so that the agent reconnects and advertises
+// it's current services in the preamble instead.
+func (a *Agent) updateServices(upd *pb.ServicesUpdate) error {
+	a.mu.RLock()
+	sessions := make([]*yamux.Session, len(a.sessions))
+	copy(sessions, a.sessions)
+
+	hubs := make(map[*yamux.Session]*hubSession)
+
+	for _, session := range sessions {
+		hubs[session] = a.hubs[session]
+	}
+
+	a.mu.RUnlock()
+
+	var retErr error
+
+	for _, session := range sessions {
+		if !hubs[session].features.Has(wire.FeatureServicesUpdate) {
+			a.L.Info("hub does not support services updates, reconnecting")
+			session.Close()
+			continue
+		}
+
+		err := a.sendServicesUpdate(session, hubs[session], upd)
+		if err != nil {
+			a.L.Error("error updating services on hub, reconnecting", "error", err)
+			session.Close()
+
+			if retErr == nil {
+				retErr = err
+			}
+		}
+	}
+
+	return retErr
+}
+
+func (a *Agent) sendServicesUpdate(session *yamux.Session, hs *hubSession, upd *pb.ServicesUpdate) error {
+	stream, err := session.OpenStream()
+	if err != nil {
+		return errors.Wrapf(err, "error opening new yamux stream")
+	}
+
+	defer stream.Close()
+
+	stream.SetDeadline(time.Now().Add(30 * time.Second))
+
+	cs, err := hs.openCompressed(stream, "")
+	if err != nil {
+		return err
+	}
+
+	defer cs.Close()
+
+	fw, err := wire.NewFramingWriter(cs)
+	if err != nil {
+		return err
+	}
+
+	defer fw.Recycle()
+
+	fr, err := wire.NewFramingReader(cs)
+	if err != nil {
+		return err
+	}
+
+	defer fr.Recycle()
+
+	_, err = fw.WriteMarshal(2, upd)
+	if err != nil {
+		return errors.Wrapf(err, "error writing services update")
+	}
+
+	var resp pb.Response
+
+	tag, _, err := fr.ReadMarshal(&resp)
+	if err != nil {
+		return errors.Wrapf(err, "error reading services update response")
+	}
+
+	switch tag {
+	case 1:
+		return nil
+	case 255:
+		return fmt.Errorf("hub rejected services update: %s", resp.Error)
+	default:
+		return ErrProtocolError
+	}
 }
 
 func (a *Agent) Run(ctx context.Context, hcp discovery.HubConfigProvider) error {
@@ -183,6 +389,10 @@ func (a *Agent) Run(ctx context.Context, hcp discovery.HubConfigProvider) error
 func (a *Agent) Start(ctx context.Context, hcp discovery.HubConfigProvider) error {
 	a.hcp = hcp
 
+	if _, ok := hcp.(discovery.HubRanker); ok && a.RebalanceInterval > 0 {
+		a.rebalanceTicker = time.NewTicker(a.RebalanceInterval)
+	}
+
 	for i := 0; i < 5; i++ {
 		cfg, ok := hcp.Take(ctx)
 		if ok {
@@ -206,16 +416,38 @@ func (a *Agent) Start(ctx context.Context, hcp discovery.HubConfigProvider) erro
 }
 
 func (a *Agent) processStatus(ctx context.Context) (bool, error) {
+	var rebalance <-chan time.Time
+	if a.rebalanceTicker != nil {
+		rebalance = a.rebalanceTicker.C
+	}
+
 	select {
 	case <-ctx.Done():
 		return false, ctx.Err()
+	case <-rebalance:
+		a.rebalance(ctx)
+		return false, nil
 	case stat := <-a.statuses:
 		if !stat.connected {
 			a.active--
 			a.L.Warn("disconnected from hub", "error", stat.err, "addr", stat.cfg.Addr)
 
+			delete(a.activeHubs, stat.cfg.Addr)
+
 			a.hcp.Return(stat.cfg)
 
+			// The hub was only connected to in order to replace another,
+			// which is still connected, or it was being replaced.
+			if _, ok := a.rebalancing[stat.cfg.Addr]; ok {
+				delete(a.rebalancing, stat.cfg.Addr)
+				return false, nil
+			}
+
+			if _, ok := a.retiring[stat.cfg.Addr]; ok {
+				delete(a.retiring, stat.cfg.Addr)
+				return false, nil
+			}
+
 			newcfg, ok := a.hcp.Take(ctx)
 			if ok {
 				// If we returned the config and got the same one, don't spam
@@ -236,12 +468,24 @@ func (a *Agent) processStatus(ctx context.Context) (bool, error) {
 		} else {
 			a.active++
 			a.L.Debug("connected to hub", "addr", stat.cfg.Addr)
+
+			a.activeHubs[stat.cfg.Addr] = stat.cfg
+
+			if old, ok := a.rebalancing[stat.cfg.Addr]; ok {
+				delete(a.rebalancing, stat.cfg.Addr)
+				a.retireHub(old)
+			}
+
 			return true, nil
 		}
 	}
 }
 
 func (a *Agent) Wait(ctx context.Context) error {
+	if a.rebalanceTicker != nil {
+		defer a.rebalanceTicker.Stop()
+	}
+
 	var err error
 
 	for err == nil {
@@ -284,13 +528,23 @@ func (a *Agent) connectToHub(ctx context.Context, hub discovery.HubConfig, statu
 	}
 
 	clientTlsConfig.ServerName = hub.Name
+	clientTlsConfig.VerifyPeerCertificate = hub.VerifyPinnedCert
 
-	conn, err := tls.Dial("tcp", hub.Addr, &clientTlsConfig)
+	tcp, err := hub.Dial(ctx, nil)
 	if err != nil {
 		status <- hubStatus{cfg: hub, err: err}
 		return
 	}
 
+	conn := tls.Client(tcp, &clientTlsConfig)
+
+	err = conn.Handshake()
+	if err != nil {
+		tcp.Close()
+		status <- hubStatus{cfg: hub, err: err}
+		return
+	}
+
 	err = a.Nego(ctx, a.L, conn, hub, status)
 	if err != nil {
 		status <- hubStatus{cfg: hub, err: err}
@@ -300,7 +554,11 @@ func (a *Agent) connectToHub(ctx context.Context, hub discovery.HubConfig, statu
 	status <- hubStatus{cfg: hub, connected: true}
 }
 
-var ErrProtocolError = errors.New("protocol error detected")
+var (
+	ErrProtocolError   = errors.New("protocol error detected")
+	ErrUnknownService  = errors.New("unknown service")
+	ErrServicesChanged = errors.New("services changed while connecting")
+)
 
 func (a *Agent) Nego(ctx context.Context, L hclog.Logger, conn net.Conn, hubCfg discovery.HubConfig, status chan hubStatus) error {
 	id := pb.NewULID()
@@ -321,23 +579,27 @@ func (a *Agent) Nego(ctx context.Context, L hclog.Logger, conn net.Conn, hubCfg
 	preamble.Token = a.Token
 	preamble.SessionId = id.String()
 	preamble.Labels = a.Labels
-	preamble.Compression = "lz4"
+	preamble.CompressionCodecs = wire.CodecNames(a.Compression)
+	preamble.ProtocolVersion = wire.ProtocolVersion
+	preamble.Features = wire.Features
+
+	// Hubs that predate codec negotiation only look for lz4 here.
+	for _, c := range a.Compression {
+		if c.Codec == wire.CompressionLZ4 {
+			preamble.Compression = wire.CompressionLZ4
+		}
+	}
 
-	for _, serv := range a.services {
-		var md []*pb.KVPair
+	a.mu.RLock()
 
-		for k, v := range serv.Metadata {
-			md = append(md, &pb.KVPair{Key: k, Value: v})
-		}
+	gen := a.servicesGen
 
-		preamble.Services = append(preamble.Services, &pb.ServiceInfo{
-			ServiceId: serv.Id,
-			Type:      serv.Type,
-			Metadata:  md,
-			Labels:    serv.Labels,
-		})
+	for _, serv := range a.services {
+		preamble.Services = append(preamble.Services, serv.info())
 	}
 
+	a.mu.RUnlock()
+
 	_, err = fw.WriteMarshal(1, &preamble)
 	if err != nil {
 		return err
@@ -355,11 +617,24 @@ func (a *Agent) Nego(ctx context.Context, L hclog.Logger, conn net.Conn, hubCfg
 		return ErrProtocolError
 	}
 
-	if wc.Status != "connected" {
+	switch wc.Status {
+	case "connected":
+		// ok
+	case "unsupported-version":
+		return errors.Wrapf(wire.ErrUnsupportedVersion,
+			"hub rejected connection: hub version %d, agent version %d",
+			wire.PeerVersion(wc.ProtocolVersion), wire.ProtocolVersion)
+	default:
 		return fmt.Errorf("hub rejected connection: %s", wc.Status)
 	}
 
-	useLZ4 := wc.Compression == "lz4"
+	compression := wire.NegotiateCompression(a.Compression, []string{wc.Compression})
+	if wc.Compression != "" && compression.Codec != wc.Compression {
+		return errors.Wrapf(wire.ErrUnknownCodec, "hub chose codec that wasn't offered: %s", wc.Compression)
+	}
+
+	// Hubs that predate versioning send no features, so none are used.
+	features := wire.NegotiateFeatures(wire.Features, wc.Features)
 
 	latency := time.Since(t)
 
@@ -383,16 +658,37 @@ func (a *Agent) Nego(ctx context.Context, L hclog.Logger, conn net.Conn, hubCfg
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	a.sessions = append(a.sessions, session)
+	// The services changed while we were connecting, so the update was never
+	// sent to this hub. Reconnect to send the current set.
+	if a.servicesGen != gen {
+		session.Close()
+		return ErrServicesChanged
+	}
 
-	L.Debug("connected successfully", "status", wc.Status, "latency", latency, "skew", skew)
+	hs := &hubSession{
+		cfg:         hubCfg,
+		features:    features,
+		compression: compression,
+	}
 
-	go a.watchSession(ctx, L, session, fr, hubCfg, status, useLZ4)
+	a.sessions = append(a.sessions, session)
+	a.hubs[session] = hs
+
+	L.Debug("connected successfully",
+		"status", wc.Status,
+		"latency", latency,
+		"skew", skew,
+		"version", wire.PeerVersion(wc.ProtocolVersion),
+		"features", features.List(),
+		"compression", compression.Codec,
+	)
+
+	go a.watchSession(ctx, L, session, fr, hubCfg, status, hs)
 
 	return nil
 }
 
-func (a *Agent) watchSession(ctx context.Context, L hclog.Logger, session *yamux.Session, fr *wire.FramingReader, hubCfg discovery.HubConfig, status chan hubStatus, useLZ4 bool) {
+func (a *Agent) watchSession(ctx context.Context, L hclog.Logger, session *yamux.Session, fr *wire.FramingReader, hubCfg discovery.HubConfig, status chan hubStatus, hs *hubSession) {
 	defer fr.Recycle()
 	defer func() {
 		status <- hubStatus{
@@ -402,6 +698,8 @@ func (a *Agent) watchSession(ctx context.Context, L hclog.Logger, session *yamux
 		a.mu.Lock()
 		defer a.mu.Unlock()
 
+		delete(a.hubs, session)
+
 		for i, sess := range a.sessions {
 			if sess == session {
 				a.sessions = append(a.sessions[:i], a.sessions[i+1:]...)
@@ -434,26 +732,24 @@ func (a *Agent) watchSession(ctx context.Context, L hclog.Logger, session *yamux
 			return
 		}
 
-		go a.handleStream(ctx, L, session, stream, useLZ4)
+		go a.handleStream(ctx, L, session, stream, hs)
 	}
 }
 
-func (a *Agent) handleStream(ctx context.Context, L hclog.Logger, session *yamux.Session, stream *yamux.Stream, useLZ4 bool) {
+func (a *Agent) handleStream(ctx context.Context, L hclog.Logger, session *yamux.Session, stream *yamux.Stream, hs *hubSession) {
 	defer stream.Close()
 
-	L.Trace("stream accepted", "id", stream.StreamID(), "lz4", useLZ4)
+	cs, err := wire.AcceptCompressedStream(stream, hs.compression, hs.features.Has(wire.FeatureStreamCompression))
+	if err != nil {
+		L.Error("error setting up stream compression", "error", err)
+		return
+	}
 
-	var (
-		r io.Reader = stream
-		w io.Writer = stream
-	)
+	defer cs.Close()
 
-	if useLZ4 {
-		r = lz4.NewReader(stream)
-		w = lz4.NewWriter(stream)
-	}
+	L.Trace("stream accepted", "id", stream.StreamID(), "compression", hs.compression.Codec)
 
-	fr, err := wire.NewFramingReader(r)
+	fr, err := wire.NewFramingReader(cs)
 	if err != nil {
 		L.Error("error creating frame reader", "error", err)
 		return
@@ -461,7 +757,7 @@ func (a *Agent) handleStream(ctx context.Context, L hclog.Logger, session *yamux
 
 	defer fr.Recycle()
 
-	fw, err := wire.NewFramingWriter(w)
+	fw, err := wire.NewFramingWriter(cs)
 	if err != nil {
 		L.Error("error creating framing writer", "error", err)
 		return
@@ -469,19 +765,47 @@ func (a *Agent) handleStream(ctx context.Context, L hclog.Logger, session *yamux
 
 	defer fw.Recycle()
 
-	var req pb.SessionIdentification
+	// The type of the request depends on the tag, so read the raw message
+	// first.
+	var raw wire.MarshalBytes
 
-	tag, _, err := fr.ReadMarshal(&req)
+	tag, _, err := fr.ReadMarshal(&raw)
 	if err != nil {
 		L.Error("error decoding request", "error", err)
 		return
 	}
 
-	if tag != 11 {
+	switch tag {
+	case 11:
+		// SessionIdentification, handled below
+	case wire.RPCRequestTag:
+		var req pb.RPCRequest
+
+		err = req.Unmarshal(raw)
+		if err != nil {
+			L.Error("error decoding rpc request", "error", err)
+			return
+		}
+
+		err = a.rpc.HandleRequest(ctx, L, wire.NewContext(nil, fr, fw), &req)
+		if err != nil {
+			L.Error("error handling rpc", "host", req.Host, "path", req.Path, "error", err)
+		}
+
+		return
+	default:
 		L.Error("incorrect message tag", "tag", tag)
 		return
 	}
 
+	var req pb.SessionIdentification
+
+	err = req.Unmarshal(raw)
+	if err != nil {
+		L.Error("error decoding request", "error", err)
+		return
+	}
+
 	targetService := req.ServiceId.SpecString()
 
 	a.mu.RLock()
@@ -506,10 +830,13 @@ func (a *Agent) handleStream(ctx context.Context, L hclog.Logger, session *yamux
 	}
 
 	sctx := &serviceContext{
-		Context:    wire.NewContext(nil, fr, fw),
-		protocolId: req.ProtocolId,
-		fr:         fr,
-		stream:     w,
+		Context:       wire.NewContext(nil, fr, fw),
+		protocolId:    req.ProtocolId,
+		fr:            fr,
+		stream:        cs,
+		ystream:       stream,
+		sourceAccount: req.SourceAccount,
+		sourceAgent:   req.SourceAgent,
 	}
 
 	err = serv.Handler.HandleRequest(ctx, L, sctx)
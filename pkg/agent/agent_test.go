@@ -3,6 +3,15 @@ package agent
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,6 +21,7 @@ import (
 	"github.com/hashicorp/horizon/pkg/discovery"
 	"github.com/hashicorp/horizon/pkg/hub"
 	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/testutils"
 	"github.com/hashicorp/horizon/pkg/testutils/central"
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/yamux"
@@ -161,4 +171,1112 @@ func TestAgent(t *testing.T) {
 			assert.Equal(t, []byte("hello hzn"), []byte(mb2))
 		})
 	})
+
+	t.Run("can add and remove services without reconnecting", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			L := hclog.New(&hclog.LoggerOptions{
+				Name:  "dev",
+				Level: hclog.Trace,
+			})
+
+			h, err := hub.NewHub(L.Named("hub"), setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			go func() {
+				err := h.Run(ctx, setup.ClientListener)
+				require.NoError(t, err)
+			}()
+
+			time.Sleep(time.Second)
+
+			agent, err := NewAgent(L.Named("agent"))
+			require.NoError(t, err)
+
+			agent.Token = setup.AgentToken
+
+			_, err = agent.AddService(&Service{
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("env=test,service=echo"),
+				Handler: EchoHandler(),
+			})
+			require.NoError(t, err)
+
+			err = agent.Start(ctx, discovery.HubConfigs(discovery.HubConfig{
+				Addr:     setup.HubAddr,
+				Insecure: true,
+			}))
+			require.NoError(t, err)
+
+			go agent.Wait(ctx)
+
+			time.Sleep(time.Second)
+
+			agent.mu.RLock()
+			session := agent.sessions[0]
+			agent.mu.RUnlock()
+
+			serviceId, err := agent.AddService(&Service{
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("env=test,service=echo2"),
+				Handler: EchoHandler(),
+			})
+			require.NoError(t, err)
+
+			var so control.Service
+			err = dbx.Check(setup.DB.Where("service_id = ?", serviceId.Bytes()).First(&so))
+			require.NoError(t, err)
+
+			err = agent.RemoveService(serviceId)
+			require.NoError(t, err)
+
+			var count int
+			err = dbx.Check(setup.DB.Model(&control.Service{}).Where("service_id = ?", serviceId.Bytes()).Count(&count))
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, count)
+
+			assert.False(t, session.IsClosed())
+
+			err = agent.RemoveService(serviceId)
+			assert.True(t, errors.Is(err, ErrUnknownService))
+		})
+	})
+
+	t.Run("can serve a listener to another agent", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			L := hclog.New(&hclog.LoggerOptions{
+				Name:  "dev",
+				Level: hclog.Trace,
+			})
+
+			h, err := hub.NewHub(L.Named("hub"), setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			go func() {
+				err := h.Run(ctx, setup.ClientListener)
+				require.NoError(t, err)
+			}()
+
+			time.Sleep(time.Second)
+
+			hubs := discovery.HubConfigs(discovery.HubConfig{
+				Addr:     setup.HubAddr,
+				Insecure: true,
+			})
+
+			server, err := NewAgent(L.Named("server"))
+			require.NoError(t, err)
+
+			server.Token = setup.AgentToken
+
+			l, err := server.Listen("test", pb.ParseLabelSet("service=listener"))
+			require.NoError(t, err)
+
+			defer l.Close()
+
+			err = server.Start(ctx, hubs)
+			require.NoError(t, err)
+
+			go server.Wait(ctx)
+
+			client, err := NewAgent(L.Named("client"))
+			require.NoError(t, err)
+
+			client.Token = setup.AgentToken
+
+			err = client.Start(ctx, discovery.HubConfigs(discovery.HubConfig{
+				Addr:     setup.HubAddr,
+				Insecure: true,
+			}))
+			require.NoError(t, err)
+
+			go client.Wait(ctx)
+
+			time.Sleep(time.Second)
+
+			cc, err := client.Connect(pb.ParseLabelSet("service=listener"))
+			require.NoError(t, err)
+
+			defer cc.Close()
+
+			_, err = cc.Write([]byte("hello"))
+			require.NoError(t, err)
+
+			sc, err := l.Accept()
+			require.NoError(t, err)
+
+			defer sc.Close()
+
+			buf := make([]byte, 5)
+
+			_, err = io.ReadFull(sc, buf)
+			require.NoError(t, err)
+
+			assert.Equal(t, "hello", string(buf))
+
+			conn := sc.(*Conn)
+
+			assert.True(t, setup.Account.Equal(conn.PeerAccount))
+			assert.NotNil(t, conn.PeerAgent)
+
+			_, err = sc.Write([]byte("world"))
+			require.NoError(t, err)
+
+			_, err = io.ReadFull(cc, buf)
+			require.NoError(t, err)
+
+			assert.Equal(t, "world", string(buf))
+		})
+	})
+
+	t.Run("can dial a service from an http client", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			L := hclog.New(&hclog.LoggerOptions{
+				Name:  "dev",
+				Level: hclog.Trace,
+			})
+
+			h, err := hub.NewHub(L.Named("hub"), setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			go func() {
+				err := h.Run(ctx, setup.ClientListener)
+				require.NoError(t, err)
+			}()
+
+			time.Sleep(time.Second)
+
+			hubs := discovery.HubConfigs(discovery.HubConfig{
+				Addr:     setup.HubAddr,
+				Insecure: true,
+			})
+
+			server, err := NewAgent(L.Named("server"))
+			require.NoError(t, err)
+
+			server.Token = setup.AgentToken
+
+			l, err := server.Listen("test", pb.ParseLabelSet("service=web"))
+			require.NoError(t, err)
+
+			defer l.Close()
+
+			go http.Serve(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, "hello from %s", r.Host)
+			}))
+
+			err = server.Start(ctx, hubs)
+			require.NoError(t, err)
+
+			go server.Wait(ctx)
+
+			client, err := NewAgent(L.Named("client"))
+			require.NoError(t, err)
+
+			client.Token = setup.AgentToken
+
+			err = client.Start(ctx, hubs)
+			require.NoError(t, err)
+
+			go client.Wait(ctx)
+
+			time.Sleep(time.Second)
+
+			hc := &http.Client{
+				Transport: &http.Transport{
+					DialContext: client.DialContext,
+				},
+			}
+
+			resp, err := hc.Get("http://service=web/")
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, "hello from service=web", string(body))
+
+			_, err = client.DialContext(ctx, "hzn", "hzn://service=missing")
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, ErrNoRoute))
+		})
+	})
+
+	t.Run("can query and watch the services of peers", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			L := hclog.New(&hclog.LoggerOptions{
+				Name:  "dev",
+				Level: hclog.Trace,
+			})
+
+			h, err := hub.NewHub(L.Named("hub"), setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+			defer cancel()
+
+			go func() {
+				err := h.Run(ctx, setup.ClientListener)
+				require.NoError(t, err)
+			}()
+
+			time.Sleep(time.Second)
+
+			hubs := discovery.HubConfigs(discovery.HubConfig{
+				Addr:     setup.HubAddr,
+				Insecure: true,
+			})
+
+			server, err := NewAgent(L.Named("server"))
+			require.NoError(t, err)
+
+			server.Token = setup.AgentToken
+
+			serviceId, err := server.AddService(&Service{
+				Type:     "test",
+				Labels:   pb.ParseLabelSet("env=test,service=echo"),
+				Metadata: map[string]string{"version": "1"},
+				Handler:  EchoHandler(),
+			})
+			require.NoError(t, err)
+
+			err = server.Start(ctx, hubs)
+			require.NoError(t, err)
+
+			go server.Wait(ctx)
+
+			client, err := NewAgent(L.Named("client"))
+			require.NoError(t, err)
+
+			client.Token = setup.AgentToken
+
+			err = client.Start(ctx, hubs)
+			require.NoError(t, err)
+
+			go client.Wait(ctx)
+
+			time.Sleep(time.Second)
+
+			services, err := client.QueryPeerService(ctx, pb.ParseLabelSet("service=echo"))
+			require.NoError(t, err)
+
+			require.Equal(t, 1, len(services))
+
+			serv := services[0]
+			assert.True(t, serviceId.Equal(serv.Id))
+			assert.Equal(t, "test", serv.Type)
+			assert.Equal(t, "env=test,service=echo", serv.Labels.SpecString())
+
+			require.Equal(t, 1, len(serv.Metadata))
+			assert.Equal(t, "version", serv.Metadata[0].Key)
+			assert.Equal(t, "1", serv.Metadata[0].Value)
+
+			services, err = client.QueryPeerService(ctx, pb.ParseLabelSet("service=missing"))
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, len(services))
+
+			conn, err := client.ConnectToPeer(ctx, serv)
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("hello"))
+			require.NoError(t, err)
+
+			buf := make([]byte, 5)
+
+			_, err = io.ReadFull(conn, buf)
+			require.NoError(t, err)
+
+			assert.Equal(t, "hello", string(buf))
+
+			watch, err := client.WatchPeerServices(ctx, pb.ParseLabelSet("env=test"))
+			require.NoError(t, err)
+
+			defer watch.Close()
+
+			services, err = watch.Next()
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, len(services))
+
+			_, err = server.AddService(&Service{
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("env=test,service=echo2"),
+				Handler: EchoHandler(),
+			})
+			require.NoError(t, err)
+
+			services, err = watch.Next()
+			require.NoError(t, err)
+
+			assert.Equal(t, 2, len(services))
+		})
+	})
+}
+
+func TestParseAddress(t *testing.T) {
+	id := pb.NewULID()
+
+	cases := []struct {
+		name, addr string
+		expected   *Address
+		err        bool
+	}{
+		{
+			name: "labels",
+			addr: "hzn://env=prod,app=api",
+			expected: &Address{
+				Labels: pb.ParseLabelSet("env=prod,app=api"),
+			},
+		},
+		{
+			name: "protocol",
+			addr: "hzn://env=prod,app=api/tcp",
+			expected: &Address{
+				Labels:   pb.ParseLabelSet("env=prod,app=api"),
+				Protocol: "tcp",
+			},
+		},
+		{
+			name: "account",
+			addr: "hzn://app=api/grpc?account=/test!" + id.SpecString(),
+			expected: &Address{
+				Labels:   pb.ParseLabelSet("app=api"),
+				Protocol: "grpc",
+				Account: &pb.Account{
+					Namespace: "/test",
+					AccountId: id,
+				},
+			},
+		},
+		{
+			name: "compression",
+			addr: "hzn://app=api/tcp?compression=none",
+			expected: &Address{
+				Labels:      pb.ParseLabelSet("app=api"),
+				Protocol:    "tcp",
+				Compression: wire.CompressionNone,
+			},
+		},
+		{
+			name: "unknown compression",
+			addr: "hzn://app=api?compression=brotli",
+			err:  true,
+		},
+		{
+			name: "host and port",
+			addr: "app=api:80",
+			expected: &Address{
+				Labels: pb.ParseLabelSet("app=api"),
+			},
+		},
+		{
+			name: "missing labels",
+			addr: "hzn:///tcp",
+			err:  true,
+		},
+		{
+			name: "bad account",
+			addr: "hzn://app=api?account=test",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			addr, err := ParseAddress(c.addr)
+			if c.err {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidAddress))
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, c.expected, addr)
+		})
+	}
+}
+
+func TestListener(t *testing.T) {
+	t.Run("accepts streams with the identity of the peer", func(t *testing.T) {
+		L := hclog.L()
+
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		l, err := agent.Listen("test", pb.ParseLabelSet("service=listener"))
+		require.NoError(t, err)
+
+		left, right := net.Pipe()
+
+		ss, err := yamux.Server(left, nil)
+		require.NoError(t, err)
+
+		defer ss.Close()
+
+		cs, err := yamux.Client(right, nil)
+		require.NoError(t, err)
+
+		defer cs.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			stream, err := ss.AcceptStream()
+			if err != nil {
+				return
+			}
+
+			agent.handleStream(ctx, L, ss, stream, &hubSession{})
+		}()
+
+		stream, err := cs.OpenStream()
+		require.NoError(t, err)
+
+		fr, err := wire.NewFramingReader(stream)
+		require.NoError(t, err)
+
+		fw, err := wire.NewFramingWriter(stream)
+		require.NoError(t, err)
+
+		account := &pb.Account{AccountId: pb.NewULID(), Namespace: "/test"}
+		peer := pb.NewULID()
+
+		_, err = fw.WriteMarshal(11, &pb.SessionIdentification{
+			ServiceId:     l.(*Listener).ServiceId(),
+			SourceAccount: account,
+			SourceAgent:   peer,
+		})
+		require.NoError(t, err)
+
+		wctx := wire.NewContext(nil, fr, fw)
+
+		_, err = wctx.Writer().Write([]byte("hello"))
+		require.NoError(t, err)
+
+		conn, err := l.Accept()
+		require.NoError(t, err)
+
+		buf := make([]byte, 5)
+
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", string(buf))
+
+		assert.Equal(t, peer, conn.(*Conn).PeerAgent)
+		assert.Equal(t, "hzn", conn.RemoteAddr().Network())
+		assert.Equal(t, fmt.Sprintf("account=%s,agent=%s", account.SpecString(), peer.SpecString()), conn.RemoteAddr().String())
+
+		_, err = conn.Write([]byte("world"))
+		require.NoError(t, err)
+
+		_, err = io.ReadFull(wctx.Reader(), buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, "world", string(buf))
+
+		require.NoError(t, conn.Close())
+		require.NoError(t, l.Close())
+
+		_, err = l.Accept()
+		assert.Equal(t, ErrListenerClosed, err)
+	})
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Run("passes the client address to the upstream", func(t *testing.T) {
+		hreq := httptest.NewRequest("GET", "/", nil)
+		hreq.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+		setForwardedHeaders(hreq, &pb.Request{
+			Host:       "example.com",
+			RemoteAddr: "192.0.2.1:5555",
+			Scheme:     "https",
+		})
+
+		assert.Equal(t, "10.0.0.1, 192.0.2.1", hreq.Header.Get("X-Forwarded-For"))
+		assert.Equal(t, "https", hreq.Header.Get("X-Forwarded-Proto"))
+		assert.Equal(t, `for=192.0.2.1;host="example.com";proto=https`, hreq.Header.Get("Forwarded"))
+	})
+
+	t.Run("connects to https upstreams with mutual tls", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "hzn")
+		require.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		serverCert, serverKey, err := testutils.SelfSignedCert()
+		require.NoError(t, err)
+
+		clientCert, clientKey, err := testutils.SelfSignedCert()
+		require.NoError(t, err)
+
+		cert, err := tls.X509KeyPair(serverCert, serverKey)
+		require.NoError(t, err)
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s %d", r.TLS.ServerName, len(r.TLS.PeerCertificates))
+		}))
+
+		srv.TLS = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAnyClientCert,
+		}
+
+		srv.StartTLS()
+		defer srv.Close()
+
+		caPath := filepath.Join(dir, "ca.pem")
+		certPath := filepath.Join(dir, "client.pem")
+		keyPath := filepath.Join(dir, "client-key.pem")
+
+		require.NoError(t, ioutil.WriteFile(caPath, serverCert, 0600))
+		require.NoError(t, ioutil.WriteFile(certPath, clientCert, 0600))
+		require.NoError(t, ioutil.WriteFile(keyPath, clientKey, 0600))
+
+		h, err := NewHTTPHandler(HTTPConfig{
+			URL:            srv.URL,
+			CACertFile:     caPath,
+			ClientCertFile: certPath,
+			ClientKeyFile:  keyPath,
+			ServerName:     "hub.test",
+		})
+		require.NoError(t, err)
+
+		resp, err := h.(*httpHandler).client.Get(srv.URL)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hub.test 1", string(data))
+
+		_, err = NewHTTPHandler(HTTPConfig{
+			URL: srv.URL,
+			H2C: true,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("quotes ipv6 addresses in the forwarded header", func(t *testing.T) {
+		hreq := httptest.NewRequest("GET", "/", nil)
+
+		setForwardedHeaders(hreq, &pb.Request{
+			RemoteAddr: "[2001:db8::1]:5555",
+		})
+
+		assert.Equal(t, "2001:db8::1", hreq.Header.Get("X-Forwarded-For"))
+		assert.Equal(t, `for="[2001:db8::1]"`, hreq.Header.Get("Forwarded"))
+	})
+}
+
+// serveTestRequest runs h on one end of a pipe and sends req from the other,
+// returning the response and it's body.
+func serveTestRequest(t *testing.T, h ServiceHandler, proto string, req *pb.Request) (*pb.Response, []byte) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	L := hclog.L()
+
+	go func() {
+		defer server.Close()
+
+		fr, err := wire.NewFramingReader(server)
+		require.NoError(t, err)
+
+		fw, err := wire.NewFramingWriter(server)
+		require.NoError(t, err)
+
+		h.HandleRequest(context.Background(), L, &serviceContext{
+			Context:    wire.NewContext(nil, fr, fw),
+			protocolId: proto,
+			fr:         fr,
+			stream:     server,
+		})
+	}()
+
+	fr, err := wire.NewFramingReader(client)
+	require.NoError(t, err)
+
+	fw, err := wire.NewFramingWriter(client)
+	require.NoError(t, err)
+
+	wctx := wire.NewContext(nil, fr, fw)
+
+	// The pipe is unbuffered and handlers need not read the body, so the
+	// request is sent concurrently with reading the response.
+	go func() {
+		wctx.WriteMarshal(1, req)
+		wctx.CloseWrite()
+	}()
+
+	var resp pb.Response
+
+	tag, err := wctx.ReadMarshal(&resp)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), tag)
+
+	body, err := ioutil.ReadAll(wctx.Reader())
+	require.NoError(t, err)
+
+	return &resp, body
+}
+
+func responseHeader(resp *pb.Response, name string) string {
+	for _, h := range resp.Headers {
+		if http.CanonicalHeaderKey(h.Name) == name && len(h.Value) > 0 {
+			return h.Value[0]
+		}
+	}
+
+	return ""
+}
+
+func TestStaticHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzn")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello hzn"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "site"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "site", "index.html"), []byte("<h1>hzn</h1>"), 0644))
+
+	h := StaticHandler(dir)
+
+	t.Run("serves files with an etag", func(t *testing.T) {
+		resp, body := serveTestRequest(t, h, "http", &pb.Request{
+			Method: "GET",
+			Path:   "/hello.txt",
+		})
+
+		assert.Equal(t, int32(200), resp.Code)
+		assert.Equal(t, "hello hzn", string(body))
+		assert.NotEmpty(t, responseHeader(resp, "Etag"))
+
+		resp, body = serveTestRequest(t, h, "http", &pb.Request{
+			Method: "GET",
+			Path:   "/hello.txt",
+			Headers: []*pb.Header{
+				{Name: "If-None-Match", Value: []string{responseHeader(resp, "Etag")}},
+			},
+		})
+
+		assert.Equal(t, int32(304), resp.Code)
+		assert.Empty(t, body)
+	})
+
+	t.Run("serves ranges", func(t *testing.T) {
+		resp, body := serveTestRequest(t, h, "http", &pb.Request{
+			Method: "GET",
+			Path:   "/hello.txt",
+			Headers: []*pb.Header{
+				{Name: "Range", Value: []string{"bytes=6-"}},
+			},
+		})
+
+		assert.Equal(t, int32(206), resp.Code)
+		assert.Equal(t, "hzn", string(body))
+	})
+
+	t.Run("serves the index of a directory", func(t *testing.T) {
+		resp, body := serveTestRequest(t, h, "http", &pb.Request{
+			Method: "GET",
+			Path:   "/site/",
+		})
+
+		assert.Equal(t, int32(200), resp.Code)
+		assert.Equal(t, "<h1>hzn</h1>", string(body))
+		assert.NotEmpty(t, responseHeader(resp, "Etag"))
+	})
+
+	t.Run("reports missing files", func(t *testing.T) {
+		resp, _ := serveTestRequest(t, h, "http", &pb.Request{
+			Method: "GET",
+			Path:   "/nope.txt",
+		})
+
+		assert.Equal(t, int32(404), resp.Code)
+	})
+}
+
+func TestUnixHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzn")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "api.sock")
+
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	defer l.Close()
+
+	go http.Serve(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s", r.Method, r.URL.Path)
+	}))
+
+	t.Run("forwards http requests to the socket", func(t *testing.T) {
+		resp, body := serveTestRequest(t, UnixHandler(path), "http", &pb.Request{
+			Method: "GET",
+			Path:   "/containers/json",
+		})
+
+		assert.Equal(t, int32(200), resp.Code)
+		assert.Equal(t, "GET /containers/json", string(body))
+	})
+}
+
+func TestAgentRPC(t *testing.T) {
+	t.Run("serves rpcs made by a hub", func(t *testing.T) {
+		L := hclog.L()
+
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		agent.AddRPCMethod("", "/echo", wire.RPCHandlerFunc(func(ctx context.Context, wctx wire.RPCContext) error {
+			var mb wire.MarshalBytes
+
+			err := wctx.ReadRequest(&mb)
+			if err != nil {
+				return err
+			}
+
+			return wctx.WriteResponse(&mb)
+		}))
+
+		left, right := net.Pipe()
+
+		ss, err := yamux.Server(left, nil)
+		require.NoError(t, err)
+
+		defer ss.Close()
+
+		cs, err := yamux.Client(right, nil)
+		require.NoError(t, err)
+
+		defer cs.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			stream, err := ss.AcceptStream()
+			if err != nil {
+				return
+			}
+
+			agent.handleStream(ctx, L, ss, stream, &hubSession{})
+		}()
+
+		stream, err := cs.OpenStream()
+		require.NoError(t, err)
+
+		client := wire.NewRPCClient(stream, stream, stream)
+		defer client.Close()
+
+		req := wire.MarshalBytes("hello")
+
+		var resp wire.MarshalBytes
+
+		err = client.CallContext(ctx, "", "/echo", &req, &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", string(resp))
+	})
+}
+
+// fakeHub performs the hub side of the handshake on conn, replying to the
+// preamble with wc, and returns the preamble the agent sent.
+func fakeHub(t *testing.T, conn net.Conn, wc *pb.Confirmation) chan *pb.Preamble {
+	preambles := make(chan *pb.Preamble, 1)
+
+	go func() {
+		fr, err := wire.NewFramingReader(conn)
+		require.NoError(t, err)
+
+		fw, err := wire.NewFramingWriter(conn)
+		require.NoError(t, err)
+
+		var preamble pb.Preamble
+
+		_, _, err = fr.ReadMarshal(&preamble)
+		if err != nil {
+			return
+		}
+
+		preambles <- &preamble
+
+		_, err = fw.WriteMarshal(1, wc)
+		if err != nil {
+			return
+		}
+
+		sess, err := yamux.Server(&wire.ComposedConn{
+			Reader: fr.BufReader(),
+			Writer: conn,
+			Closer: conn,
+		}, nil)
+		if err != nil {
+			return
+		}
+
+		for {
+			stream, err := sess.AcceptStream()
+			if err != nil {
+				return
+			}
+
+			stream.Close()
+		}
+	}()
+
+	return preambles
+}
+
+func TestNegotiation(t *testing.T) {
+	L := hclog.L()
+
+	t.Run("connects to hubs that predate versioning", func(t *testing.T) {
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		left, right := net.Pipe()
+		defer right.Close()
+
+		preambles := fakeHub(t, right, &pb.Confirmation{
+			Time:        pb.NewTimestamp(time.Now()),
+			Status:      "connected",
+			Compression: "lz4",
+		})
+
+		err = agent.Nego(ctx, L, left, discovery.HubConfig{}, make(chan hubStatus, 1))
+		require.NoError(t, err)
+
+		preamble := <-preambles
+
+		assert.Equal(t, int32(wire.ProtocolVersion), preamble.ProtocolVersion)
+		assert.Equal(t, wire.Features, preamble.Features)
+
+		// The hub only knows about lz4, and picks it.
+		assert.Equal(t, wire.CompressionLZ4, preamble.Compression)
+
+		agent.mu.RLock()
+		session := agent.sessions[0]
+		agent.mu.RUnlock()
+
+		_, err = agent.RPCClient()
+		assert.True(t, errors.Is(err, wire.ErrUnsupportedFeature))
+
+		// The hub can't be told about the service, so the agent reconnects
+		// to advertise it.
+		_, err = agent.AddService(&Service{
+			Type:    "test",
+			Labels:  pb.ParseLabelSet("service=echo"),
+			Handler: EchoHandler(),
+		})
+		require.NoError(t, err)
+
+		assert.True(t, session.IsClosed())
+	})
+
+	t.Run("uses the features agreed with the hub", func(t *testing.T) {
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		left, right := net.Pipe()
+		defer right.Close()
+
+		fakeHub(t, right, &pb.Confirmation{
+			Time:            pb.NewTimestamp(time.Now()),
+			Status:          "connected",
+			Compression:     "lz4",
+			ProtocolVersion: wire.ProtocolVersion,
+			Features:        []string{wire.FeatureRPC},
+		})
+
+		err = agent.Nego(ctx, L, left, discovery.HubConfig{}, make(chan hubStatus, 1))
+		require.NoError(t, err)
+
+		agent.mu.RLock()
+		features := agent.hubs[agent.sessions[0]].features
+		agent.mu.RUnlock()
+
+		assert.True(t, features.Has(wire.FeatureRPC))
+		assert.False(t, features.Has(wire.FeatureServicesUpdate))
+
+		agent.mu.RLock()
+		compression := agent.hubs[agent.sessions[0]].compression
+		agent.mu.RUnlock()
+
+		assert.Equal(t, wire.CompressionLZ4, compression.Codec)
+
+		client, err := agent.RPCClient()
+		require.NoError(t, err)
+
+		client.Close()
+	})
+
+	t.Run("reports hubs rejecting its version", func(t *testing.T) {
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		left, right := net.Pipe()
+		defer right.Close()
+
+		fakeHub(t, right, &pb.Confirmation{
+			Time:            pb.NewTimestamp(time.Now()),
+			Status:          "unsupported-version",
+			ProtocolVersion: wire.ProtocolVersion + 1,
+		})
+
+		err = agent.Nego(ctx, L, left, discovery.HubConfig{}, make(chan hubStatus, 1))
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, wire.ErrUnsupportedVersion))
+	})
+	t.Run("uses the codec chosen by the hub", func(t *testing.T) {
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		agent.Compression = []wire.Compression{
+			{Codec: wire.CompressionZstd, Level: 3},
+			{Codec: wire.CompressionLZ4},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		left, right := net.Pipe()
+		defer right.Close()
+
+		preambles := fakeHub(t, right, &pb.Confirmation{
+			Time:            pb.NewTimestamp(time.Now()),
+			Status:          "connected",
+			Compression:     wire.CompressionZstd,
+			ProtocolVersion: wire.ProtocolVersion,
+		})
+
+		err = agent.Nego(ctx, L, left, discovery.HubConfig{}, make(chan hubStatus, 1))
+		require.NoError(t, err)
+
+		preamble := <-preambles
+
+		assert.Equal(t, []string{wire.CompressionZstd, wire.CompressionLZ4}, preamble.CompressionCodecs)
+
+		agent.mu.RLock()
+		compression := agent.hubs[agent.sessions[0]].compression
+		agent.mu.RUnlock()
+
+		assert.Equal(t, wire.Compression{Codec: wire.CompressionZstd, Level: 3}, compression)
+	})
+
+	t.Run("rejects codecs it didn't offer", func(t *testing.T) {
+		agent, err := NewAgent(L)
+		require.NoError(t, err)
+
+		agent.Compression = []wire.Compression{{Codec: wire.CompressionLZ4}}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		left, right := net.Pipe()
+		defer right.Close()
+
+		fakeHub(t, right, &pb.Confirmation{
+			Time:            pb.NewTimestamp(time.Now()),
+			Status:          "connected",
+			Compression:     wire.CompressionZstd,
+			ProtocolVersion: wire.ProtocolVersion,
+		})
+
+		err = agent.Nego(ctx, L, left, discovery.HubConfig{}, make(chan hubStatus, 1))
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, wire.ErrUnknownCodec))
+	})
+}
+
+func TestRebalance(t *testing.T) {
+	hub := func(addr string, lat time.Duration) discovery.HubScore {
+		return discovery.HubScore{
+			HubConfig: discovery.HubConfig{Addr: addr, Name: addr},
+			Latency:   lat,
+		}
+	}
+
+	active := map[string]discovery.HubConfig{
+		"a:443": {Addr: "a:443", Name: "a:443"},
+		"b:443": {Addr: "b:443", Name: "b:443"},
+	}
+
+	t.Run("replaces the slowest hub with a much faster one", func(t *testing.T) {
+		old, better, ok := pickRebalance(active, []discovery.HubScore{
+			hub("c:443", 10*time.Millisecond),
+			hub("a:443", 20*time.Millisecond),
+			hub("b:443", 40*time.Millisecond),
+		})
+		require.True(t, ok)
+
+		assert.Equal(t, "b:443", old.Addr)
+		assert.Equal(t, "c:443", better.Addr)
+	})
+
+	t.Run("ignores hubs that are only slightly faster", func(t *testing.T) {
+		_, _, ok := pickRebalance(active, []discovery.HubScore{
+			hub("a:443", 20*time.Millisecond),
+			hub("c:443", 35*time.Millisecond),
+			hub("b:443", 40*time.Millisecond),
+		})
+		assert.False(t, ok)
+	})
+
+	t.Run("ignores hubs that haven't been measured", func(t *testing.T) {
+		_, _, ok := pickRebalance(active, []discovery.HubScore{
+			hub("a:443", 20*time.Millisecond),
+			hub("b:443", 40*time.Millisecond),
+			hub("c:443", 0),
+		})
+		assert.False(t, ok)
+	})
+
+	t.Run("replaces hubs that are no longer ranked", func(t *testing.T) {
+		old, better, ok := pickRebalance(active, []discovery.HubScore{
+			hub("a:443", 20*time.Millisecond),
+			hub("c:443", 30*time.Millisecond),
+		})
+		require.True(t, ok)
+
+		assert.Equal(t, "b:443", old.Addr)
+		assert.Equal(t, "c:443", better.Addr)
+	})
 }
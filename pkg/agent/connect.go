@@ -1,15 +1,16 @@
 package agent
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/yamux"
-	"github.com/pierrec/lz4/v3"
-	"github.com/pkg/errors"
 )
 
 type Conn struct {
@@ -18,15 +19,43 @@ type Conn struct {
 
 	Stream *yamux.Stream
 	Labels *pb.LabelSet
+
+	// The account and agent that opened a connection accepted by
+	// a Listener. They are nil if the connection didn't come from an agent.
+	PeerAccount *pb.Account
+	PeerAgent   *pb.ULID
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	// Releases the codec of the stream, if the connection owns it.
+	compressed *wire.CompressedStream
 }
 
 type agentConnAddr struct {
-	labels *pb.LabelSet
+	labels  *pb.LabelSet
+	account *pb.Account
+	agent   *pb.ULID
 }
 
 func (c *Conn) Close() error {
 	c.WriteCloser.Close()
-	return c.Stream.Close()
+
+	var err error
+
+	if c.compressed != nil {
+		err = c.compressed.Close()
+	} else {
+		err = c.Stream.Close()
+	}
+
+	if c.closed != nil {
+		c.closeOnce.Do(func() {
+			close(c.closed)
+		})
+	}
+
+	return err
 }
 
 func (a *agentConnAddr) Network() string {
@@ -34,6 +63,10 @@ func (a *agentConnAddr) Network() string {
 }
 
 func (a *agentConnAddr) String() string {
+	if a.agent != nil {
+		return fmt.Sprintf("account=%s,agent=%s", a.account.SpecString(), a.agent.SpecString())
+	}
+
 	if a.labels == nil {
 		return "type=local"
 	}
@@ -46,9 +79,14 @@ func (c *Conn) LocalAddr() net.Addr {
 	return &agentConnAddr{}
 }
 
-// RemoteAddr returns the remote network address.
+// RemoteAddr returns the remote network address. For connections accepted
+// by a Listener from another agent, it identifies that agent.
 func (c *Conn) RemoteAddr() net.Addr {
-	return &agentConnAddr{labels: c.Labels}
+	return &agentConnAddr{
+		labels:  c.Labels,
+		account: c.PeerAccount,
+		agent:   c.PeerAgent,
+	}
 }
 
 func (c *Conn) SetDeadline(t time.Time) error {
@@ -63,51 +101,7 @@ func (c *Conn) SetWriteDeadline(t time.Time) error {
 	return c.Stream.SetWriteDeadline(t)
 }
 
+// Connect opens a connection to a service matching labels.
 func (a *Agent) Connect(labels *pb.LabelSet) (net.Conn, error) {
-	a.mu.Lock()
-	stream, err := a.sessions[0].OpenStream()
-	a.mu.Unlock()
-
-	if err != nil {
-		return nil, errors.Wrapf(err, "error opening new yamux stream")
-	}
-
-	sr := lz4.NewReader(stream)
-	sw := lz4.NewWriter(stream)
-
-	fw, err := wire.NewFramingWriter(sw)
-	if err != nil {
-		return nil, err
-	}
-
-	fr, err := wire.NewFramingReader(sr)
-	if err != nil {
-		return nil, err
-	}
-
-	var conreq pb.ConnectRequest
-	conreq.Target = labels
-
-	_, err = fw.WriteMarshal(1, &conreq)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error writing connect request")
-	}
-
-	var ack pb.ConnectAck
-
-	tag, _, err := fr.ReadMarshal(&ack)
-	if err != nil {
-		return nil, err
-	}
-
-	if tag != 1 {
-		return nil, wire.ErrProtocolError
-	}
-
-	ctx := wire.NewContext(nil, fr, fw)
-
-	r := ctx.Reader()
-	w := ctx.Writer()
-
-	return &Conn{Reader: r, WriteCloser: w, Stream: stream}, nil
+	return a.dial(context.Background(), &pb.ConnectRequest{Target: labels}, "")
 }
@@ -0,0 +1,284 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+	"github.com/hashicorp/yamux"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidAddress = errors.New("invalid hzn address")
+	ErrNoRoute        = errors.New("no route to service")
+	ErrPivotDenied    = errors.New("account pivot denied")
+	ErrHubUnavailable = errors.New("no hub available")
+)
+
+// Address identifies a service to connect to. It's written as
+//
+//	hzn://env=prod,app=api/tcp?account=namespace!01E0QHSK...
+//
+// where the host is the labels of the service, the path the protocol id
+// to request and the optional account is the account to pivot to, as
+// namespace!id. A compression parameter picks the codec used for the
+// connection, for instance compression=none for data that is already
+// compressed.
+type Address struct {
+	Labels      *pb.LabelSet
+	Protocol    string
+	Account     *pb.Account
+	Compression string
+}
+
+// ParseAddress parses a hzn:// address. Bare labels are also accepted,
+// optionally followed by a port, as passed to dialers by http.Transport.
+// The port is ignored.
+func ParseAddress(addr string) (*Address, error) {
+	if !strings.HasPrefix(addr, "hzn://") {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+
+		if addr == "" {
+			return nil, errors.Wrapf(ErrInvalidAddress, "missing labels")
+		}
+
+		return &Address{Labels: pb.ParseLabelSet(addr)}, nil
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidAddress, "%s", err)
+	}
+
+	if u.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidAddress, "missing labels")
+	}
+
+	a := &Address{
+		Labels:   pb.ParseLabelSet(u.Host),
+		Protocol: strings.TrimPrefix(u.Path, "/"),
+	}
+
+	if codec := u.Query().Get("compression"); codec != "" {
+		if !wire.KnownCodec(codec) {
+			return nil, errors.Wrapf(ErrInvalidAddress, "unknown compression codec: %s", codec)
+		}
+
+		a.Compression = codec
+	}
+
+	if acc := u.Query().Get("account"); acc != "" {
+		idx := strings.LastIndexByte(acc, '!')
+		if idx == -1 {
+			return nil, errors.Wrapf(ErrInvalidAddress, "invalid account: %s", acc)
+		}
+
+		id, err := pb.ParseULID(acc[idx+1:])
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidAddress, "invalid account id: %s", acc)
+		}
+
+		a.Account = &pb.Account{
+			Namespace: acc[:idx],
+			AccountId: id,
+		}
+	}
+
+	return a, nil
+}
+
+// DialContext connects to the service at address, which is parsed by
+// ParseAddress. The network must be "hzn", or "tcp" so that DialContext
+// can be used directly by http.Transport. The context bounds the time spent
+// establishing the connection, not the life of the connection.
+//
+// Errors can be checked with errors.Is against ErrNoRoute, ErrPivotDenied
+// and ErrHubUnavailable.
+func (a *Agent) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	switch network {
+	case "hzn", "tcp", "":
+		// ok
+	default:
+		return nil, errors.Wrapf(ErrInvalidAddress, "unsupported network: %s", network)
+	}
+
+	addr, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.dial(ctx, &pb.ConnectRequest{
+		Target:       addr.Labels,
+		ProtocolId:   addr.Protocol,
+		PivotAccount: addr.Account,
+	}, addr.Compression)
+}
+
+// openStream opens a stream on the first hub session that will take one,
+// returning it along with what was agreed with the hub. If feature is set,
+// only sessions with hubs that support it are used.
+func (a *Agent) openStream(feature string) (*yamux.Stream, *hubSession, error) {
+	a.mu.RLock()
+
+	var (
+		sessions []*yamux.Session
+		hubs     []*hubSession
+	)
+
+	for _, sess := range a.sessions {
+		if hs := a.hubs[sess]; feature == "" || hs.features.Has(feature) {
+			sessions = append(sessions, sess)
+			hubs = append(hubs, hs)
+		}
+	}
+
+	connected := len(a.sessions) > 0
+
+	a.mu.RUnlock()
+
+	if len(sessions) == 0 {
+		if connected {
+			return nil, nil, errors.Wrapf(wire.ErrUnsupportedFeature, "no connected hub supports %s", feature)
+		}
+
+		return nil, nil, errors.Wrapf(ErrHubUnavailable, "not connected to any hubs")
+	}
+
+	var err error
+
+	for i, sess := range sessions {
+		var stream *yamux.Stream
+
+		stream, err = sess.OpenStream()
+		if err == nil {
+			return stream, hubs[i], nil
+		}
+	}
+
+	return nil, nil, errors.Wrapf(ErrHubUnavailable, "error opening new yamux stream: %s", err)
+}
+
+// dial connects to a service with conreq. If codec is set, it's used to
+// compress the connection instead of the codec of the session.
+func (a *Agent) dial(ctx context.Context, conreq *pb.ConnectRequest, codec string) (net.Conn, error) {
+	stream, hs, err := a.openStream("")
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetDeadline(deadline)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-done:
+		}
+	}()
+
+	conn, err := a.connectStream(stream, hs, conreq, codec)
+
+	close(done)
+
+	if ctx.Err() != nil {
+		if conn != nil {
+			conn.Close()
+		} else {
+			stream.Close()
+		}
+
+		return nil, ctx.Err()
+	}
+
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	stream.SetDeadline(time.Time{})
+
+	return conn, nil
+}
+
+func (a *Agent) connectStream(stream *yamux.Stream, hs *hubSession, conreq *pb.ConnectRequest, codec string) (*Conn, error) {
+	cs, err := hs.openCompressed(stream, codec)
+	if err != nil {
+		return nil, errors.Wrapf(ErrHubUnavailable, "error setting up stream compression: %s", err)
+	}
+
+	conn, err := a.handshakeStream(stream, cs, conreq)
+	if err != nil {
+		cs.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (a *Agent) handshakeStream(stream *yamux.Stream, cs *wire.CompressedStream, conreq *pb.ConnectRequest) (*Conn, error) {
+	fw, err := wire.NewFramingWriter(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	fr, err := wire.NewFramingReader(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = fw.WriteMarshal(1, conreq)
+	if err != nil {
+		return nil, errors.Wrapf(ErrHubUnavailable, "error writing connect request: %s", err)
+	}
+
+	var ack pb.ConnectAck
+
+	tag, _, err := fr.ReadMarshal(&ack)
+	if err != nil {
+		var re *wire.RemoteError
+
+		if errors.As(err, &re) {
+			return nil, connectError(re)
+		}
+
+		return nil, errors.Wrapf(ErrHubUnavailable, "error reading connect response: %s", err)
+	}
+
+	if tag != 1 {
+		return nil, wire.ErrProtocolError
+	}
+
+	ctx := wire.NewContext(nil, fr, fw)
+
+	return &Conn{
+		Reader:      ctx.Reader(),
+		WriteCloser: ctx.Writer(),
+		Stream:      stream,
+		Labels:      conreq.Target,
+		compressed:  cs,
+	}, nil
+}
+
+// connectError converts the error a hub returned for a ConnectRequest.
+func connectError(re *wire.RemoteError) error {
+	switch re.Code {
+	case http.StatusForbidden:
+		return errors.Wrapf(ErrPivotDenied, "%s", re.Message)
+	case http.StatusNotFound, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return errors.Wrapf(ErrNoRoute, "%s", re.Message)
+	default:
+		return re
+	}
+}
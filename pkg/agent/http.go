@@ -2,26 +2,225 @@ package agent
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
 )
 
+// HTTPConfig configures the handler returned by NewHTTPHandler.
+type HTTPConfig struct {
+	// The address of the upstream service to forward requests to. Either
+	// http:// or https:// can be used. If there is no scheme, http:// is used.
+	URL string
+
+	// Connect to the Unix domain socket at SocketPath rather than the host
+	// in URL.
+	SocketPath string
+
+	// Speak HTTP/2 without TLS (h2c) to an http:// upstream for all requests,
+	// rather than HTTP/1.1. gRPC requests always use h2c. https:// upstreams
+	// negotiate HTTP/2 as part of the TLS handshake instead.
+	H2C bool
+
+	// A PEM file of CA certificates to verify an https:// upstream with,
+	// rather than the system roots.
+	CACertFile string
+
+	// A certificate and key to present to an https:// upstream, for
+	// services that require mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// Overrides the server name sent via SNI and used to verify the
+	// upstream's certificate.
+	ServerName string
+
+	// Skip verifying the upstream's certificate. Only for development.
+	InsecureSkipVerify bool
+
+	// The maximum number of idle connections to keep to the upstream.
+	// Defaults to 100.
+	MaxIdleConns int
+
+	// How long idle connections are kept. Defaults to 90 seconds.
+	IdleConnTimeout time.Duration
+
+	// How long to wait while connecting to the upstream. Defaults to 30
+	// seconds.
+	DialTimeout time.Duration
+
+	// How long to wait for the upstream to return the response headers once
+	// the request is sent. Defaults to no limit.
+	ResponseHeaderTimeout time.Duration
+}
+
 type httpHandler struct {
 	url string
+
+	client    *http.Client
+	h2cClient *http.Client
+	h2c       bool
 }
 
+// HTTPHandler returns a handler that forwards requests to the service at url.
 func HTTPHandler(url string) ServiceHandler {
-	if !strings.HasPrefix(url, "http://") {
-		url = "http://" + url
+	return newHTTPHandler(HTTPConfig{URL: url}, &tls.Config{})
+}
+
+// NewHTTPHandler returns a handler that forwards requests to the service
+// described by cfg.
+func NewHTTPHandler(cfg HTTPConfig) (ServiceHandler, error) {
+	if strings.HasPrefix(upstreamURL(cfg.URL), "https://") && cfg.H2C {
+		return nil, fmt.Errorf("h2c can not be used with an https upstream")
+	}
+
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return newHTTPHandler(cfg, tlsCfg), nil
+}
+
+// upstreamURL adds the default http:// scheme to url if it has none.
+func upstreamURL(url string) string {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "http://" + url
+	}
+
+	return url
+}
+
+// newHTTPHandler builds the handler for a cfg that NewHTTPHandler has
+// already checked, using tlsCfg for https:// upstreams.
+func newHTTPHandler(cfg HTTPConfig, tlsCfg *tls.Config) *httpHandler {
+	url := upstreamURL(cfg.URL)
+	secure := strings.HasPrefix(url, "https://")
+
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = 100
+	}
+
+	if cfg.IdleConnTimeout == 0 {
+		cfg.IdleConnTimeout = 90 * time.Second
+	}
+
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = 30 * time.Second
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   cfg.DialTimeout,
+		KeepAlive: 30 * time.Second,
 	}
 
-	return &httpHandler{url}
+	dial := dialer.DialContext
+
+	if cfg.SocketPath != "" {
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", cfg.SocketPath)
+		}
+	}
+
+	h := &httpHandler{
+		url: url,
+		h2c: cfg.H2C,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				DialContext:           dial,
+				TLSClientConfig:       tlsCfg,
+				ForceAttemptHTTP2:     true,
+				MaxIdleConns:          cfg.MaxIdleConns,
+				MaxIdleConnsPerHost:   cfg.MaxIdleConns,
+				IdleConnTimeout:       cfg.IdleConnTimeout,
+				ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+				TLSHandshakeTimeout:   10 * time.Second,
+			},
+		},
+	}
+
+	// https upstreams negotiate HTTP/2 for gRPC via ALPN, so only plain
+	// upstreams need a separate h2c client.
+	if secure {
+		h.h2cClient = h.client
+	} else {
+		h.h2cClient = &http.Client{
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+
+				// http2.Transport only dials TLS, so we swap in a plain
+				// connection to speak h2c.
+				DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+					return dial(context.Background(), network, addr)
+				},
+			},
+		}
+	}
+
+	return h
+}
+
+func (cfg *HTTPConfig) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertFile != "" {
+		data, err := ioutil.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading ca file")
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in ca file: %s", cfg.CACertFile)
+		}
+
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.ClientCertFile != "" || cfg.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading client certificate")
+		}
+
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// isGRPC indicates if the request is a gRPC call, which requires HTTP/2.
+// gRPC-Web is excluded because it works over HTTP/1.1.
+func isGRPC(req *pb.Request) bool {
+	for _, h := range req.Headers {
+		if http.CanonicalHeaderKey(h.Name) != "Content-Type" || len(h.Value) == 0 {
+			continue
+		}
+
+		ct := h.Value[0]
+
+		return ct == "application/grpc" ||
+			strings.HasPrefix(ct, "application/grpc+") ||
+			strings.HasPrefix(ct, "application/grpc;")
+	}
+
+	return false
 }
 
 func (h *httpHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx ServiceContext) error {
@@ -40,11 +239,15 @@ func (h *httpHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx Se
 
 	L.Info("request started", "method", req.Method, "path", req.Path)
 
-	hreq, err := http.NewRequestWithContext(ctx, req.Method, h.url+req.Path, sctx.BodyReader())
+	body := &trailerReader{sctx: sctx, r: sctx.Reader(), trailers: req.Trailers}
+
+	hreq, err := http.NewRequestWithContext(ctx, req.Method, h.url+req.Path, body)
 	if err != nil {
 		return err
 	}
 
+	body.hreq = hreq
+
 	hreq.Host = req.Host
 	hreq.URL.RawQuery = req.Query
 	hreq.URL.Fragment = req.Fragment
@@ -52,12 +255,35 @@ func (h *httpHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx Se
 		hreq.URL.User = url.UserPassword(req.Auth.User, req.Auth.Password)
 	}
 	for _, h := range req.Headers {
+		// Declared trailers are populated by the trailerReader once the
+		// body has been sent.
+		if http.CanonicalHeaderKey(h.Name) == "Trailer" {
+			if hreq.Trailer == nil {
+				hreq.Trailer = make(http.Header)
+			}
+
+			for _, v := range h.Value {
+				hreq.Trailer[http.CanonicalHeaderKey(v)] = nil
+			}
+
+			continue
+		}
+
 		for _, v := range h.Value {
 			hreq.Header.Add(h.Name, v)
 		}
 	}
 
-	hresp, err := http.DefaultClient.Do(hreq)
+	setForwardedHeaders(hreq, &req)
+
+	client := h.client
+
+	if h.h2c || isGRPC(&req) {
+		client = h.h2cClient
+		removeConnectionHeaders(hreq.Header)
+	}
+
+	hresp, err := client.Do(hreq)
 	if err != nil {
 		return err
 	}
@@ -66,6 +292,7 @@ func (h *httpHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx Se
 
 	var resp pb.Response
 	resp.Code = int32(hresp.StatusCode)
+	resp.Trailers = req.Trailers
 
 	for k, v := range hresp.Header {
 		resp.Headers = append(resp.Headers, &pb.Header{
@@ -82,9 +309,132 @@ func (h *httpHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx Se
 	w := sctx.Writer()
 	defer w.Close()
 
-	n, _ := io.Copy(w, hresp.Body)
+	n, err := io.Copy(w, hresp.Body)
+	if err != nil {
+		return err
+	}
 
 	L.Info("request ended", "size", n)
 
-	return nil
+	if !req.Trailers {
+		return nil
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	// The trailers are only available once the body has been read.
+	var trailers pb.Trailers
+
+	for k, v := range hresp.Trailer {
+		trailers.Headers = append(trailers.Headers, &pb.Header{
+			Name:  k,
+			Value: v,
+		})
+	}
+
+	return sctx.WriteMarshal(1, &trailers)
+}
+
+// trailerReader reads the body of a request and, once it's complete, the
+// trailers that follow it, populating the trailers of hreq.
+type trailerReader struct {
+	sctx     ServiceContext
+	r        io.Reader
+	hreq     *http.Request
+	trailers bool
+}
+
+func (t *trailerReader) Read(b []byte) (int, error) {
+	n, err := t.r.Read(b)
+	if err != io.EOF || !t.trailers {
+		return n, err
+	}
+
+	t.trailers = false
+
+	var trailers pb.Trailers
+
+	_, terr := t.sctx.ReadMarshal(&trailers)
+	if terr != nil {
+		return n, terr
+	}
+
+	for _, h := range trailers.Headers {
+		if t.hreq.Trailer == nil {
+			t.hreq.Trailer = make(http.Header)
+		}
+
+		for _, v := range h.Value {
+			t.hreq.Trailer.Add(h.Name, v)
+		}
+	}
+
+	return n, io.EOF
+}
+
+// HTTP/2 forbids connection specific headers, so they have to be removed
+// from requests that arrived via HTTP/1.1.
+var connectionHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+func removeConnectionHeaders(hdr http.Header) {
+	for _, name := range connectionHeaders {
+		hdr.Del(name)
+	}
+
+	// TE is allowed, but only to indicate trailers are supported, which
+	// gRPC requires.
+	if te := hdr.Get("Te"); te != "" && te != "trailers" {
+		hdr.Del("Te")
+	}
+}
+
+// setForwardedHeaders adds the standard proxy headers to hreq so that the
+// upstream service sees the address of the original client rather than
+// the agent's.
+func setForwardedHeaders(hreq *http.Request, req *pb.Request) {
+	if req.RemoteAddr == "" {
+		return
+	}
+
+	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		clientIP = req.RemoteAddr
+	}
+
+	if prior, ok := hreq.Header["X-Forwarded-For"]; ok {
+		hreq.Header.Set("X-Forwarded-For", strings.Join(prior, ", ")+", "+clientIP)
+	} else {
+		hreq.Header.Set("X-Forwarded-For", clientIP)
+	}
+
+	fwd := "for=" + clientIP
+
+	// IPv6 addresses must be quoted and bracketed, per RFC 7239.
+	if strings.Contains(clientIP, ":") {
+		fwd = `for="[` + clientIP + `]"`
+	}
+
+	if req.Host != "" {
+		fwd += `;host="` + req.Host + `"`
+	}
+
+	if req.Scheme != "" {
+		hreq.Header.Set("X-Forwarded-Proto", req.Scheme)
+		fwd += ";proto=" + req.Scheme
+	}
+
+	if prior, ok := hreq.Header["Forwarded"]; ok {
+		fwd = strings.Join(prior, ", ") + ", " + fwd
+	}
+
+	hreq.Header.Set("Forwarded", fwd)
 }
@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+var ErrListenerClosed = errors.New("listener closed")
+
+// Listener is a net.Listener that accepts the connections made to a service
+// advertised by the agent.
+type Listener struct {
+	agent  *Agent
+	id     *pb.ULID
+	labels *pb.LabelSet
+
+	conns chan *Conn
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Listen advertises a service with the given type and labels and returns
+// a Listener that accepts a connection for each stream opened to it. This
+// allows servers that work with a net.Listener, such as http.Server or
+// grpc.Server, to be served through the agent directly.
+//
+// The connections carry the raw bytes sent by the peer, as with those
+// returned by Connect. Requests from the web frontend use a framed protocol
+// rather than plain HTTP, so they are rejected; serve those with
+// a ServiceHandler instead.
+func (a *Agent) Listen(typ string, labels *pb.LabelSet) (net.Listener, error) {
+	l := &Listener{
+		agent:  a,
+		labels: labels,
+		conns:  make(chan *Conn),
+		done:   make(chan struct{}),
+	}
+
+	id, err := a.AddService(&Service{
+		Type:    typ,
+		Labels:  labels,
+		Handler: &listenHandler{l},
+	})
+
+	if err != nil {
+		// The agent keeps the service even if a hub rejected it.
+		a.RemoveService(id)
+		return nil, err
+	}
+
+	l.id = id
+
+	return l, nil
+}
+
+// Accept waits for and returns the next connection to the service.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case <-l.done:
+		return nil, ErrListenerClosed
+	case conn := <-l.conns:
+		return conn, nil
+	}
+}
+
+// Close stops advertising the service. Connections already accepted are
+// unaffected.
+func (l *Listener) Close() error {
+	var err error
+
+	l.closeOnce.Do(func() {
+		close(l.done)
+		err = l.agent.RemoveService(l.id)
+	})
+
+	return err
+}
+
+// Addr returns the labels of the service.
+func (l *Listener) Addr() net.Addr {
+	return &agentConnAddr{labels: l.labels}
+}
+
+// ServiceId returns the id the service is advertised with.
+func (l *Listener) ServiceId() *pb.ULID {
+	return l.id
+}
+
+type listenHandler struct {
+	l *Listener
+}
+
+func (h *listenHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx ServiceContext) error {
+	sc, ok := sctx.(*serviceContext)
+	if !ok {
+		return fmt.Errorf("unsupported service context: %T", sctx)
+	}
+
+	if sc.protocolId == "http" {
+		sctx.Close()
+		return fmt.Errorf("unsupported protocol for listener: %s", sc.protocolId)
+	}
+
+	conn := &Conn{
+		Reader:      sctx.Reader(),
+		WriteCloser: sctx.Writer(),
+		Stream:      sc.ystream,
+		PeerAccount: sc.sourceAccount,
+		PeerAgent:   sc.sourceAgent,
+		closed:      make(chan struct{}),
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-h.l.done:
+		sctx.Close()
+		return ErrListenerClosed
+	case h.l.conns <- conn:
+		// ok
+	}
+
+	// The stream is closed once we return, so wait for the connection to
+	// be done with.
+	select {
+	case <-ctx.Done():
+		conn.Close()
+	case <-conn.closed:
+		// ok
+	}
+
+	return nil
+}
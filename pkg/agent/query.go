@@ -1,37 +1,34 @@
 package agent
 
 import (
+	"context"
+	"net"
+
+	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/wire"
 )
 
-func (a *Agent) RPCClient() (*wire.RPCClient, error) {
-	a.mu.Lock()
-	stream, err := a.sessions[0].OpenStream()
-	a.mu.Unlock()
-
-	if err != nil {
-		return nil, err
-	}
-
-	return wire.NewRPCClient(stream), nil
-}
-
-/*
+const hubRPCHost = "hub"
 
-func (a *Agent) QueryPeerService(labels []string) ([]*agents.Service, error) {
+// QueryPeerService returns the services visible to the agent's account
+// that match labels, including their type, labels and metadata. All the
+// services are returned if labels is nil.
+func (a *Agent) QueryPeerService(ctx context.Context, labels *pb.LabelSet) ([]*pb.PeerService, error) {
 	rpc, err := a.RPCClient()
 	if err != nil {
 		return nil, err
 	}
 
+	defer rpc.Close()
+
 	var (
-		req  agents.QueryRequest
-		resp agents.QueryResponse
+		req  pb.ServicesQuery
+		resp pb.ServicesQueryResponse
 	)
 
 	req.Labels = labels
 
-	err = rpc.Call("agents.edge", "/query/peers", &req, &resp)
+	err = rpc.CallContext(ctx, hubRPCHost, "/query/peers", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -39,21 +36,74 @@ func (a *Agent) QueryPeerService(labels []string) ([]*agents.Service, error) {
 	return resp.Services, nil
 }
 
-func (a *Agent) ConnectToPeer(serv *agents.Service) (wire.Context, error) {
+// PeerWatch receives the services matching a query as they change.
+type PeerWatch struct {
+	stream *wire.RPCStream
+}
+
+// WatchPeerServices is like QueryPeerService but keeps watching the
+// services. Each call to Next on the returned PeerWatch returns the
+// services once they differ from the ones previously returned. The watch
+// ends when ctx is done or the PeerWatch is closed.
+func (a *Agent) WatchPeerServices(ctx context.Context, labels *pb.LabelSet) (*PeerWatch, error) {
 	rpc, err := a.RPCClient()
 	if err != nil {
 		return nil, err
 	}
 
-	var req agents.ConnectRequest
-	req.Target = serv
+	stream, err := rpc.Stream(ctx, hubRPCHost, "/query/peers")
+	if err != nil {
+		rpc.Close()
+		return nil, err
+	}
+
+	var req pb.ServicesQuery
+	req.Labels = labels
+	req.Watch = true
+
+	err = stream.Send(&req)
+	if err == nil {
+		err = stream.CloseSend()
+	}
 
-	wctx, err := rpc.Begin("agents.edge", "/connect/peer", &req)
 	if err != nil {
+		stream.Close()
 		return nil, err
 	}
 
-	return wctx, nil
+	return &PeerWatch{stream: stream}, nil
 }
 
-*/
+// Next blocks until the services change, returning all the services that
+// currently match. The first call returns the services at the time the
+// watch began.
+func (w *PeerWatch) Next() ([]*pb.PeerService, error) {
+	var resp pb.ServicesQueryResponse
+
+	err := w.stream.Recv(&resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Services, nil
+}
+
+// Close stops the watch. A blocked call to Next returns an error.
+func (w *PeerWatch) Close() error {
+	return w.stream.Close()
+}
+
+// ConnectToPeer opens a connection to the specific service instance serv,
+// as returned by QueryPeerService. This allows a client to pick the
+// instance itself, for instance to balance connections across them.
+func (a *Agent) ConnectToPeer(ctx context.Context, serv *pb.PeerService) (net.Conn, error) {
+	labels := serv.Labels
+	if labels == nil {
+		labels = &pb.LabelSet{}
+	}
+
+	return a.dial(ctx, &pb.ConnectRequest{
+		Target:    labels,
+		ServiceId: serv.Id,
+	}, "")
+}
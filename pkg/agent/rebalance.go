@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/discovery"
+)
+
+// How much lower the latency of a hub must be than that of a connected hub
+// for the agent to move to it, so that it doesn't keep moving between hubs
+// with similar latencies.
+const rebalanceMargin = 0.25
+
+// pickRebalance returns the connected hub that should be replaced and the
+// hub to replace it with, given the hubs ranked by latency. A connected hub
+// missing from scores, because it's failing probes or is no longer
+// advertised, is always worth replacing.
+func pickRebalance(active map[string]discovery.HubConfig, scores []discovery.HubScore) (discovery.HubConfig, discovery.HubConfig, bool) {
+	latencies := make(map[string]time.Duration)
+
+	for _, s := range scores {
+		latencies[s.Addr] = s.Latency
+	}
+
+	var addrs []string
+
+	for addr := range active {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Strings(addrs)
+
+	var (
+		worst        string
+		worstLatency time.Duration
+		unlisted     bool
+	)
+
+	for _, addr := range addrs {
+		lat, ok := latencies[addr]
+		if !ok {
+			worst = addr
+			unlisted = true
+			break
+		}
+
+		if lat > worstLatency {
+			worst = addr
+			worstLatency = lat
+		}
+	}
+
+	if worst == "" {
+		return discovery.HubConfig{}, discovery.HubConfig{}, false
+	}
+
+	for _, s := range scores {
+		// Hubs that haven't been measured yet aren't known to be better.
+		if s.Latency == 0 {
+			continue
+		}
+
+		if _, ok := active[s.Addr]; ok {
+			continue
+		}
+
+		if unlisted || float64(s.Latency) < float64(worstLatency)*(1-rebalanceMargin) {
+			return active[worst], s.HubConfig, true
+		}
+
+		// scores is ordered by latency, so no other hub is better.
+		break
+	}
+
+	return discovery.HubConfig{}, discovery.HubConfig{}, false
+}
+
+// rebalance connects to a hub that has lower latency than one of the hubs
+// the agent is connected to, if there is one. The hub it replaces is
+// disconnected from once the connection to the new hub is established.
+func (a *Agent) rebalance(ctx context.Context) {
+	ranker, ok := a.hcp.(discovery.HubRanker)
+	if !ok {
+		return
+	}
+
+	// Only replace one hub at a time.
+	if len(a.rebalancing) > 0 {
+		return
+	}
+
+	active := make(map[string]discovery.HubConfig)
+
+	for addr, cfg := range a.activeHubs {
+		if _, ok := a.retiring[addr]; !ok {
+			active[addr] = cfg
+		}
+	}
+
+	scores, err := ranker.Best(ctx, 0)
+	if err != nil {
+		a.L.Warn("error ranking hubs", "error", err)
+		return
+	}
+
+	old, better, ok := pickRebalance(active, scores)
+	if !ok {
+		return
+	}
+
+	cfg, ok := a.hcp.Take(ctx)
+	if !ok {
+		return
+	}
+
+	// The ranking changed since Best was called, try again next time.
+	if cfg.Addr != better.Addr {
+		a.hcp.Return(cfg)
+		return
+	}
+
+	a.L.Info("moving to hub with lower latency", "from", old.Addr, "to", cfg.Addr)
+
+	a.rebalancing[cfg.Addr] = old.Addr
+
+	go a.connectToHub(ctx, cfg, a.statuses)
+}
+
+// retireHub closes the session to the hub at addr. The hub isn't replaced
+// when the disconnect is seen.
+func (a *Agent) retireHub(addr string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for session, hs := range a.hubs {
+		if hs.cfg.Addr == addr {
+			a.retiring[addr] = struct{}{}
+			session.Close()
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package agent
+
+import (
+	"github.com/hashicorp/horizon/pkg/wire"
+)
+
+// RPCClient returns a client to make an RPC to a hub over a new stream,
+// using a hub that supports RPCs. Close the client when done with it.
+func (a *Agent) RPCClient() (*wire.RPCClient, error) {
+	stream, hs, err := a.openStream(wire.FeatureRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := hs.openCompressed(stream, "")
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	return wire.NewRPCClient(cs, cs, cs), nil
+}
+
+// AddRPCMethod registers handler to serve RPCs that hubs make to path on
+// host. An empty host matches any host.
+func (a *Agent) AddRPCMethod(host, path string, handler wire.RPCHandler) {
+	a.rpc.AddHostMethod(host, path, handler)
+}
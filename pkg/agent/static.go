@@ -0,0 +1,222 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+type staticHandler struct {
+	root  http.FileSystem
+	files http.Handler
+}
+
+// StaticHandler returns a handler that serves the files in dir over HTTP.
+// Range and conditional requests are supported, with ETags derived from
+// the size and modification time of each file. Requests for a directory
+// serve it's index.html, or a listing of the directory if there is none.
+func StaticHandler(dir string) ServiceHandler {
+	root := http.Dir(dir)
+
+	return &staticHandler{
+		root:  root,
+		files: http.FileServer(root),
+	}
+}
+
+func (h *staticHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx ServiceContext) error {
+	return serveHTTP(ctx, L, sctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if etag := h.etag(r.URL.Path); etag != "" {
+			w.Header().Set("Etag", etag)
+		}
+
+		h.files.ServeHTTP(w, r)
+	}))
+}
+
+// etag returns the ETag of the file that will be served for name, or an
+// empty string if there is no such file. http.FileServer handles the
+// conditional headers itself, provided the ETag is set.
+func (h *staticHandler) etag(name string) string {
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+
+	name = path.Clean(name)
+
+	fi, err := h.stat(name)
+	if err != nil {
+		return ""
+	}
+
+	if fi.IsDir() {
+		fi, err = h.stat(path.Join(name, "index.html"))
+		if err != nil || fi.IsDir() {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf(`"%x-%x"`, fi.ModTime().UnixNano(), fi.Size())
+}
+
+func (h *staticHandler) stat(name string) (os.FileInfo, error) {
+	f, err := h.root.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return f.Stat()
+}
+
+// serveHTTP reads a request from sctx and serves it with handler, writing
+// the response back to sctx.
+func serveHTTP(ctx context.Context, L hclog.Logger, sctx ServiceContext, handler http.Handler) error {
+	proto := sctx.ProtocolId()
+
+	if !(proto == "" || proto == "http") {
+		return fmt.Errorf("unknown protocol: %s", proto)
+	}
+
+	var req pb.Request
+
+	_, err := sctx.ReadMarshal(&req)
+	if err != nil {
+		return err
+	}
+
+	L.Info("request started", "method", req.Method, "path", req.Path)
+
+	body := &trailerReader{sctx: sctx, r: sctx.Reader(), trailers: req.Trailers}
+
+	hreq, err := http.NewRequestWithContext(ctx, req.Method, req.Path, body)
+	if err != nil {
+		return err
+	}
+
+	body.hreq = hreq
+
+	hreq.Host = req.Host
+	hreq.RemoteAddr = req.RemoteAddr
+	hreq.URL.RawQuery = req.Query
+	hreq.RequestURI = hreq.URL.RequestURI()
+
+	for _, h := range req.Headers {
+		for _, v := range h.Value {
+			hreq.Header.Add(h.Name, v)
+		}
+	}
+
+	rw := &responseWriter{
+		sctx:     sctx,
+		hdr:      make(http.Header),
+		trailers: req.Trailers,
+	}
+
+	handler.ServeHTTP(rw, hreq)
+
+	err = rw.finish()
+	if err != nil {
+		return err
+	}
+
+	L.Info("request ended", "code", rw.code, "size", rw.size)
+
+	return nil
+}
+
+// responseWriter adapts a ServiceContext to an http.ResponseWriter.
+type responseWriter struct {
+	sctx     ServiceContext
+	hdr      http.Header
+	trailers bool
+
+	w    io.WriteCloser
+	code int
+	size int64
+	err  error
+}
+
+func (r *responseWriter) Header() http.Header {
+	return r.hdr
+}
+
+func (r *responseWriter) WriteHeader(code int) {
+	if r.w != nil {
+		return
+	}
+
+	r.code = code
+
+	var resp pb.Response
+	resp.Code = int32(code)
+	resp.Trailers = r.trailers
+
+	for k, v := range r.hdr {
+		resp.Headers = append(resp.Headers, &pb.Header{
+			Name:  k,
+			Value: v,
+		})
+	}
+
+	r.err = r.sctx.WriteMarshal(1, &resp)
+	r.w = r.sctx.Writer()
+}
+
+func (r *responseWriter) Write(b []byte) (int, error) {
+	if r.w == nil {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.w.Write(b)
+	r.size += int64(n)
+
+	return n, err
+}
+
+// finish completes the response, sending any trailers the handler set.
+func (r *responseWriter) finish() error {
+	if r.w == nil {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	err := r.w.Close()
+	if err != nil {
+		return err
+	}
+
+	if !r.trailers {
+		return nil
+	}
+
+	var trailers pb.Trailers
+
+	for k, v := range r.hdr {
+		if !strings.HasPrefix(k, http.TrailerPrefix) {
+			continue
+		}
+
+		trailers.Headers = append(trailers.Headers, &pb.Header{
+			Name:  strings.TrimPrefix(k, http.TrailerPrefix),
+			Value: v,
+		})
+	}
+
+	return r.sctx.WriteMarshal(1, &trailers)
+}
@@ -12,29 +12,38 @@ import (
 )
 
 type tcpHandler struct {
-	addr string
+	network string
+	addr    string
 }
 
 func TCPHandler(addr string) ServiceHandler {
-	return &tcpHandler{addr}
+	return &tcpHandler{network: "tcp", addr: addr}
 }
 
 func (h *tcpHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx ServiceContext) error {
-	defer sctx.Close()
 	proto := sctx.ProtocolId()
 
 	if !(proto == "" || proto == "tcp") {
+		sctx.Close()
 		return fmt.Errorf("unknown protocol: %s", proto)
 	}
 
-	c, err := net.Dial("tcp", h.addr)
+	return h.proxy(L, sctx)
+}
+
+// proxy copies data between the session and a new connection to the
+// handler's address until both sides are done.
+func (h *tcpHandler) proxy(L hclog.Logger, sctx ServiceContext) error {
+	defer sctx.Close()
+
+	c, err := net.Dial(h.network, h.addr)
 	if err != nil {
 		return err
 	}
 
 	id := pb.NewULID()
 
-	L.Trace("tcp session started", "id", id, "addr", h.addr, "session-addr", c.LocalAddr())
+	L.Trace("tcp session started", "id", id, "network", h.network, "addr", h.addr, "session-addr", c.LocalAddr())
 
 	r := sctx.Reader()
 	w := sctx.Writer()
@@ -0,0 +1,40 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+type unixHandler struct {
+	stream *tcpHandler
+	http   ServiceHandler
+}
+
+// UnixHandler returns a handler that forwards to the Unix domain socket at
+// path. HTTP requests, such as those from the web frontend, are forwarded
+// as HTTP, while other connections are proxied as a raw stream.
+func UnixHandler(path string) ServiceHandler {
+	h, _ := NewHTTPHandler(HTTPConfig{
+		URL:        "http://localhost",
+		SocketPath: path,
+	})
+
+	return &unixHandler{
+		stream: &tcpHandler{network: "unix", addr: path},
+		http:   h,
+	}
+}
+
+func (h *unixHandler) HandleRequest(ctx context.Context, L hclog.Logger, sctx ServiceContext) error {
+	switch proto := sctx.ProtocolId(); proto {
+	case "http":
+		return h.http.HandleRequest(ctx, L, sctx)
+	case "", "tcp", "unix":
+		return h.stream.proxy(L, sctx)
+	default:
+		sctx.Close()
+		return fmt.Errorf("unknown protocol: %s", proto)
+	}
+}
@@ -0,0 +1,246 @@
+// Package agentconfig implements the configuration file for hznagent, which
+// declares how to find hubs, the token to use and the services to advertise.
+// Files ending in .hcl are parsed as HCL, while .yaml, .yml and .json files
+// are parsed as YAML (of which JSON is a subset).
+package agentconfig
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	ErrUnknownFormat  = errors.New("unknown config file format")
+	ErrInvalidConfig  = errors.New("invalid configuration")
+	ErrUnknownHandler = errors.New("unknown service handler")
+)
+
+// Config is the top level of the configuration file.
+type Config struct {
+	// The address of the control plane to discover hubs from. If neither
+	// Control nor Hubs is set, the default control plane is used.
+	Control string `hcl:"control" json:"control"`
+
+	// The hex encoded public key that the control plane signs tokens with.
+	// If set, the hubs discovered from the control plane must be signed
+	// with it and their certificates are pinned.
+	ControlKey string `hcl:"control_key" json:"control_key"`
+
+	// Hubs to connect to directly, instead of discovering them.
+	Hubs []*Hub `hcl:"hub" json:"hubs"`
+
+	// The token to authenticate with. If Token is not set, the token is
+	// read from TokenFile.
+	Token     string `hcl:"token" json:"token"`
+	TokenFile string `hcl:"token_file" json:"token_file"`
+
+	// The labels of the agent itself.
+	Labels []string `hcl:"labels" json:"labels"`
+
+	Services []*Service `hcl:"service" json:"services"`
+}
+
+// Hub is a hub to connect to without consulting the control plane.
+type Hub struct {
+	Address  string `hcl:"address" json:"address"`
+	Name     string `hcl:"name" json:"name"`
+	Insecure bool   `hcl:"insecure" json:"insecure"`
+}
+
+// Service declares a service for the agent to advertise. Services are
+// identified by their name, which is only used within the configuration
+// to detect which services changed when it's reloaded.
+type Service struct {
+	Name string `hcl:",key" json:"name"`
+
+	// The type of the service. Defaults to the kind of handler.
+	Type string `hcl:"type" json:"type"`
+
+	// The labels of the service, in the form "key=val,key2=val2".
+	Labels string `hcl:"labels" json:"labels"`
+
+	Metadata map[string]string `hcl:"metadata" json:"metadata"`
+
+	// The kind of handler for the service: http, https, tcp, unix, static
+	// or echo.
+	Handler string `hcl:"handler" json:"handler"`
+
+	// The address the http, https and tcp handlers forward to.
+	Address string `hcl:"address" json:"address"`
+
+	// The socket the unix handler forwards to, or the directory the static
+	// handler serves.
+	Path string `hcl:"path" json:"path"`
+
+	// Options for http and https handlers.
+	HTTP *HTTPOptions `hcl:"http" json:"http"`
+}
+
+// HTTPOptions tune the connections made by http and https handlers. The
+// timeouts are durations, such as "30s".
+type HTTPOptions struct {
+	H2C             bool   `hcl:"h2c" json:"h2c"`
+	CACert          string `hcl:"ca_cert" json:"ca_cert"`
+	ClientCert      string `hcl:"client_cert" json:"client_cert"`
+	ClientKey       string `hcl:"client_key" json:"client_key"`
+	ServerName      string `hcl:"server_name" json:"server_name"`
+	Insecure        bool   `hcl:"insecure" json:"insecure"`
+	MaxIdleConns    int    `hcl:"max_idle_conns" json:"max_idle_conns"`
+	IdleTimeout     string `hcl:"idle_timeout" json:"idle_timeout"`
+	DialTimeout     string `hcl:"dial_timeout" json:"dial_timeout"`
+	ResponseTimeout string `hcl:"response_timeout" json:"response_timeout"`
+}
+
+// Parse reads and validates the configuration file at path.
+func Parse(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".hcl":
+		err = hcl.Decode(&cfg, string(data))
+	case ".yaml", ".yml", ".json":
+		err = yaml.UnmarshalStrict(data, &cfg)
+	default:
+		return nil, errors.Wrapf(ErrUnknownFormat, "path: %s", path)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", path)
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that the configuration is complete and consistent.
+func (c *Config) Validate() error {
+	if c.Control != "" && len(c.Hubs) > 0 {
+		return errors.Wrapf(ErrInvalidConfig, "control and hub are mutually exclusive")
+	}
+
+	for _, hub := range c.Hubs {
+		if hub.Address == "" {
+			return errors.Wrapf(ErrInvalidConfig, "hub missing address")
+		}
+	}
+
+	if c.ControlKey != "" {
+		_, err := c.PublicKey()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Token != "" && c.TokenFile != "" {
+		return errors.Wrapf(ErrInvalidConfig, "token and token_file are mutually exclusive")
+	}
+
+	seen := map[string]bool{}
+
+	for _, serv := range c.Services {
+		if serv.Name == "" {
+			return errors.Wrapf(ErrInvalidConfig, "service missing name")
+		}
+
+		if seen[serv.Name] {
+			return errors.Wrapf(ErrInvalidConfig, "duplicate service: %s", serv.Name)
+		}
+
+		seen[serv.Name] = true
+
+		err := serv.validate()
+		if err != nil {
+			return errors.Wrapf(err, "service %s", serv.Name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) validate() error {
+	switch s.Handler {
+	case "http", "https", "tcp":
+		if s.Address == "" {
+			return errors.Wrapf(ErrInvalidConfig, "%s handler requires an address", s.Handler)
+		}
+	case "unix", "static":
+		if s.Path == "" {
+			return errors.Wrapf(ErrInvalidConfig, "%s handler requires a path", s.Handler)
+		}
+	case "echo":
+		// ok
+	case "":
+		return errors.Wrapf(ErrInvalidConfig, "missing handler")
+	default:
+		return errors.Wrapf(ErrUnknownHandler, "handler: %s", s.Handler)
+	}
+
+	if s.HTTP != nil {
+		if s.Handler != "http" && s.Handler != "https" {
+			return errors.Wrapf(ErrInvalidConfig, "http options given for %s handler", s.Handler)
+		}
+
+		for _, d := range []string{s.HTTP.IdleTimeout, s.HTTP.DialTimeout, s.HTTP.ResponseTimeout} {
+			if d == "" {
+				continue
+			}
+
+			_, err := time.ParseDuration(d)
+			if err != nil {
+				return errors.Wrapf(ErrInvalidConfig, "invalid duration: %s", d)
+			}
+		}
+	}
+
+	return nil
+}
+
+// PublicKey decodes ControlKey. It returns nil if ControlKey isn't set.
+func (c *Config) PublicKey() (ed25519.PublicKey, error) {
+	if c.ControlKey == "" {
+		return nil, nil
+	}
+
+	key, err := hex.DecodeString(c.ControlKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.Wrapf(ErrInvalidConfig, "invalid control_key")
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+// ReadToken returns the configured token, reading it from TokenFile if
+// needed. It returns an empty string if neither is set.
+func (c *Config) ReadToken() (string, error) {
+	if c.Token != "" {
+		return c.Token, nil
+	}
+
+	if c.TokenFile == "" {
+		return "", nil
+	}
+
+	data, err := ioutil.ReadFile(c.TokenFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "error reading token file")
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
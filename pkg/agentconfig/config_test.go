@@ -0,0 +1,226 @@
+package agentconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/agent"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHCL = `
+control = "control.example.com"
+control_key = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+token_file = "/etc/hzn/token"
+labels = ["host=web1"]
+
+service "web" {
+  handler = "http"
+  address = "8080"
+  labels = "app=web,env=prod"
+
+  metadata {
+    version = "1.2"
+  }
+
+  http {
+    h2c = true
+    dial_timeout = "5s"
+  }
+}
+
+service "db" {
+  handler = "tcp"
+  address = "localhost:5432"
+  labels = "app=db"
+}
+`
+
+const testYAML = `
+hubs:
+  - address: hub.example.com:443
+    insecure: true
+token: abcd
+services:
+  - name: web
+    handler: https
+    address: internal.example.com
+    labels: app=web
+    http:
+      ca_cert: /etc/hzn/ca.pem
+  - name: echo
+    handler: echo
+    type: test
+  - name: files
+    handler: static
+    path: /srv/files
+`
+
+func writeConfig(t *testing.T, pattern, data string) string {
+	f, err := ioutil.TempFile("", pattern)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	_, err = f.Write([]byte(data))
+	require.NoError(t, err)
+
+	return f.Name()
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("parses hcl", func(t *testing.T) {
+		path := writeConfig(t, "agent*.hcl", testHCL)
+		defer os.Remove(path)
+
+		cfg, err := Parse(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, "control.example.com", cfg.Control)
+
+		key, err := cfg.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, 32, len(key))
+		assert.Equal(t, "/etc/hzn/token", cfg.TokenFile)
+		assert.Equal(t, []string{"host=web1"}, cfg.Labels)
+
+		require.Equal(t, 2, len(cfg.Services))
+
+		web := cfg.Services[0]
+		assert.Equal(t, "web", web.Name)
+		assert.Equal(t, "http", web.ServiceType())
+		assert.Equal(t, "http://127.0.0.1:8080", web.url())
+		assert.Equal(t, map[string]string{"version": "1.2"}, web.Metadata)
+
+		require.NotNil(t, web.HTTP)
+		assert.True(t, web.HTTP.H2C)
+		assert.Equal(t, "5s", web.HTTP.DialTimeout)
+
+		db := cfg.Services[1]
+		assert.Equal(t, "db", db.Name)
+		assert.Equal(t, "tcp", db.ServiceType())
+	})
+
+	t.Run("parses yaml", func(t *testing.T) {
+		path := writeConfig(t, "agent*.yaml", testYAML)
+		defer os.Remove(path)
+
+		cfg, err := Parse(path)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(cfg.Hubs))
+		assert.Equal(t, "hub.example.com:443", cfg.Hubs[0].Address)
+		assert.True(t, cfg.Hubs[0].Insecure)
+
+		token, err := cfg.ReadToken()
+		require.NoError(t, err)
+		assert.Equal(t, "abcd", token)
+
+		require.Equal(t, 3, len(cfg.Services))
+
+		web := cfg.Services[0]
+		assert.Equal(t, "http", web.ServiceType())
+		assert.Equal(t, "https://internal.example.com:443", web.url())
+		assert.Equal(t, "/etc/hzn/ca.pem", web.HTTP.CACert)
+
+		assert.Equal(t, "test", cfg.Services[1].ServiceType())
+
+		files := cfg.Services[2]
+		assert.Equal(t, "http", files.ServiceType())
+		assert.Equal(t, "/srv/files", files.Path)
+	})
+
+	t.Run("rejects invalid configurations", func(t *testing.T) {
+		cases := map[string]*Config{
+			"control and hubs": {
+				Control: "control.example.com",
+				Hubs:    []*Hub{{Address: "hub.example.com:443"}},
+			},
+			"duplicate services": {
+				Services: []*Service{
+					{Name: "a", Handler: "echo"},
+					{Name: "a", Handler: "echo"},
+				},
+			},
+			"missing address": {
+				Services: []*Service{{Name: "a", Handler: "tcp"}},
+			},
+			"missing path": {
+				Services: []*Service{{Name: "a", Handler: "unix"}},
+			},
+			"bad control key": {
+				Control:    "control.example.com",
+				ControlKey: "abcd",
+			},
+			"bad duration": {
+				Services: []*Service{{
+					Name:    "a",
+					Handler: "http",
+					Address: "80",
+					HTTP:    &HTTPOptions{IdleTimeout: "soon"},
+				}},
+			},
+		}
+
+		for name, cfg := range cases {
+			assert.True(t, errors.Is(cfg.Validate(), ErrInvalidConfig), name)
+		}
+
+		cfg := &Config{Services: []*Service{{Name: "a", Handler: "ftp"}}}
+		assert.True(t, errors.Is(cfg.Validate(), ErrUnknownHandler))
+
+		path := writeConfig(t, "agent*.toml", "")
+		defer os.Remove(path)
+
+		_, err := Parse(path)
+		assert.True(t, errors.Is(err, ErrUnknownFormat))
+	})
+
+	t.Run("applies only changed services", func(t *testing.T) {
+		L := hclog.New(&hclog.LoggerOptions{
+			Name:  "test",
+			Level: hclog.Trace,
+		})
+
+		a, err := agent.NewAgent(L)
+		require.NoError(t, err)
+
+		set := NewServiceSet(L, a)
+
+		err = set.Apply([]*Service{
+			{Name: "a", Handler: "echo", Labels: "app=a"},
+			{Name: "b", Handler: "tcp", Address: "4000"},
+		})
+		require.NoError(t, err)
+
+		idA := set.ID("a")
+		idB := set.ID("b")
+
+		require.NotNil(t, idA)
+		require.NotNil(t, idB)
+
+		err = set.Apply([]*Service{
+			{Name: "a", Handler: "echo", Labels: "app=a"},
+			{Name: "b", Handler: "tcp", Address: "5000"},
+			{Name: "c", Handler: "echo"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, idA, set.ID("a"))
+		assert.NotEqual(t, idB, set.ID("b"))
+		assert.NotNil(t, set.ID("c"))
+
+		err = set.Apply([]*Service{
+			{Name: "c", Handler: "echo"},
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, set.ID("a"))
+		assert.Nil(t, set.ID("b"))
+		assert.NotNil(t, set.ID("c"))
+	})
+}
@@ -0,0 +1,213 @@
+package agentconfig
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/horizon/pkg/agent"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// ServiceType returns the type the service is advertised as.
+func (s *Service) ServiceType() string {
+	if s.Type != "" {
+		return s.Type
+	}
+
+	if s.Handler == "https" || s.Handler == "static" {
+		return "http"
+	}
+
+	return s.Handler
+}
+
+// NewHandler creates the handler declared by the service.
+func (s *Service) NewHandler() (agent.ServiceHandler, error) {
+	switch s.Handler {
+	case "http", "https":
+		cfg := agent.HTTPConfig{
+			URL: s.url(),
+		}
+
+		if o := s.HTTP; o != nil {
+			cfg.H2C = o.H2C
+			cfg.CACertFile = o.CACert
+			cfg.ClientCertFile = o.ClientCert
+			cfg.ClientKeyFile = o.ClientKey
+			cfg.ServerName = o.ServerName
+			cfg.InsecureSkipVerify = o.Insecure
+			cfg.MaxIdleConns = o.MaxIdleConns
+
+			// The durations were checked by Validate.
+			cfg.IdleConnTimeout, _ = parseDuration(o.IdleTimeout)
+			cfg.DialTimeout, _ = parseDuration(o.DialTimeout)
+			cfg.ResponseHeaderTimeout, _ = parseDuration(o.ResponseTimeout)
+		}
+
+		return agent.NewHTTPHandler(cfg)
+	case "tcp":
+		return agent.TCPHandler(hostPort(s.Address, "")), nil
+	case "unix":
+		return agent.UnixHandler(s.Path), nil
+	case "static":
+		return agent.StaticHandler(s.Path), nil
+	case "echo":
+		return agent.EchoHandler(), nil
+	default:
+		return nil, ErrUnknownHandler
+	}
+}
+
+func (s *Service) url() string {
+	if strings.Contains(s.Address, "://") {
+		return s.Address
+	}
+
+	if s.Handler == "https" {
+		return "https://" + hostPort(s.Address, "443")
+	}
+
+	return "http://" + hostPort(s.Address, "80")
+}
+
+// hostPort expands a bare port to a local address and adds defPort to
+// a bare host.
+func hostPort(addr, defPort string) string {
+	if strings.IndexByte(addr, ':') != -1 {
+		return addr
+	}
+
+	if _, err := strconv.Atoi(addr); err == nil {
+		return "127.0.0.1:" + addr
+	}
+
+	if defPort == "" {
+		return addr
+	}
+
+	return addr + ":" + defPort
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(s)
+}
+
+// ServiceSet keeps the services advertised by an agent in line with the
+// services in a configuration.
+type ServiceSet struct {
+	L     hclog.Logger
+	agent *agent.Agent
+
+	active map[string]*activeService
+}
+
+type activeService struct {
+	cfg *Service
+	id  *pb.ULID
+}
+
+// NewServiceSet returns a ServiceSet that manages the services of a.
+func NewServiceSet(L hclog.Logger, a *agent.Agent) *ServiceSet {
+	return &ServiceSet{
+		L:      L,
+		agent:  a,
+		active: make(map[string]*activeService),
+	}
+}
+
+// Apply changes the services of the agent to match services. Services that
+// are no longer present are removed, new ones are added and ones whose
+// declaration changed are replaced. Services that are unchanged are left
+// alone, so connections to them are unaffected.
+func (s *ServiceSet) Apply(services []*Service) error {
+	want := make(map[string]*Service)
+
+	for _, serv := range services {
+		want[serv.Name] = serv
+	}
+
+	// Create all the new handlers before touching the agent so that a bad
+	// declaration leaves the current services in place.
+	handlers := make(map[string]agent.ServiceHandler)
+
+	for _, serv := range services {
+		cur, ok := s.active[serv.Name]
+		if ok && reflect.DeepEqual(cur.cfg, serv) {
+			continue
+		}
+
+		h, err := serv.NewHandler()
+		if err != nil {
+			return err
+		}
+
+		handlers[serv.Name] = h
+	}
+
+	var result error
+
+	for name, cur := range s.active {
+		if _, ok := handlers[name]; !ok && want[name] != nil {
+			continue
+		}
+
+		s.L.Info("removing service", "name", name, "id", cur.id)
+
+		err := s.agent.RemoveService(cur.id)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		delete(s.active, name)
+	}
+
+	// Add in a stable order so the logs are predictable.
+	var names []string
+
+	for name := range handlers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		serv := want[name]
+
+		// The agent keeps the service even if a hub rejects it, so track it
+		// regardless of the error.
+		id, err := s.agent.AddService(&agent.Service{
+			Type:     serv.ServiceType(),
+			Labels:   pb.ParseLabelSet(serv.Labels),
+			Metadata: serv.Metadata,
+			Handler:  handlers[name],
+		})
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		s.L.Info("added service", "name", name, "id", id, "type", serv.ServiceType(), "labels", serv.Labels)
+
+		s.active[name] = &activeService{cfg: serv, id: id}
+	}
+
+	return result
+}
+
+// ID returns the id of the service with the given name, or nil if there
+// is no such service.
+func (s *ServiceSet) ID(name string) *pb.ULID {
+	if cur, ok := s.active[name]; ok {
+		return cur.id
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package connect
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"net"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/netloc"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/yamux"
@@ -33,43 +35,66 @@ var ErrInvalidToken = errors.New("invalid token")
 var activeSessions = new(int64)
 
 func Connect(L hclog.Logger, addr, token string) (*Session, error) {
-	var clientTlsConfig tls.Config
-	clientTlsConfig.InsecureSkipVerify = true
-	clientTlsConfig.NextProtos = []string{"hzn"}
+	return ConnectAddresses(context.Background(), L, []string{addr}, token)
+}
 
-	cconn, err := tls.Dial("tcp", addr, &clientTlsConfig)
+// ConnectAddresses connects to a hub that can be reached at any of addrs,
+// racing the addresses of each family and using the first connection to
+// succeed. Addresses without a port use 443.
+func ConnectAddresses(ctx context.Context, L hclog.Logger, addrs []string, token string) (*Session, error) {
+	conn, err := netloc.DialAddresses(ctx, nil, addrs, "443")
 	if err != nil {
 		return nil, err
 	}
 
-	var preamble pb.Preamble
-	preamble.Token = token
+	return NewSession(ctx, L, conn, token)
+}
 
-	fw, err := wire.NewFramingWriter(cconn)
-	if err != nil {
-		return nil, err
-	}
+// NewSession establishes a session with a hub over conn. The handshake is
+// abandoned if ctx is done before it completes. conn is closed if the
+// session can't be established.
+func NewSession(ctx context.Context, L hclog.Logger, conn net.Conn, token string) (*Session, error) {
+	var clientTlsConfig tls.Config
+	clientTlsConfig.InsecureSkipVerify = true
+	clientTlsConfig.NextProtos = []string{"hzn"}
 
-	_, err = fw.WriteMarshal(1, &preamble)
-	if err != nil {
-		return nil, err
+	cconn := tls.Client(conn, &clientTlsConfig)
+
+	// Interrupt the handshake if ctx is done by expiring the deadline of
+	// the connection.
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	fr, err := handshake(cconn, token)
+
+	close(done)
+	<-stopped
+
+	if err == nil {
+		err = ctx.Err()
 	}
 
-	fr, err := wire.NewFramingReader(cconn)
 	if err != nil {
-		return nil, err
-	}
+		cconn.Close()
 
-	var confirmation pb.Confirmation
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
-	_, _, err = fr.ReadMarshal(&confirmation)
-	if err != nil {
 		return nil, err
 	}
 
-	if confirmation.Status != "connected" {
-		return nil, ErrInvalidToken
-	}
+	conn.SetDeadline(time.Time{})
 
 	bc := &wire.ComposedConn{
 		Reader: fr.BufReader(),
@@ -96,6 +121,46 @@ func Connect(L hclog.Logger, addr, token string) (*Session, error) {
 	return &Session{conn: cconn, session: session}, nil
 }
 
+// handshake performs the TLS handshake and presents token to the hub,
+// returning the reader for the rest of the connection.
+func handshake(cconn *tls.Conn, token string) (*wire.FramingReader, error) {
+	err := cconn.Handshake()
+	if err != nil {
+		return nil, err
+	}
+
+	var preamble pb.Preamble
+	preamble.Token = token
+
+	fw, err := wire.NewFramingWriter(cconn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = fw.WriteMarshal(1, &preamble)
+	if err != nil {
+		return nil, err
+	}
+
+	fr, err := wire.NewFramingReader(cconn)
+	if err != nil {
+		return nil, err
+	}
+
+	var confirmation pb.Confirmation
+
+	_, _, err = fr.ReadMarshal(&confirmation)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmation.Status != "connected" {
+		return nil, ErrInvalidToken
+	}
+
+	return fr, nil
+}
+
 func (s *Session) Close() error {
 	val := atomic.AddInt64(activeSessions, -1)
 	metrics.SetGauge([]string{"connect", "sessions"}, float32(val))
@@ -105,6 +170,16 @@ func (s *Session) Close() error {
 }
 
 func (s *Session) ConnecToAccountService(acc *pb.Account, labels *pb.LabelSet) (*Conn, error) {
+	var conreq pb.ConnectRequest
+	conreq.Target = labels
+	conreq.PivotAccount = acc
+
+	return s.Connect(&conreq)
+}
+
+// Connect sends conreq to the hub and returns a connection to the service
+// it routes the request to.
+func (s *Session) Connect(conreq *pb.ConnectRequest) (*Conn, error) {
 	stream, err := s.session.OpenStream()
 	if err != nil {
 		return nil, err
@@ -120,11 +195,7 @@ func (s *Session) ConnecToAccountService(acc *pb.Account, labels *pb.LabelSet) (
 		return nil, err
 	}
 
-	var conreq pb.ConnectRequest
-	conreq.Target = labels
-	conreq.PivotAccount = acc
-
-	_, err = fw2.WriteMarshal(1, &conreq)
+	_, err = fw2.WriteMarshal(1, conreq)
 	if err != nil {
 		return nil, err
 	}
@@ -3,80 +3,245 @@ package control
 import (
 	context "context"
 	"encoding/json"
+	fmt "fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/horizon/pkg/dbx"
 	"github.com/jinzhu/gorm"
-	"github.com/lib/pq"
+	"github.com/pkg/errors"
 )
 
 // A quick note. This activity system is different than the one used between the hubs and control.
-// This system is for managing an activity log that is in postgresql and shared between central
-// teir instances. We use it instead of a message queue system right now for simplicity.
+// This system is for managing an activity log that is shared between central teir instances.
 
+// ActivityLog is an event in the activity log. Events are delivered in the
+// order of their ids, and the id of an event is the cursor to resume
+// reading after it.
 type ActivityLog struct {
 	Id        int64 `gorm:"primary_key"`
 	Event     []byte
 	CreatedAt time.Time
 }
 
-type ActivityReader struct {
-	db       *gorm.DB
-	listener *pq.Listener
+// Pass to ActivityBus.Subscribe to only read events published after
+// subscribing.
+const CursorLatest int64 = -1
 
-	lastEntry int64
-	cancel    func()
+// Returned by ActivityBus.Subscribe when events after the cursor are no
+// longer retained, or the cursor didn't come from this log.
+var ErrCursorOutOfRange = errors.New("activity cursor is out of range")
 
-	C chan []*ActivityLog
+// How long events are kept in the activity log by default. Readers can
+// only resume from a cursor within this window.
+var ActivityRetention = 6 * time.Hour
 
-	wg sync.WaitGroup
+// How often readers check for new events in case a notification was
+// missed.
+var activityPollInterval = time.Minute
+
+// ActivityBus carries activity between central tier instances. Events are
+// delivered to each reader in order, and a reader can start from the
+// cursor of the last event it saw to replay the events after it.
+type ActivityBus interface {
+	// Publish adds an event to the log. v is either a []byte or is encoded
+	// as JSON.
+	Publish(ctx context.Context, v interface{}) error
+
+	// Subscribe returns a reader of the events after cursor. Use 0 to read
+	// every event that's still retained, or CursorLatest for new events
+	// only. Returns ErrCursorOutOfRange if events after cursor may have
+	// been pruned.
+	Subscribe(ctx context.Context, cursor int64) (*ActivityReader, error)
+
+	// Prune removes the events that are older than the retention period.
+	Prune(ctx context.Context) error
+
+	Close() error
 }
 
-var pgActivityChannel = "activaty_added"
+const (
+	ActivityBusPostgres = "postgres"
+	ActivityBusMemory   = "memory"
+	ActivityBusHTTP     = "http"
+)
 
-func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReader, error) {
-	db, err := gorm.Open(dbtype, conn)
-	if err != nil {
-		return nil, err
+type ActivityBusConfig struct {
+	// One of ActivityBusPostgres, ActivityBusMemory, or ActivityBusHTTP.
+	// Defaults to ActivityBusPostgres.
+	Type string
+
+	// How long to keep events for. Defaults to ActivityRetention.
+	Retention time.Duration
+
+	// Used by the postgres bus, the database the log is stored in and the
+	// connection string to listen for notifications on.
+	DB       *gorm.DB
+	ConnInfo string
+
+	// Used by the http bus, the base URLs of the other control instances
+	// and the token they authenticate with.
+	Peers []string
+	Token string
+}
+
+// NewActivityBus creates an ActivityBus of the type requested by cfg.
+func NewActivityBus(ctx context.Context, cfg ActivityBusConfig) (ActivityBus, error) {
+	if cfg.Retention == 0 {
+		cfg.Retention = ActivityRetention
 	}
 
-	L := hclog.FromContext(ctx)
+	switch cfg.Type {
+	case ActivityBusPostgres, "":
+		if cfg.DB == nil {
+			return nil, fmt.Errorf("postgres activity bus requires a database")
+		}
 
-	reportProblem := func(ev pq.ListenerEventType, err error) {
-		if err != nil {
-			L.Error("problem observed while listen on postgres channel", "error", err)
+		return NewPostgresActivityBus(ctx, cfg.DB, cfg.ConnInfo, cfg.Retention)
+	case ActivityBusMemory:
+		return NewMemActivityBus(cfg.Retention), nil
+	case ActivityBusHTTP:
+		// Peers push activity to an endpoint on the public mux, so it's
+		// never served without authentication.
+		if cfg.Token == "" {
+			return nil, fmt.Errorf("http activity bus requires a token")
 		}
+
+		return NewHTTPActivityBus(ctx, cfg.Peers, cfg.Token, cfg.Retention), nil
+	default:
+		return nil, fmt.Errorf("unknown activity bus type: %s", cfg.Type)
 	}
+}
 
-	minReconn := 10 * time.Second
-	maxReconn := time.Minute
-	listener := pq.NewListener(conn, minReconn, maxReconn, reportProblem)
+// encodeActivity returns the encoded form of an event passed to Publish.
+func encodeActivity(v interface{}) ([]byte, error) {
+	if data, ok := v.([]byte); ok {
+		return data, nil
+	}
+
+	return json.Marshal(v)
+}
 
-	err = listener.Listen(pgActivityChannel)
+// activityStore is the log that an ActivityReader reads from.
+type activityStore interface {
+	// eventsAfter returns up to limit events with ids greater than cursor,
+	// in order.
+	eventsAfter(ctx context.Context, cursor int64, limit int) ([]*ActivityLog, error)
+
+	// lastId returns the id of the newest event, or 0 if there are none.
+	lastId(ctx context.Context) (int64, error)
+
+	// firstId returns the id of the oldest event that's still retained, or
+	// 0 if there are none.
+	firstId(ctx context.Context) (int64, error)
+}
+
+// checkCursor returns ErrCursorOutOfRange unless every event after cursor
+// is still in store. A cursor past the newest event didn't come from
+// store at all.
+func checkCursor(ctx context.Context, store activityStore, cursor int64) error {
+	first, err := store.firstId(ctx)
 	if err != nil {
-		db.Close()
-		return nil, err
+		return err
 	}
 
-	var entry ActivityLog
-
-	err = dbx.Check(db.Last(&entry))
+	last, err := store.lastId(ctx)
 	if err != nil {
-		if err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	switch {
+	case cursor > last:
+		return errors.Wrapf(ErrCursorOutOfRange, "cursor %d is past the newest event", cursor)
+	case first == 0 && cursor < last, first > 0 && cursor < first-1:
+		return errors.Wrapf(ErrCursorOutOfRange, "events after cursor %d have been pruned", cursor)
+	default:
+		return nil
+	}
+}
+
+// notifier wakes up readers when events are added to a log.
+type notifier struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func (n *notifier) subscribe() (chan struct{}, func()) {
+	c := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.subs == nil {
+		n.subs = make(map[chan struct{}]struct{})
+	}
+
+	n.subs[c] = struct{}{}
+
+	return c, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.subs, c)
+	}
+}
+
+func (n *notifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for c := range n.subs {
+		select {
+		case c <- struct{}{}:
+		default:
+			// Already has a pending notification
+		}
+	}
+}
+
+// ActivityReader delivers the events of an ActivityBus on C in order.
+type ActivityReader struct {
+	store  activityStore
+	notify <-chan struct{}
+	done   func()
+
+	cursor int64
+	cancel func()
+
+	C chan []*ActivityLog
+
+	wg sync.WaitGroup
+}
+
+func newActivityReader(ctx context.Context, store activityStore, n *notifier, cursor int64) (*ActivityReader, error) {
+	if cursor == CursorLatest {
+		last, err := store.lastId(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor = last
+	} else if cursor > 0 {
+		err := checkCursor(ctx, store, cursor)
+		if err != nil {
 			return nil, err
 		}
 	}
 
+	notify, done := n.subscribe()
+
+	L := hclog.FromContext(ctx)
+
 	ctx, cancel := context.WithCancel(ctx)
 
 	ar := &ActivityReader{
-		db:        db,
-		listener:  listener,
-		C:         make(chan []*ActivityLog),
-		lastEntry: entry.Id,
-		cancel:    cancel,
+		store:  store,
+		notify: notify,
+		done:   done,
+		cursor: cursor,
+		cancel: cancel,
+		C:      make(chan []*ActivityLog),
 	}
 
 	ar.wg.Add(1)
@@ -85,36 +250,39 @@ func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReade
 	return ar, nil
 }
 
+// Cursor returns the id of the last event delivered on C. Subscribing with
+// it later resumes where this reader left off.
+func (ar *ActivityReader) Cursor() int64 {
+	return atomic.LoadInt64(&ar.cursor)
+}
+
 func (ar *ActivityReader) watch(ctx context.Context, L hclog.Logger) {
 	defer ar.wg.Done()
 
-	ticker := time.NewTicker(time.Minute)
+	ticker := time.NewTicker(activityPollInterval)
 	defer ticker.Stop()
 
 	for {
+		// Check first so that a reader started from a cursor replays the
+		// events after it right away.
+		ar.checkLog(ctx, L)
+
 		select {
 		case <-ctx.Done():
 			return
-		case <-ar.listener.Notify:
+		case <-ar.notify:
 			// got event
 		case <-ticker.C:
 			// timed out, check
 		}
-
-		ar.checkLog(ctx, L)
 	}
 }
 
 func (ar *ActivityReader) checkLog(ctx context.Context, L hclog.Logger) {
 	for {
-		var entries []*ActivityLog
-
-		err := dbx.Check(ar.db.Where("id > ?", ar.lastEntry).Limit(100).Find(&entries))
+		entries, err := ar.store.eventsAfter(ctx, ar.Cursor(), 100)
 		if err != nil {
-			if err != gorm.ErrRecordNotFound {
-				L.Error("error looking for new activity log entries", "error", err)
-			}
-
+			L.Error("error looking for new activity log entries", "error", err)
 			return
 		}
 
@@ -129,45 +297,13 @@ func (ar *ActivityReader) checkLog(ctx context.Context, L hclog.Logger) {
 			// ok
 		}
 
-		ar.lastEntry = entries[len(entries)-1].Id
+		atomic.StoreInt64(&ar.cursor, entries[len(entries)-1].Id)
 	}
 }
 
 func (ar *ActivityReader) Close() error {
 	ar.cancel()
 	ar.wg.Wait()
-	return ar.db.Close()
-}
-
-type ActivityInjector struct {
-	db *gorm.DB
-}
-
-func NewActivityInjector(db *gorm.DB) (*ActivityInjector, error) {
-	ai := &ActivityInjector{
-		db: db,
-	}
-
-	return ai, nil
-}
-
-func (ai *ActivityInjector) Inject(ctx context.Context, v interface{}) error {
-	var entry ActivityLog
-
-	switch sv := v.(type) {
-	case []byte:
-		entry.Event = sv
-	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return err
-		}
-		entry.Event = data
-	}
-
-	tx := ai.db.Begin()
-	tx.Create(&entry)
-	tx.Exec("NOTIFY " + pgActivityChannel)
-
-	return dbx.Check(tx.Commit())
+	ar.done()
+	return nil
 }
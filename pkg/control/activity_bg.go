@@ -2,19 +2,14 @@ package control
 
 import (
 	context "context"
-
-	"github.com/hashicorp/horizon/pkg/dbx"
-	"github.com/jinzhu/gorm"
 )
 
-var LogPruneInterval = "6 hours"
-
+// LogCleaner removes events past their retention from the activity log of
+// Bus. It's run periodically as the cleanup-activity-log job.
 type LogCleaner struct {
-	DB *gorm.DB
+	Bus ActivityBus
 }
 
 func (l *LogCleaner) CleanupActivityLog(ctx context.Context, jobType string, _ *struct{}) error {
-	return dbx.Check(
-		l.DB.Exec("DELETE FROM activity_logs WHERE created_at < now() - ?::interval", LogPruneInterval),
-	)
+	return l.Bus.Prune(ctx)
 }
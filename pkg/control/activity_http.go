@@ -0,0 +1,281 @@
+package control
+
+import (
+	"bytes"
+	context "context"
+	"crypto/subtle"
+	"encoding/json"
+	fmt "fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// The path that control servers accept activity pushed by their peers on.
+const ActivityHTTPPath = "/activity/events"
+
+// How long to wait before pushing to a peer again after a push failed.
+var activityRetryInterval = 5 * time.Second
+
+// HTTPActivityBus fans activity out to the other control instances over
+// HTTP, without a shared database. Each instance keeps its own log in
+// memory, which its readers read from. Events published on an instance are
+// pushed to each peer in the order they were published, so events from the
+// same instance are delivered in that order everywhere. A peer that was
+// unreachable or restarted is caught up from the events that are still
+// retained.
+type HTTPActivityBus struct {
+	L hclog.Logger
+
+	// The log that readers on this instance read.
+	log *MemActivityBus
+
+	// The events published on this instance, which are pushed to the
+	// peers. The origin is unique to each process so that peers don't
+	// confuse the events of a restarted instance with those from before.
+	origin string
+	own    *MemActivityBus
+
+	peers  []string
+	token  string
+	client *http.Client
+
+	mu sync.Mutex
+
+	// The id of the last event received from each origin.
+	received map[string]int64
+
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+type activityPush struct {
+	Origin string `json:"origin"`
+
+	// The id of the event before the first one in Events. Events are only
+	// accepted if this is the last one received from the origin.
+	After int64 `json:"after"`
+
+	Events []*ActivityLog `json:"events"`
+}
+
+type activityPushResponse struct {
+	// The id of the last event received from the origin.
+	Last int64 `json:"last"`
+}
+
+// NewHTTPActivityBus creates a bus that pushes activity to peers, the base
+// URLs of the other control instances. The bus must also be served at
+// ActivityHTTPPath to receive their activity. token is sent with pushes
+// and required of the pushes received, which are all rejected if it's
+// empty.
+func NewHTTPActivityBus(ctx context.Context, peers []string, token string, retention time.Duration) *HTTPActivityBus {
+	ctx, cancel := context.WithCancel(ctx)
+
+	b := &HTTPActivityBus{
+		L:      hclog.FromContext(ctx),
+		log:    NewMemActivityBus(retention),
+		origin: pb.NewULID().SpecString(),
+		own:    NewMemActivityBus(retention),
+		peers:  peers,
+		token:  token,
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: cleanhttp.DefaultPooledTransport(),
+		},
+		received: make(map[string]int64),
+		cancel:   cancel,
+	}
+
+	// Cursors are only meaningful to the instance that issued them. Start
+	// the ids of the log past those of any instance started before this
+	// one, so that a cursor from another instance, or from before a
+	// restart, is out of range rather than silently skipping events.
+	b.log.last = time.Now().UnixNano()
+
+	for _, peer := range peers {
+		b.wg.Add(1)
+		go b.pushTo(ctx, strings.TrimRight(peer, "/"))
+	}
+
+	return b
+}
+
+func (b *HTTPActivityBus) Publish(ctx context.Context, v interface{}) error {
+	data, err := encodeActivity(v)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	b.log.append(data, now)
+	b.own.append(data, now)
+
+	return nil
+}
+
+func (b *HTTPActivityBus) Subscribe(ctx context.Context, cursor int64) (*ActivityReader, error) {
+	return b.log.Subscribe(ctx, cursor)
+}
+
+func (b *HTTPActivityBus) Prune(ctx context.Context) error {
+	b.log.Prune(ctx)
+	return b.own.Prune(ctx)
+}
+
+// pushTo sends the events published on this instance to peer as they're
+// published, starting with those the peer hasn't seen.
+func (b *HTTPActivityBus) pushTo(ctx context.Context, peer string) {
+	defer b.wg.Done()
+
+	notify, done := b.own.n.subscribe()
+	defer done()
+
+	ticker := time.NewTicker(activityPollInterval)
+	defer ticker.Stop()
+
+	var acked int64
+
+	for {
+		var retry <-chan time.Time
+
+		for {
+			events, _ := b.own.eventsAfter(ctx, acked, 100)
+			if len(events) == 0 {
+				break
+			}
+
+			last, err := b.push(ctx, peer, acked, events)
+			if err != nil {
+				b.L.Warn("error pushing activity to peer", "peer", peer, "error", err)
+				retry = time.After(activityRetryInterval)
+				break
+			}
+
+			// The peer was at a different event, so continue from there.
+			// If it didn't move, wait rather than pushing the same events
+			// again right away.
+			if last == acked {
+				break
+			}
+
+			acked = last
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-notify:
+		case <-retry:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *HTTPActivityBus) push(ctx context.Context, peer string, after int64, events []*ActivityLog) (int64, error) {
+	data, err := json.Marshal(&activityPush{
+		Origin: b.origin,
+		After:  after,
+		Events: events,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", peer+ActivityHTTPPath, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("peer responded with status %d", resp.StatusCode)
+	}
+
+	var pr activityPushResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&pr)
+	if err != nil {
+		return 0, err
+	}
+
+	return pr.Last, nil
+}
+
+// receive adds the events of push to the log if they follow the last event
+// received from its origin, and returns the id of the last event received.
+func (b *HTTPActivityBus) receive(push *activityPush) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	last := b.received[push.Origin]
+
+	if push.After != last {
+		return last
+	}
+
+	for _, ev := range push.Events {
+		if ev.Id <= last {
+			continue
+		}
+
+		b.log.append(ev.Event, ev.CreatedAt)
+		last = ev.Id
+	}
+
+	b.received[push.Origin] = last
+
+	return last
+}
+
+func (b *HTTPActivityBus) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	auth := req.Header.Get("Authorization")
+
+	if b.token == "" || subtle.ConstantTimeCompare([]byte(auth), []byte("Bearer "+b.token)) != 1 {
+		http.Error(w, "bad authentication information presented", http.StatusUnauthorized)
+		return
+	}
+
+	var push activityPush
+
+	err := json.NewDecoder(req.Body).Decode(&push)
+	if err != nil {
+		http.Error(w, "unable to decode activity", http.StatusBadRequest)
+		return
+	}
+
+	last := b.receive(&push)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&activityPushResponse{Last: last})
+}
+
+func (b *HTTPActivityBus) Close() error {
+	b.cancel()
+	b.wg.Wait()
+	return nil
+}
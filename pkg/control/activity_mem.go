@@ -0,0 +1,129 @@
+package control
+
+import (
+	context "context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemActivityBus keeps the activity log in memory. It's useful for tests and
+// for running a single control server. Events past the retention period are
+// pruned as new ones are published.
+type MemActivityBus struct {
+	retention time.Duration
+
+	mu     sync.RWMutex
+	events []*ActivityLog
+	last   int64
+
+	n notifier
+}
+
+func NewMemActivityBus(retention time.Duration) *MemActivityBus {
+	if retention == 0 {
+		retention = ActivityRetention
+	}
+
+	return &MemActivityBus{
+		retention: retention,
+	}
+}
+
+func (m *MemActivityBus) Publish(ctx context.Context, v interface{}) error {
+	data, err := encodeActivity(v)
+	if err != nil {
+		return err
+	}
+
+	m.append(data, time.Now())
+
+	return nil
+}
+
+// append adds an event created at the given time to the log.
+func (m *MemActivityBus) append(data []byte, createdAt time.Time) *ActivityLog {
+	m.mu.Lock()
+
+	m.last++
+
+	entry := &ActivityLog{
+		Id:        m.last,
+		Event:     data,
+		CreatedAt: createdAt,
+	}
+
+	m.events = append(m.events, entry)
+	m.pruneLocked(time.Now())
+
+	m.mu.Unlock()
+
+	m.n.notify()
+
+	return entry
+}
+
+func (m *MemActivityBus) Subscribe(ctx context.Context, cursor int64) (*ActivityReader, error) {
+	return newActivityReader(ctx, m, &m.n, cursor)
+}
+
+func (m *MemActivityBus) eventsAfter(ctx context.Context, cursor int64, limit int) ([]*ActivityLog, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	idx := sort.Search(len(m.events), func(i int) bool {
+		return m.events[i].Id > cursor
+	})
+
+	end := len(m.events)
+	if end-idx > limit {
+		end = idx + limit
+	}
+
+	return append([]*ActivityLog(nil), m.events[idx:end]...), nil
+}
+
+func (m *MemActivityBus) lastId(ctx context.Context) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.last, nil
+}
+
+func (m *MemActivityBus) firstId(ctx context.Context) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+
+	return m.events[0].Id, nil
+}
+
+func (m *MemActivityBus) Prune(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneLocked(time.Now())
+
+	return nil
+}
+
+func (m *MemActivityBus) pruneLocked(now time.Time) {
+	cutoff := now.Add(-m.retention)
+
+	var i int
+
+	for i < len(m.events) && m.events[i].CreatedAt.Before(cutoff) {
+		i++
+	}
+
+	if i > 0 {
+		m.events = append([]*ActivityLog(nil), m.events[i:]...)
+	}
+}
+
+func (m *MemActivityBus) Close() error {
+	return nil
+}
@@ -0,0 +1,164 @@
+package control
+
+import (
+	context "context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+)
+
+var pgActivityChannel = "activaty_added"
+
+// The key of the advisory lock that serializes publishing. Without it, a
+// transaction could commit an event with a lower id after a reader has
+// already moved past it.
+const pgActivityLock = 0x687a6e01
+
+// PostgresActivityBus stores the activity log in the activity_logs table
+// and wakes readers with a postgresql NOTIFY when events are added.
+type PostgresActivityBus struct {
+	db        *gorm.DB
+	listener  *pq.Listener
+	retention time.Duration
+
+	n notifier
+
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func NewPostgresActivityBus(ctx context.Context, db *gorm.DB, conn string, retention time.Duration) (*PostgresActivityBus, error) {
+	L := hclog.FromContext(ctx)
+
+	reportProblem := func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			L.Error("problem observed while listen on postgres channel", "error", err)
+		}
+	}
+
+	minReconn := 10 * time.Second
+	maxReconn := time.Minute
+	listener := pq.NewListener(conn, minReconn, maxReconn, reportProblem)
+
+	err := listener.Listen(pgActivityChannel)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	bus := &PostgresActivityBus{
+		db:        db,
+		listener:  listener,
+		retention: retention,
+		cancel:    cancel,
+	}
+
+	bus.wg.Add(1)
+	go bus.listen(ctx)
+
+	return bus, nil
+}
+
+// listen passes notifications from postgresql on to the readers. A nil
+// notification is sent after reconnecting, when events may have been
+// missed, so readers check the log for those too.
+func (p *PostgresActivityBus) listen(ctx context.Context) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.listener.Notify:
+			p.n.notify()
+		}
+	}
+}
+
+func (p *PostgresActivityBus) Publish(ctx context.Context, v interface{}) error {
+	data, err := encodeActivity(v)
+	if err != nil {
+		return err
+	}
+
+	entry := ActivityLog{Event: data}
+
+	tx := p.db.Begin()
+
+	err = dbx.Check(tx.Exec("SELECT pg_advisory_xact_lock(?)", pgActivityLock))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = dbx.Check(tx.Create(&entry))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = dbx.Check(tx.Exec("NOTIFY " + pgActivityChannel))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return dbx.Check(tx.Commit())
+}
+
+func (p *PostgresActivityBus) Subscribe(ctx context.Context, cursor int64) (*ActivityReader, error) {
+	return newActivityReader(ctx, p, &p.n, cursor)
+}
+
+func (p *PostgresActivityBus) eventsAfter(ctx context.Context, cursor int64, limit int) ([]*ActivityLog, error) {
+	var entries []*ActivityLog
+
+	err := dbx.Check(p.db.Where("id > ?", cursor).Order("id").Limit(limit).Find(&entries))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (p *PostgresActivityBus) lastId(ctx context.Context) (int64, error) {
+	var entry ActivityLog
+
+	err := dbx.Check(p.db.Last(&entry))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return 0, err
+	}
+
+	return entry.Id, nil
+}
+
+func (p *PostgresActivityBus) firstId(ctx context.Context) (int64, error) {
+	var entry ActivityLog
+
+	err := dbx.Check(p.db.First(&entry))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return 0, err
+	}
+
+	return entry.Id, nil
+}
+
+// Prune is run periodically by the cleanup-activity-log job, see
+// LogCleaner.
+func (p *PostgresActivityBus) Prune(ctx context.Context) error {
+	return dbx.Check(
+		p.db.Exec("DELETE FROM activity_logs WHERE created_at < ?", time.Now().Add(-p.retention)),
+	)
+}
+
+func (p *PostgresActivityBus) Close() error {
+	p.cancel()
+	p.wg.Wait()
+	return p.listener.Close()
+}
@@ -2,15 +2,37 @@ package control
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/horizon/internal/testsql"
 	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// readEvents reads from ar until it has seen n events.
+func readEvents(t *testing.T, ctx context.Context, ar *ActivityReader, n int) []string {
+	var out []string
+
+	for len(out) < n {
+		select {
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		case entries := <-ar.C:
+			for _, e := range entries {
+				out = append(out, string(e.Event))
+			}
+		}
+	}
+
+	return out
+}
+
 func TestActivity(t *testing.T) {
 	const testDbName = "hzn_control"
 
@@ -21,18 +43,20 @@ func TestActivity(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		ar, err := NewActivityReader(ctx, "postgres",
-			testsql.TestPostgresDBString(t, testDbName))
+		bus, err := NewPostgresActivityBus(ctx, db,
+			testsql.TestPostgresDBString(t, testDbName), ActivityRetention)
+		require.NoError(t, err)
+
+		defer bus.Close()
+
+		ar, err := bus.Subscribe(ctx, CursorLatest)
 		require.NoError(t, err)
 
 		defer ar.Close()
 
 		time.Sleep(time.Second)
 
-		ai, err := NewActivityInjector(db)
-		require.NoError(t, err)
-
-		err = ai.Inject(ctx, []byte(`"this is an event"`))
+		err = bus.Publish(ctx, []byte(`"this is an event"`))
 		require.NoError(t, err)
 
 		select {
@@ -42,7 +66,7 @@ func TestActivity(t *testing.T) {
 			assert.Equal(t, []byte(`"this is an event"`), entries[0].Event)
 		}
 
-		err = ai.Inject(ctx, []byte(`"this is a second event"`))
+		err = bus.Publish(ctx, []byte(`"this is a second event"`))
 		require.NoError(t, err)
 
 		select {
@@ -51,6 +75,19 @@ func TestActivity(t *testing.T) {
 		case entries := <-ar.C:
 			assert.Equal(t, []byte(`"this is a second event"`), entries[0].Event)
 		}
+
+		// A new reader picks up from the cursor of the old one.
+		cursor := ar.Cursor()
+
+		err = bus.Publish(ctx, []byte(`"this is a third event"`))
+		require.NoError(t, err)
+
+		ar2, err := bus.Subscribe(ctx, cursor)
+		require.NoError(t, err)
+
+		defer ar2.Close()
+
+		assert.Equal(t, []string{`"this is a third event"`}, readEvents(t, ctx, ar2, 1))
 	})
 
 	t.Run("prunes old logs", func(t *testing.T) {
@@ -64,9 +101,14 @@ func TestActivity(t *testing.T) {
 		err := dbx.Check(db.Create(&ae))
 		require.NoError(t, err)
 
-		var lc LogCleaner
-		lc.DB = db
-		err = lc.CleanupActivityLog(nil, "cleanup-activity-log", nil)
+		bus, err := NewPostgresActivityBus(context.Background(), db,
+			testsql.TestPostgresDBString(t, testDbName), ActivityRetention)
+		require.NoError(t, err)
+
+		defer bus.Close()
+
+		lc := LogCleaner{Bus: bus}
+		err = lc.CleanupActivityLog(context.Background(), "cleanup-activity-log", nil)
 		require.NoError(t, err)
 
 		var ae2 ActivityLog
@@ -74,3 +116,209 @@ func TestActivity(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestMemActivityBus(t *testing.T) {
+	t.Run("delivers events in order", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		bus := NewMemActivityBus(0)
+
+		ar, err := bus.Subscribe(ctx, CursorLatest)
+		require.NoError(t, err)
+
+		defer ar.Close()
+
+		for _, ev := range []string{"a", "b", "c"} {
+			require.NoError(t, bus.Publish(ctx, []byte(ev)))
+		}
+
+		require.NoError(t, bus.Publish(ctx, map[string]string{"d": "e"}))
+
+		assert.Equal(t, []string{"a", "b", "c", `{"d":"e"}`}, readEvents(t, ctx, ar, 4))
+		assert.Equal(t, int64(4), ar.Cursor())
+	})
+
+	t.Run("replays events after a cursor", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		bus := NewMemActivityBus(0)
+
+		for _, ev := range []string{"a", "b", "c"} {
+			require.NoError(t, bus.Publish(ctx, []byte(ev)))
+		}
+
+		ar, err := bus.Subscribe(ctx, 1)
+		require.NoError(t, err)
+
+		defer ar.Close()
+
+		assert.Equal(t, []string{"b", "c"}, readEvents(t, ctx, ar, 2))
+
+		ar2, err := bus.Subscribe(ctx, 0)
+		require.NoError(t, err)
+
+		defer ar2.Close()
+
+		assert.Equal(t, []string{"a", "b", "c"}, readEvents(t, ctx, ar2, 3))
+	})
+
+	t.Run("prunes events past the retention", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		bus := NewMemActivityBus(time.Hour)
+
+		bus.append([]byte("old"), time.Now().Add(-2*time.Hour))
+		bus.append([]byte("new"), time.Now())
+
+		lc := LogCleaner{Bus: bus}
+		require.NoError(t, lc.CleanupActivityLog(ctx, "cleanup-activity-log", nil))
+
+		ar, err := bus.Subscribe(ctx, 0)
+		require.NoError(t, err)
+
+		defer ar.Close()
+
+		assert.Equal(t, []string{"new"}, readEvents(t, ctx, ar, 1))
+	})
+
+	t.Run("rejects cursors outside of the retained events", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		bus := NewMemActivityBus(time.Hour)
+
+		bus.append([]byte("old"), time.Now().Add(-2*time.Hour))
+		bus.append([]byte("older"), time.Now().Add(-2*time.Hour))
+		bus.append([]byte("new"), time.Now())
+
+		// Event 2 was pruned, so resuming after 1 would skip it.
+		_, err := bus.Subscribe(ctx, 1)
+		assert.Equal(t, ErrCursorOutOfRange, errors.Cause(err))
+
+		_, err = bus.Subscribe(ctx, 4)
+		assert.Equal(t, ErrCursorOutOfRange, errors.Cause(err))
+
+		ar, err := bus.Subscribe(ctx, 2)
+		require.NoError(t, err)
+
+		defer ar.Close()
+
+		assert.Equal(t, []string{"new"}, readEvents(t, ctx, ar, 1))
+
+		empty := NewMemActivityBus(time.Hour)
+		empty.append([]byte("old"), time.Now().Add(-2*time.Hour))
+		require.NoError(t, empty.Prune(ctx))
+
+		_, err = empty.Subscribe(ctx, 0)
+		assert.NoError(t, err)
+
+		ar2, err := empty.Subscribe(ctx, 1)
+		require.NoError(t, err)
+
+		ar2.Close()
+	})
+}
+
+func TestHTTPActivityBus(t *testing.T) {
+	defer func(d time.Duration) {
+		activityRetryInterval = d
+	}(activityRetryInterval)
+
+	activityRetryInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The second instance isn't up yet, so pushes to it fail at first.
+	var (
+		upB  int32
+		busB *HTTPActivityBus
+	)
+
+	servB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&upB) == 0 {
+			http.Error(w, "starting", http.StatusServiceUnavailable)
+			return
+		}
+
+		busB.ServeHTTP(w, req)
+	}))
+	defer servB.Close()
+
+	busA := NewHTTPActivityBus(ctx, []string{servB.URL}, "secret", 0)
+	defer busA.Close()
+
+	servA := httptest.NewServer(busA)
+	defer servA.Close()
+
+	busB = NewHTTPActivityBus(ctx, []string{servA.URL}, "secret", 0)
+	defer busB.Close()
+
+	arA, err := busA.Subscribe(ctx, 0)
+	require.NoError(t, err)
+
+	defer arA.Close()
+
+	arB, err := busB.Subscribe(ctx, 0)
+	require.NoError(t, err)
+
+	defer arB.Close()
+
+	require.NoError(t, busA.Publish(ctx, []byte("a1")))
+	require.NoError(t, busA.Publish(ctx, []byte("a2")))
+
+	assert.Equal(t, []string{"a1", "a2"}, readEvents(t, ctx, arA, 2))
+
+	atomic.StoreInt32(&upB, 1)
+
+	// Events published while the instance was down are caught up, in
+	// order.
+	assert.Equal(t, []string{"a1", "a2"}, readEvents(t, ctx, arB, 2))
+
+	require.NoError(t, busB.Publish(ctx, []byte("b1")))
+	require.NoError(t, busA.Publish(ctx, []byte("a3")))
+
+	assert.ElementsMatch(t, []string{"b1", "a3"}, readEvents(t, ctx, arA, 2))
+	assert.ElementsMatch(t, []string{"b1", "a3"}, readEvents(t, ctx, arB, 2))
+
+	t.Run("rejects cursors from other instances", func(t *testing.T) {
+		_, err := busB.Subscribe(ctx, arA.Cursor())
+		assert.Equal(t, ErrCursorOutOfRange, errors.Cause(err))
+
+		_, err = busA.Subscribe(ctx, arB.Cursor())
+		assert.Equal(t, ErrCursorOutOfRange, errors.Cause(err))
+
+		ar, err := busA.Subscribe(ctx, arA.Cursor())
+		require.NoError(t, err)
+
+		ar.Close()
+	})
+
+	t.Run("rejects pushes without the token", func(t *testing.T) {
+		other := NewHTTPActivityBus(ctx, nil, "wrong", 0)
+		defer other.Close()
+
+		_, err := other.push(ctx, servA.URL, 0, []*ActivityLog{{Id: 1, Event: []byte("x")}})
+		assert.Error(t, err)
+	})
+
+	t.Run("requires a token", func(t *testing.T) {
+		_, err := NewActivityBus(ctx, ActivityBusConfig{
+			Type:  ActivityBusHTTP,
+			Peers: []string{servA.URL},
+		})
+		assert.Error(t, err)
+
+		open := NewHTTPActivityBus(ctx, nil, "", 0)
+		defer open.Close()
+
+		serv := httptest.NewServer(open)
+		defer serv.Close()
+
+		_, err = open.push(ctx, serv.URL, 0, []*ActivityLog{{Id: 1, Event: []byte("x")}})
+		assert.Error(t, err)
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/ed25519"
 	"crypto/tls"
 	"encoding/hex"
-	io "io"
 	"io/ioutil"
 	"math/rand"
 	"net"
@@ -19,10 +18,8 @@ import (
 	client "k8s.io/client-go/kubernetes"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/horizon/pkg/grpc/lz4"
@@ -30,6 +27,7 @@ import (
 	"github.com/hashicorp/horizon/pkg/netloc"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/periodic"
+	"github.com/hashicorp/horizon/pkg/proxyproto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	gcreds "google.golang.org/grpc/credentials"
@@ -46,7 +44,7 @@ type accountInfo struct {
 	Mu       sync.RWMutex
 	MapKey   string
 	LastUse  time.Time
-	S3Key    string
+	Key      string
 	FileName string
 	Services *pb.AccountServices
 	Process  chan struct{}
@@ -70,8 +68,7 @@ type Client struct {
 
 	accountServices map[string]*accountInfo
 
-	bucket string
-	s3api  *s3.S3
+	store ObjectStore
 
 	workDir string
 
@@ -98,6 +95,10 @@ type Client struct {
 	clientset *client.Clientset
 
 	liveHubs *lru.ARCCache
+
+	// Restored from SecretsPath, used when central is unavailable.
+	lastConfig    *pb.ConfigResponse
+	serviceTokens map[string]string
 }
 
 type hubLiveness struct {
@@ -118,9 +119,31 @@ type ClientConfig struct {
 	WorkDir    string
 	Insecure   bool
 
+	// Where to read routing data published by the control server from. If
+	// not set, an S3 store is created from Session and S3Bucket.
+	ObjectStore ObjectStore
+
+	// Where to persist routing data so that it can be restored on restart.
+	// Optional.
+	Snapshots SnapshotStore
+
+	// A file to keep the hub's config and service tokens in, so that it can
+	// start while central is unavailable. They include the hub's TLS key
+	// and credentials, so the file is only readable by its owner and they're
+	// never written to Snapshots. Optional.
+	SecretsPath string
+
 	// The kubernetes deployment name used for the service using this client
 	K8Deployment string
 
+	// Set when the ingress listener is behind a load balancer that sends
+	// a PROXY protocol header, so that the real address of clients is known.
+	ProxyProtocol bool
+
+	// Where to read the cloud metadata of the host from when learning its
+	// locations. Defaults to detecting the cloud automatically.
+	LocationProviders []netloc.Provider
+
 	// Where hub integrates it's handler for the hzn protocol
 	NextProto map[string]func(hs *http.Server, tlsConn *tls.Conn, h http.Handler)
 
@@ -184,14 +207,29 @@ func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 		accountServices: make(map[string]*accountInfo),
 		localServices:   make(map[string]*pb.ServiceRequest),
 		workDir:         cfg.WorkDir,
-		bucket:          cfg.S3Bucket,
+		store:           cfg.ObjectStore,
 		cancel:          cancel,
 		hubActivity:     make(chan *pb.HubActivity, 10),
 		liveHubs:        liveHubs,
+		serviceTokens:   make(map[string]string),
+	}
+
+	if client.store == nil && cfg.Session != nil && cfg.S3Bucket != "" {
+		client.store = NewS3ObjectStore(cfg.Session, cfg.S3Bucket)
 	}
 
-	if cfg.Session != nil {
-		client.s3api = s3.New(cfg.Session)
+	if cfg.Snapshots != nil {
+		err = client.restoreSnapshots(cfg.Logger)
+		if err != nil {
+			cfg.Logger.Error("error restoring snapshots", "error", err)
+		}
+	}
+
+	if cfg.SecretsPath != "" {
+		err = client.restoreSecrets(cfg.Logger)
+		if err != nil {
+			cfg.Logger.Error("error restoring hub secrets", "error", err)
+		}
 	}
 
 	return client, nil
@@ -235,7 +273,12 @@ func (c *Client) Locations() []*pb.NetworkLocation {
 }
 
 func (c *Client) LearnLocations(def *pb.LabelSet) ([]*pb.NetworkLocation, error) {
-	locs, err := netloc.Locate(def)
+	providers := c.cfg.LocationProviders
+	if providers == nil {
+		providers = netloc.DefaultProviders()
+	}
+
+	locs, err := netloc.LocateWith(def, providers)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +295,22 @@ func (c *Client) BootstrapConfig(ctx context.Context) error {
 		Locations:  c.netloc,
 	})
 	if err != nil {
-		return err
+		c.mu.Lock()
+		last := c.lastConfig
+		c.mu.Unlock()
+
+		if last == nil {
+			return err
+		}
+
+		c.L.Warn("unable to fetch config from central, using saved config", "error", err)
+
+		resp = last
+	} else {
+		c.mu.Lock()
+		c.lastConfig = resp
+		c.saveSecrets(c.L)
+		c.mu.Unlock()
 	}
 
 	c.rawtlsCert = resp.TlsCert
@@ -266,7 +324,9 @@ func (c *Client) BootstrapConfig(ctx context.Context) error {
 
 	c.tlsCert = &cert
 
-	if resp.S3AccessKey != "" {
+	// An explicitly configured object store takes precedence over the S3
+	// access that the server provides.
+	if resp.S3AccessKey != "" && c.cfg.ObjectStore == nil {
 		L := c.L
 
 		L.Info("reconfiguring s3 access to use server provided credentials",
@@ -280,10 +340,9 @@ func (c *Client) BootstrapConfig(ctx context.Context) error {
 		cfg.WithCredentials(credentials.NewStaticCredentials(resp.S3AccessKey, resp.S3SecretKey, ""))
 
 		c.cfg.Session = session.New(&cfg)
-		c.s3api = s3.New(c.cfg.Session)
-
-		c.bucket = resp.S3Bucket
 		c.cfg.S3Bucket = resp.S3Bucket
+
+		c.store = NewS3ObjectStore(c.cfg.Session, c.cfg.S3Bucket)
 	}
 
 	if resp.ImageTag != "" {
@@ -360,6 +419,11 @@ func (c *Client) RunIngress(ctx context.Context, li net.Listener, npn map[string
 		}
 	})
 
+	if c.cfg.ProxyProtocol {
+		L.Info("parsing PROXY protocol headers on ingress")
+		li = proxyproto.NewListener(li)
+	}
+
 	L.Info("client ingress running")
 
 	return hs.ServeTLS(li, "", "")
@@ -492,10 +556,11 @@ func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels
 	for _, reg := range c.localServices {
 		if reg.Account.Equal(account) && labels.Matches(reg.Labels) {
 			route := &pb.ServiceRoute{
-				Id:     reg.Id,
-				Hub:    reg.Hub,
-				Type:   reg.Type,
-				Labels: reg.Labels,
+				Id:       reg.Id,
+				Hub:      reg.Hub,
+				Type:     reg.Type,
+				Labels:   reg.Labels,
+				Metadata: reg.Metadata,
 			}
 
 			out = append(out, route)
@@ -510,7 +575,7 @@ func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels
 	if !ok {
 		info = &accountInfo{
 			MapKey:   accStr,
-			S3Key:    "account_services/" + account.HashKey(),
+			Key:      accountServicesKey(account),
 			LastUse:  time.Now(),
 			FileName: account.HashKey(),
 			Process:  make(chan struct{}),
@@ -586,69 +651,32 @@ func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels
 }
 
 func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
-	tmp, err := ioutil.TempFile(c.workDir, info.FileName)
-	if err != nil {
-		L.Error("error creating temp file for account data", "error", err)
+	if c.store == nil {
 		return
 	}
 
-	defer os.Remove(tmp.Name())
-
-	obj := &s3.GetObjectInput{
-		Bucket: aws.String(c.bucket),
-		Key:    &info.S3Key,
-	}
-
-	if info.LastMD5 != "" {
-		obj.IfNoneMatch = aws.String(info.LastMD5)
-	}
-
-	resp, err := c.s3api.GetObject(obj)
+	obj, err := c.store.Get(context.Background(), info.Key, info.LastMD5)
 	if err != nil {
-		if rf, ok := err.(awserr.RequestFailure); ok {
-			if rf.StatusCode() == 304 {
-				L.Trace("account data not modified", "key", info.S3Key)
-				return
-			}
-
-			if rf.StatusCode() == 404 {
-				L.Trace("no account data available", "key", info.S3Key)
-				return
-			}
+		switch err {
+		case ErrNotModified:
+			L.Trace("account data not modified", "key", info.Key)
+		case ErrNoSuchObject:
+			L.Trace("no account data available", "key", info.Key)
+		default:
+			L.Error("error fetching account data", "error", err, "key", info.Key)
 		}
-		L.Error("error fetching account data", "error", err, "key", info.S3Key, "bucket", c.bucket)
-		return
-	}
-
-	defer resp.Body.Close()
-
-	n, err := io.Copy(tmp, resp.Body)
-	if err != nil {
-		L.Error("error copying s3 object to disk", "error", err)
 		return
 	}
 
-	L.Debug("downloaded account data", "key", info.S3Key, "size", n)
-
-	err = os.Rename(tmp.Name(), filepath.Join(c.workDir, info.FileName))
-	if err != nil {
-		L.Error("error renaming account data", "tmpfile", tmp.Name(), "target", info.FileName)
-		return
-	}
-
-	_, err = tmp.Seek(0, os.SEEK_SET)
-	if err != nil {
-		L.Error("error seeking account data to start of file", "error", err)
-		return
-	}
+	L.Debug("downloaded account data", "key", info.Key, "size", len(obj.Data))
 
-	compressedData, err := ioutil.ReadAll(tmp)
+	err = c.writeWorkFile(info.FileName, obj.Data)
 	if err != nil {
-		L.Error("error reading account data", "error", err)
+		L.Error("error writing account data to disk", "error", err)
 		return
 	}
 
-	data, err := zstdDecompress(compressedData)
+	data, err := zstdDecompress(obj.Data)
 	if err != nil {
 		L.Error("error uncompressing data", "error", err)
 		return
@@ -661,7 +689,9 @@ func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
 		return
 	}
 
-	info.LastMD5 = *resp.ETag
+	info.LastMD5 = obj.ETag
+
+	c.saveSnapshot(L, snapshotAccountServices+info.MapKey, obj.ETag, obj.Data)
 
 	info.Mu.Lock()
 	defer info.Mu.Unlock()
@@ -740,7 +770,15 @@ func (c *Client) Run(ctx context.Context) error {
 
 	err := c.updateLabelLinks(ctx, L)
 	if err != nil {
-		return err
+		c.labelMu.RLock()
+		restored := c.labelLinks != nil
+		c.labelMu.RUnlock()
+
+		if !restored {
+			return err
+		}
+
+		L.Warn("unable to update label links, using label links from snapshot", "error", err)
 	}
 
 	var activity pb.ControlServices_StreamActivityClient
@@ -751,10 +789,18 @@ func (c *Client) Run(ctx context.Context) error {
 		L.Debug("configuring activity stream")
 		activity, err = c.streamActivity(ctx, L, activityChan)
 		if err != nil {
-			return err
-		}
+			if c.cfg.Snapshots == nil {
+				return err
+			}
 
-		defer activity.CloseSend()
+			// We're running off snapshots, so keep going and let the
+			// reconnect logic below establish the stream when central
+			// becomes available.
+			L.Warn("unable to start activity stream, will retry", "error", err)
+			close(activityChan)
+		} else {
+			defer activity.CloseSend()
+		}
 	} else {
 		L.Debug("no client present, activity stream disabled")
 	}
@@ -787,6 +833,12 @@ func (c *Client) Run(ctx context.Context) error {
 					if err == nil {
 						break
 					}
+
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(time.Second):
+					}
 				}
 				L.Info("rebootstraping after activity stream reconnection")
 				err = c.BootstrapConfig(ctx)
@@ -882,8 +934,8 @@ func (c *Client) ForceLabelLinkUpdate(ctx context.Context, L hclog.Logger) error
 }
 
 func (c *Client) updateLabelLinks(ctx context.Context, L hclog.Logger) error {
-	if c.bucket == "" {
-		L.Debug("no bucket configured, not updating label links")
+	if c.store == nil {
+		L.Debug("no object store configured, not updating label links")
 		return nil
 	}
 
@@ -894,90 +946,73 @@ func (c *Client) updateLabelLinks(ctx context.Context, L hclog.Logger) error {
 	c.recentLabelLinks = nil
 	c.labelMu.Unlock()
 
-	tmp, err := ioutil.TempFile(c.workDir, "label-links")
-	if err != nil {
-		return err
-	}
-
-	defer os.Remove(tmp.Name())
-
-	obj := &s3.GetObjectInput{
-		Bucket: aws.String(c.bucket),
-		Key:    aws.String("label_links"),
-	}
-
-	if c.lastLabelMD5 != "" {
-		obj.IfNoneMatch = aws.String(c.lastLabelMD5)
-	}
-
-	resp, err := c.s3api.GetObjectWithContext(ctx, obj)
+	obj, err := c.store.Get(ctx, labelLinksKey, c.lastLabelMD5)
 	if err != nil {
-		if rf, ok := err.(awserr.RequestFailure); ok {
-			if rf.StatusCode() == 304 {
-				L.Trace("label links not modified")
-				return nil
-			}
-
-			if rf.StatusCode() == 404 {
-				L.Trace("no label links available")
-				return nil
-			}
-		}
-
-		if s3e, ok := err.(awserr.Error); ok {
-			if s3e.Code() == s3.ErrCodeNoSuchKey {
-				return nil
-			}
+		switch err {
+		case ErrNotModified:
+			L.Trace("label links not modified")
+			return nil
+		case ErrNoSuchObject:
+			L.Trace("no label links available")
+			return nil
+		default:
+			return err
 		}
-
-		return err
 	}
 
-	defer resp.Body.Close()
+	L.Debug("downloaded label links data", "size", len(obj.Data))
 
-	n, err := io.Copy(tmp, resp.Body)
+	err = c.writeWorkFile("label-links", obj.Data)
 	if err != nil {
 		return err
 	}
 
-	L.Debug("downloaded label links data", "size", n)
-
-	err = os.Rename(tmp.Name(), filepath.Join(c.workDir, "label-links"))
+	data, err := zstdDecompress(obj.Data)
 	if err != nil {
 		return err
 	}
 
-	_, err = tmp.Seek(0, os.SEEK_SET)
+	var lls pb.LabelLinks
+	err = lls.Unmarshal(data)
 	if err != nil {
 		return err
 	}
 
-	compressedData, err := ioutil.ReadAll(tmp)
+	c.lastLabelMD5 = obj.ETag
+
+	c.saveSnapshot(L, snapshotLabelLinks, obj.ETag, obj.Data)
+
+	c.labelMu.Lock()
+	defer c.labelMu.Unlock()
+
+	c.labelLinks = &lls
+
+	L.Info("label links updated", "etag", c.lastLabelMD5, "size", len(c.labelLinks.LabelLinks))
+
+	return err
+}
+
+// writeWorkFile atomically writes data to name within the work directory.
+func (c *Client) writeWorkFile(name string, data []byte) error {
+	tmp, err := ioutil.TempFile(c.workDir, name)
 	if err != nil {
 		return err
 	}
 
-	data, err := zstdDecompress(compressedData)
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
 	if err != nil {
+		tmp.Close()
 		return err
 	}
 
-	var lls pb.LabelLinks
-	err = lls.Unmarshal(data)
+	err = tmp.Close()
 	if err != nil {
 		return err
 	}
 
-	c.lastLabelMD5 = *resp.ETag
-
-	c.labelMu.Lock()
-	defer c.labelMu.Unlock()
-
-	c.labelLinks = &lls
-
-	L.Info("label links updated", "etag", c.lastLabelMD5, "size", len(c.labelLinks.LabelLinks))
-
-	return err
+	return os.Rename(tmp.Name(), filepath.Join(c.workDir, name))
 }
 
 func (c *Client) ResolveLabelLink(label *pb.LabelSet) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
@@ -1026,6 +1061,51 @@ func (c *Client) ResolveLabelLink(label *pb.LabelSet) (*pb.Account, *pb.LabelSet
 	return nil, nil, nil, nil
 }
 
+// CachedLabelLinks returns all the label links the client currently knows
+// about, including ones pushed by central since the last full download.
+func (c *Client) CachedLabelLinks() []*pb.LabelLink {
+	c.labelMu.RLock()
+	defer c.labelMu.RUnlock()
+
+	var out []*pb.LabelLink
+
+	out = append(out, c.recentLabelLinks...)
+	out = append(out, c.lessRecentLabelLinks...)
+
+	if c.labelLinks != nil {
+		out = append(out, c.labelLinks.LabelLinks...)
+	}
+
+	return out
+}
+
+// CachedAccountRoutes returns the service routes the client currently has
+// cached for each account, keyed by the account's StringKey.
+func (c *Client) CachedAccountRoutes() map[string][]*pb.ServiceRoute {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make(map[string][]*pb.ServiceRoute, len(c.accountServices))
+
+	for key, info := range c.accountServices {
+		info.Mu.RLock()
+
+		var routes []*pb.ServiceRoute
+
+		if info.Services != nil {
+			routes = append(routes, info.Services.Services...)
+		}
+
+		routes = append(routes, info.Recent...)
+
+		info.Mu.RUnlock()
+
+		out[key] = routes
+	}
+
+	return out
+}
+
 func (c *Client) AllHubs(ctx context.Context) ([]*pb.HubInfo, error) {
 	list, err := c.client.AllHubs(ctx, &pb.Noop{})
 	if err != nil {
@@ -1055,9 +1135,20 @@ func (c *Client) RequestServiceToken(ctx context.Context, namespace string) (str
 		Namespace: namespace,
 	})
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if err != nil {
+		if token, ok := c.serviceTokens[namespace]; ok {
+			c.L.Warn("unable to request service token from central, using saved token", "error", err)
+			return token, nil
+		}
+
 		return "", err
 	}
 
+	c.serviceTokens[namespace] = resp.Token
+	c.saveSecrets(c.L)
+
 	return resp.Token, nil
 }
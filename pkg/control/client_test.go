@@ -16,13 +16,12 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/armon/go-metrics"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/internal/testsql"
 	"github.com/hashicorp/horizon/pkg/dbx"
@@ -39,22 +38,14 @@ import (
 
 func TestClient(t *testing.T) {
 	vc := testutils.SetupVault()
-	sess := testutils.AWSSession(t)
-
-	bucket := "hzntest-" + pb.NewULID().SpecString()
-	s3.New(sess).CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucket),
-	})
-
-	defer testutils.DeleteBucket(s3.New(sess), bucket)
+	store := NewMemObjectStore()
 
 	scfg := ServerConfig{
 		VaultClient:       vc,
 		VaultPath:         pb.NewULID().SpecString(),
 		KeyId:             "k1",
 		RegisterToken:     "aabbcc",
-		AwsSession:        sess,
-		Bucket:            bucket,
+		ObjectStore:       store,
 		DisablePrometheus: true,
 	}
 
@@ -117,11 +108,11 @@ func TestClient(t *testing.T) {
 		id := pb.NewULID()
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:      id,
-			Token:   ctr.Token,
-			Version: "test",
-			Client:  gClient,
-			Session: sess,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -228,13 +219,12 @@ func TestClient(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:       id,
-			Token:    ctr.Token,
-			Version:  "test",
-			Client:   gClient,
-			WorkDir:  dir,
-			Session:  sess,
-			S3Bucket: bucket,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			WorkDir:     dir,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -417,13 +407,12 @@ func TestClient(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:       id,
-			Token:    ctr.Token,
-			Version:  "test",
-			Client:   gClient,
-			WorkDir:  dir,
-			Session:  sess,
-			S3Bucket: bucket,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			WorkDir:     dir,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -578,13 +567,12 @@ func TestClient(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:       id,
-			Token:    ctr.Token,
-			Version:  "test",
-			Client:   gClient,
-			WorkDir:  dir,
-			Session:  sess,
-			S3Bucket: bucket,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			WorkDir:     dir,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -600,7 +588,7 @@ func TestClient(t *testing.T) {
 		// Setup the info so that we're tracking the account when the event arrives
 		client.accountServices[account.StringKey()] = &accountInfo{
 			MapKey:   account.StringKey(),
-			S3Key:    "account_services/" + account.HashKey(),
+			Key:      accountServicesKey(account),
 			FileName: account.StringKey(),
 			Process:  make(chan struct{}),
 		}
@@ -720,13 +708,12 @@ func TestClient(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:       id,
-			Token:    ctr.Token,
-			Version:  "test",
-			Client:   gClient,
-			WorkDir:  dir,
-			Session:  sess,
-			S3Bucket: bucket,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			WorkDir:     dir,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -742,7 +729,7 @@ func TestClient(t *testing.T) {
 		// Setup the info so that we're tracking the account when the event arrives
 		client.accountServices[account.StringKey()] = &accountInfo{
 			MapKey:   account.StringKey(),
-			S3Key:    "account_services/" + account.HashKey(),
+			Key:      accountServicesKey(account),
 			FileName: account.StringKey(),
 			Process:  make(chan struct{}),
 		}
@@ -850,12 +837,11 @@ func TestClient(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:       id,
-			Token:    hubtoken.Token,
-			Version:  "test",
-			WorkDir:  dir,
-			Session:  sess,
-			S3Bucket: bucket,
+			Id:          id,
+			Token:       hubtoken.Token,
+			Version:     "test",
+			WorkDir:     dir,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -979,11 +965,11 @@ func TestClient(t *testing.T) {
 		id := pb.NewULID()
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:      id,
-			Token:   ctr.Token,
-			Version: "test",
-			Client:  gClient,
-			Session: sess,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -1085,11 +1071,11 @@ func TestClient(t *testing.T) {
 		id := pb.NewULID()
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:      id,
-			Token:   ctr.Token,
-			Version: "test",
-			Client:  gClient,
-			Session: sess,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -1228,11 +1214,11 @@ func TestClient(t *testing.T) {
 		id := pb.NewULID()
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:      id,
-			Token:   ctr.Token,
-			Version: "test",
-			Client:  gClient,
-			Session: sess,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -1328,11 +1314,11 @@ func TestClient(t *testing.T) {
 		id := pb.NewULID()
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:      id,
-			Token:   ctr.Token,
-			Version: "test",
-			Client:  gClient,
-			Session: sess,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -1340,11 +1326,11 @@ func TestClient(t *testing.T) {
 		// Setup another client so we can track the hub id change
 		// notification.
 		c2, err := NewClient(ctx, ClientConfig{
-			Id:      pb.NewULID(),
-			Token:   ctr.Token,
-			Version: "test",
-			Client:  gClient,
-			Session: sess,
+			Id:          pb.NewULID(),
+			Token:       ctr.Token,
+			Version:     "test",
+			Client:      gClient,
+			ObjectStore: store,
 		})
 
 		require.NoError(t, err)
@@ -1458,12 +1444,11 @@ func TestClient(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		client, err := NewClient(ctx, ClientConfig{
-			Id:       id,
-			Token:    ctr.Token,
-			Version:  "test",
-			WorkDir:  dir,
-			Session:  sess,
-			S3Bucket: bucket,
+			Id:          id,
+			Token:       ctr.Token,
+			Version:     "test",
+			WorkDir:     dir,
+			ObjectStore: store,
 
 			Addr:     li.Addr().String(),
 			Insecure: true,
@@ -1491,7 +1476,7 @@ func TestClient(t *testing.T) {
 		// Setup the info so that we're tracking the account when the event arrives
 		client.accountServices[account.StringKey()] = &accountInfo{
 			MapKey:   account.StringKey(),
-			S3Key:    "account_services/" + account.HashKey(),
+			Key:      accountServicesKey(account),
 			FileName: account.StringKey(),
 			Process:  make(chan struct{}),
 		}
@@ -1532,4 +1517,154 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, serviceId, services[0].Id)
 	})
 
+	t.Run("restores routing data from snapshots", func(t *testing.T) {
+		snaps := &memSnapshots{data: make(map[string]memSnapshot)}
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		label := pb.ParseLabelSet(":hostname=foo.com")
+		target := pb.ParseLabelSet("service=www,env=prod")
+
+		lls := &pb.LabelLinks{
+			LabelLinks: []*pb.LabelLink{
+				{
+					Account: account,
+					Labels:  label,
+					Target:  target,
+				},
+			},
+		}
+
+		data, err := lls.Marshal()
+		require.NoError(t, err)
+
+		data, err = zstdCompress(data)
+		require.NoError(t, err)
+
+		require.NoError(t, snaps.SaveSnapshot(snapshotLabelLinks, "\"ll1\"", data))
+
+		serviceId := pb.NewULID()
+
+		acs := &pb.AccountServices{
+			Services: []*pb.ServiceRoute{
+				{
+					Hub:    pb.NewULID(),
+					Id:     serviceId,
+					Type:   "test",
+					Labels: target,
+				},
+			},
+		}
+
+		data, err = acs.Marshal()
+		require.NoError(t, err)
+
+		data, err = zstdCompress(data)
+		require.NoError(t, err)
+
+		require.NoError(t, snaps.SaveSnapshot(snapshotAccountServices+account.StringKey(), "\"as1\"", data))
+
+		client, err := NewClient(context.Background(), ClientConfig{
+			Id:        pb.NewULID(),
+			Version:   "test",
+			Snapshots: snaps,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "\"ll1\"", client.lastLabelMD5)
+
+		acc, lt, _, err := client.ResolveLabelLink(label)
+		require.NoError(t, err)
+
+		assert.Equal(t, account, acc)
+		assert.Equal(t, target, lt)
+
+		calc, err := client.LookupService(context.Background(), account, target)
+		require.NoError(t, err)
+
+		services := calc.Services()
+
+		require.Equal(t, 1, len(services))
+
+		assert.Equal(t, serviceId, services[0].Id)
+
+		info := client.accountServices[account.StringKey()]
+		require.NotNil(t, info)
+
+		assert.Equal(t, "\"as1\"", info.LastMD5)
+		assert.Equal(t, accountServicesKey(account), info.Key)
+	})
+
+	t.Run("keeps its config and service tokens out of the snapshots", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "hzn")
+		require.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "hub.secrets")
+
+		snaps := &memSnapshots{data: make(map[string]memSnapshot)}
+
+		cfg := ClientConfig{
+			Id:          pb.NewULID(),
+			Version:     "test",
+			Snapshots:   snaps,
+			SecretsPath: path,
+		}
+
+		client, err := NewClient(context.Background(), cfg)
+		require.NoError(t, err)
+
+		client.mu.Lock()
+		client.lastConfig = &pb.ConfigResponse{TlsKey: []byte("key"), S3SecretKey: "secret"}
+		client.serviceTokens["/web"] = "tok"
+		client.saveSecrets(hclog.L())
+		client.mu.Unlock()
+
+		assert.Empty(t, snaps.data)
+
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+		client2, err := NewClient(context.Background(), cfg)
+		require.NoError(t, err)
+
+		require.NotNil(t, client2.lastConfig)
+
+		assert.Equal(t, []byte("key"), client2.lastConfig.TlsKey)
+		assert.Equal(t, "secret", client2.lastConfig.S3SecretKey)
+		assert.Equal(t, "tok", client2.serviceTokens["/web"])
+	})
+}
+
+type memSnapshot struct {
+	etag string
+	data []byte
+}
+
+type memSnapshots struct {
+	data map[string]memSnapshot
+}
+
+func (m *memSnapshots) SaveSnapshot(key, etag string, data []byte) error {
+	m.data[key] = memSnapshot{etag: etag, data: data}
+	return nil
+}
+
+func (m *memSnapshots) LoadSnapshots(prefix string, fn func(key, etag string, data []byte) error) error {
+	for k, v := range m.data {
+		if strings.HasPrefix(k, prefix) {
+			err := fn(k, v.etag, v.data)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1 @@
+ALTER TABLE services ADD COLUMN metadata text[] NULL;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+  id bytea PRIMARY KEY,
+  namespace text NOT NULL,
+  url text NOT NULL,
+  secret text NOT NULL,
+  events text[],
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_namespace ON webhooks (namespace);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id bytea PRIMARY KEY,
+  webhook_id bytea NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+  event text NOT NULL,
+  payload jsonb NOT NULL,
+  status text NOT NULL DEFAULT 'pending',
+  attempts int NOT NULL DEFAULT 0,
+  status_code int NOT NULL DEFAULT 0,
+  error text NOT NULL DEFAULT '',
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  delivered_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, created_at);
+
+CREATE TABLE IF NOT EXISTS agents (
+  agent_id bytea PRIMARY KEY,
+  account_id bytea NOT NULL,
+  hub_id bytea NOT NULL,
+  started_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS agents_account_id ON agents (account_id);
+CREATE INDEX IF NOT EXISTS agents_hub_id ON agents (hub_id);
@@ -0,0 +1,3 @@
+ALTER TABLE agents ADD COLUMN last_seen timestamp with time zone NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS agents_last_seen ON agents (last_seen);
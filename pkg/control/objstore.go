@@ -0,0 +1,107 @@
+package control
+
+import (
+	context "context"
+	"crypto/md5"
+	"encoding/hex"
+	fmt "fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+var (
+	// Returned by ObjectStore.Get when there is no object at the requested key.
+	ErrNoSuchObject = errors.New("no such object")
+
+	// Returned by ObjectStore.Get when the etag passed matches the current
+	// etag of the object.
+	ErrNotModified = errors.New("object not modified")
+)
+
+// Object is a blob of data retrieved from an ObjectStore
+type Object struct {
+	Data []byte
+	ETag string
+}
+
+// ObjectStore is where the control server publishes the routing data
+// (account services and label links) that hubs consume.
+type ObjectStore interface {
+	// Get returns the object stored at key. If etag is not empty and
+	// matches the object's current etag, ErrNotModified is returned
+	// instead. If there is no object, ErrNoSuchObject is returned.
+	Get(ctx context.Context, key, etag string) (*Object, error)
+
+	// Put stores data at key, replacing any existing object. It returns the
+	// etag of the new object.
+	Put(ctx context.Context, key string, data []byte) (string, error)
+
+	// Delete removes the object at key. Deleting a missing object is not
+	// an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the keys of all objects that begin with prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// The key that the label links of all accounts are stored under.
+const labelLinksKey = "label_links"
+
+// The key that the services of the given account are stored under.
+func accountServicesKey(account *pb.Account) string {
+	return "account_services/" + account.HashKey()
+}
+
+const (
+	ObjectStoreS3     = "s3"
+	ObjectStoreFS     = "fs"
+	ObjectStoreMemory = "memory"
+)
+
+type ObjectStoreConfig struct {
+	// One of ObjectStoreS3, ObjectStoreFS, or ObjectStoreMemory. Defaults
+	// to ObjectStoreS3.
+	Type string
+
+	// Used by the S3 store
+	Session  *session.Session
+	Bucket   string
+	KMSKeyId string
+
+	// Used by the filesystem store, the directory to store objects in.
+	Path string
+}
+
+// NewObjectStore creates an ObjectStore of the type requested by cfg.
+func NewObjectStore(cfg ObjectStoreConfig) (ObjectStore, error) {
+	switch cfg.Type {
+	case ObjectStoreS3, "":
+		if cfg.Session == nil {
+			return nil, fmt.Errorf("s3 object store requires an aws session")
+		}
+
+		if cfg.Bucket == "" {
+			return nil, fmt.Errorf("s3 object store requires a bucket")
+		}
+
+		s := NewS3ObjectStore(cfg.Session, cfg.Bucket)
+		s.kmsKeyId = cfg.KMSKeyId
+
+		return s, nil
+	case ObjectStoreFS:
+		return NewFSObjectStore(cfg.Path)
+	case ObjectStoreMemory:
+		return NewMemObjectStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown object store type: %s", cfg.Type)
+	}
+}
+
+// calculateETag returns an etag for data in the same form that S3 uses,
+// a quoted hex encoded md5 sum.
+func calculateETag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
@@ -0,0 +1,160 @@
+package control
+
+import (
+	context "context"
+	fmt "fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const fsTempPrefix = ".hzn-obj-"
+
+// FSObjectStore stores objects as files within a directory. Keys that contain
+// slashes are stored in subdirectories.
+type FSObjectStore struct {
+	dir string
+}
+
+func NewFSObjectStore(dir string) (*FSObjectStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("filesystem object store requires a path")
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FSObjectStore{dir: dir}, nil
+}
+
+func (f *FSObjectStore) path(key string) (string, error) {
+	if key == "" || path.Clean(key) != key || strings.HasPrefix(key, "/") ||
+		strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("invalid object key: %s", key)
+	}
+
+	if strings.HasPrefix(path.Base(key), fsTempPrefix) {
+		return "", fmt.Errorf("invalid object key: %s", key)
+	}
+
+	return filepath.Join(f.dir, filepath.FromSlash(key)), nil
+}
+
+func (f *FSObjectStore) Get(ctx context.Context, key, etag string) (*Object, error) {
+	p, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSuchObject
+		}
+
+		return nil, err
+	}
+
+	cur := calculateETag(data)
+
+	if etag != "" && etag == cur {
+		return nil, ErrNotModified
+	}
+
+	return &Object{Data: data, ETag: cur}, nil
+}
+
+func (f *FSObjectStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	p, err := f.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Dir(p)
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file and rename it into place so that readers
+	// never observe a partially written object.
+	tmp, err := ioutil.TempFile(dir, fsTempPrefix)
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Rename(tmp.Name(), p)
+	if err != nil {
+		return "", err
+	}
+
+	return calculateETag(data), nil
+}
+
+func (f *FSObjectStore) Delete(ctx context.Context, key string) error {
+	p, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(p)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (f *FSObjectStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+
+	err := filepath.Walk(f.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || strings.HasPrefix(info.Name(), fsTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(f.dir, p)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
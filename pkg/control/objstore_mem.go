@@ -0,0 +1,80 @@
+package control
+
+import (
+	context "context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemObjectStore keeps objects in memory. It's useful for tests and for
+// running a control server and hub within the same process.
+type MemObjectStore struct {
+	mu      sync.RWMutex
+	objects map[string]*Object
+}
+
+func NewMemObjectStore() *MemObjectStore {
+	return &MemObjectStore{
+		objects: make(map[string]*Object),
+	}
+}
+
+func (m *MemObjectStore) Get(ctx context.Context, key, etag string) (*Object, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	obj, ok := m.objects[key]
+	if !ok {
+		return nil, ErrNoSuchObject
+	}
+
+	if etag != "" && etag == obj.ETag {
+		return nil, ErrNotModified
+	}
+
+	return &Object{
+		Data: append([]byte(nil), obj.Data...),
+		ETag: obj.ETag,
+	}, nil
+}
+
+func (m *MemObjectStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	obj := &Object{
+		Data: append([]byte(nil), data...),
+		ETag: calculateETag(data),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[key] = obj
+
+	return obj.ETag, nil
+}
+
+func (m *MemObjectStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.objects, key)
+
+	return nil
+}
+
+func (m *MemObjectStore) List(ctx context.Context, prefix string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var keys []string
+
+	for key := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
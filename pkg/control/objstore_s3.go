@@ -0,0 +1,152 @@
+package control
+
+import (
+	bytes "bytes"
+	context "context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	fmt "fmt"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+// S3ObjectStore stores objects in an S3 bucket.
+type S3ObjectStore struct {
+	api      *s3.S3
+	bucket   string
+	kmsKeyId string
+}
+
+func NewS3ObjectStore(sess *session.Session, bucket string) *S3ObjectStore {
+	return &S3ObjectStore{
+		api:    s3.New(sess),
+		bucket: bucket,
+	}
+}
+
+func (s *S3ObjectStore) Get(ctx context.Context, key, etag string) (*Object, error) {
+	obj := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+
+	if etag != "" {
+		obj.IfNoneMatch = aws.String(etag)
+	}
+
+	resp, err := s.api.GetObjectWithContext(ctx, obj)
+	if err != nil {
+		if rf, ok := err.(awserr.RequestFailure); ok {
+			if rf.StatusCode() == 304 {
+				return nil, ErrNotModified
+			}
+
+			if rf.StatusCode() == 404 {
+				return nil, ErrNoSuchObject
+			}
+		}
+
+		if s3e, ok := err.(awserr.Error); ok {
+			if s3e.Code() == s3.ErrCodeNoSuchKey {
+				return nil, ErrNoSuchObject
+			}
+		}
+
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Object{
+		Data: data,
+		ETag: aws.StringValue(resp.ETag),
+	}, nil
+}
+
+func (s *S3ObjectStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	h := md5.New()
+	h.Write(data)
+	sum := h.Sum(nil)
+
+	inputEtag := base64.StdEncoding.EncodeToString(sum)
+
+	putIn := &s3.PutObjectInput{
+		ACL:         aws.String("private"),
+		Body:        bytes.NewReader(data),
+		ContentMD5:  aws.String(inputEtag),
+		ContentType: aws.String("application/horizon"),
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Tagging:     aws.String("usage=horizon"),
+	}
+
+	if s.kmsKeyId != "" {
+		putIn.SSEKMSKeyId = aws.String(s.kmsKeyId)
+		putIn.ServerSideEncryption = aws.String("aws:kms")
+	}
+
+	putOut, err := s.api.PutObjectWithContext(ctx, putIn)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to upload object")
+	}
+
+	outet := aws.StringValue(putOut.ETag)
+
+	if len(outet) < 2 {
+		return "", fmt.Errorf("invalid etag returned: %s", outet)
+	}
+
+	outSum, err := hex.DecodeString(outet[1 : len(outet)-1])
+	if err != nil {
+		return "", err
+	}
+
+	if !bytes.Equal(sum, outSum) {
+		return "", fmt.Errorf("corruption detected, wrong etag: %s / %s", hex.EncodeToString(sum), outet)
+	}
+
+	return outet, nil
+}
+
+func (s *S3ObjectStore) Delete(ctx context.Context, key string) error {
+	_, err := s.api.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+func (s *S3ObjectStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+
+	err := s.api.ListObjectsV2PagesWithContext(ctx,
+		&s3.ListObjectsV2Input{
+			Bucket: aws.String(s.bucket),
+			Prefix: aws.String(prefix),
+		},
+		func(page *s3.ListObjectsV2Output, _ bool) bool {
+			for _, obj := range page.Contents {
+				keys = append(keys, aws.StringValue(obj.Key))
+			}
+			return true
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,124 @@
+package control
+
+import (
+	context "context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testObjectStore(t *testing.T, store ObjectStore) {
+	ctx := context.Background()
+
+	t.Run("returns an error for missing objects", func(t *testing.T) {
+		_, err := store.Get(ctx, "nope", "")
+		assert.Equal(t, ErrNoSuchObject, err)
+	})
+
+	t.Run("stores and retrieves objects", func(t *testing.T) {
+		etag, err := store.Put(ctx, "account_services/a1", []byte("hello"))
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, etag)
+
+		obj, err := store.Get(ctx, "account_services/a1", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte("hello"), obj.Data)
+		assert.Equal(t, etag, obj.ETag)
+	})
+
+	t.Run("honors the etag of the caller", func(t *testing.T) {
+		etag, err := store.Put(ctx, "label_links", []byte("links"))
+		require.NoError(t, err)
+
+		_, err = store.Get(ctx, "label_links", etag)
+		assert.Equal(t, ErrNotModified, err)
+
+		etag2, err := store.Put(ctx, "label_links", []byte("links2"))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, etag, etag2)
+
+		obj, err := store.Get(ctx, "label_links", etag)
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte("links2"), obj.Data)
+		assert.Equal(t, etag2, obj.ETag)
+	})
+
+	t.Run("lists objects by prefix", func(t *testing.T) {
+		_, err := store.Put(ctx, "account_services/a2", []byte("world"))
+		require.NoError(t, err)
+
+		keys, err := store.List(ctx, "account_services/")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"account_services/a1", "account_services/a2"}, keys)
+	})
+
+	t.Run("deletes objects", func(t *testing.T) {
+		err := store.Delete(ctx, "account_services/a2")
+		require.NoError(t, err)
+
+		_, err = store.Get(ctx, "account_services/a2", "")
+		assert.Equal(t, ErrNoSuchObject, err)
+
+		err = store.Delete(ctx, "account_services/a2")
+		require.NoError(t, err)
+
+		keys, err := store.List(ctx, "account_services/")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"account_services/a1"}, keys)
+	})
+}
+
+func TestObjectStore(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testObjectStore(t, NewMemObjectStore())
+	})
+
+	t.Run("s3", func(t *testing.T) {
+		sess := testutils.AWSSession(t)
+
+		// Bucket names must be lowercase.
+		bucket := "hzntest-" + strings.ToLower(pb.NewULID().SpecString())
+
+		s3api := s3.New(sess)
+
+		_, err := s3api.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		require.NoError(t, err)
+
+		defer testutils.DeleteBucket(s3api, bucket)
+
+		testObjectStore(t, NewS3ObjectStore(sess, bucket))
+	})
+
+	t.Run("filesystem", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "hzn")
+		require.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		store, err := NewFSObjectStore(dir)
+		require.NoError(t, err)
+
+		testObjectStore(t, store)
+
+		t.Run("rejects keys outside the directory", func(t *testing.T) {
+			_, err := store.Put(context.Background(), "../escape", []byte("x"))
+			assert.Error(t, err)
+		})
+	})
+}
@@ -1,22 +1,16 @@
 package control
 
 import (
-	bytes "bytes"
 	context "context"
 	"crypto/md5"
 	"database/sql"
 	"encoding/base64"
-	"encoding/hex"
-	fmt "fmt"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/horizon/pkg/dbx"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/jinzhu/gorm"
 	"github.com/lib/pq"
-	"github.com/pkg/errors"
 )
 
 func (s *Server) calculateAccountRouting(ctx context.Context, gdb *sql.DB, account *pb.Account, action string) ([]byte, error) {
@@ -46,7 +40,7 @@ func (s *Server) calculateAccountRouting(ctx context.Context, gdb *sql.DB, accou
 		default:
 		}
 
-		rows, err := gdb.QueryContext(ctx, "SELECT id, hub_id, service_id, labels, type FROM services WHERE account_id = $1 AND id > $2 LIMIT 1000", key, lastId)
+		rows, err := gdb.QueryContext(ctx, "SELECT id, hub_id, service_id, labels, type, metadata FROM services WHERE account_id = $1 AND id > $2 LIMIT 1000", key, lastId)
 		if err != nil {
 			return nil, err
 		}
@@ -56,12 +50,13 @@ func (s *Server) calculateAccountRouting(ctx context.Context, gdb *sql.DB, accou
 			serviceId []byte
 			labels    pq.StringArray
 			typ       string
+			metadata  pq.StringArray
 			cnt       int
 		)
 
 		for rows.Next() {
 			cnt++
-			err = rows.Scan(&lastId, &hubId, &serviceId, &labels, &typ)
+			err = rows.Scan(&lastId, &hubId, &serviceId, &labels, &typ, &metadata)
 			if err != nil {
 				return nil, err
 			}
@@ -74,10 +69,11 @@ func (s *Server) calculateAccountRouting(ctx context.Context, gdb *sql.DB, accou
 			}
 
 			accountServices.Services = append(accountServices.Services, &pb.ServiceRoute{
-				Hub:    pb.ULIDFromBytes(hubId),
-				Id:     pb.ULIDFromBytes(serviceId),
-				Type:   typ,
-				Labels: &ls,
+				Hub:      pb.ULIDFromBytes(hubId),
+				Id:       pb.ULIDFromBytes(serviceId),
+				Type:     typ,
+				Labels:   &ls,
+				Metadata: parseMetadataArray(metadata),
 			})
 		}
 
@@ -114,7 +110,7 @@ func (s *Server) updateAccountRouting(ctx context.Context, db *sql.DB, account *
 
 	accountKey := account.HashKey()
 
-	key := fmt.Sprintf("account_services/%s", accountKey)
+	key := accountServicesKey(account)
 
 	lockKey := "account-" + accountKey
 
@@ -157,41 +153,11 @@ func (s *Server) updateAccountRouting(ctx context.Context, db *sql.DB, account *
 		continue
 	}
 
-	s3obj := s3.New(s.awsSess)
-
-	inputEtag := base64.StdEncoding.EncodeToString(sum)
-
-	putIn := &s3.PutObjectInput{
-		ACL:         aws.String("private"),
-		Body:        bytes.NewReader(outData),
-		ContentMD5:  aws.String(inputEtag),
-		ContentType: aws.String("application/horizon"),
-		Bucket:      &s.bucket,
-		Key:         &key,
-		Tagging:     aws.String("usage=horizon"),
-	}
-
-	if s.kmsKeyId != "" {
-		putIn.SSEKMSKeyId = aws.String(s.kmsKeyId)
-		putIn.ServerSideEncryption = aws.String("aws:kms")
-	}
-
-	putOut, err := s3obj.PutObject(putIn)
-	if err != nil {
-		return errors.Wrapf(err, "unable to upload object")
-	}
-
-	outet := *putOut.ETag
-
-	outSum, err := hex.DecodeString(outet[1 : len(outet)-1])
+	_, err = s.store.Put(ctx, key, outData)
 	if err != nil {
 		return err
 	}
 
-	if !bytes.Equal(sum, outSum) {
-		return fmt.Errorf("corruption detected, wrong etag: %s / %s", hex.EncodeToString(sum), outet)
-	}
-
 	return nil
 }
 
@@ -260,44 +226,10 @@ func (s *Server) updateLabelLinks(ctx context.Context) error {
 		return err
 	}
 
-	h := md5.New()
-	h.Write(outData)
-	sum := h.Sum(nil)
-
-	s3obj := s3.New(s.awsSess)
-
-	inputEtag := base64.StdEncoding.EncodeToString(sum)
-
-	putIn := &s3.PutObjectInput{
-		ACL:         aws.String("private"),
-		Body:        bytes.NewReader(outData),
-		ContentMD5:  aws.String(inputEtag),
-		ContentType: aws.String("application/horizon"),
-		Bucket:      &s.bucket,
-		Key:         aws.String("label_links"),
-		Tagging:     aws.String("usage=horizon"),
-	}
-
-	if s.kmsKeyId != "" {
-		putIn.SSEKMSKeyId = aws.String(s.kmsKeyId)
-		putIn.ServerSideEncryption = aws.String("aws:kms")
-	}
-
-	putOut, err := s3obj.PutObject(putIn)
-	if err != nil {
-		return errors.Wrapf(err, "unable to upload object")
-	}
-
-	outet := *putOut.ETag
-
-	outSum, err := hex.DecodeString(outet[1 : len(outet)-1])
+	_, err = s.store.Put(ctx, labelLinksKey, outData)
 	if err != nil {
 		return err
 	}
 
-	if !bytes.Equal(sum, outSum) {
-		return fmt.Errorf("corruption detected, wrong etag: %s / %s", hex.EncodeToString(sum), outet)
-	}
-
 	return nil
 }
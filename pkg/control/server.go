@@ -7,10 +7,12 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,11 +23,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/horizon/internal/sqljson"
 	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/discovery"
 	_ "github.com/hashicorp/horizon/pkg/grpc/lz4"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/hashicorp/horizon/pkg/workq"
 	"github.com/hashicorp/vault/api"
 	"github.com/jinzhu/gorm"
 	"github.com/lib/pq"
@@ -56,12 +61,10 @@ type Server struct {
 	bg     context.Context
 	cancel func()
 
-	db       *gorm.DB
-	bucket   string
-	awsSess  *session.Session
-	kmsKeyId string
-	privKey  ed25519.PrivateKey
-	pubKey   ed25519.PublicKey
+	db      *gorm.DB
+	store   ObjectStore
+	privKey ed25519.PrivateKey
+	pubKey  ed25519.PublicKey
 
 	registerToken string
 	opsToken      string
@@ -76,6 +79,11 @@ type Server struct {
 	hubKey    []byte
 	hubDomain string
 
+	// The fingerprints of the current hub certificate and the one before
+	// it, which hubs use until they pick up the new one.
+	fpMu            sync.Mutex
+	hubFingerprints []string
+
 	mu            sync.RWMutex
 	connectedHubs map[string]*connectedHub
 
@@ -85,8 +93,18 @@ type Server struct {
 
 	flowTop *FlowTop
 
+	activity ActivityBus
+
+	// Queues the deliveries of webhooks, see sendWebhooks.
+	jobs          *workq.Injector
+	webhookClient *http.Client
+
+	// The agents seen in flows that have already been recorded.
+	knownAgents *lru.Cache
+
 	mux   *http.ServeMux
 	asnDB *geoip2.Reader
+	geoDB *geoip2.Reader
 
 	hubImageTag string
 }
@@ -103,11 +121,19 @@ type ServerConfig struct {
 	VaultPath   string
 	KeyId       string
 
+	// Where routing data for hubs is published. If not set, an S3 store
+	// is created from AwsSession and Bucket.
+	ObjectStore ObjectStore
+
 	AwsSession *session.Session
 	Bucket     string
 
 	ASNDB string
 
+	// The path to a GeoIP2 or GeoLite2 City database, used to order the
+	// hubs in the discovery data by their distance from the requester.
+	GeoDB string
+
 	HubAccessKey string
 	HubSecretKey string
 
@@ -119,6 +145,10 @@ type ServerConfig struct {
 	DisablePrometheus bool
 
 	LockManager LockManager
+
+	// Carries activity between central tier instances. Required to use
+	// StartActivityReader.
+	ActivityBus ActivityBus
 }
 
 func NewServer(cfg ServerConfig) (*Server, error) {
@@ -167,6 +197,11 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		return nil, err
 	}
 
+	knownAgents, err := lru.New(DefaultKnownAgents)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		hubImageTag  string
 		hubImageFile string
@@ -200,8 +235,8 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		keyId:         cfg.KeyId,
 		registerToken: cfg.RegisterToken,
 		opsToken:      cfg.OpsToken,
-		awsSess:       cfg.AwsSession,
-		bucket:        cfg.Bucket,
+		store:         cfg.ObjectStore,
+		activity:      cfg.ActivityBus,
 
 		connectedHubs: make(map[string]*connectedHub),
 		m:             me,
@@ -209,6 +244,12 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		flowTop:       flowTop,
 		mux:           http.NewServeMux(),
 		hubImageTag:   hubImageTag,
+		webhookClient: newWebhookClient(),
+		knownAgents:   knownAgents,
+	}
+
+	if cfg.DB != nil {
+		s.jobs = workq.NewInjector(cfg.DB)
 	}
 
 	L.Debug("setting up routes")
@@ -224,6 +265,26 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		}
 	}
 
+	if cfg.GeoDB != "" {
+		L.Debug("loading GeoDB")
+
+		r, err := geoip2.Open(cfg.GeoDB)
+		if err == nil {
+			s.geoDB = r
+		} else {
+			L.Warn("unable to load geo database", "error", err, "path", cfg.GeoDB)
+		}
+	}
+
+	if s.store == nil {
+		if cfg.AwsSession != nil && cfg.Bucket != "" {
+			s.store = NewS3ObjectStore(cfg.AwsSession, cfg.Bucket)
+		} else {
+			L.Warn("no object store configured, routing data will only be kept in memory")
+			s.store = NewMemObjectStore()
+		}
+	}
+
 	if cfg.LockManager != nil {
 		s.lockMgr = cfg.LockManager
 	} else {
@@ -286,6 +347,42 @@ func (s *Server) SetHubTLS(cert, key []byte, domain string) {
 	s.hubCert = cert
 	s.hubKey = key
 	s.hubDomain = domain
+
+	block, _ := pem.Decode(cert)
+	if block == nil {
+		s.L.Error("unable to decode hub certificate, not advertising fingerprints")
+		return
+	}
+
+	fp := discovery.CertFingerprint(block.Bytes)
+
+	s.fpMu.Lock()
+	defer s.fpMu.Unlock()
+
+	if len(s.hubFingerprints) > 0 && s.hubFingerprints[0] == fp {
+		return
+	}
+
+	fps := []string{fp}
+	if len(s.hubFingerprints) > 0 {
+		fps = append(fps, s.hubFingerprints[0])
+	}
+
+	s.hubFingerprints = fps
+}
+
+// HubCertFingerprints returns the fingerprints of the certificates hubs may
+// present. All hubs share the same certificate.
+func (s *Server) HubCertFingerprints(hub *pb.NetworkLocation) []string {
+	s.fpMu.Lock()
+	defer s.fpMu.Unlock()
+
+	return s.hubFingerprints
+}
+
+// Sign signs the discovery data with the token signing key.
+func (s *Server) Sign(data []byte) ([]byte, error) {
+	return token.SignWithVault(s.vaultClient, s.vaultPath, data)
 }
 
 type Account struct {
@@ -311,11 +408,55 @@ type Service struct {
 	Type        string
 	Description string
 	Labels      pq.StringArray
+	Metadata    pq.StringArray
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// metadataArray encodes service metadata as key=value strings for storage.
+func metadataArray(md []*pb.KVPair) pq.StringArray {
+	var out pq.StringArray
+
+	for _, kv := range md {
+		out = append(out, kv.Key+"="+kv.ValueString())
+	}
+
+	return out
+}
+
+// parseMetadataArray decodes metadata stored by metadataArray.
+func parseMetadataArray(arr pq.StringArray) []*pb.KVPair {
+	var out []*pb.KVPair
+
+	for _, str := range arr {
+		idx := strings.IndexByte(str, '=')
+		if idx == -1 {
+			out = append(out, &pb.KVPair{Key: str})
+		} else {
+			out = append(out, &pb.KVPair{Key: str[:idx], Value: str[idx+1:]})
+		}
+	}
+
+	return out
+}
+
+// toPB returns the service as it's presented to management clients.
+func (svc *Service) toPB() (*pb.Service, error) {
+	var labelSet pb.LabelSet
+	if err := labelSet.Scan(svc.Labels); err != nil {
+		return nil, err
+	}
+
+	return &pb.Service{
+		Id:       pb.ULIDFromBytes(svc.ServiceId),
+		Hub:      pb.ULIDFromBytes(svc.HubId),
+		Type:     svc.Type,
+		Labels:   &labelSet,
+		Metadata: parseMetadataArray(svc.Metadata),
+	}, nil
+}
+
 func (s *Server) checkFromHub(ctx context.Context, action string) (*token.ValidToken, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -361,6 +502,7 @@ func (s *Server) AddService(ctx context.Context, service *pb.ServiceRequest) (*p
 	so.ServiceId = service.Id.Bytes()
 	so.Type = service.Type
 	so.Labels = service.Labels.AsStringArray()
+	so.Metadata = metadataArray(service.Metadata)
 
 	err = dbx.Check(s.db.Create(&so))
 	if err != nil {
@@ -373,16 +515,30 @@ func (s *Server) AddService(ctx context.Context, service *pb.ServiceRequest) (*p
 				Account: service.Account,
 				Services: []*pb.ServiceRoute{
 					{
-						Hub:    service.Hub,
-						Id:     service.Id,
-						Type:   service.Type,
-						Labels: service.Labels,
+						Hub:      service.Hub,
+						Id:       service.Id,
+						Type:     service.Type,
+						Labels:   service.Labels,
+						Metadata: service.Metadata,
 					},
 				},
 			},
 		},
 	})
 
+	s.publishActivity(ctx, &pb.ActivityEntry{
+		ServiceAdded: &pb.ServiceChange{
+			Account: service.Account,
+			Service: &pb.Service{
+				Id:       service.Id,
+				Hub:      service.Hub,
+				Type:     service.Type,
+				Labels:   service.Labels,
+				Metadata: service.Metadata,
+			},
+		},
+	})
+
 	err = s.updateAccountRouting(ctx, s.db.DB(), service.Account, "add-service")
 	if err != nil {
 		return nil, err
@@ -399,11 +555,20 @@ func (s *Server) RemoveService(ctx context.Context, service *pb.ServiceRequest)
 
 	s.m.IncrCounter([]string{"service", "remove"}, 1)
 
+	var sos []*Service
+
+	err = dbx.Check(s.db.Where("service_id = ?", service.Id.Bytes()).Find(&sos))
+	if err != nil {
+		return nil, err
+	}
+
 	err = dbx.Check(s.db.Where("service_id = ?", service.Id.Bytes()).Delete(Service{}))
 	if err != nil {
 		return nil, err
 	}
 
+	s.publishServicesRemoved(ctx, sos)
+
 	err = s.updateAccountRouting(ctx, s.db.DB(), service.Account, "remove-service")
 	if err != nil {
 		return nil, err
@@ -421,17 +586,12 @@ func (s *Server) ListServices(ctx context.Context, req *pb.ListServicesRequest)
 
 	var resp pb.ListServicesResponse
 	for _, svc := range services {
-		var labelSet pb.LabelSet
-		if err := labelSet.Scan(svc.Labels); err != nil {
+		ps, err := svc.toPB()
+		if err != nil {
 			return nil, err
 		}
 
-		resp.Services = append(resp.Services, &pb.Service{
-			Id:     pb.ULIDFromBytes(svc.ServiceId),
-			Hub:    pb.ULIDFromBytes(svc.HubId),
-			Type:   svc.Type,
-			Labels: &labelSet,
-		})
+		resp.Services = append(resp.Services, ps)
 	}
 
 	return &resp, nil
@@ -456,6 +616,8 @@ func (s *Server) removeHubServices(ctx context.Context, db *gorm.DB, hubId *pb.U
 		accounts[string(service.AccountId)] = struct{}{}
 	}
 
+	s.publishServicesRemoved(ctx, sos)
+
 	s.L.Info("updating account routing", "num-accounts", len(accounts))
 
 	for key := range accounts {
@@ -551,6 +713,11 @@ func (s *Server) FetchConfig(ctx context.Context, req *pb.ConfigRequest) (*pb.Co
 				if err != nil {
 					s.L.Error("error removing old hub services", "error", err, "hub", req.StableId)
 				}
+
+				err = s.removeHubAgents(s.bg, prev)
+				if err != nil {
+					s.L.Error("error removing old hub agents", "error", err, "hub", req.StableId)
+				}
 			}()
 		}
 
@@ -602,6 +769,11 @@ func (s *Server) HubDisconnect(ctx context.Context, req *pb.HubDisconnectRequest
 		err = multierror.Append(err, serr)
 	}
 
+	serr = s.removeHubAgents(ctx, req.InstanceId)
+	if serr != nil {
+		err = multierror.Append(err, serr)
+	}
+
 	s.L.Info("removing hub", "id", req.StableId)
 
 	serr = dbx.Check(s.db.Where("stable_id = ?", req.StableId.Bytes()).Delete(&Hub{}))
@@ -668,6 +840,12 @@ func (s *Server) processFlows(ch *connectedHub, flows []*pb.FlowRecord) {
 			}
 
 			s.m.SetGaugeWithLabels([]string{"hub", "streams"}, float32(rec.Agent.ActiveStreams), labels)
+
+			s.processAgentFlow(s.bg, rec.Agent)
+		}
+
+		if rec.RateLimit != nil {
+			s.processRateLimitFlow(s.bg, rec.RateLimit)
 		}
 
 		if rec.HubStats != nil {
@@ -795,13 +973,23 @@ func (s *Server) StreamActivity(stream pb.ControlServices_StreamActivityServer)
 	}
 }
 
-func (s *Server) StartActivityReader(ctx context.Context, dbtype, conn string) error {
-	ar, err := NewActivityReader(ctx, dbtype, conn)
+var ErrNoActivityBus = errors.New("no activity bus configured")
+
+// StartActivityReader broadcasts the routes added in activity published on
+// the activity bus to the connected hubs.
+func (s *Server) StartActivityReader(ctx context.Context) error {
+	if s.activity == nil {
+		return ErrNoActivityBus
+	}
+
+	ar, err := s.activity.Subscribe(ctx, CursorLatest)
 	if err != nil {
 		return err
 	}
 
 	go func() {
+		defer ar.Close()
+
 		L := s.L
 
 		for {
@@ -826,9 +1014,19 @@ func (s *Server) StartActivityReader(ctx context.Context, dbtype, conn string) e
 						continue
 					}
 
+					// Changes published for WatchServices are already sent to
+					// the hubs directly.
+					if ae.RouteAdded == nil {
+						continue
+					}
+
 					adds = append(adds, ae.RouteAdded)
 				}
 
+				if len(adds) == 0 {
+					continue
+				}
+
 				s.broadcastActivity(ctx, &pb.CentralActivity{
 					AccountServices: adds,
 				})
@@ -991,7 +1189,7 @@ func (s *Server) checkMgmtAllowed(ctx context.Context) (*token.ValidToken, error
 	}
 
 	if token.Body.Role != pb.MANAGE {
-		return nil, ErrBadAuthentication
+		return nil, ErrPermissionDenied
 	}
 
 	return token, nil
@@ -1024,7 +1222,7 @@ func (s *Server) AddAccount(ctx context.Context, req *pb.AddAccountRequest) (*pb
 			"requested-namespace", req.Account.Namespace,
 		)
 
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+		return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested")
 	}
 
 	var ao Account
@@ -1085,7 +1283,7 @@ func (s *Server) AddLabelLink(ctx context.Context, req *pb.AddLabelLinkRequest)
 			"requested-namespace", req.Account.Namespace,
 		)
 
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+		return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested")
 	}
 
 	var ao Account
@@ -1129,6 +1327,10 @@ func (s *Server) AddLabelLink(ctx context.Context, req *pb.AddLabelLinkRequest)
 		NewLabelLinks: &out,
 	})
 
+	s.publishActivity(ctx, &pb.ActivityEntry{
+		LabelLinkAdded: out.LabelLinks[0],
+	})
+
 	err = s.updateLabelLinks(ctx)
 	if err != nil {
 		return nil, err
@@ -1144,7 +1346,7 @@ func (s *Server) RemoveLabelLink(ctx context.Context, req *pb.RemoveLabelLinkReq
 	}
 
 	if !caller.AllowAccount(req.Account.Namespace) {
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+		return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested")
 	}
 
 	var llr LabelLink
@@ -1161,6 +1363,13 @@ func (s *Server) RemoveLabelLink(ctx context.Context, req *pb.RemoveLabelLinkReq
 		return nil, err
 	}
 
+	s.publishActivity(ctx, &pb.ActivityEntry{
+		LabelLinkRemoved: &pb.LabelLink{
+			Account: req.Account,
+			Labels:  req.Labels,
+		},
+	})
+
 	err = s.updateLabelLinks(ctx)
 	if err != nil {
 		return nil, err
@@ -1171,6 +1380,11 @@ func (s *Server) RemoveLabelLink(ctx context.Context, req *pb.RemoveLabelLinkReq
 
 var ErrInvalidRequest = errors.New("invalid request")
 
+// ErrPermissionDenied is returned when the caller's token is valid but
+// doesn't allow the request, such as for an account outside of its
+// namespace.
+var ErrPermissionDenied = errors.New("permission denied")
+
 func (s *Server) CreateToken(ctx context.Context, req *pb.CreateTokenRequest) (*pb.CreateTokenResponse, error) {
 	caller, err := s.checkMgmtAllowed(ctx)
 	if err != nil {
@@ -1178,14 +1392,14 @@ func (s *Server) CreateToken(ctx context.Context, req *pb.CreateTokenRequest) (*
 	}
 
 	if !caller.AllowAccount(req.Account.Namespace) {
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+		return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested")
 	}
 
 	// If the caller is requesting access capability, make sure it's under the callers namespace
 	for _, cb := range req.Capabilities {
 		if cb.Capability == pb.ACCESS {
 			if !caller.AllowAccount(cb.Value) {
-				return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested in access capability")
+				return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested in access capability")
 			}
 		}
 	}
@@ -46,9 +46,21 @@ func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("/ulid", s.genUlid)
 
 	var wk discovery.WellKnown
+	wk.L = s.L
 	wk.GetNetlocs = s
+	wk.Locator = s
+	wk.ClientIP = ipFromRequest
+	wk.HubCerts = s
+	wk.Signer = s
 
 	s.mux.Handle(discovery.HTTPPath, &wk)
+
+	s.mux.Handle(RESTPrefix+"/", &restAPI{s: s, routes: s.restRoutes()})
+
+	// Buses that fan activity out to the other instances receive it here.
+	if h, ok := s.activity.(http.Handler); ok {
+		s.mux.Handle(ActivityHTTPPath, h)
+	}
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -135,3 +147,41 @@ func (s *Server) httpIPInfo(w http.ResponseWriter, req *http.Request) {
 
 	json.NewEncoder(w).Encode(&info)
 }
+
+// LocateIP looks up ip in the ASN and geo databases, if they're loaded.
+func (s *Server) LocateIP(ip net.IP) (discovery.Geo, bool) {
+	var (
+		geo   discovery.Geo
+		found bool
+	)
+
+	if s.asnDB != nil {
+		if asnInfo, err := s.asnDB.ASN(ip); err == nil && asnInfo.AutonomousSystemNumber != 0 {
+			geo.ASN = asnInfo.AutonomousSystemNumber
+			found = true
+		}
+	}
+
+	if s.geoDB != nil {
+		if city, err := s.geoDB.City(ip); err == nil {
+			if city.Location.Latitude != 0 || city.Location.Longitude != 0 {
+				geo.HasLocation = true
+				geo.Latitude = city.Location.Latitude
+				geo.Longitude = city.Location.Longitude
+				found = true
+			}
+
+			geo.Region = city.Country.IsoCode
+
+			if len(city.Subdivisions) > 0 && city.Subdivisions[0].IsoCode != "" && geo.Region != "" {
+				geo.Region += "-" + city.Subdivisions[0].IsoCode
+			}
+
+			if geo.Region != "" {
+				found = true
+			}
+		}
+	}
+
+	return geo, found
+}
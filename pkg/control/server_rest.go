@@ -0,0 +1,471 @@
+package control
+
+import (
+	context "context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+// The path that the REST API is served under. It mirrors the management
+// RPCs for clients that can't easily use gRPC, with the request and response
+// bodies in the JSON form of the same messages. It's described by the
+// OpenAPI document at RESTPrefix + "/openapi.json".
+const RESTPrefix = "/api/v1"
+
+// The largest request body the REST API accepts.
+const restMaxBody = 1024 * 1024
+
+type restAuth int
+
+const (
+	restPublic restAuth = iota
+
+	// Requires a management token, like the ControlManagement RPCs.
+	restMgmt
+
+	// Requires the ops token, like the FlowTopReporter RPCs.
+	restOps
+)
+
+type restHandler func(ctx context.Context, req *http.Request, params map[string]string) (interface{}, error)
+
+type restRoute struct {
+	method string
+
+	// The segments of the path after RESTPrefix. A segment in braces
+	// matches any value, which is passed to the handler by that name.
+	path string
+
+	auth    restAuth
+	handler restHandler
+}
+
+type restError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Every error from the REST API has a body of this form.
+type restErrorResponse struct {
+	Error restError `json:"error"`
+}
+
+type restAPI struct {
+	s      *Server
+	routes []restRoute
+}
+
+func (s *Server) restRoutes() []restRoute {
+	return []restRoute{
+		{"GET", "/openapi.json", restPublic, s.restOpenAPI},
+		{"GET", "/token-public-key", restPublic, s.restGetTokenPublicKey},
+		{"GET", "/accounts", restMgmt, s.restListAccounts},
+		{"POST", "/accounts", restMgmt, s.restAddAccount},
+		{"POST", "/label-links", restMgmt, s.restAddLabelLink},
+		{"DELETE", "/label-links", restMgmt, s.restRemoveLabelLink},
+		{"POST", "/tokens", restMgmt, s.restCreateToken},
+		{"GET", "/services", restMgmt, s.restListServices},
+		{"GET", "/hubs", restOps, s.restListHubs},
+		{"GET", "/flow-top", restOps, s.restFlowTop},
+		{"GET", "/webhooks", restMgmt, s.restListWebhooks},
+		{"POST", "/webhooks", restMgmt, s.restAddWebhook},
+		{"DELETE", "/webhooks/{id}", restMgmt, s.restRemoveWebhook},
+		{"GET", "/webhooks/{id}/deliveries", restMgmt, s.restListWebhookDeliveries},
+	}
+}
+
+// matchRESTPath returns the values of the parameters in pattern if path
+// matches it.
+func matchRESTPath(pattern, path string) (map[string]string, bool) {
+	ps := strings.Split(strings.Trim(pattern, "/"), "/")
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(ps) != len(segs) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+
+	for i, p := range ps {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if segs[i] == "" {
+				return nil, false
+			}
+
+			params[p[1:len(p)-1]] = segs[i]
+		} else if p != segs[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func bearerToken(req *http.Request) string {
+	auth := req.Header.Get("Authorization")
+
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "bearer ") {
+		return ""
+	}
+
+	return strings.TrimSpace(auth[7:])
+}
+
+func (a *restAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, RESTPrefix)
+
+	var (
+		route   *restRoute
+		params  map[string]string
+		allowed []string
+	)
+
+	for i := range a.routes {
+		p, ok := matchRESTPath(a.routes[i].path, path)
+		if !ok {
+			continue
+		}
+
+		if a.routes[i].method != req.Method {
+			allowed = append(allowed, a.routes[i].method)
+			continue
+		}
+
+		route = &a.routes[i]
+		params = p
+		break
+	}
+
+	if route == nil {
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeRESTError(w, http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed")
+			return
+		}
+
+		writeRESTError(w, http.StatusNotFound, "not_found", "no such endpoint")
+		return
+	}
+
+	ctx := req.Context()
+
+	if route.auth != restPublic {
+		tok := bearerToken(req)
+		if tok == "" {
+			writeRESTError(w, http.StatusUnauthorized, "bad_authentication", ErrBadAuthentication.Error())
+			return
+		}
+
+		// The handlers call the RPCs, which read the token from the
+		// metadata of the context.
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tok))
+
+		err := a.s.restAuthorize(ctx, route.auth)
+		if err != nil {
+			status, code := restErrorStatus(err)
+			writeRESTError(w, status, code, err.Error())
+			return
+		}
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, restMaxBody)
+
+	a.s.m.IncrCounter([]string{"rest", "request"}, 1)
+
+	resp, err := route.handler(ctx, req, params)
+	if err != nil {
+		status, code := restErrorStatus(err)
+
+		msg := err.Error()
+		if status == http.StatusInternalServerError {
+			a.s.L.Error("error handling rest request", "method", req.Method, "path", req.URL.Path, "error", err)
+			msg = "internal error"
+		}
+
+		writeRESTError(w, status, code, msg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// restAuthorize checks that the token in ctx can use a route requiring
+// auth. It returns ErrPermissionDenied for a valid token that doesn't
+// grant access, and ErrBadAuthentication otherwise.
+func (s *Server) restAuthorize(ctx context.Context, auth restAuth) error {
+	if auth == restOps && s.checkOpsAllowed(ctx) {
+		return nil
+	}
+
+	_, err := s.checkMgmtAllowed(ctx)
+
+	switch {
+	case err == nil && auth == restMgmt:
+		return nil
+	case err == nil, errors.Cause(err) == ErrPermissionDenied:
+		return ErrPermissionDenied
+	default:
+		return ErrBadAuthentication
+	}
+}
+
+// restErrorStatus returns the status and error code that err is reported
+// with.
+func restErrorStatus(err error) (int, string) {
+	switch errors.Cause(err) {
+	case ErrBadAuthentication, token.ErrBadToken, token.ErrNoLongerValid:
+		return http.StatusUnauthorized, "bad_authentication"
+	case ErrPermissionDenied:
+		return http.StatusForbidden, "permission_denied"
+	case ErrInvalidRequest, pb.ErrInvalidAccount:
+		return http.StatusBadRequest, "invalid_request"
+	case gorm.ErrRecordNotFound, ErrNoSuchWebhook:
+		return http.StatusNotFound, "not_found"
+	default:
+		return http.StatusInternalServerError, "internal"
+	}
+}
+
+func writeRESTError(w http.ResponseWriter, status int, code, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(&restErrorResponse{
+		Error: restError{
+			Code:    code,
+			Message: msg,
+		},
+	})
+}
+
+// decodeRESTBody reads the JSON form of a request message from the body.
+func decodeRESTBody(req *http.Request, v interface{}) error {
+	err := json.NewDecoder(req.Body).Decode(v)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidRequest, "unable to decode request body: %s", err)
+	}
+
+	return nil
+}
+
+func restQueryInt(req *http.Request, name string) (int32, error) {
+	v := req.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || i < 0 {
+		return 0, errors.Wrapf(ErrInvalidRequest, "invalid %s: %s", name, v)
+	}
+
+	return int32(i), nil
+}
+
+// restQueryAccount parses an account given in the form returned by
+// Account.StringKey, namespace!id.
+func restQueryAccount(req *http.Request) (*pb.Account, error) {
+	v := req.URL.Query().Get("account")
+	if v == "" {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing account")
+	}
+
+	account, err := pb.AccountFromStringKey([]byte(v))
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "invalid account: %s", v)
+	}
+
+	return account, nil
+}
+
+func restParamULID(params map[string]string, name string) (*pb.ULID, error) {
+	id, err := pb.ParseULID(params[name])
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "invalid %s: %s", name, params[name])
+	}
+
+	return id, nil
+}
+
+func (s *Server) restOpenAPI(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	return json.RawMessage(openAPIDocument), nil
+}
+
+func (s *Server) restGetTokenPublicKey(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	return s.GetTokenPublicKey(ctx, &pb.Noop{})
+}
+
+func (s *Server) restListAccounts(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	var lr pb.ListAccountsRequest
+
+	limit, err := restQueryInt(req, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	lr.Limit = limit
+
+	// The marker is the next_marker of the previous page, which is base64
+	// encoded in the JSON. Accept either alphabet so that it doesn't need
+	// escaping in the query.
+	if m := req.URL.Query().Get("marker"); m != "" {
+		m = strings.NewReplacer("-", "+", "_", "/").Replace(m)
+
+		lr.Marker, err = base64.StdEncoding.DecodeString(m)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidRequest, "invalid marker")
+		}
+	}
+
+	return s.ListAccounts(ctx, &lr)
+}
+
+func (s *Server) restAddAccount(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	var ar pb.AddAccountRequest
+
+	err := decodeRESTBody(req, &ar)
+	if err != nil {
+		return nil, err
+	}
+
+	if ar.Account == nil || ar.Account.AccountId == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing account")
+	}
+
+	return s.AddAccount(ctx, &ar)
+}
+
+func (s *Server) restAddLabelLink(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	var lr pb.AddLabelLinkRequest
+
+	err := decodeRESTBody(req, &lr)
+	if err != nil {
+		return nil, err
+	}
+
+	if lr.Account == nil || lr.Account.AccountId == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing account")
+	}
+
+	if lr.Labels == nil || lr.Target == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing labels or target")
+	}
+
+	return s.AddLabelLink(ctx, &lr)
+}
+
+func (s *Server) restRemoveLabelLink(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	account, err := restQueryAccount(req)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := req.URL.Query().Get("labels")
+	if labels == "" {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing labels")
+	}
+
+	return s.RemoveLabelLink(ctx, &pb.RemoveLabelLinkRequest{
+		Account: account,
+		Labels:  pb.ParseLabelSet(labels),
+	})
+}
+
+func (s *Server) restCreateToken(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	var tr pb.CreateTokenRequest
+
+	err := decodeRESTBody(req, &tr)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.Account == nil || tr.Account.AccountId == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing account")
+	}
+
+	return s.CreateToken(ctx, &tr)
+}
+
+// ListServices is called by hubs, which see every account, so the REST
+// API limits it to the accounts in the caller's namespace.
+func (s *Server) restListServices(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := restQueryAccount(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.AllowAccount(account.Namespace) {
+		return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested")
+	}
+
+	return s.ListServices(ctx, &pb.ListServicesRequest{Account: account})
+}
+
+func (s *Server) restListHubs(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	return s.AllHubs(ctx, &pb.Noop{})
+}
+
+func (s *Server) restFlowTop(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	max, err := restQueryInt(req, "max_records")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CurrentFlowTop(ctx, &pb.FlowTopRequest{MaxRecords: max})
+}
+
+func (s *Server) restListWebhooks(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	return s.ListWebhooks(ctx, &pb.ListWebhooksRequest{})
+}
+
+func (s *Server) restAddWebhook(ctx context.Context, req *http.Request, _ map[string]string) (interface{}, error) {
+	var wr pb.AddWebhookRequest
+
+	err := decodeRESTBody(req, &wr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.AddWebhook(ctx, &wr)
+}
+
+func (s *Server) restRemoveWebhook(ctx context.Context, req *http.Request, params map[string]string) (interface{}, error) {
+	id, err := restParamULID(params, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.RemoveWebhook(ctx, &pb.RemoveWebhookRequest{Id: id})
+}
+
+func (s *Server) restListWebhookDeliveries(ctx context.Context, req *http.Request, params map[string]string) (interface{}, error) {
+	id, err := restParamULID(params, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := restQueryInt(req, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{
+		WebhookId: id,
+		Limit:     limit,
+	})
+}
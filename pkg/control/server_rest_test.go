@@ -0,0 +1,227 @@
+package control
+
+import (
+	context "context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/internal/testsql"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/testutils"
+	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// restCall performs a request against the REST API and decodes the
+// response into out, if it's set.
+func restCall(t *testing.T, s *Server, method, path, tok string, body interface{}, out interface{}) int {
+	var r io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		r = strings.NewReader(string(data))
+	}
+
+	req := httptest.NewRequest(method, RESTPrefix+path, r)
+
+	if tok != "" {
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+
+	w := httptest.NewRecorder()
+
+	api := &restAPI{s: s, routes: s.restRoutes()}
+	api.ServeHTTP(w, req)
+
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	if out != nil {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), out))
+	}
+
+	return w.Code
+}
+
+func TestServerREST(t *testing.T) {
+	L := hclog.L()
+
+	t.Run("documents every route", func(t *testing.T) {
+		var doc struct {
+			Paths map[string]map[string]interface{} `json:"paths"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(openAPIDocument), &doc))
+
+		var s Server
+
+		for _, r := range s.restRoutes() {
+			if r.path == "/openapi.json" {
+				continue
+			}
+
+			_, ok := doc.Paths[r.path][strings.ToLower(r.method)]
+			assert.True(t, ok, "missing %s %s", r.method, r.path)
+		}
+	})
+
+	t.Run("reports errors as json", func(t *testing.T) {
+		var s Server
+		s.L = L
+		s.opsToken = "ops"
+
+		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
+
+		var er restErrorResponse
+
+		code := restCall(t, &s, "GET", "/accounts", "", nil, &er)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Equal(t, "bad_authentication", er.Error.Code)
+
+		code = restCall(t, &s, "GET", "/flow-top", "not-ops", nil, &er)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		code = restCall(t, &s, "GET", "/nope", "", nil, &er)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "not_found", er.Error.Code)
+
+		code = restCall(t, &s, "PUT", "/webhooks", "", nil, &er)
+		assert.Equal(t, http.StatusMethodNotAllowed, code)
+		assert.Equal(t, "method_not_allowed", er.Error.Code)
+	})
+
+	t.Run("can manage accounts and label-links", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		vc := testutils.SetupVault()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.store = NewMemObjectStore()
+		s.lockMgr = &inmemLockMgr{}
+
+		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ct, err := s.Register(metadata.NewIncomingContext(context.Background(), md), &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		account := &pb.Account{
+			Namespace: "/foo/bar",
+			AccountId: pb.NewULID(),
+		}
+
+		code := restCall(t, &s, "POST", "/accounts", ct.Token, &pb.AddAccountRequest{
+			Account: account,
+			Limits:  &pb.Account_Limits{HttpRequests: 10},
+		}, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var accounts pb.ListAccountsResponse
+
+		code = restCall(t, &s, "GET", "/accounts", ct.Token, nil, &accounts)
+		require.Equal(t, http.StatusOK, code)
+
+		require.Len(t, accounts.Accounts, 1)
+		assert.Equal(t, account.StringKey(), accounts.Accounts[0].StringKey())
+
+		var er restErrorResponse
+
+		code = restCall(t, &s, "POST", "/accounts", ct.Token, &pb.AddAccountRequest{
+			Account: &pb.Account{
+				Namespace: "/qux",
+				AccountId: pb.NewULID(),
+			},
+		}, &er)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Equal(t, "permission_denied", er.Error.Code)
+
+		code = restCall(t, &s, "POST", "/accounts", ct.Token, map[string]string{"bogus": "x"}, &er)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_request", er.Error.Code)
+
+		// Hubs aren't scoped to a namespace, so only the ops token can list
+		// them.
+		code = restCall(t, &s, "GET", "/hubs", ct.Token, nil, &er)
+		assert.Equal(t, http.StatusForbidden, code)
+
+		code = restCall(t, &s, "DELETE", "/webhooks/"+pb.NewULID().SpecString(), ct.Token, nil, &er)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "not_found", er.Error.Code)
+
+		code = restCall(t, &s, "POST", "/label-links", ct.Token, &pb.AddLabelLinkRequest{
+			Account: account,
+			Labels:  pb.ParseLabelSet(":hostname=foo.com"),
+			Target:  pb.ParseLabelSet("service=www"),
+		}, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var link LabelLink
+		require.NoError(t, db.Where("account_id = ?", account.Key()).First(&link).Error)
+
+		assert.Equal(t, FlattenLabels(pb.ParseLabelSet("service=www")), link.Target)
+
+		code = restCall(t, &s, "POST", "/label-links", ct.Token, &pb.AddLabelLinkRequest{
+			Account: &pb.Account{
+				Namespace: "/foo",
+				AccountId: pb.NewULID(),
+			},
+			Labels: pb.ParseLabelSet(":hostname=bar.com"),
+			Target: pb.ParseLabelSet("service=www"),
+		}, &er)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		q := url.Values{
+			"account": {account.StringKey()},
+			"labels":  {":hostname=foo.com"},
+		}
+
+		code = restCall(t, &s, "DELETE", "/label-links?"+q.Encode(), ct.Token, nil, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var count int
+		require.NoError(t, db.Model(&LabelLink{}).Where("account_id = ?", account.Key()).Count(&count).Error)
+
+		assert.Equal(t, 0, count)
+
+		var services pb.ListServicesResponse
+
+		code = restCall(t, &s, "GET", "/services?"+q.Encode(), ct.Token, nil, &services)
+		require.Equal(t, http.StatusOK, code)
+
+		assert.Len(t, services.Services, 0)
+
+		other := url.Values{
+			"account": {(&pb.Account{Namespace: "/qux", AccountId: pb.NewULID()}).StringKey()},
+		}
+
+		code = restCall(t, &s, "GET", "/services?"+other.Encode(), ct.Token, nil, &er)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
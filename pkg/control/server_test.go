@@ -2,23 +2,29 @@ package control
 
 import (
 	context "context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/armon/go-metrics"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/internal/testsql"
 	"github.com/hashicorp/horizon/pkg/dbx"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/testutils"
 	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/hashicorp/horizon/pkg/workq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type staticServerStream struct {
@@ -60,25 +66,26 @@ func (s *staticServerStream) RecvMsg(m interface{}) error {
 	panic("not implemented")
 }
 
-func TestServer(t *testing.T) {
-	vc := testutils.SetupVault()
-	sess := testutils.AWSSession(t)
-
-	bucket := "hzntest"
+type watchServicesStream struct {
+	staticServerStream
+	EventC chan *pb.ServiceEvent
+}
 
-	s3.New(sess).CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucket),
-	})
+func (s *watchServicesStream) Send(ev *pb.ServiceEvent) error {
+	s.EventC <- ev
+	return nil
+}
 
-	defer testutils.DeleteBucket(s3.New(sess), bucket)
+func TestServer(t *testing.T) {
+	vc := testutils.SetupVault()
+	store := NewMemObjectStore()
 
 	scfg := ServerConfig{
 		VaultClient:   vc,
 		VaultPath:     pb.NewULID().SpecString(),
 		KeyId:         "k1",
 		RegisterToken: "aabbcc",
-		AwsSession:    sess,
-		Bucket:        bucket,
+		ObjectStore:   store,
 	}
 
 	L := hclog.L()
@@ -526,8 +533,7 @@ func TestServer(t *testing.T) {
 		s.vaultPath = pb.NewULID().SpecString()
 		s.keyId = "k1"
 		s.registerToken = "aabbcc"
-		s.awsSess = sess
-		s.bucket = bucket
+		s.store = store
 
 		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
 
@@ -588,21 +594,13 @@ func TestServer(t *testing.T) {
 
 		require.NoError(t, err)
 
-		// Check the labe link payload written to s3
-
-		s3api := s3.New(sess)
-
-		resp, err := s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("label_links"),
-		})
+		// Check the label link payload written to the object store
 
-		require.NoError(t, err)
+		obj, err := store.Get(top, labelLinksKey, "")
 
-		compressedData, err := ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
 
-		data, err := zstdDecompress(compressedData)
+		data, err := zstdDecompress(obj.Data)
 		require.NoError(t, err)
 
 		var lls pb.LabelLinks
@@ -640,17 +638,11 @@ func TestServer(t *testing.T) {
 
 		assert.Error(t, err)
 
-		resp, err = s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("label_links"),
-		})
-
-		require.NoError(t, err)
+		obj, err = store.Get(top, labelLinksKey, "")
 
-		compressedData, err = ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
 
-		data, err = zstdDecompress(compressedData)
+		data, err = zstdDecompress(obj.Data)
 		require.NoError(t, err)
 
 		var lls2 pb.LabelLinks
@@ -672,8 +664,7 @@ func TestServer(t *testing.T) {
 		s.vaultPath = pb.NewULID().SpecString()
 		s.keyId = "k1"
 		s.registerToken = "aabbcc"
-		s.awsSess = sess
-		s.bucket = bucket
+		s.store = store
 		s.lockMgr = &inmemLockMgr{}
 
 		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
@@ -734,21 +725,13 @@ func TestServer(t *testing.T) {
 		)
 		require.NoError(t, err)
 
-		// Check the account payload written to s3
+		// Check the account payload written to the object store
 
-		s3api := s3.New(sess)
+		obj, err := store.Get(top, accountServicesKey(account), "")
 
-		resp, err := s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("account_services/" + account.HashKey()),
-		})
-
-		require.NoError(t, err)
-
-		compressedData, err := ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
 
-		data, err := zstdDecompress(compressedData)
+		data, err := zstdDecompress(obj.Data)
 		require.NoError(t, err)
 
 		var accs pb.AccountServices
@@ -794,17 +777,11 @@ func TestServer(t *testing.T) {
 
 		assert.Error(t, err)
 
-		resp, err = s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("account_services/" + account.HashKey()),
-		})
-
-		require.NoError(t, err)
+		obj, err = store.Get(top, accountServicesKey(account), "")
 
-		compressedData, err = ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
 
-		data, err = zstdDecompress(compressedData)
+		data, err = zstdDecompress(obj.Data)
 		require.NoError(t, err)
 
 		var accs2 pb.AccountServices
@@ -819,14 +796,20 @@ func TestServer(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
+		top := context.Background()
+
+		bus, err := NewPostgresActivityBus(top, db, testsql.TestPostgresDBString(t, "hzn"), ActivityRetention)
+		require.NoError(t, err)
+
+		defer bus.Close()
+
 		cfg := scfg
 		cfg.DB = db
+		cfg.ActivityBus = bus
 
 		s, err := NewServer(cfg)
 		require.NoError(t, err)
 
-		top := context.Background()
-
 		md := make(metadata.MD)
 		md.Set("authorization", "aabbcc")
 
@@ -838,7 +821,7 @@ func TestServer(t *testing.T) {
 
 		require.NoError(t, err)
 
-		err = s.StartActivityReader(ctx, "postgres", testsql.TestPostgresDBString(t, "hzn"))
+		err = s.StartActivityReader(ctx)
 		require.NoError(t, err)
 
 		md2 := make(metadata.MD)
@@ -865,15 +848,12 @@ func TestServer(t *testing.T) {
 
 		go s.StreamActivity(&stream)
 
-		ai, err := NewActivityInjector(db)
-		require.NoError(t, err)
-
 		labels := pb.ParseLabelSet("service=www,env=prod")
 
 		hubId := pb.NewULID()
 		serviceId := pb.NewULID()
 
-		err = ai.Inject(ctx, &pb.ActivityEntry{
+		err = bus.Publish(ctx, &pb.ActivityEntry{
 			RouteAdded: &pb.AccountServices{
 				Account: &pb.Account{
 					AccountId: accountId,
@@ -908,6 +888,419 @@ func TestServer(t *testing.T) {
 		}
 	})
 
+	t.Run("streams service changes in the namespace to watchers", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.store = store
+		s.lockMgr = &inmemLockMgr{}
+		s.activity = NewMemActivityBus(0)
+
+		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/watch",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		account := &pb.Account{
+			Namespace: "/watch",
+			AccountId: pb.NewULID(),
+		}
+
+		other := &pb.Account{
+			Namespace: "/other",
+			AccountId: pb.NewULID(),
+		}
+
+		labels := pb.ParseLabelSet("service=www,env=prod")
+
+		hubId := pb.NewULID()
+
+		for _, acc := range []*pb.Account{other, account} {
+			_, err = s.AddService(
+				metadata.NewIncomingContext(top, md3),
+				&pb.ServiceRequest{
+					Account: acc,
+					Hub:     hubId,
+					Id:      pb.NewULID(),
+					Type:    "test",
+					Labels:  labels,
+				},
+			)
+			require.NoError(t, err)
+		}
+
+		watch := func(resume string) *watchServicesStream {
+			stream := &watchServicesStream{
+				EventC: make(chan *pb.ServiceEvent, 10),
+			}
+
+			stream.ctx = metadata.NewIncomingContext(top, md2)
+
+			go s.WatchServices(&pb.WatchServicesRequest{ResumeToken: resume}, stream)
+
+			return stream
+		}
+
+		next := func(stream *watchServicesStream) *pb.ServiceEvent {
+			select {
+			case <-top.Done():
+				require.NoError(t, top.Err())
+				return nil
+			case ev := <-stream.EventC:
+				return ev
+			}
+		}
+
+		// Only the service in the caller's namespace is seen.
+		stream := watch("0")
+
+		ev := next(stream)
+		assert.Equal(t, pb.SERVICE_ADDED, ev.Type)
+		assert.Equal(t, account.AccountId, ev.Account.AccountId)
+		assert.Equal(t, labels, ev.Service.Labels)
+
+		_, err = s.RemoveService(
+			metadata.NewIncomingContext(top, md3),
+			&pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      ev.Service.Id,
+			},
+		)
+		require.NoError(t, err)
+
+		ev2 := next(stream)
+		assert.Equal(t, pb.SERVICE_REMOVED, ev2.Type)
+		assert.Equal(t, ev.Service.Id, ev2.Service.Id)
+		assert.Equal(t, labels, ev2.Service.Labels)
+
+		// Resuming picks up after the event the token came from.
+		stream2 := watch(ev.ResumeToken)
+
+		ev3 := next(stream2)
+		assert.Equal(t, pb.SERVICE_REMOVED, ev3.Type)
+		assert.Equal(t, ev2.ResumeToken, ev3.ResumeToken)
+
+		var bad watchServicesStream
+		bad.ctx = metadata.NewIncomingContext(top, md2)
+
+		err = s.WatchServices(&pb.WatchServicesRequest{ResumeToken: "nope"}, &bad)
+		assert.Error(t, err)
+
+		// A token for events that aren't in the log makes the caller relist.
+		err = s.WatchServices(&pb.WatchServicesRequest{ResumeToken: "1000000"}, &bad)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("delivers signed webhooks for account events", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.store = store
+		s.lockMgr = &inmemLockMgr{}
+		s.jobs = workq.NewInjector(db)
+		s.webhookClient = http.DefaultClient
+
+		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/hooks",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mctx := metadata.NewIncomingContext(top, md2)
+
+		var (
+			mu       sync.Mutex
+			received []*http.Request
+			bodies   [][]byte
+			fail     bool
+		)
+
+		hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			body, _ := ioutil.ReadAll(req.Body)
+
+			received = append(received, req)
+			bodies = append(bodies, body)
+		}))
+		defer hs.Close()
+
+		_, err = s.AddWebhook(mctx, &pb.AddWebhookRequest{
+			Url:    "ftp://example.com",
+			Events: []string{WebhookServiceAdded},
+		})
+		require.Error(t, err)
+
+		_, err = s.AddWebhook(mctx, &pb.AddWebhookRequest{
+			Namespace: "/other",
+			Url:       hs.URL,
+		})
+		require.Error(t, err)
+
+		// The test server listens on loopback, which is otherwise blocked.
+		_, err = s.AddWebhook(mctx, &pb.AddWebhookRequest{
+			Url: hs.URL,
+		})
+		require.Error(t, err)
+
+		defer func(nets []*net.IPNet) {
+			blockedWebhookNets = nets
+		}(blockedWebhookNets)
+
+		blockedWebhookNets = nil
+
+		wr, err := s.AddWebhook(mctx, &pb.AddWebhookRequest{
+			Url: hs.URL,
+			Events: []string{
+				WebhookServiceAdded,
+				WebhookAgentConnected,
+				WebhookAgentDisconnected,
+				WebhookAccountOffline,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/hooks", wr.Webhook.Namespace)
+		assert.NotEmpty(t, wr.Secret)
+
+		lr, err := s.ListWebhooks(mctx, &pb.ListWebhooksRequest{})
+		require.NoError(t, err)
+
+		require.Len(t, lr.Webhooks, 1)
+		assert.Equal(t, wr.Webhook.Id, lr.Webhooks[0].Id)
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		account := &pb.Account{
+			Namespace: "/hooks/team",
+			AccountId: pb.NewULID(),
+		}
+
+		hubId := pb.NewULID()
+
+		for _, acc := range []*pb.Account{account, {Namespace: "/other", AccountId: pb.NewULID()}} {
+			_, err = s.AddService(
+				metadata.NewIncomingContext(top, md3),
+				&pb.ServiceRequest{
+					Account: acc,
+					Hub:     hubId,
+					Id:      pb.NewULID(),
+					Type:    "test",
+					Labels:  pb.ParseLabelSet("service=www"),
+				},
+			)
+			require.NoError(t, err)
+		}
+
+		// Runs the queued deliveries, in the order they were queued.
+		deliver := func() {
+			var jobs []*workq.Job
+
+			err := dbx.Check(db.Where("job_type = ?", WebhookJobType).Order("created_at").Find(&jobs))
+			require.NoError(t, err)
+
+			for _, job := range jobs {
+				var wj WebhookJob
+
+				require.NoError(t, json.Unmarshal(job.Payload, &wj))
+				require.NoError(t, s.DeliverWebhook(top, job.JobType, &wj))
+
+				require.NoError(t, dbx.Check(db.Delete(job)))
+			}
+		}
+
+		deliver()
+
+		mu.Lock()
+		require.Len(t, received, 1)
+
+		req := received[0]
+
+		assert.Equal(t, WebhookServiceAdded, req.Header.Get(WebhookEventHeader))
+		assert.Equal(t,
+			"sha256="+SignWebhook(wr.Secret, req.Header.Get(WebhookTimestampHeader), bodies[0]),
+			req.Header.Get(WebhookSignatureHeader))
+
+		var payload WebhookPayload
+
+		require.NoError(t, json.Unmarshal(bodies[0], &payload))
+		assert.Equal(t, WebhookServiceAdded, payload.Event)
+		assert.Equal(t, account.AccountId.SpecString(), payload.AccountID)
+		mu.Unlock()
+
+		agentId := pb.NewULID()
+
+		s.processAgentFlow(top, &pb.FlowRecord_AgentConnection{
+			HubId:     hubId,
+			AgentId:   agentId,
+			Account:   account,
+			StartedAt: pb.NewTimestamp(time.Now()),
+		})
+
+		// The periodic flows for the same agent aren't connects.
+		s.processAgentFlow(top, &pb.FlowRecord_AgentConnection{
+			HubId:     hubId,
+			AgentId:   agentId,
+			Account:   account,
+			StartedAt: pb.NewTimestamp(time.Now()),
+		})
+
+		s.processAgentFlow(top, &pb.FlowRecord_AgentConnection{
+			HubId:     hubId,
+			AgentId:   agentId,
+			Account:   account,
+			StartedAt: pb.NewTimestamp(time.Now()),
+			EndedAt:   pb.NewTimestamp(time.Now()),
+		})
+
+		deliver()
+
+		mu.Lock()
+		var events []string
+		for _, req := range received[1:] {
+			events = append(events, req.Header.Get(WebhookEventHeader))
+		}
+		mu.Unlock()
+
+		assert.Equal(t, []string{
+			WebhookAgentConnected,
+			WebhookAgentDisconnected,
+			WebhookAccountOffline,
+		}, events)
+
+		dr, err := s.ListWebhookDeliveries(mctx, &pb.ListWebhookDeliveriesRequest{
+			WebhookId: wr.Webhook.Id,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, dr.Deliveries, 4)
+
+		for _, d := range dr.Deliveries {
+			assert.Equal(t, DeliveryDelivered, d.Status)
+			assert.Equal(t, int32(1), d.Attempts)
+			assert.NotNil(t, d.DeliveredAt)
+		}
+
+		// Failed deliveries are retried until they run out of attempts.
+		mu.Lock()
+		fail = true
+		mu.Unlock()
+
+		s.processAgentFlow(top, &pb.FlowRecord_AgentConnection{
+			HubId:     hubId,
+			AgentId:   pb.NewULID(),
+			Account:   account,
+			StartedAt: pb.NewTimestamp(time.Now()),
+		})
+
+		var delivery WebhookDelivery
+		require.NoError(t, dbx.Check(db.Where("status = ?", DeliveryPending).First(&delivery)))
+
+		for i := 1; i <= WebhookMaxAttempts; i++ {
+			err = s.DeliverWebhook(top, WebhookJobType, &WebhookJob{DeliveryID: delivery.ID})
+			if i < WebhookMaxAttempts {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+
+		require.NoError(t, dbx.Check(db.Where("id = ?", delivery.ID).First(&delivery)))
+
+		assert.Equal(t, DeliveryFailed, delivery.Status)
+		assert.Equal(t, WebhookMaxAttempts, delivery.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+
+		// Agents that their hub stops reporting are swept.
+		staleId := pb.NewULID()
+
+		s.processAgentFlow(top, &pb.FlowRecord_AgentConnection{
+			HubId:     hubId,
+			AgentId:   staleId,
+			Account:   account,
+			StartedAt: pb.NewTimestamp(time.Now()),
+		})
+
+		require.NoError(t, dbx.Check(
+			db.Model(&Agent{}).
+				Where("agent_id = ?", staleId.Bytes()).
+				UpdateColumn("last_seen", time.Now().Add(-2*AgentTimeout)),
+		))
+
+		require.NoError(t, s.SweepAgents(top, AgentSweepJobType, nil))
+
+		var agents int
+		require.NoError(t, dbx.Check(db.Model(&Agent{}).Where("agent_id = ?", staleId.Bytes()).Count(&agents)))
+		assert.Equal(t, 0, agents)
+	})
+
 	t.Run("supports using consul for account locking", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
@@ -922,8 +1315,7 @@ func TestServer(t *testing.T) {
 		s.vaultPath = pb.NewULID().SpecString()
 		s.keyId = "k1"
 		s.registerToken = "aabbcc"
-		s.awsSess = sess
-		s.bucket = bucket
+		s.store = store
 
 		s.m, _ = metrics.New(metrics.DefaultConfig("test"), &metrics.BlackholeSink{})
 
@@ -985,21 +1377,13 @@ func TestServer(t *testing.T) {
 		)
 		require.NoError(t, err)
 
-		// Check the account payload written to s3
+		// Check the account payload written to the object store
 
-		s3api := s3.New(sess)
+		obj, err := store.Get(top, accountServicesKey(account), "")
 
-		resp, err := s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("account_services/" + account.HashKey()),
-		})
-
-		require.NoError(t, err)
-
-		compressedData, err := ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
 
-		data, err := zstdDecompress(compressedData)
+		data, err := zstdDecompress(obj.Data)
 		require.NoError(t, err)
 
 		var accs pb.AccountServices
@@ -1063,17 +1447,11 @@ func TestServer(t *testing.T) {
 
 		assert.Error(t, err)
 
-		resp, err = s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("account_services/" + account.HashKey()),
-		})
-
-		require.NoError(t, err)
+		obj, err = store.Get(top, accountServicesKey(account), "")
 
-		compressedData, err = ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
 
-		data, err = zstdDecompress(compressedData)
+		data, err = zstdDecompress(obj.Data)
 		require.NoError(t, err)
 
 		var accs2 pb.AccountServices
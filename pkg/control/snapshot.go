@@ -0,0 +1,226 @@
+package control
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// SnapshotStore persists the routing data that a hub has retrieved from
+// central so that it can be restored when the hub restarts, even if central
+// is unavailable at the time.
+type SnapshotStore interface {
+	// SaveSnapshot stores data, along with the etag it was retrieved with,
+	// under key.
+	SaveSnapshot(key, etag string, data []byte) error
+
+	// LoadSnapshots calls fn for every snapshot whose key begins with prefix.
+	LoadSnapshots(prefix string, fn func(key, etag string, data []byte) error) error
+}
+
+const (
+	snapshotLabelLinks      = "label_links"
+	snapshotAccountServices = "account_services/"
+)
+
+func (c *Client) saveSnapshot(L hclog.Logger, key, etag string, data []byte) {
+	if c.cfg.Snapshots == nil {
+		return
+	}
+
+	err := c.cfg.Snapshots.SaveSnapshot(key, etag, data)
+	if err != nil {
+		L.Error("error saving snapshot", "key", key, "error", err)
+	}
+}
+
+// restoreSnapshots loads the previously persisted routing data into
+// the client. This is done before contacting central so that the client
+// is able to route even if central is unavailable.
+func (c *Client) restoreSnapshots(L hclog.Logger) error {
+	snaps := c.cfg.Snapshots
+
+	err := snaps.LoadSnapshots(snapshotLabelLinks, func(key, etag string, data []byte) error {
+		if key != snapshotLabelLinks {
+			return nil
+		}
+
+		raw, err := zstdDecompress(data)
+		if err != nil {
+			return err
+		}
+
+		var lls pb.LabelLinks
+		err = lls.Unmarshal(raw)
+		if err != nil {
+			return err
+		}
+
+		c.labelMu.Lock()
+		c.labelLinks = &lls
+		c.lastLabelMD5 = etag
+		c.labelMu.Unlock()
+
+		L.Info("restored label links from snapshot", "etag", etag, "size", len(lls.LabelLinks))
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return snaps.LoadSnapshots(snapshotAccountServices, func(key, etag string, data []byte) error {
+		accStr := strings.TrimPrefix(key, snapshotAccountServices)
+
+		account, err := pb.AccountFromStringKey([]byte(accStr))
+		if err != nil {
+			L.Error("invalid account in snapshot, skipping", "key", key, "error", err)
+			return nil
+		}
+
+		raw, err := zstdDecompress(data)
+		if err != nil {
+			return err
+		}
+
+		var ac pb.AccountServices
+		err = ac.Unmarshal(raw)
+		if err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.accountServices[accStr] = &accountInfo{
+			MapKey:   accStr,
+			Key:      accountServicesKey(account),
+			LastUse:  time.Now(),
+			FileName: account.HashKey(),
+			Process:  make(chan struct{}),
+			LastMD5:  etag,
+			Services: &ac,
+		}
+
+		L.Debug("restored account services from snapshot", "account", accStr, "services", len(ac.Services))
+
+		return nil
+	})
+}
+
+// hubSecrets is the contents of ClientConfig.SecretsPath.
+type hubSecrets struct {
+	// The marshaled pb.ConfigResponse.
+	Config []byte `json:"config"`
+
+	// Service tokens by namespace.
+	ServiceTokens map[string]string `json:"service_tokens"`
+}
+
+// restoreSecrets loads the config and service tokens saved by saveSecrets.
+func (c *Client) restoreSecrets(L hclog.Logger) error {
+	data, err := ioutil.ReadFile(c.cfg.SecretsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var hs hubSecrets
+
+	err = json.Unmarshal(data, &hs)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(hs.Config) > 0 {
+		var cfg pb.ConfigResponse
+
+		err = cfg.Unmarshal(hs.Config)
+		if err != nil {
+			return err
+		}
+
+		c.lastConfig = &cfg
+
+		L.Info("restored hub configuration", "path", c.cfg.SecretsPath)
+	}
+
+	for ns, token := range hs.ServiceTokens {
+		c.serviceTokens[ns] = token
+	}
+
+	return nil
+}
+
+// saveSecrets writes the config and service tokens to SecretsPath, readable
+// only by its owner. c.mu must be held.
+func (c *Client) saveSecrets(L hclog.Logger) {
+	if c.cfg.SecretsPath == "" {
+		return
+	}
+
+	hs := hubSecrets{
+		ServiceTokens: c.serviceTokens,
+	}
+
+	if c.lastConfig != nil {
+		data, err := c.lastConfig.Marshal()
+		if err != nil {
+			L.Error("error marshaling hub configuration", "error", err)
+			return
+		}
+
+		hs.Config = data
+	}
+
+	err := writePrivateFile(c.cfg.SecretsPath, hs)
+	if err != nil {
+		L.Error("error saving hub secrets", "path", c.cfg.SecretsPath, "error", err)
+	}
+}
+
+// writePrivateFile replaces path with the JSON encoding of v, in a file
+// only its owner can read.
+func writePrivateFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	// TempFile already creates the file as 0600, but be explicit since
+	// that's what keeps the secrets private.
+	err = f.Chmod(0600)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
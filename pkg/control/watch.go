@@ -0,0 +1,175 @@
+package control
+
+import (
+	context "context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// publishActivity adds a change to the activity log so that it's seen by
+// WatchServices callers on any instance, and sends it to the webhooks
+// subscribed to it. Changes are still applied if publishing fails, so the
+// error is only logged.
+func (s *Server) publishActivity(ctx context.Context, ae *pb.ActivityEntry) {
+	if s.activity != nil {
+		err := s.activity.Publish(ctx, ae)
+		if err != nil {
+			s.L.Error("error publishing activity", "error", err)
+		}
+	}
+
+	s.sendChangeWebhooks(ctx, ae)
+}
+
+// publishServicesRemoved publishes the removal of services that were
+// deleted from the database.
+func (s *Server) publishServicesRemoved(ctx context.Context, sos []*Service) {
+	for _, so := range sos {
+		acc, err := pb.AccountFromKey(so.AccountId)
+		if err != nil {
+			s.L.Error("error decoding account of removed service", "error", err)
+			continue
+		}
+
+		ps, err := so.toPB()
+		if err != nil {
+			s.L.Error("error decoding removed service", "error", err)
+			continue
+		}
+
+		s.publishActivity(ctx, &pb.ActivityEntry{
+			ServiceRemoved: &pb.ServiceChange{
+				Account: acc,
+				Service: ps,
+			},
+		})
+	}
+}
+
+// A resume token is the id of the activity log event that an event came
+// from.
+func resumeToken(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func parseResumeToken(tok string) (int64, error) {
+	id, err := strconv.ParseInt(tok, 10, 64)
+	if err != nil || id < 0 {
+		return 0, errors.Wrapf(ErrInvalidRequest, "invalid resume token: %s", tok)
+	}
+
+	return id, nil
+}
+
+// serviceEvent returns the event that WatchServices sends for ae, or nil if
+// ae isn't a change to services or label links.
+func serviceEvent(ae *pb.ActivityEntry) *pb.ServiceEvent {
+	switch {
+	case ae.ServiceAdded != nil:
+		return &pb.ServiceEvent{
+			Type:    pb.SERVICE_ADDED,
+			Account: ae.ServiceAdded.Account,
+			Service: ae.ServiceAdded.Service,
+		}
+	case ae.ServiceRemoved != nil:
+		return &pb.ServiceEvent{
+			Type:    pb.SERVICE_REMOVED,
+			Account: ae.ServiceRemoved.Account,
+			Service: ae.ServiceRemoved.Service,
+		}
+	case ae.LabelLinkAdded != nil:
+		return &pb.ServiceEvent{
+			Type:      pb.LABEL_LINK_ADDED,
+			Account:   ae.LabelLinkAdded.Account,
+			LabelLink: ae.LabelLinkAdded,
+		}
+	case ae.LabelLinkRemoved != nil:
+		return &pb.ServiceEvent{
+			Type:      pb.LABEL_LINK_REMOVED,
+			Account:   ae.LabelLinkRemoved.Account,
+			LabelLink: ae.LabelLinkRemoved,
+		}
+	default:
+		return nil
+	}
+}
+
+// WatchServices streams the services and label-links that are added and
+// removed in the accounts of the caller's namespace. Each event carries a
+// resume token, which can be passed in a later request to continue after
+// that event, as long as it's still within ActivityRetention. Otherwise
+// the stream fails with codes.OutOfRange.
+func (s *Server) WatchServices(req *pb.WatchServicesRequest, stream pb.ControlManagement_WatchServicesServer) error {
+	ctx := stream.Context()
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return err
+	}
+
+	if s.activity == nil {
+		return ErrNoActivityBus
+	}
+
+	cursor := CursorLatest
+
+	if req.ResumeToken != "" {
+		cursor, err = parseResumeToken(req.ResumeToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.m.IncrCounter([]string{"watch", "services"}, 1)
+
+	ar, err := s.activity.Subscribe(ctx, cursor)
+	if err != nil {
+		// The events after the token are gone, so the caller has to list
+		// the services again and watch from there.
+		if errors.Cause(err) == ErrCursorOutOfRange {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+
+		return err
+	}
+
+	defer ar.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entries := <-ar.C:
+			for _, act := range entries {
+				var ae pb.ActivityEntry
+
+				err := json.Unmarshal(act.Event, &ae)
+				if err != nil {
+					s.L.Error("error unmarshaling activity log entry", "error", err)
+					continue
+				}
+
+				ev := serviceEvent(&ae)
+				if ev == nil || ev.Account == nil {
+					continue
+				}
+
+				if !caller.AllowAccount(ev.Account.Namespace) {
+					continue
+				}
+
+				ev.ResumeToken = resumeToken(act.Id)
+
+				err = stream.Send(ev)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
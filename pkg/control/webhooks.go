@@ -0,0 +1,916 @@
+package control
+
+import (
+	"bytes"
+	context "context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	fmt "fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/workq"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// The events that can be delivered to webhooks.
+const (
+	WebhookAgentConnected    = "agent.connected"
+	WebhookAgentDisconnected = "agent.disconnected"
+
+	// Sent along with agent.disconnected when the last agent of an account
+	// disconnects.
+	WebhookAccountOffline = "account.offline"
+
+	WebhookServiceAdded       = "service.added"
+	WebhookServiceRemoved     = "service.removed"
+	WebhookLabelLinkAdded     = "label-link.added"
+	WebhookLabelLinkRemoved   = "label-link.removed"
+	WebhookAccountRateLimited = "account.rate-limited"
+)
+
+var webhookEvents = map[string]bool{
+	WebhookAgentConnected:     true,
+	WebhookAgentDisconnected:  true,
+	WebhookAccountOffline:     true,
+	WebhookServiceAdded:       true,
+	WebhookServiceRemoved:     true,
+	WebhookLabelLinkAdded:     true,
+	WebhookLabelLinkRemoved:   true,
+	WebhookAccountRateLimited: true,
+}
+
+// The job type that delivers webhooks. Register Server.DeliverWebhook as its
+// handler on the workq registry.
+const WebhookJobType = "deliver-webhook"
+
+// The queue that webhook deliveries are added to.
+var WebhookQueue = "default"
+
+// How many times a delivery is attempted before it's marked as failed. The
+// workq worker backs off between attempts.
+var WebhookMaxAttempts = 10
+
+// How long to wait for a webhook to respond.
+var webhookTimeout = 10 * time.Second
+
+// ErrWebhookAddressBlocked is returned when a webhook is on an address the
+// control plane doesn't make requests to.
+var ErrWebhookAddressBlocked = errors.New("webhook address is not allowed")
+
+// ErrNoSuchWebhook is returned for a webhook that doesn't exist or isn't in
+// the caller's namespace.
+var ErrNoSuchWebhook = errors.New("no such webhook")
+
+// Webhook URLs are supplied by tenants, so they aren't allowed to reach the
+// networks of the control plane itself: loopback, private, link-local
+// (including cloud metadata services), and similar ranges.
+var blockedWebhookNets = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		nets = append(nets, n)
+	}
+
+	return nets
+}
+
+func webhookIPAllowed(ip net.IP) bool {
+	for _, n := range blockedWebhookNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkWebhookDial is run for each connection made to a webhook, once the
+// host has been resolved, so that names resolving to blocked addresses are
+// caught too.
+func checkWebhookDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !webhookIPAllowed(ip) {
+		return errors.Wrapf(ErrWebhookAddressBlocked, "address: %s", host)
+	}
+
+	return nil
+}
+
+// newWebhookClient returns the client that delivers webhooks. It only
+// connects to allowed addresses, and doesn't follow redirects, which could
+// otherwise lead it anywhere.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   checkWebhookDial,
+	}
+
+	tr := cleanhttp.DefaultPooledTransport()
+	tr.DialContext = dialer.DialContext
+
+	// A proxy would be dialed in place of the webhook.
+	tr.Proxy = nil
+
+	return &http.Client{
+		Transport: tr,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// The statuses of a webhook delivery.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// The headers sent with each delivery. The signature is the hex encoded
+// HMAC-SHA256, keyed with the secret of the webhook, of the timestamp, a
+// period, and the body.
+const (
+	WebhookEventHeader     = "X-Horizon-Event"
+	WebhookDeliveryHeader  = "X-Horizon-Delivery"
+	WebhookTimestampHeader = "X-Horizon-Timestamp"
+	WebhookSignatureHeader = "X-Horizon-Signature"
+)
+
+type Webhook struct {
+	ID        []byte `gorm:"primary_key"`
+	Namespace string
+	URL       string
+	Secret    string
+	Events    pq.StringArray
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w *Webhook) toPB() *pb.Webhook {
+	return &pb.Webhook{
+		Id:        pb.ULIDFromBytes(w.ID),
+		Namespace: w.Namespace,
+		Url:       w.URL,
+		Events:    w.Events,
+		CreatedAt: pb.NewTimestamp(w.CreatedAt),
+	}
+}
+
+// WebhookDelivery is the record of an event being delivered to a webhook.
+type WebhookDelivery struct {
+	ID        []byte `gorm:"primary_key"`
+	WebhookID []byte
+	Event     string
+	Payload   []byte
+
+	Status     string
+	Attempts   int
+	StatusCode int
+	Error      string
+
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeliveredAt *time.Time
+}
+
+func (d *WebhookDelivery) toPB() *pb.WebhookDelivery {
+	pd := &pb.WebhookDelivery{
+		Id:         pb.ULIDFromBytes(d.ID),
+		WebhookId:  pb.ULIDFromBytes(d.WebhookID),
+		Event:      d.Event,
+		Status:     d.Status,
+		Attempts:   int32(d.Attempts),
+		StatusCode: int32(d.StatusCode),
+		Error:      d.Error,
+		CreatedAt:  pb.NewTimestamp(d.CreatedAt),
+	}
+
+	if d.DeliveredAt != nil {
+		pd.DeliveredAt = pb.NewTimestamp(*d.DeliveredAt)
+	}
+
+	return pd
+}
+
+// WebhookPayload is the body of a delivery.
+type WebhookPayload struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	Namespace string          `json:"namespace"`
+	AccountID string          `json:"account_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookJob is the payload of a deliver-webhook job.
+type WebhookJob struct {
+	DeliveryID []byte `json:"delivery_id"`
+}
+
+// SignWebhook returns the signature of a delivery with the given timestamp
+// and body, as sent in the X-Horizon-Signature header.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte{'.'})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *Server) AddWebhook(ctx context.Context, req *pb.AddWebhookRequest) (*pb.AddWebhookResponse, error) {
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Namespace == "" {
+		req.Namespace = caller.Account().Namespace
+	}
+
+	if !caller.AllowAccount(req.Namespace) {
+		return nil, errors.Wrapf(ErrPermissionDenied, "invalid namespace requested")
+	}
+
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidRequest, "invalid webhook url: %s", req.Url)
+	}
+
+	// Names are checked when they're resolved for each delivery, but
+	// reject the addresses that are known to be blocked up front.
+	if ip := net.ParseIP(u.Hostname()); (ip != nil && !webhookIPAllowed(ip)) || u.Hostname() == "localhost" {
+		return nil, errors.Wrapf(ErrInvalidRequest, "webhook url not allowed: %s", req.Url)
+	}
+
+	for _, ev := range req.Events {
+		if !webhookEvents[ev] {
+			return nil, errors.Wrapf(ErrInvalidRequest, "unknown webhook event: %s", ev)
+		}
+	}
+
+	secret := make([]byte, 32)
+
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	wh := Webhook{
+		ID:        pb.NewULID().Bytes(),
+		Namespace: req.Namespace,
+		URL:       req.Url,
+		Secret:    hex.EncodeToString(secret),
+		Events:    req.Events,
+	}
+
+	err = dbx.Check(s.db.Create(&wh))
+	if err != nil {
+		return nil, err
+	}
+
+	s.m.IncrCounter([]string{"webhook", "create"}, 1)
+
+	return &pb.AddWebhookResponse{
+		Webhook: wh.toPB(),
+		Secret:  wh.Secret,
+	}, nil
+}
+
+// lookupWebhook returns the webhook with the given id, if it's in a
+// namespace the caller has access to.
+func (s *Server) lookupWebhook(ctx context.Context, id *pb.ULID) (*Webhook, error) {
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "missing webhook id")
+	}
+
+	var wh Webhook
+
+	err = dbx.Check(s.db.Where("id = ?", id.Bytes()).First(&wh))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNoSuchWebhook
+		}
+
+		return nil, err
+	}
+
+	// Webhooks in other namespaces are reported the same as missing ones,
+	// so that callers can't probe for them.
+	if !caller.AllowAccount(wh.Namespace) {
+		return nil, ErrNoSuchWebhook
+	}
+
+	return &wh, nil
+}
+
+func (s *Server) RemoveWebhook(ctx context.Context, req *pb.RemoveWebhookRequest) (*pb.Noop, error) {
+	wh, err := s.lookupWebhook(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbx.Check(s.db.Where("id = ?", wh.ID).Delete(&Webhook{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Noop{}, nil
+}
+
+func (s *Server) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, ns := caller.HasCapability(pb.ACCESS)
+	if !ok {
+		return nil, ErrInvalidRequest
+	}
+
+	var webhooks []*Webhook
+
+	err = dbx.Check(
+		s.db.
+			Where("namespace = ? OR starts_with(namespace, ?)", ns, ns+"/").
+			Order("id ASC").
+			Find(&webhooks),
+	)
+
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var resp pb.ListWebhooksResponse
+
+	for _, wh := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, wh.toPB())
+	}
+
+	return &resp, nil
+}
+
+const DefaultListWebhookDeliveriesLimit = 100
+
+// ListWebhookDeliveries returns the most recent deliveries to a webhook,
+// newest first.
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	wh, err := s.lookupWebhook(ctx, req.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultListWebhookDeliveriesLimit
+	}
+
+	var deliveries []*WebhookDelivery
+
+	err = dbx.Check(
+		s.db.Where("webhook_id = ?", wh.ID).
+			Order("created_at DESC").
+			Limit(limit).
+			Find(&deliveries),
+	)
+
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var resp pb.ListWebhookDeliveriesResponse
+
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, d.toPB())
+	}
+
+	return &resp, nil
+}
+
+// sendWebhooks queues a delivery of the event to each webhook that's
+// subscribed to it in the namespace of account or a parent of it. Events
+// are best effort, so errors are only logged.
+func (s *Server) sendWebhooks(ctx context.Context, account *pb.Account, event string, data interface{}) {
+	if s.jobs == nil || account == nil {
+		return
+	}
+
+	L := s.L.Named("webhooks")
+
+	var webhooks []*Webhook
+
+	err := dbx.Check(
+		s.db.
+			Where("namespace = ? OR starts_with(?, namespace || '/')", account.Namespace, account.Namespace).
+			Where("cardinality(events) = 0 OR events IS NULL OR ? = ANY(events)", event).
+			Find(&webhooks),
+	)
+
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			L.Error("error looking up webhooks", "error", err, "event", event)
+		}
+
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		L.Error("error encoding webhook data", "error", err, "event", event)
+		return
+	}
+
+	for _, wh := range webhooks {
+		id := pb.NewULID()
+
+		payload, err := json.Marshal(&WebhookPayload{
+			ID:        id.SpecString(),
+			Event:     event,
+			Namespace: account.Namespace,
+			AccountID: account.AccountId.SpecString(),
+			CreatedAt: time.Now(),
+			Data:      encoded,
+		})
+
+		if err != nil {
+			L.Error("error encoding webhook payload", "error", err, "event", event)
+			return
+		}
+
+		delivery := WebhookDelivery{
+			ID:        id.Bytes(),
+			WebhookID: wh.ID,
+			Event:     event,
+			Payload:   payload,
+			Status:    DeliveryPending,
+		}
+
+		err = dbx.Check(s.db.Create(&delivery))
+		if err != nil {
+			L.Error("error recording webhook delivery", "error", err, "event", event)
+			continue
+		}
+
+		job := workq.NewJob()
+		job.Queue = WebhookQueue
+
+		err = job.Set(WebhookJobType, &WebhookJob{DeliveryID: delivery.ID})
+		if err == nil {
+			err = s.jobs.Inject(job)
+		}
+
+		if err != nil {
+			L.Error("error queueing webhook delivery", "error", err, "event", event)
+			continue
+		}
+
+		s.m.IncrCounter([]string{"webhook", "queued"}, 1)
+	}
+}
+
+// DeliverWebhook is the handler of deliver-webhook jobs. Errors cause the
+// job to be retried, until the delivery has been attempted
+// WebhookMaxAttempts times.
+func (s *Server) DeliverWebhook(ctx context.Context, jobType string, job *WebhookJob) error {
+	var delivery WebhookDelivery
+
+	err := dbx.Check(s.db.Where("id = ?", job.DeliveryID).First(&delivery))
+	if err != nil {
+		// Removed along with its webhook.
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	if delivery.Status != DeliveryPending {
+		return nil
+	}
+
+	var wh Webhook
+
+	err = dbx.Check(s.db.Where("id = ?", delivery.WebhookID).First(&wh))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	code, derr := s.postWebhook(ctx, &wh, &delivery)
+
+	attempts := delivery.Attempts + 1
+
+	updates := map[string]interface{}{
+		"attempts":    attempts,
+		"status_code": code,
+		"error":       "",
+	}
+
+	var retry bool
+
+	switch {
+	case derr == nil:
+		updates["status"] = DeliveryDelivered
+		updates["delivered_at"] = time.Now()
+		s.m.IncrCounter([]string{"webhook", "delivered"}, 1)
+	case attempts >= WebhookMaxAttempts:
+		updates["status"] = DeliveryFailed
+		updates["error"] = derr.Error()
+		s.m.IncrCounter([]string{"webhook", "failed"}, 1)
+	default:
+		updates["error"] = derr.Error()
+		retry = true
+	}
+
+	err = dbx.Check(s.db.Model(&delivery).Updates(updates))
+	if err != nil {
+		return err
+	}
+
+	if retry {
+		return errors.Wrapf(derr, "delivering webhook, attempt %d", attempts)
+	}
+
+	return nil
+}
+
+func (s *Server) postWebhook(ctx context.Context, wh *Webhook, delivery *WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest("POST", wh.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req = req.WithContext(ctx)
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, pb.ULIDFromBytes(delivery.ID).SpecString())
+	req.Header.Set(WebhookTimestampHeader, ts)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(wh.Secret, ts, delivery.Payload))
+
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// The name of the webhook event for each kind of change published by the
+// server.
+var changeWebhooks = map[pb.ServiceEvent_Type]string{
+	pb.SERVICE_ADDED:      WebhookServiceAdded,
+	pb.SERVICE_REMOVED:    WebhookServiceRemoved,
+	pb.LABEL_LINK_ADDED:   WebhookLabelLinkAdded,
+	pb.LABEL_LINK_REMOVED: WebhookLabelLinkRemoved,
+}
+
+// sendChangeWebhooks sends a change to services or label-links to the
+// webhooks subscribed to it.
+func (s *Server) sendChangeWebhooks(ctx context.Context, ae *pb.ActivityEntry) {
+	ev := serviceEvent(ae)
+	if ev == nil {
+		return
+	}
+
+	var data interface{}
+
+	if ev.Service != nil {
+		data = ev.Service
+	} else {
+		data = ev.LabelLink
+	}
+
+	s.sendWebhooks(ctx, ev.Account, changeWebhooks[ev.Type], data)
+}
+
+// How many agents each server remembers having recorded.
+const DefaultKnownAgents = 10000
+
+// Hubs send a flow for every connected agent each minute. An agent's
+// last_seen is refreshed at most once per AgentSeenInterval, and agents not
+// seen for AgentTimeout are considered disconnected by the sweep-agents job.
+var (
+	AgentSeenInterval = 30 * time.Second
+	AgentTimeout      = 5 * time.Minute
+)
+
+// The job type that disconnects agents whose hub has stopped reporting them.
+// Register Server.SweepAgents as its handler and run it periodically.
+const AgentSweepJobType = "sweep-agents"
+
+// Agent tracks the agents connected to hubs, so that connects and
+// disconnects can be told apart from the periodic flows about an agent.
+type Agent struct {
+	AgentID   []byte `gorm:"primary_key"`
+	AccountID []byte
+	HubID     []byte
+	StartedAt time.Time
+	LastSeen  time.Time
+}
+
+type agentWebhookData struct {
+	AgentID        string     `json:"agent_id"`
+	HubID          string     `json:"hub_id"`
+	ConnectedAt    time.Time  `json:"connected_at"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
+
+	// How many agents of the account are connected after the event.
+	ConnectedAgents int `json:"connected_agents"`
+}
+
+type rateLimitWebhookData struct {
+	HubID      string    `json:"hub_id"`
+	Limit      string    `json:"limit"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (s *Server) connectedAgents(account []byte) (int, error) {
+	var count int
+
+	err := dbx.Check(s.db.Model(&Agent{}).Where("account_id = ?", account).Count(&count))
+	return count, err
+}
+
+// processAgentFlow sends agent.connected the first time an agent is seen,
+// and agent.disconnected when a flow says it has disconnected.
+func (s *Server) processAgentFlow(ctx context.Context, rec *pb.FlowRecord_AgentConnection) {
+	if rec.AgentId == nil || rec.HubId == nil || rec.Account == nil {
+		return
+	}
+
+	var started time.Time
+
+	if rec.StartedAt != nil {
+		started = rec.StartedAt.Time()
+	} else {
+		started = time.Now()
+	}
+
+	key := rec.AgentId.SpecString()
+
+	if rec.EndedAt != nil {
+		s.agentDisconnected(ctx, rec.AgentId.Bytes(), rec.EndedAt.Time())
+		return
+	}
+
+	now := time.Now()
+
+	// Agents send flows every time a stream starts and ends, as well as a
+	// heartbeat each minute, so only refresh the ones we've already recorded.
+	if s.knownAgents != nil {
+		if v, ok := s.knownAgents.Get(key); ok {
+			if now.Sub(v.(time.Time)) < AgentSeenInterval {
+				return
+			}
+
+			touched, err := s.touchAgent(rec.AgentId, now)
+			if err != nil {
+				s.L.Error("error tracking agent", "error", err, "agent", rec.AgentId)
+				return
+			}
+
+			if touched {
+				s.knownAgents.Add(key, now)
+				return
+			}
+
+			// The agent was swept or disconnected meanwhile, so record it again.
+		}
+	}
+
+	res := s.db.Exec(
+		"INSERT INTO agents (agent_id, account_id, hub_id, started_at, last_seen) VALUES (?, ?, ?, ?, ?) ON CONFLICT (agent_id) DO NOTHING",
+		rec.AgentId.Bytes(), rec.Account.Key(), rec.HubId.Bytes(), started, now,
+	)
+
+	err := dbx.Check(res)
+	if err != nil {
+		s.L.Error("error tracking agent", "error", err, "agent", rec.AgentId)
+		return
+	}
+
+	if s.knownAgents != nil {
+		s.knownAgents.Add(key, now)
+	}
+
+	// Recorded already, by another server or before it fell out of knownAgents.
+	if res.RowsAffected == 0 {
+		_, err = s.touchAgent(rec.AgentId, now)
+		if err != nil {
+			s.L.Error("error tracking agent", "error", err, "agent", rec.AgentId)
+		}
+
+		return
+	}
+
+	count, err := s.connectedAgents(rec.Account.Key())
+	if err != nil {
+		s.L.Error("error counting agents", "error", err, "account", rec.Account)
+		return
+	}
+
+	s.sendWebhooks(ctx, rec.Account, WebhookAgentConnected, &agentWebhookData{
+		AgentID:         rec.AgentId.SpecString(),
+		HubID:           rec.HubId.SpecString(),
+		ConnectedAt:     started,
+		ConnectedAgents: count,
+	})
+}
+
+// touchAgent records that an agent was reported as connected at seen. It
+// returns false if the agent isn't being tracked.
+func (s *Server) touchAgent(agentId *pb.ULID, seen time.Time) (bool, error) {
+	res := s.db.Model(&Agent{}).Where("agent_id = ?", agentId.Bytes()).UpdateColumn("last_seen", seen)
+
+	err := dbx.Check(res)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// agentDisconnected stops tracking an agent, sending agent.disconnected, and
+// account.offline if it was the last agent of its account.
+func (s *Server) agentDisconnected(ctx context.Context, agentId []byte, ended time.Time) {
+	if s.knownAgents != nil {
+		s.knownAgents.Remove(pb.ULIDFromBytes(agentId).SpecString())
+	}
+
+	var agent Agent
+
+	err := dbx.Check(s.db.Where("agent_id = ?", agentId).First(&agent))
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			s.L.Error("error looking up agent", "error", err)
+		}
+
+		return
+	}
+
+	res := s.db.Where("agent_id = ?", agentId).Delete(&Agent{})
+
+	err = dbx.Check(res)
+	if err != nil {
+		s.L.Error("error removing agent", "error", err)
+		return
+	}
+
+	// Another instance got to it first
+	if res.RowsAffected == 0 {
+		return
+	}
+
+	account, err := pb.AccountFromKey(agent.AccountID)
+	if err != nil {
+		s.L.Error("error decoding account of agent", "error", err)
+		return
+	}
+
+	count, err := s.connectedAgents(agent.AccountID)
+	if err != nil {
+		s.L.Error("error counting agents", "error", err, "account", account)
+		return
+	}
+
+	data := &agentWebhookData{
+		AgentID:         pb.ULIDFromBytes(agent.AgentID).SpecString(),
+		HubID:           pb.ULIDFromBytes(agent.HubID).SpecString(),
+		ConnectedAt:     agent.StartedAt,
+		DisconnectedAt:  &ended,
+		ConnectedAgents: count,
+	}
+
+	s.sendWebhooks(ctx, account, WebhookAgentDisconnected, data)
+
+	if count == 0 {
+		s.sendWebhooks(ctx, account, WebhookAccountOffline, data)
+	}
+}
+
+// removeHubAgents disconnects the agents that were connected to a hub that
+// has gone away.
+func (s *Server) removeHubAgents(ctx context.Context, hubId *pb.ULID) error {
+	var agents []*Agent
+
+	err := dbx.Check(s.db.Where("hub_id = ?", hubId.Bytes()).Find(&agents))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, agent := range agents {
+		s.agentDisconnected(ctx, agent.AgentID, now)
+	}
+
+	return nil
+}
+
+// SweepAgents is the handler of sweep-agents jobs. It disconnects the agents
+// that haven't been reported by their hub for AgentTimeout, which covers hubs
+// that go away without disconnecting or registering again.
+func (s *Server) SweepAgents(ctx context.Context, jobType string, _ *struct{}) error {
+	var agents []*Agent
+
+	err := dbx.Check(s.db.Where("last_seen < ?", time.Now().Add(-AgentTimeout)).Find(&agents))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	for _, agent := range agents {
+		s.L.Info("removing agent that stopped being reported", "agent", pb.ULIDFromBytes(agent.AgentID), "last-seen", agent.LastSeen)
+		s.agentDisconnected(ctx, agent.AgentID, agent.LastSeen)
+	}
+
+	return nil
+}
+
+// processRateLimitFlow sends account.rate-limited for a limit hit on a hub.
+func (s *Server) processRateLimitFlow(ctx context.Context, rec *pb.FlowRecord_RateLimit) {
+	if rec.HubId == nil || rec.Account == nil {
+		return
+	}
+
+	occurred := time.Now()
+
+	if rec.OccurredAt != nil {
+		occurred = rec.OccurredAt.Time()
+	}
+
+	s.sendWebhooks(ctx, rec.Account, WebhookAccountRateLimited, &rateLimitWebhookData{
+		HubID:      rec.HubId.SpecString(),
+		Limit:      rec.Limit,
+		OccurredAt: occurred,
+	})
+}
@@ -0,0 +1,61 @@
+package control
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookClient(t *testing.T) {
+	t.Run("rejects addresses on blocked networks", func(t *testing.T) {
+		var hit bool
+
+		hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			hit = true
+		}))
+		defer hs.Close()
+
+		_, err := newWebhookClient().Post(hs.URL, "application/json", strings.NewReader("{}"))
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), ErrWebhookAddressBlocked.Error())
+		assert.False(t, hit)
+
+		for _, addr := range []string{"127.0.0.1", "169.254.169.254", "10.1.2.3", "192.168.0.1", "::1", "fe80::1", "::ffff:127.0.0.1"} {
+			assert.False(t, webhookIPAllowed(net.ParseIP(addr)), addr)
+		}
+
+		assert.True(t, webhookIPAllowed(net.ParseIP("93.184.216.34")))
+	})
+
+	t.Run("doesn't follow redirects", func(t *testing.T) {
+		defer func(nets []*net.IPNet) {
+			blockedWebhookNets = nets
+		}(blockedWebhookNets)
+
+		blockedWebhookNets = nil
+
+		var hit bool
+
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			hit = true
+		}))
+		defer target.Close()
+
+		hs := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+		defer hs.Close()
+
+		resp, err := newWebhookClient().Post(hs.URL, "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+		assert.False(t, hit)
+	})
+}
@@ -20,7 +20,7 @@ type Bolt struct {
 
 func NewBolt(path string) (*Bolt, error) {
 	opts := bbolt.DefaultOptions
-	db, err := bbolt.Open(path, 0755, opts)
+	db, err := bbolt.Open(path, 0600, opts)
 	if err != nil {
 		return nil, err
 	}
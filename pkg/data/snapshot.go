@@ -0,0 +1,65 @@
+package data
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"go.etcd.io/bbolt"
+)
+
+var ErrCorruptSnapshot = errors.New("corrupt snapshot")
+
+// SaveSnapshot stores data, along with it's etag, under key. Any previous
+// snapshot at key is replaced.
+func (b *Bolt) SaveSnapshot(key, etag string, data []byte) error {
+	val := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(etag)+len(data))
+	n := binary.PutUvarint(val, uint64(len(etag)))
+
+	val = append(val[:n], etag...)
+	val = append(val, data...)
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		buk, err := tx.CreateBucketIfNotExists([]byte("snapshots"))
+		if err != nil {
+			return err
+		}
+
+		return buk.Put([]byte(key), val)
+	})
+}
+
+// LoadSnapshots calls fn with each snapshot that has a key starting with
+// prefix.
+func (b *Bolt) LoadSnapshots(prefix string, fn func(key, etag string, data []byte) error) error {
+	bprefix := []byte(prefix)
+
+	return b.db.View(func(tx *bbolt.Tx) error {
+		buk := tx.Bucket([]byte("snapshots"))
+		if buk == nil {
+			return nil
+		}
+
+		cur := buk.Cursor()
+
+		for k, v := cur.Seek(bprefix); k != nil && bytes.HasPrefix(k, bprefix); k, v = cur.Next() {
+			sz, n := binary.Uvarint(v)
+			if n <= 0 || uint64(len(v)-n) < sz {
+				return ErrCorruptSnapshot
+			}
+
+			etag := string(v[n : n+int(sz)])
+
+			// The data is only valid for the life of the transaction, so
+			// hand the callback a copy.
+			data := append([]byte(nil), v[n+int(sz):]...)
+
+			err := fn(string(k), etag, data)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
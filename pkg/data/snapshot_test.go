@@ -0,0 +1,119 @@
+package data
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestSnapshots(t *testing.T) {
+	type snapshot struct {
+		key, etag, data string
+	}
+
+	setup := func(t *testing.T) (*Bolt, func()) {
+		dir, err := ioutil.TempDir("", "hzn")
+		require.NoError(t, err)
+
+		b, err := NewBolt(filepath.Join(dir, "data.db"))
+		require.NoError(t, err)
+
+		return b, func() {
+			b.db.Close()
+			os.RemoveAll(dir)
+		}
+	}
+
+	load := func(t *testing.T, b *Bolt, prefix string) []snapshot {
+		var out []snapshot
+
+		err := b.LoadSnapshots(prefix, func(key, etag string, data []byte) error {
+			out = append(out, snapshot{key, etag, string(data)})
+			return nil
+		})
+		require.NoError(t, err)
+
+		return out
+	}
+
+	t.Run("loads the snapshots that were saved under a prefix", func(t *testing.T) {
+		b, cleanup := setup(t)
+		defer cleanup()
+
+		assert.Empty(t, load(t, b, "accounts/"))
+
+		require.NoError(t, b.SaveSnapshot("accounts/b", "e2", []byte("second")))
+		require.NoError(t, b.SaveSnapshot("accounts/a", "e1", []byte("first")))
+		require.NoError(t, b.SaveSnapshot("label-links", "e3", []byte("links")))
+		require.NoError(t, b.SaveSnapshot("accounts/c", "", nil))
+
+		assert.Equal(t, []snapshot{
+			{"accounts/a", "e1", "first"},
+			{"accounts/b", "e2", "second"},
+			{"accounts/c", "", ""},
+		}, load(t, b, "accounts/"))
+
+		assert.Equal(t, []snapshot{
+			{"label-links", "e3", "links"},
+		}, load(t, b, "label-links"))
+	})
+
+	t.Run("replaces a snapshot saved under the same key", func(t *testing.T) {
+		b, cleanup := setup(t)
+		defer cleanup()
+
+		require.NoError(t, b.SaveSnapshot("accounts/a", "e1", []byte("first")))
+		require.NoError(t, b.SaveSnapshot("accounts/a", "etag-2", []byte("replaced")))
+
+		assert.Equal(t, []snapshot{
+			{"accounts/a", "etag-2", "replaced"},
+		}, load(t, b, "accounts/"))
+	})
+
+	t.Run("rejects corrupt snapshots", func(t *testing.T) {
+		b, cleanup := setup(t)
+		defer cleanup()
+
+		err := b.db.Update(func(tx *bbolt.Tx) error {
+			buk, err := tx.CreateBucketIfNotExists([]byte("snapshots"))
+			if err != nil {
+				return err
+			}
+
+			// Claims a 10 byte etag, but has none.
+			return buk.Put([]byte("accounts/a"), []byte{10})
+		})
+		require.NoError(t, err)
+
+		err = b.LoadSnapshots("accounts/", func(key, etag string, data []byte) error {
+			return nil
+		})
+		assert.Equal(t, ErrCorruptSnapshot, err)
+	})
+
+	t.Run("stops at the first error from the callback", func(t *testing.T) {
+		b, cleanup := setup(t)
+		defer cleanup()
+
+		require.NoError(t, b.SaveSnapshot("accounts/a", "e1", []byte("first")))
+		require.NoError(t, b.SaveSnapshot("accounts/b", "e2", []byte("second")))
+
+		stop := errors.New("stop")
+
+		var calls int
+
+		err := b.LoadSnapshots("accounts/", func(key, etag string, data []byte) error {
+			calls++
+			return stop
+		})
+
+		assert.Equal(t, stop, err)
+		assert.Equal(t, 1, calls)
+	})
+}
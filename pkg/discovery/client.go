@@ -1,25 +1,45 @@
 package discovery
 
 import (
-	"container/list"
 	"context"
+	"crypto/ed25519"
 	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/netloc"
 	"github.com/hashicorp/horizon/pkg/pb"
 )
 
+// Client finds the hubs an agent should connect to. It keeps probing the
+// hubs advertised by the discovery data to rank them by latency, and
+// periodically refreshes the list of hubs.
 type Client struct {
+	L   hclog.Logger
 	URL string
 
+	// How often the list of hubs is refreshed and how often each hub is
+	// probed. Change them before calling Refresh.
+	RefreshInterval time.Duration
+	ProbeInterval   time.Duration
+
+	// Measures the latency of a hub. Defaults to TLSProbe.
+	Probe ProbeFunc
+
+	// The key the control plane signs tokens with. If set, the discovery
+	// data must be signed with it and the certificates of the hubs are
+	// pinned to the fingerprints it lists.
+	PublicKey ed25519.PublicKey
+
+	// How old signed discovery data can be. Data signed before the data
+	// already accepted is also rejected. Defaults to DefaultMaxDataAge.
+	MaxDataAge time.Duration
+
 	mu sync.Mutex
 
 	location []*pb.NetworkLocation
@@ -29,10 +49,12 @@ type Client struct {
 
 	lastData *DiscoveryData
 
-	available *list.List
-	results   chan *pb.NetworkLocation
-	known     map[string]struct{}
-	latest    map[string]struct{}
+	// The hubs in the latest discovery data, keyed by name, and the names
+	// of the hubs that have been taken and not returned.
+	hubs  map[string]*hubState
+	inUse map[string]struct{}
+
+	background sync.Once
 }
 
 func NewClient(surl string) (*Client, error) {
@@ -68,24 +90,59 @@ func NewClient(surl string) (*Client, error) {
 	}
 
 	return &Client{
-		URL:       surl,
-		location:  locs,
-		dev:       dev,
-		available: list.New(),
-		known:     make(map[string]struct{}),
-		latest:    make(map[string]struct{}),
+		L:               hclog.L().Named("discovery"),
+		URL:             surl,
+		RefreshInterval: 5 * time.Minute,
+		ProbeInterval:   30 * time.Second,
+		Probe:           TLSProbe,
+		MaxDataAge:      DefaultMaxDataAge,
+		location:        locs,
+		dev:             dev,
+		hubs:            make(map[string]*hubState),
+		inUse:           make(map[string]struct{}),
 	}, nil
 }
 
+// Refresh fetches the hubs from the discovery data and probes them. The
+// first call also starts refreshing and probing in the background until
+// ctx is done.
 func (c *Client) Refresh(ctx context.Context) error {
 	if c.dev != "" {
-		c.available.PushFront(HubConfig{
-			Addr:     c.dev,
-			Insecure: true,
-		})
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.hubs = map[string]*hubState{
+			c.dev: {
+				cfg: HubConfig{
+					Addr:     c.dev,
+					Insecure: true,
+				},
+			},
+		}
+
 		return nil
 	}
 
+	err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.probe(ctx)
+
+	c.background.Do(func() {
+		go c.backgroundRefresh(ctx)
+	})
+
+	return nil
+}
+
+// fetch retrieves the discovery data and replaces the known hubs with the
+// ones it lists, keeping the scores of hubs that are still listed. Hubs no
+// longer listed aren't handed out again.
+func (c *Client) fetch(ctx context.Context) error {
+	// The data is authenticated by its signature when PublicKey is set,
+	// rather than by the certificate of the control plane.
 	client := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -94,193 +151,179 @@ func (c *Client) Refresh(ctx context.Context) error {
 		},
 	}
 
-	resp, err := client.Get(c.URL)
+	req, err := http.NewRequest("GET", c.URL, nil)
 	if err != nil {
 		return err
 	}
 
-	var dd DiscoveryData
-
-	err = json.NewDecoder(resp.Body).Decode(&dd)
+	resp, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return err
 	}
 
-	c.lastData = &dd
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error fetching discovery data: %s", resp.Status)
+	}
+
+	dd := &DiscoveryData{}
 
-	ch, err := c.search(ctx)
+	err = json.NewDecoder(resp.Body).Decode(dd)
 	if err != nil {
 		return err
 	}
 
-	c.results = ch
+	if c.PublicKey != nil {
+		dd, err = dd.verify(c.PublicKey, c.notBefore())
+		if err != nil {
+			return err
+		}
+	} else {
+		// Without the key, the fingerprints can't be trusted any more than
+		// the hubs they're for.
+		dd.CertFingerprints = nil
+	}
 
-	// TODO(evanphx) There is a bug caused by setting latest to nil and THEN
-	// populating the search results. Because the search is just reordered
-	// the servers based on latency, just skip it for now and use our initial
-	// results.
-	// go c.backgroundRefresh(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastData = dd
+	c.hubs = newHubStates(c.location, dd.Hubs, dd.CertFingerprints, c.hubs)
 
 	return nil
 }
 
-func (c *Client) backgroundRefresh(ctx context.Context) {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
+// notBefore returns the earliest time that signed discovery data is
+// accepted from.
+func (c *Client) notBefore() time.Time {
+	maxAge := c.MaxDataAge
+	if maxAge == 0 {
+		maxAge = DefaultMaxDataAge
+	}
 
-	var (
-		results chan *pb.NetworkLocation
-	)
+	t := time.Now().Add(-maxAge)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			results, _ = c.search(ctx)
-			c.latest = make(map[string]struct{})
-		case loc, ok := <-results:
-			if !ok {
-				results = nil
-				continue
-			}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-			c.mu.Lock()
+	if c.lastData != nil && c.lastData.ServerTime.After(t) {
+		t = c.lastData.ServerTime
+	}
 
-			c.latest[loc.Name] = struct{}{}
+	return t
+}
 
-			if _, ok := c.known[loc.Name]; !ok {
-				addr := loc.Addresses[0]
-				if !strings.Contains(addr, ":") {
-					addr += ":443"
-				}
+// probe measures the latency of each known hub.
+func (c *Client) probe(ctx context.Context) {
+	c.mu.Lock()
 
-				cfg := HubConfig{
-					Addr: addr,
-					Name: loc.Name,
-				}
+	var hubs []*hubState
 
-				c.known[loc.Name] = struct{}{}
+	for _, h := range c.hubs {
+		hubs = append(hubs, h)
+	}
 
-				c.available.PushBack(cfg)
-			}
+	c.mu.Unlock()
 
-			c.mu.Unlock()
-		}
-	}
+	probeHubs(ctx, c.Probe, &c.mu, hubs)
 }
 
-var ErrBadServer = errors.New("bad server")
+func (c *Client) backgroundRefresh(ctx context.Context) {
+	refresh := time.NewTicker(c.RefreshInterval)
+	defer refresh.Stop()
 
-func (c *Client) search(ctx context.Context) (chan *pb.NetworkLocation, error) {
-	client := &http.Client{
-		Timeout: 5 * time.Second,
-	}
+	probe := time.NewTicker(c.ProbeInterval)
+	defer probe.Stop()
 
-	input := &netloc.BestInput{
-		Local:      c.location,
-		Remote:     c.lastData.Hubs,
-		PublicOnly: true,
-		Latency: func(addr string) error {
-			resp, err := client.Get(fmt.Sprintf("http://%s/__hzn/healthz", addr))
-			if err == nil {
-				resp.Body.Close()
-
-				if resp.StatusCode != 200 {
-					return ErrBadServer
-				}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refresh.C:
+			err := c.fetch(ctx)
+			if err != nil {
+				c.L.Warn("error refreshing discovery data", "error", err)
 			}
-
-			return err
-		},
+		case <-probe.C:
+			c.probe(ctx)
+		}
 	}
+}
+
+// ranked returns the known hubs, best first. c.mu must be held.
+func (c *Client) ranked() []*hubState {
+	var hubs []*hubState
 
-	ch := make(chan *pb.NetworkLocation, 1)
+	for _, h := range c.hubs {
+		hubs = append(hubs, h)
+	}
 
-	go netloc.FindBestLive(ctx, input, ch)
+	rankHubs(hubs)
 
-	return ch, nil
+	return hubs
 }
 
+// Take returns the best hub that isn't already taken. It returns false if
+// all the known hubs are taken.
 func (c *Client) Take(ctx context.Context) (HubConfig, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if front := c.available.Front(); front != nil {
-		cfg := front.Value.(HubConfig)
-		c.available.Remove(front)
-		return cfg, true
-	}
-
-	select {
-	case <-ctx.Done():
-		return HubConfig{}, false
-	case loc, ok := <-c.results:
-		if !ok {
-			return HubConfig{}, false
+	for _, h := range c.ranked() {
+		if _, ok := c.inUse[h.cfg.Name]; ok {
+			continue
 		}
 
-		c.known[loc.Name] = struct{}{}
-		c.latest[loc.Name] = struct{}{}
+		c.inUse[h.cfg.Name] = struct{}{}
 
-		addr := loc.Addresses[0]
-		if !strings.Contains(addr, ":") {
-			addr += ":443"
-		}
-
-		return HubConfig{
-			Addr: addr,
-			Name: loc.Name,
-		}, true
+		return h.cfg, true
 	}
+
+	return HubConfig{}, false
 }
 
+// Return makes a hub returned by Take available again.
 func (c *Client) Return(cfg HubConfig) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// This will automatically prune out broken addresses
-	// that the centrial tier is no longer advertising.
-	if _, ok := c.latest[cfg.Name]; ok {
-		c.available.PushBack(cfg)
-	}
+	delete(c.inUse, cfg.Name)
 }
 
-func (c *Client) Best(ctx context.Context, count int) ([]string, error) {
-	if c.lastData == nil {
+// Best returns up to count of the healthy hubs, those with the lowest
+// latency first. All the healthy hubs are returned if count is 0.
+func (c *Client) Best(ctx context.Context, count int) ([]HubScore, error) {
+	c.mu.Lock()
+	refresh := c.lastData == nil && c.dev == ""
+	c.mu.Unlock()
+
+	if refresh {
 		err := c.Refresh(ctx)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	client := &http.Client{
-		Timeout: 5 * time.Second,
-	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	locs, err := netloc.FindBest(&netloc.BestInput{
-		Count:      count,
-		Local:      c.location,
-		Remote:     c.lastData.Hubs,
-		PublicOnly: true,
-		Latency: func(addr string) error {
-			resp, err := client.Get(fmt.Sprintf("http://%s/healthz", addr))
-			if err == nil {
-				resp.Body.Close()
-			}
-			return err
-		},
-	})
+	var best []HubScore
 
-	if err != nil {
-		return nil, err
-	}
+	for _, h := range c.ranked() {
+		if count > 0 && len(best) == count {
+			break
+		}
 
-	var addrs []string
+		if !h.healthy() {
+			continue
+		}
 
-	for _, loc := range locs {
-		addrs = append(addrs, loc.Addresses[0])
+		best = append(best, HubScore{
+			HubConfig: h.cfg,
+			Latency:   h.latency,
+		})
 	}
 
-	return addrs, nil
+	return best, nil
 }
@@ -0,0 +1,287 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProbes struct {
+	mu        sync.Mutex
+	latencies map[string]time.Duration
+	failing   map[string]bool
+}
+
+func (f *fakeProbes) set(name string, lat time.Duration, failing bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.latencies[name] = lat
+	f.failing[name] = failing
+}
+
+func (f *fakeProbes) probe(ctx context.Context, cfg HubConfig) (time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failing[cfg.Name] {
+		return 0, errors.New("connection refused")
+	}
+
+	return f.latencies[cfg.Name], nil
+}
+
+func TestClient(t *testing.T) {
+	setup := func(t *testing.T) (*Client, *fakeProbes, func([]*pb.NetworkLocation)) {
+		var (
+			mu   sync.Mutex
+			hubs []*pb.NetworkLocation
+		)
+
+		serve := func(locs []*pb.NetworkLocation) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			hubs = locs
+		}
+
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			json.NewEncoder(w).Encode(&DiscoveryData{
+				ServerTime: time.Now(),
+				Hubs:       hubs,
+			})
+		}))
+
+		probes := &fakeProbes{
+			latencies: make(map[string]time.Duration),
+			failing:   make(map[string]bool),
+		}
+
+		c, err := NewClient(serv.URL + HTTPPath)
+		require.NoError(t, err)
+
+		// Only refresh and probe when the test asks to.
+		c.RefreshInterval = time.Hour
+		c.ProbeInterval = time.Hour
+		c.Probe = probes.probe
+
+		return c, probes, serve
+	}
+
+	hubA := &pb.NetworkLocation{Name: "a.hzn", Addresses: []string{"10.0.0.1"}}
+	hubB := &pb.NetworkLocation{Name: "b.hzn", Addresses: []string{"10.0.0.2:4433"}}
+	hubC := &pb.NetworkLocation{Name: "c.hzn", Addresses: []string{"10.0.0.3"}}
+
+	private := &pb.NetworkLocation{
+		Name:      "private.hzn",
+		Addresses: []string{"192.168.1.1"},
+		Labels:    pb.ParseLabelSet("type=private"),
+	}
+
+	t.Run("ranks hubs by latency", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, probes, serve := setup(t)
+
+		serve([]*pb.NetworkLocation{hubA, hubB, private})
+
+		probes.set("a.hzn", 50*time.Millisecond, false)
+		probes.set("b.hzn", 10*time.Millisecond, false)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		best, err := c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 2)
+
+		assert.Equal(t, "b.hzn", best[0].Name)
+		assert.Equal(t, "10.0.0.2:4433", best[0].Addr)
+		assert.Equal(t, 10*time.Millisecond, best[0].Latency)
+
+		assert.Equal(t, "a.hzn", best[1].Name)
+		assert.Equal(t, "10.0.0.1:443", best[1].Addr)
+
+		best, err = c.Best(ctx, 1)
+		require.NoError(t, err)
+
+		require.Len(t, best, 1)
+		assert.Equal(t, "b.hzn", best[0].Name)
+	})
+
+	t.Run("hands out each hub once until it is returned", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, probes, serve := setup(t)
+
+		serve([]*pb.NetworkLocation{hubA, hubB})
+
+		probes.set("a.hzn", 50*time.Millisecond, false)
+		probes.set("b.hzn", 10*time.Millisecond, false)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		cfg, ok := c.Take(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "b.hzn", cfg.Name)
+
+		cfg, ok = c.Take(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "a.hzn", cfg.Name)
+
+		_, ok = c.Take(ctx)
+		assert.False(t, ok)
+
+		c.Return(HubConfig{Name: "b.hzn", Addr: "10.0.0.2:4433"})
+
+		cfg, ok = c.Take(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "b.hzn", cfg.Name)
+	})
+
+	t.Run("averages latency across probes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, probes, serve := setup(t)
+
+		serve([]*pb.NetworkLocation{hubA, hubB})
+
+		probes.set("a.hzn", 20*time.Millisecond, false)
+		probes.set("b.hzn", 10*time.Millisecond, false)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		// A single slow probe doesn't reorder the hubs.
+		probes.set("b.hzn", 40*time.Millisecond, false)
+		c.probe(ctx)
+
+		best, err := c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 2)
+		assert.Equal(t, "b.hzn", best[0].Name)
+		assert.Equal(t, 19*time.Millisecond, best[0].Latency)
+
+		c.probe(ctx)
+
+		best, err = c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 2)
+		assert.Equal(t, "a.hzn", best[0].Name)
+	})
+
+	t.Run("skips hubs that keep failing probes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, probes, serve := setup(t)
+
+		serve([]*pb.NetworkLocation{hubA, hubB})
+
+		probes.set("a.hzn", 50*time.Millisecond, false)
+		probes.set("b.hzn", 10*time.Millisecond, false)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		probes.set("b.hzn", 0, true)
+
+		for i := 0; i < maxProbeFailures; i++ {
+			c.probe(ctx)
+		}
+
+		best, err := c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 1)
+		assert.Equal(t, "a.hzn", best[0].Name)
+
+		cfg, ok := c.Take(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "a.hzn", cfg.Name)
+
+		probes.set("b.hzn", 10*time.Millisecond, false)
+		c.probe(ctx)
+
+		best, err = c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 2)
+		assert.Equal(t, "b.hzn", best[0].Name)
+	})
+
+	t.Run("uses the order of the discovery data until hubs are measured", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, probes, serve := setup(t)
+
+		serve([]*pb.NetworkLocation{hubB, hubA})
+
+		probes.set("a.hzn", 0, true)
+		probes.set("b.hzn", 0, true)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		cfg, ok := c.Take(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "b.hzn", cfg.Name)
+	})
+
+	t.Run("keeps scores when the hubs are refreshed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, probes, serve := setup(t)
+
+		serve([]*pb.NetworkLocation{hubA, hubB})
+
+		probes.set("a.hzn", 50*time.Millisecond, false)
+		probes.set("b.hzn", 10*time.Millisecond, false)
+		probes.set("c.hzn", 30*time.Millisecond, false)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		cfg, ok := c.Take(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "b.hzn", cfg.Name)
+
+		serve([]*pb.NetworkLocation{hubA, hubC})
+
+		require.NoError(t, c.fetch(ctx))
+
+		best, err := c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 2)
+
+		// c.hzn hasn't been probed yet, so it's ranked after a.hzn.
+		assert.Equal(t, "a.hzn", best[0].Name)
+		assert.Equal(t, 50*time.Millisecond, best[0].Latency)
+		assert.Equal(t, "c.hzn", best[1].Name)
+		assert.Equal(t, time.Duration(0), best[1].Latency)
+
+		c.probe(ctx)
+
+		best, err = c.Best(ctx, 0)
+		require.NoError(t, err)
+
+		require.Len(t, best, 2)
+		assert.Equal(t, "c.hzn", best[0].Name)
+	})
+}
@@ -0,0 +1,158 @@
+package discovery
+
+import (
+	"math"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// The label that the region of a hub is advertised with in the discovery
+// data, such as region=us-or.
+const RegionLabel = "region"
+
+// Geo is what is known about where an IP address is on the network.
+type Geo struct {
+	// The autonomous system the address is announced by, 0 if unknown.
+	ASN uint
+
+	// The ISO 3166-2 code of the area the address is in, such as US-OR,
+	// or just the country code if the area isn't known.
+	Region string
+
+	// Set if the coordinates of the address are known.
+	HasLocation bool
+	Latitude    float64
+	Longitude   float64
+}
+
+// GeoLocator looks up where IP addresses are. It returns false if nothing
+// is known about the address.
+type GeoLocator interface {
+	LocateIP(ip net.IP) (Geo, bool)
+}
+
+// hubIP returns the IP address of the first address of the hub, or nil if
+// that's a hostname.
+func hubIP(loc *pb.NetworkLocation) net.IP {
+	if len(loc.Addresses) == 0 {
+		return nil
+	}
+
+	host := loc.Addresses[0]
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return net.ParseIP(host)
+}
+
+// The mean radius of the earth in kilometers.
+const earthRadius = 6371
+
+// distance returns the great-circle distance between a and b in
+// kilometers.
+func distance(a, b Geo) float64 {
+	rad := func(deg float64) float64 {
+		return deg * math.Pi / 180
+	}
+
+	lat1, lat2 := rad(a.Latitude), rad(b.Latitude)
+	dlat := lat2 - lat1
+	dlon := rad(b.Longitude - a.Longitude)
+
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlon/2)*math.Sin(dlon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// orderHubs sorts hubs closest to client first and adds the region label to
+// hubs that don't have one. Hubs in the same autonomous system as the
+// client come first, then hubs by their distance from it. Hubs whose
+// location isn't known keep their order after those.
+func orderHubs(locator GeoLocator, client net.IP, hubs []*pb.NetworkLocation) []*pb.NetworkLocation {
+	type located struct {
+		loc   *pb.NetworkLocation
+		geo   Geo
+		known bool
+	}
+
+	out := make([]located, len(hubs))
+
+	for i, loc := range hubs {
+		out[i].loc = loc
+
+		ip := hubIP(loc)
+		if ip == nil {
+			continue
+		}
+
+		out[i].geo, out[i].known = locator.LocateIP(ip)
+
+		if out[i].geo.Region == "" {
+			continue
+		}
+
+		if loc.Labels != nil {
+			if _, ok := loc.Labels.GetLabel(RegionLabel); ok {
+				continue
+			}
+		}
+
+		labels := &pb.LabelSet{}
+		if loc.Labels != nil {
+			labels = loc.Labels
+		}
+
+		labels = labels.Add(RegionLabel, strings.ToLower(out[i].geo.Region))
+
+		// Copied so that the caller's location isn't changed.
+		cloc := *loc
+		cloc.Labels = labels
+
+		out[i].loc = &cloc
+	}
+
+	var cgeo Geo
+
+	if client != nil {
+		cgeo, _ = locator.LocateIP(client)
+	}
+
+	sameASN := func(l located) bool {
+		return cgeo.ASN != 0 && l.known && l.geo.ASN == cgeo.ASN
+	}
+
+	nearby := func(l located) bool {
+		return cgeo.HasLocation && l.known && l.geo.HasLocation
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+
+		if sameASN(a) != sameASN(b) {
+			return sameASN(a)
+		}
+
+		if nearby(a) != nearby(b) {
+			return nearby(a)
+		}
+
+		if nearby(a) {
+			return distance(cgeo, a.geo) < distance(cgeo, b.geo)
+		}
+
+		return false
+	})
+
+	sorted := make([]*pb.NetworkLocation, len(out))
+
+	for i, l := range out {
+		sorted[i] = l.loc
+	}
+
+	return sorted
+}
@@ -3,7 +3,11 @@ package discovery
 import (
 	"context"
 	"crypto/x509"
+	"net"
 	"sync"
+
+	"github.com/hashicorp/horizon/pkg/netloc"
+	"github.com/pkg/errors"
 )
 
 type HubConfig struct {
@@ -11,6 +15,50 @@ type HubConfig struct {
 	Name       string
 	Insecure   bool
 	PinnedCert *x509.Certificate
+
+	// The fingerprints of the certificates the hub may present, from
+	// signed discovery data. If set, the hub must present one of them.
+	CertFingerprints []string
+
+	// All the addresses of the hub, which may be of different address
+	// families. If set, they are raced when connecting rather than only
+	// using Addr.
+	Addresses []string
+}
+
+// Dial connects to the hub over TCP, using Happy Eyeballs across its
+// addresses.
+func (h HubConfig) Dial(ctx context.Context, dialer *net.Dialer) (net.Conn, error) {
+	addrs := h.Addresses
+	if len(addrs) == 0 {
+		addrs = []string{h.Addr}
+	}
+
+	return netloc.DialAddresses(ctx, dialer, addrs, "443")
+}
+
+var ErrCertNotPinned = errors.New("hub certificate does not match pinned fingerprints")
+
+// VerifyPinnedCert checks that the certificate presented by the hub is one
+// of its CertFingerprints. It's used as tls.Config.VerifyPeerCertificate.
+func (h HubConfig) VerifyPinnedCert(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(h.CertFingerprints) == 0 {
+		return nil
+	}
+
+	if len(rawCerts) == 0 {
+		return ErrCertNotPinned
+	}
+
+	fp := CertFingerprint(rawCerts[0])
+
+	for _, pinned := range h.CertFingerprints {
+		if pinned == fp {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrCertNotPinned, "hub: %s", h.Name)
 }
 
 type HubConnectDetails interface {
@@ -24,6 +72,13 @@ type HubConfigProvider interface {
 	Return(HubConfig)
 }
 
+// HubRanker is implemented by providers that can rank hubs by their
+// measured latency, allowing an agent to move to better hubs as they
+// appear.
+type HubRanker interface {
+	Best(ctx context.Context, count int) ([]HubScore, error)
+}
+
 type StaticHubConfigs struct {
 	mu      sync.Mutex
 	configs []HubConfig
@@ -0,0 +1,217 @@
+package discovery
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+var (
+	// How long a single probe of a hub can take.
+	probeTimeout = 5 * time.Second
+
+	// The weight of a new probe in the moving average of a hub's latency.
+	probeWeight = 0.3
+
+	// A hub that fails this many probes in a row is considered down until
+	// a probe succeeds again.
+	maxProbeFailures = 3
+)
+
+// ProbeFunc measures the round trip time to a hub.
+type ProbeFunc func(ctx context.Context, cfg HubConfig) (time.Duration, error)
+
+// TLSProbe measures the time taken to connect to the hub and complete a TLS
+// handshake. The certificate isn't verified as only the timing matters
+// here; it's verified when the agent connects for real.
+func TLSProbe(ctx context.Context, cfg HubConfig) (time.Duration, error) {
+	start := time.Now()
+
+	conn, err := cfg.Dial(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	tconn := tls.Client(conn, &tls.Config{
+		ServerName:         cfg.Name,
+		InsecureSkipVerify: true,
+	})
+
+	err = tconn.Handshake()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
+
+// HubScore is a hub along with its measured latency.
+type HubScore struct {
+	HubConfig
+
+	// The moving average of the round trip time to the hub, zero if the
+	// hub hasn't been probed yet.
+	Latency time.Duration
+}
+
+// hubState is what is known about a hub advertised by the discovery data.
+type hubState struct {
+	cfg HubConfig
+
+	// How closely the hub's labels match the local network location.
+	card int
+
+	// The position of the hub in the discovery data, which lists the hubs
+	// closest to the agent first.
+	order int
+
+	latency  time.Duration
+	probed   bool
+	failures int
+}
+
+func (h *hubState) healthy() bool {
+	return h.failures < maxProbeFailures
+}
+
+// record adds the result of a probe to the hub's moving average.
+func (h *hubState) record(rtt time.Duration, err error) {
+	if err != nil {
+		h.failures++
+		return
+	}
+
+	h.failures = 0
+
+	if !h.probed {
+		h.latency = rtt
+		h.probed = true
+		return
+	}
+
+	h.latency = time.Duration(probeWeight*float64(rtt) + (1-probeWeight)*float64(h.latency))
+}
+
+// rankHubs sorts hubs best first. Healthy hubs come before those that keep
+// failing probes and measured hubs before those not probed yet, which are
+// ordered by how well their labels match the local network location and
+// then by the order of the discovery data.
+func rankHubs(hubs []*hubState) {
+	sort.Slice(hubs, func(i, j int) bool {
+		a, b := hubs[i], hubs[j]
+
+		if a.healthy() != b.healthy() {
+			return a.healthy()
+		}
+
+		if a.probed != b.probed {
+			return a.probed
+		}
+
+		if a.latency != b.latency {
+			return a.latency < b.latency
+		}
+
+		if a.card != b.card {
+			return a.card > b.card
+		}
+
+		if a.order != b.order {
+			return a.order < b.order
+		}
+
+		return a.cfg.Name < b.cfg.Name
+	})
+}
+
+// newHubStates converts the hubs from discovery data, keeping the scores
+// of hubs already known in prev. fingerprints are the certificate
+// fingerprints of each hub, by name.
+func newHubStates(local, remote []*pb.NetworkLocation, fingerprints map[string][]string, prev map[string]*hubState) map[string]*hubState {
+	hubs := make(map[string]*hubState)
+
+	for i, loc := range remote {
+		if (loc.Labels != nil && loc.Labels.Contains("type", "private")) || len(loc.Addresses) == 0 {
+			continue
+		}
+
+		if h, ok := prev[loc.Name]; ok {
+			h.order = i
+			h.cfg.CertFingerprints = fingerprints[loc.Name]
+			h.cfg.Addresses = loc.Addresses
+			hubs[loc.Name] = h
+			continue
+		}
+
+		addr := loc.Addresses[0]
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(addr, "443")
+		}
+
+		h := &hubState{
+			cfg: HubConfig{
+				Addr:             addr,
+				Name:             loc.Name,
+				CertFingerprints: fingerprints[loc.Name],
+				Addresses:        loc.Addresses,
+			},
+			order: i,
+		}
+
+		for _, lloc := range local {
+			if c := lloc.Cardinality(loc); c > h.card {
+				h.card = c
+			}
+		}
+
+		hubs[loc.Name] = h
+	}
+
+	return hubs
+}
+
+// probeHubs probes each of the hubs concurrently, recording the results
+// while holding mu.
+func probeHubs(ctx context.Context, probe ProbeFunc, mu *sync.Mutex, hubs []*hubState) {
+	mu.Lock()
+
+	cfgs := make([]HubConfig, len(hubs))
+	for i, h := range hubs {
+		cfgs[i] = h.cfg
+	}
+
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+
+	for i := range hubs {
+		wg.Add(1)
+
+		go func(h *hubState, cfg HubConfig) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+
+			rtt, err := probe(ctx, cfg)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			h.record(rtt, err)
+		}(hubs[i], cfgs[i])
+	}
+
+	wg.Wait()
+}
@@ -2,6 +2,7 @@ package discovery
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"time"
 
@@ -15,14 +16,45 @@ type GetNetlocs interface {
 	GetAllNetworkLocations() ([]*pb.NetworkLocation, error)
 }
 
+type HubCerts interface {
+	// HubCertFingerprints returns the fingerprints of the certificates the
+	// hub may present, see CertFingerprint.
+	HubCertFingerprints(hub *pb.NetworkLocation) []string
+}
+
 type WellKnown struct {
 	L          hclog.Logger
 	GetNetlocs GetNetlocs
+
+	// If set, hubs are ordered closest to the requester first and labeled
+	// with their region. ClientIP returns the address of the requester.
+	Locator  GeoLocator
+	ClientIP func(req *http.Request) (net.IP, error)
+
+	// If set, the fingerprints of the hubs' certificates are included so
+	// that agents can pin them.
+	HubCerts HubCerts
+
+	// If set, the discovery data is signed so agents can verify that it
+	// came from the control plane.
+	Signer Signer
+
+	sigs signatureCache
 }
 
 type DiscoveryData struct {
 	ServerTime time.Time             `json:"server_time"`
 	Hubs       []*pb.NetworkLocation `json:"hubs"`
+
+	// The fingerprints of the certificates each hub may present, keyed by
+	// the name of the hub.
+	CertFingerprints map[string][]string `json:"cert_fingerprints,omitempty"`
+
+	// When the data is signed, Signed is the JSON encoding of the data
+	// above and Signature its ed25519 signature. The data outside of Signed
+	// is left for clients that don't verify the signature.
+	Signed    []byte `json:"signed,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
 }
 
 func (wk *WellKnown) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -42,6 +74,38 @@ func (wk *WellKnown) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	if wk.Locator != nil {
+		var client net.IP
+
+		if wk.ClientIP != nil {
+			client, err = wk.ClientIP(req)
+			if err != nil {
+				wk.L.Warn("unable to determine address of requester", "error", err)
+			}
+		}
+
+		dd.Hubs = orderHubs(wk.Locator, client, dd.Hubs)
+	}
+
+	if wk.HubCerts != nil {
+		dd.CertFingerprints = make(map[string][]string)
+
+		for _, loc := range dd.Hubs {
+			if fps := wk.HubCerts.HubCertFingerprints(loc); len(fps) > 0 {
+				dd.CertFingerprints[loc.Name] = fps
+			}
+		}
+	}
+
+	if wk.Signer != nil {
+		err = dd.sign(&wk.sigs, wk.Signer)
+		if err != nil {
+			wk.L.Error("error signing discovery data for well-known", "error", err)
+			http.Error(w, "unable to sign discovery data", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	err = json.NewEncoder(w).Encode(&dd)
 	if err != nil {
 		wk.L.Error("error encoding discovery data for well-known", "error", err)
@@ -0,0 +1,306 @@
+package discovery
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticNetlocs []*pb.NetworkLocation
+
+func (s staticNetlocs) GetAllNetworkLocations() ([]*pb.NetworkLocation, error) {
+	return s, nil
+}
+
+type fakeLocator map[string]Geo
+
+func (f fakeLocator) LocateIP(ip net.IP) (Geo, bool) {
+	geo, ok := f[ip.String()]
+	return geo, ok
+}
+
+func TestWellKnown(t *testing.T) {
+	public := pb.ParseLabelSet("type=public")
+
+	hubs := staticNetlocs{
+		{Name: "unknown.hzn", Addresses: []string{"hub.example.com:443"}, Labels: public},
+		{Name: "tokyo.hzn", Addresses: []string{"203.0.113.1"}, Labels: public, Families: []pb.AddressFamily{pb.IPV4}},
+		{Name: "frankfurt.hzn", Addresses: []string{"203.0.113.2:443"}, Labels: public},
+		{Name: "virginia.hzn", Addresses: []string{"203.0.113.3"}, Labels: public},
+		{Name: "private.hzn", Addresses: []string{"10.0.0.1"}, Labels: pb.ParseLabelSet("type=private")},
+	}
+
+	locator := fakeLocator{
+		"203.0.113.1": {Region: "JP-13", HasLocation: true, Latitude: 35.68, Longitude: 139.69},
+		"203.0.113.2": {Region: "DE-HE", HasLocation: true, Latitude: 50.11, Longitude: 8.68, ASN: 64500},
+		"203.0.113.3": {Region: "US-VA", HasLocation: true, Latitude: 38.95, Longitude: -77.45},
+
+		// In Oregon, on the same network as the Frankfurt hub.
+		"198.51.100.1": {Region: "US-OR", HasLocation: true, Latitude: 45.52, Longitude: -122.68, ASN: 64500},
+
+		// In Seoul.
+		"198.51.100.2": {Region: "KR-11", HasLocation: true, Latitude: 37.57, Longitude: 126.98},
+	}
+
+	fetch := func(t *testing.T, wk *WellKnown, ip string) []*pb.NetworkLocation {
+		req, err := http.NewRequest("GET", HTTPPath, nil)
+		require.NoError(t, err)
+
+		req.RemoteAddr = ip + ":33221"
+
+		w := httptest.NewRecorder()
+		wk.ServeHTTP(w, req)
+
+		require.Equal(t, 200, w.Code)
+
+		var dd DiscoveryData
+
+		err = json.Unmarshal(w.Body.Bytes(), &dd)
+		require.NoError(t, err)
+
+		return dd.Hubs
+	}
+
+	names := func(hubs []*pb.NetworkLocation) []string {
+		var out []string
+
+		for _, h := range hubs {
+			out = append(out, h.Name)
+		}
+
+		return out
+	}
+
+	wk := &WellKnown{
+		L:          hclog.L(),
+		GetNetlocs: hubs,
+		Locator:    locator,
+		ClientIP: func(req *http.Request) (net.IP, error) {
+			host, _, err := net.SplitHostPort(req.RemoteAddr)
+			if err != nil {
+				return nil, err
+			}
+
+			return net.ParseIP(host), nil
+		},
+	}
+
+	t.Run("orders hubs by distance from the requester", func(t *testing.T) {
+		out := fetch(t, wk, "198.51.100.2")
+
+		assert.Equal(t, []string{"tokyo.hzn", "frankfurt.hzn", "virginia.hzn", "unknown.hzn"}, names(out))
+	})
+
+	t.Run("prefers hubs on the same network as the requester", func(t *testing.T) {
+		out := fetch(t, wk, "198.51.100.1")
+
+		assert.Equal(t, []string{"frankfurt.hzn", "virginia.hzn", "tokyo.hzn", "unknown.hzn"}, names(out))
+	})
+
+	t.Run("labels hubs with their region", func(t *testing.T) {
+		out := fetch(t, wk, "198.51.100.2")
+
+		region, ok := out[0].Labels.GetLabel(RegionLabel)
+		require.True(t, ok)
+		assert.Equal(t, "jp-13", region)
+
+		// Everything else about the location is kept.
+		assert.Equal(t, []pb.AddressFamily{pb.IPV4}, out[0].Families)
+
+		_, ok = out[3].Labels.GetLabel(RegionLabel)
+		assert.False(t, ok)
+
+		// The hubs returned by GetNetlocs aren't changed.
+		_, ok = hubs[1].Labels.GetLabel(RegionLabel)
+		assert.False(t, ok)
+	})
+
+	t.Run("keeps the order when the requester can't be located", func(t *testing.T) {
+		out := fetch(t, wk, "192.0.2.1")
+
+		assert.Equal(t, []string{"unknown.hzn", "tokyo.hzn", "frankfurt.hzn", "virginia.hzn"}, names(out))
+	})
+}
+
+type keySigner ed25519.PrivateKey
+
+func (k keySigner) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(k), data), nil
+}
+
+type staticCerts []string
+
+func (s staticCerts) HubCertFingerprints(hub *pb.NetworkLocation) []string {
+	return s
+}
+
+func TestSignedDiscovery(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	hubs := staticNetlocs{
+		{Name: "a.hzn", Addresses: []string{"10.0.0.1"}, Labels: pb.ParseLabelSet("type=public")},
+	}
+
+	cert := []byte("hub certificate")
+
+	serve := func(t *testing.T, wk *WellKnown) *Client {
+		wk.L = hclog.L()
+		wk.GetNetlocs = hubs
+
+		serv := httptest.NewServer(wk)
+
+		c, err := NewClient(serv.URL + HTTPPath)
+		require.NoError(t, err)
+
+		c.RefreshInterval = time.Hour
+		c.ProbeInterval = time.Hour
+		c.Probe = func(ctx context.Context, cfg HubConfig) (time.Duration, error) {
+			return time.Millisecond, nil
+		}
+
+		return c
+	}
+
+	t.Run("pins certificates listed in signed data", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c := serve(t, &WellKnown{
+			Signer:   keySigner(priv),
+			HubCerts: staticCerts{CertFingerprint(cert)},
+		})
+
+		c.PublicKey = pub
+
+		require.NoError(t, c.Refresh(ctx))
+
+		cfg, ok := c.Take(ctx)
+		require.True(t, ok)
+
+		assert.Equal(t, []string{CertFingerprint(cert)}, cfg.CertFingerprints)
+
+		assert.NoError(t, cfg.VerifyPinnedCert([][]byte{cert}, nil))
+
+		err := cfg.VerifyPinnedCert([][]byte{[]byte("other certificate")}, nil)
+		assert.True(t, errors.Is(err, ErrCertNotPinned))
+	})
+
+	t.Run("rejects data signed with another key", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		other, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		c := serve(t, &WellKnown{Signer: keySigner(priv)})
+
+		c.PublicKey = other
+
+		err = c.Refresh(ctx)
+		assert.Equal(t, ErrBadSignature, err)
+
+		_, ok := c.Take(ctx)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects unsigned data when a key is set", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c := serve(t, &WellKnown{HubCerts: staticCerts{CertFingerprint(cert)}})
+
+		c.PublicKey = pub
+
+		err := c.Refresh(ctx)
+		assert.Equal(t, ErrUnsignedData, err)
+	})
+
+	t.Run("rejects signed data that is too old", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mu      sync.Mutex
+			current *DiscoveryData
+		)
+
+		signedAt := func(ts time.Time) *DiscoveryData {
+			dd := &DiscoveryData{
+				ServerTime: ts,
+				Hubs:       hubs,
+			}
+
+			require.NoError(t, dd.sign(&signatureCache{}, keySigner(priv)))
+
+			return dd
+		}
+
+		// Replays whatever data it's given, as an attacker would.
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			json.NewEncoder(w).Encode(current)
+		}))
+		defer serv.Close()
+
+		c, err := NewClient(serv.URL + HTTPPath)
+		require.NoError(t, err)
+
+		c.PublicKey = pub
+		c.RefreshInterval = time.Hour
+		c.ProbeInterval = time.Hour
+		c.Probe = func(ctx context.Context, cfg HubConfig) (time.Duration, error) {
+			return time.Millisecond, nil
+		}
+
+		mu.Lock()
+		current = signedAt(time.Now().Add(-2 * DefaultMaxDataAge))
+		mu.Unlock()
+
+		err = c.Refresh(ctx)
+		assert.True(t, errors.Is(err, ErrStaleData))
+
+		mu.Lock()
+		current = signedAt(time.Now())
+		mu.Unlock()
+
+		require.NoError(t, c.Refresh(ctx))
+
+		// Within the age limit, but older than the data already accepted.
+		mu.Lock()
+		current = signedAt(time.Now().Add(-10 * time.Minute))
+		mu.Unlock()
+
+		err = c.fetch(ctx)
+		assert.True(t, errors.Is(err, ErrStaleData))
+	})
+
+	t.Run("ignores fingerprints when no key is set", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c := serve(t, &WellKnown{HubCerts: staticCerts{CertFingerprint(cert)}})
+
+		require.NoError(t, c.Refresh(ctx))
+
+		cfg, ok := c.Take(ctx)
+		require.True(t, ok)
+
+		assert.Empty(t, cfg.CertFingerprints)
+	})
+}
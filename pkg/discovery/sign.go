@@ -0,0 +1,125 @@
+package discovery
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnsignedData = errors.New("discovery data is not signed")
+	ErrBadSignature = errors.New("discovery data signature is invalid")
+	ErrStaleData    = errors.New("discovery data is too old")
+)
+
+// DefaultMaxDataAge is how old signed discovery data can be before clients
+// reject it, so that old data can't be replayed to pin hubs that are gone.
+// It allows for the clocks of agents being off.
+const DefaultMaxDataAge = time.Hour
+
+// Signer signs discovery data with the control plane's token signing key.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// CertFingerprint returns the fingerprint of a DER encoded certificate, as
+// listed in the discovery data.
+func CertFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// signatureCache avoids asking the signer to sign the same discovery data
+// repeatedly. As the hubs are ordered for each requester, there are a few
+// variations of the data at any time, which change every minute along with
+// the server time that is signed.
+type signatureCache struct {
+	mu     sync.Mutex
+	minute time.Time
+	sigs   map[[sha256.Size]byte][]byte
+}
+
+func (c *signatureCache) sign(signer Signer, now time.Time, data []byte) ([]byte, error) {
+	key := sha256.Sum256(data)
+
+	c.mu.Lock()
+
+	if !c.minute.Equal(now) {
+		c.minute = now
+		c.sigs = make(map[[sha256.Size]byte][]byte)
+	}
+
+	sig, ok := c.sigs[key]
+
+	c.mu.Unlock()
+
+	if ok {
+		return sig, nil
+	}
+
+	sig, err := signer.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+
+	if c.minute.Equal(now) {
+		c.sigs[key] = sig
+	}
+
+	c.mu.Unlock()
+
+	return sig, nil
+}
+
+// sign sets Signed and Signature to the signed JSON encoding of dd.
+func (dd *DiscoveryData) sign(cache *signatureCache, signer Signer) error {
+	signed := *dd
+	signed.ServerTime = dd.ServerTime.Truncate(time.Minute)
+
+	data, err := json.Marshal(&signed)
+	if err != nil {
+		return err
+	}
+
+	sig, err := cache.sign(signer, signed.ServerTime, data)
+	if err != nil {
+		return err
+	}
+
+	dd.Signed = data
+	dd.Signature = sig
+
+	return nil
+}
+
+// verify checks that dd was signed by key at notBefore or later and returns
+// the data that was signed.
+func (dd *DiscoveryData) verify(key ed25519.PublicKey, notBefore time.Time) (*DiscoveryData, error) {
+	if len(dd.Signed) == 0 {
+		return nil, ErrUnsignedData
+	}
+
+	if !ed25519.Verify(key, dd.Signed, dd.Signature) {
+		return nil, ErrBadSignature
+	}
+
+	var signed DiscoveryData
+
+	err := json.Unmarshal(dd.Signed, &signed)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding signed discovery data")
+	}
+
+	if signed.ServerTime.Before(notBefore) {
+		return nil, errors.Wrapf(ErrStaleData, "signed at %s", signed.ServerTime)
+	}
+
+	return &signed, nil
+}
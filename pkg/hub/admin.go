@@ -0,0 +1,267 @@
+package hub
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// AdminAgent describes an agent connected to the hub.
+type AdminAgent struct {
+	ID            string        `json:"id"`
+	Account       string        `json:"account"`
+	Services      []string      `json:"services"`
+	ActiveStreams int64         `json:"active_streams"`
+	TotalStreams  int64         `json:"total_streams"`
+	RemoteAddr    string        `json:"remote_addr"`
+	StartedAt     time.Time     `json:"started_at"`
+	Version       int32         `json:"protocol_version"`
+	Features      []string      `json:"features,omitempty"`
+	Compression   string        `json:"compression"`
+	Streams       []AdminStream `json:"streams,omitempty"`
+}
+
+// AdminStream describes a stream that an agent has open on the hub.
+type AdminStream struct {
+	ID        uint32    `json:"id"`
+	Target    string    `json:"target,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// AdminLabelLink describes a label link cached by the hub.
+type AdminLabelLink struct {
+	Account string `json:"account"`
+	Labels  string `json:"labels"`
+	Target  string `json:"target"`
+}
+
+// AdminRoute describes a service route cached by the hub.
+type AdminRoute struct {
+	Hub    string `json:"hub"`
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Labels string `json:"labels"`
+}
+
+// AdminHandler returns a handler that exposes the admin api of the hub.
+// The api allows for introspecting the agents connected to the hub and
+// the routing data it has cached, so it should be served on a separate,
+// non public, listener. Every request must pass token as a bearer token.
+func (h *Hub) AdminHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/agents", h.adminAgents)
+	mux.HandleFunc("/agents/", h.adminAgent)
+	mux.HandleFunc("/label-links", h.adminLabelLinks)
+	mux.HandleFunc("/label-links/refresh", h.adminRefreshLabelLinks)
+	mux.HandleFunc("/routes", h.adminRoutes)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+
+		if token == "" || !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(auth[len("Bearer "):]), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// ListenAdmin serves the admin api on addr.
+func (h *Hub) ListenAdmin(addr, token string) error {
+	return http.ListenAndServe(addr, h.AdminHandler(token))
+}
+
+// DisconnectAgent closes the session of the agent with the given id. It
+// returns false if no such agent is connected.
+func (h *Hub) DisconnectAgent(id *pb.ULID) bool {
+	h.mu.RLock()
+	ai, ok := h.agents[id.SpecString()]
+	h.mu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	h.L.Info("disconnecting agent by admin request", "agent", ai.ID, "account", ai.Account)
+
+	ai.sess.Close()
+
+	return true
+}
+
+func (h *Hub) describeAgent(ai *agentConn, withStreams bool) AdminAgent {
+	aa := AdminAgent{
+		ID:            ai.ID.SpecString(),
+		Account:       ai.Account.SpecString(),
+		ActiveStreams: atomic.LoadInt64(ai.ActiveStreams),
+		TotalStreams:  atomic.LoadInt64(ai.TotalStreams),
+		RemoteAddr:    ai.RemoteAddr,
+		StartedAt:     ai.Start.Time(),
+		Version:       ai.version,
+		Features:      ai.features.List(),
+		Compression:   ai.compression.Codec,
+	}
+
+	for _, serv := range ai.services() {
+		aa.Services = append(aa.Services, serv.ServiceId.SpecString())
+	}
+
+	if !withStreams {
+		return aa
+	}
+
+	ai.streamMu.Lock()
+	defer ai.streamMu.Unlock()
+
+	for _, st := range ai.streams {
+		as := AdminStream{
+			ID:        st.ID,
+			StartedAt: st.Start,
+		}
+
+		if st.Target != nil {
+			as.Target = st.Target.SpecString()
+		}
+
+		aa.Streams = append(aa.Streams, as)
+	}
+
+	sort.Slice(aa.Streams, func(i, j int) bool {
+		return aa.Streams[i].ID < aa.Streams[j].ID
+	})
+
+	return aa
+}
+
+func (h *Hub) adminAgents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.mu.RLock()
+
+	agents := make([]AdminAgent, 0, len(h.agents))
+
+	for _, ai := range h.agents {
+		agents = append(agents, h.describeAgent(ai, false))
+	}
+
+	h.mu.RUnlock()
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+
+	writeJSON(w, agents)
+}
+
+func (h *Hub) adminAgent(w http.ResponseWriter, r *http.Request) {
+	id, err := pb.ParseULID(strings.TrimPrefix(r.URL.Path, "/agents/"))
+	if err != nil {
+		http.Error(w, "invalid agent id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.mu.RLock()
+		ai, ok := h.agents[id.SpecString()]
+		h.mu.RUnlock()
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		writeJSON(w, h.describeAgent(ai, true))
+	case http.MethodDelete:
+		if !h.DisconnectAgent(id) {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Hub) adminLabelLinks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lls := h.cc.CachedLabelLinks()
+
+	out := make([]AdminLabelLink, 0, len(lls))
+
+	for _, ll := range lls {
+		out = append(out, AdminLabelLink{
+			Account: ll.Account.SpecString(),
+			Labels:  ll.Labels.SpecString(),
+			Target:  ll.Target.SpecString(),
+		})
+	}
+
+	writeJSON(w, out)
+}
+
+func (h *Hub) adminRefreshLabelLinks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.L.Info("refreshing label links by admin request")
+
+	err := h.cc.ForceLabelLinkUpdate(r.Context(), h.L)
+	if err != nil {
+		h.L.Error("error refreshing label links", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Hub) adminRoutes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	out := make(map[string][]AdminRoute)
+
+	for account, routes := range h.cc.CachedAccountRoutes() {
+		ar := make([]AdminRoute, 0, len(routes))
+
+		for _, route := range routes {
+			ar = append(ar, AdminRoute{
+				Hub:    route.Hub.SpecString(),
+				ID:     route.Id.SpecString(),
+				Type:   route.Type,
+				Labels: route.Labels.SpecString(),
+			})
+		}
+
+		out[account] = ar
+	}
+
+	writeJSON(w, out)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
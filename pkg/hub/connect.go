@@ -2,15 +2,11 @@ package hub
 
 import (
 	"context"
-	"io"
-	"net"
 	"time"
 
-	"github.com/hashicorp/horizon/pkg/connect"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/timing"
 	"github.com/hashicorp/horizon/pkg/wire"
-	"github.com/pierrec/lz4/v3"
 	"github.com/pkg/errors"
 )
 
@@ -50,36 +46,35 @@ func (h *Hub) ConnectToService(
 			return nil, err
 		}
 
-		var (
-			r io.Reader = stream
-			w io.Writer = stream
-		)
-
-		if ac.useLZ4 {
-			r = lz4.NewReader(stream)
-			w = lz4.NewWriter(stream)
+		cs, err := wire.OpenCompressedStream(stream, ac.compression, ac.header)
+		if err != nil {
+			stream.Close()
+			return nil, err
 		}
 
 		var sid pb.SessionIdentification
 		sid.ServiceId = target.Id
 		sid.ProtocolId = proto
 
-		fw, err := wire.NewFramingWriter(w)
+		fw, err := wire.NewFramingWriter(cs)
 		if err != nil {
+			cs.Close()
 			return nil, err
 		}
 
 		_, err = fw.WriteMarshal(11, &sid)
 		if err != nil {
+			cs.Close()
 			return nil, err
 		}
 
-		fr, err := wire.NewFramingReader(r)
+		fr, err := wire.NewFramingReader(cs)
 		if err != nil {
+			cs.Close()
 			return nil, err
 		}
 
-		wctx = wire.NewContext(account, fr, fw)
+		wctx = wire.WithCloser(wire.NewContext(account, fr, fw), cs.Close)
 	}
 
 	sub, cancel := context.WithCancel(ctx)
@@ -171,26 +166,9 @@ func (h *Hub) connectToRemoteService(
 
 	L.Trace("locations for target hub", "hub", target.Hub, "locations", locs)
 
-	addr, err := h.pickAddress(locs)
-	if err != nil {
-		return nil, err
-	}
-
-	L.Trace("picked target address", "address", addr)
-
-	host, port, err := net.SplitHostPort(addr)
-	if err != nil {
-		host = addr
-		port = "443"
-	}
-
-	addr = net.JoinHostPort(host, port)
-
-	L.Trace("spawning connection to peer hub", "hub", target.Hub, "addr", addr)
-
 	// TODO: rather than spinning up a new session each time, use a connection
 	// pool.
-	session, err := connect.Connect(L, addr, token)
+	session, err := h.connectToPeer(ctx, L, target.Hub, locs, token)
 	if err != nil {
 		return nil, err
 	}
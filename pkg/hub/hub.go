@@ -19,8 +19,9 @@ import (
 	"github.com/hashicorp/horizon/pkg/web"
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/yamux"
-	"github.com/pierrec/lz4"
 	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 var (
@@ -32,8 +33,12 @@ var (
 const ServicesPerAccount = 100
 
 type agentConnection struct {
-	useLZ4  bool
 	session *yamux.Session
+
+	// The codec to compress streams to the service with, and whether the
+	// agent reads the codec from the start of each stream.
+	compression wire.Compression
+	header      bool
 }
 
 type Hub struct {
@@ -48,11 +53,17 @@ type Hub struct {
 	mu     sync.RWMutex
 	active map[string]*agentConnection
 
+	// All connected agents, keyed by agent id. Used by the admin api.
+	agents map[string]*agentConn
+
 	// ServiceSorter ServiceSorter
 	wg sync.WaitGroup
 
 	location []*pb.NetworkLocation
 
+	// Failures connecting to the addresses of peer hubs.
+	peers peerAddrs
+
 	mux *http.ServeMux
 	fe  *web.Frontend
 
@@ -60,6 +71,16 @@ type Hub struct {
 	totalAgents  *int64
 
 	servicesPerAccount *lru.ARCCache
+
+	// RPCs that agents can make to the hub.
+	rpc wire.RPCServer
+
+	// Agents speaking an older version of the protocol are rejected.
+	minProtocolVersion int32
+
+	// The codecs that agents can choose from and the level the hub
+	// compresses at with each.
+	compression []wire.Compression
 }
 
 func NewHub(L hclog.Logger, client *control.Client, feToken string) (*Hub, error) {
@@ -77,6 +98,7 @@ func NewHub(L hclog.Logger, client *control.Client, feToken string) (*Hub, error
 		L:            L,
 		cfg:          cfg,
 		active:       make(map[string]*agentConnection),
+		agents:       make(map[string]*agentConn),
 		cc:           client,
 		id:           client.Id(),
 		mux:          http.NewServeMux(),
@@ -84,6 +106,8 @@ func NewHub(L hclog.Logger, client *control.Client, feToken string) (*Hub, error
 		totalAgents:  new(int64),
 
 		servicesPerAccount: spa,
+		minProtocolVersion: wire.MinProtocolVersion,
+		compression:        wire.DefaultCompression,
 	}
 
 	fe, err := web.NewFrontend(L, h, client, feToken)
@@ -98,9 +122,18 @@ func NewHub(L hclog.Logger, client *control.Client, feToken string) (*Hub, error
 
 	h.location = client.Locations()
 
+	h.rpc.AddMethod("/query/peers", wire.RPCHandlerFunc(h.handleQueryPeers))
+
 	return h, nil
 }
 
+// SetCompression sets the codecs that agents can choose from and the level
+// the hub compresses at with each. It must be called before the hub starts
+// accepting agents.
+func (h *Hub) SetCompression(cs []wire.Compression) {
+	h.compression = cs
+}
+
 func (h *Hub) Serve(ctx context.Context, l net.Listener) error {
 	for {
 		conn, err := l.Accept()
@@ -172,7 +205,18 @@ func (hub *Hub) WaitToDrain() error {
 }
 
 func (hub *Hub) ListenHTTP(addr string) error {
-	return http.ListenAndServe(addr, hub)
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return hub.ServeCleartext(l)
+}
+
+// ServeCleartext serves HTTP requests without TLS on l. Both HTTP/1.1 and
+// HTTP/2 (h2c) are accepted, the latter being required by gRPC clients.
+func (hub *Hub) ServeCleartext(l net.Listener) error {
+	return http.Serve(l, h2c.NewHandler(hub, &http2.Server{}))
 }
 
 func (hub *Hub) handleHZN(hs *http.Server, tlsConn *tls.Conn, h http.Handler) {
@@ -206,18 +250,72 @@ type agentConn struct {
 	ActiveStreams *int64
 	TotalStreams  *int64
 
+	RemoteAddr string
+
+	// Protects preamble.Services, which changes as the agent updates
+	// its services.
+	mu sync.Mutex
+
+	streamMu sync.Mutex
+	streams  map[uint32]*agentStream
+
 	stoken   string
 	preamble *pb.Preamble
 
 	token *token.ValidToken
 
 	sess        *yamux.Session
-	useLZ4      bool
+	compression wire.Compression
 	cleanups    []func()
 	connectOnly bool
+
+	// Set under mu once the agent has disconnected, so that a services
+	// update racing the disconnect doesn't register it again.
+	closed bool
+
+	// The protocol version of the agent and the features agreed on in the
+	// handshake.
+	version  int32
+	features wire.FeatureSet
+}
+
+type agentStream struct {
+	ID     uint32
+	Start  time.Time
+	Target *pb.LabelSet
+}
+
+// serviceConnection returns how the hub connects to serv, one of the
+// services of the agent. Streams use the codec of the session unless the
+// service asks for another and the agent can be told about it.
+func (ai *agentConn) serviceConnection(serv *pb.ServiceInfo) *agentConnection {
+	ac := &agentConnection{
+		session:     ai.sess,
+		compression: ai.compression,
+		header:      ai.features.Has(wire.FeatureStreamCompression),
+	}
+
+	if ac.header && wire.KnownCodec(serv.Compression) {
+		ac.compression = ai.compression.WithCodec(serv.Compression)
+	}
+
+	return ac
+}
+
+// services returns a copy of the services the agent advertises, which
+// can change while it's being read.
+func (ai *agentConn) services() []*pb.ServiceInfo {
+	ai.mu.Lock()
+	defer ai.mu.Unlock()
+
+	return append([]*pb.ServiceInfo(nil), ai.preamble.Services...)
 }
 
 func (ai *agentConn) cleanup() {
+	ai.mu.Lock()
+	ai.closed = true
+	ai.mu.Unlock()
+
 	for _, f := range ai.cleanups {
 		f()
 	}
@@ -284,13 +382,44 @@ func (h *Hub) handshake(ctx context.Context, conn net.Conn, fr *wire.FramingRead
 
 	wc.Status = "connected"
 
-	var useLZ4 bool
+	// Agents that don't list their codecs only support lz4.
+	codecs := preamble.CompressionCodecs
+	if len(codecs) == 0 && preamble.Compression == wire.CompressionLZ4 {
+		codecs = []string{wire.CompressionLZ4}
+	}
+
+	compression := wire.NegotiateCompression(h.compression, codecs)
+
+	// Agents that predate codec negotiation expect no value when streams
+	// aren't compressed.
+	if compression.Enabled() {
+		wc.Compression = compression.Codec
+	}
+
+	version := wire.PeerVersion(preamble.ProtocolVersion)
+	wc.ProtocolVersion = wire.ProtocolVersion
+
+	if version < h.minProtocolVersion {
+		h.L.Warn("rejected agent using an unsupported protocol version",
+			"version", version,
+			"min-version", h.minProtocolVersion,
+			"session-id", preamble.SessionId,
+			"remote-addr", conn.RemoteAddr(),
+		)
+
+		wc.Status = "unsupported-version"
+
+		_, err = fw.WriteMarshal(1, &wc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling confirmation")
+		}
 
-	if preamble.Compression == "lz4" {
-		wc.Compression = "lz4"
-		useLZ4 = true
+		return nil, errors.Wrapf(wire.ErrUnsupportedVersion, "agent version %d, minimum %d", version, h.minProtocolVersion)
 	}
 
+	features := wire.NegotiateFeatures(wire.Features, preamble.Features)
+	wc.Features = features.List()
+
 	vt, err := h.ValidateToken(preamble.Token)
 	if err != nil {
 		h.L.Error("invalid token received", "error", err)
@@ -367,12 +496,32 @@ func (h *Hub) handshake(ctx context.Context, conn net.Conn, fr *wire.FramingRead
 		return nil, errors.Wrapf(err, "error marshalling confirmation")
 	}
 
+	ai := &agentConn{
+		ID:            id,
+		Account:       vt.Account(),
+		Start:         pb.NewTimestamp(ts),
+		RemoteAddr:    conn.RemoteAddr().String(),
+		streams:       make(map[uint32]*agentStream),
+		Services:      int32(len(preamble.Services)),
+		ActiveStreams: new(int64),
+		TotalStreams:  new(int64),
+		stoken:        preamble.Token,
+		preamble:      &preamble,
+		token:         vt,
+		compression:   compression,
+		version:       version,
+		features:      features,
+		connectOnly:   len(preamble.Services) == 0,
+	}
+
 	cleanup := func() {
-		defer h.removeAccountServices(vt.Account(), len(preamble.Services))
+		services := ai.services()
+
+		defer h.removeAccountServices(vt.Account(), len(services))
 
-		h.L.Debug("removing services", "agent", id, "count", len(preamble.Services))
+		h.L.Debug("removing services", "agent", id, "count", len(services))
 
-		for _, serv := range preamble.Services {
+		for _, serv := range services {
 			h.L.Debug("removing service",
 				"agent", id,
 				"hub", h.id,
@@ -396,19 +545,7 @@ func (h *Hub) handshake(ctx context.Context, conn net.Conn, fr *wire.FramingRead
 		}
 	}
 
-	ai := &agentConn{
-		ID:            id,
-		Account:       vt.Account(),
-		Services:      int32(len(preamble.Services)),
-		ActiveStreams: new(int64),
-		TotalStreams:  new(int64),
-		stoken:        preamble.Token,
-		preamble:      &preamble,
-		token:         vt,
-		useLZ4:        useLZ4,
-		cleanups:      []func(){cleanup},
-		connectOnly:   len(preamble.Services) == 0,
-	}
+	ai.cleanups = []func(){cleanup}
 
 	return ai, nil
 }
@@ -419,27 +556,75 @@ func (h *Hub) registerAgent(ai *agentConn) error {
 
 	h.mu.Lock()
 	for _, serv := range ai.preamble.Services {
-		h.active[serv.ServiceId.SpecString()] = &agentConnection{
-			useLZ4:  ai.useLZ4,
-			session: ai.sess,
-		}
+		h.active[serv.ServiceId.SpecString()] = ai.serviceConnection(serv)
 	}
 	h.mu.Unlock()
 
 	h.L.Debug("register agent", "id", ai.ID, "account", ai.Account)
 
-	ai.cleanups = append(ai.cleanups, func() {
-		h.L.Debug("unregister agent", "id", ai.ID, "account", ai.Account)
-		atomic.AddInt64(h.activeAgents, -1)
+	return nil
+}
+
+// unregisterAgent undoes registerAgent, if the agent was registered.
+func (h *Hub) unregisterAgent(ai *agentConn) {
+	ai.mu.Lock()
+	registered := !ai.connectOnly
+	services := append([]*pb.ServiceInfo(nil), ai.preamble.Services...)
+	ai.mu.Unlock()
 
+	if !registered {
+		return
+	}
+
+	h.L.Debug("unregister agent", "id", ai.ID, "account", ai.Account)
+	atomic.AddInt64(h.activeAgents, -1)
+
+	h.mu.Lock()
+	for _, serv := range services {
+		delete(h.active, serv.ServiceId.SpecString())
+	}
+	h.mu.Unlock()
+}
+
+// trackAgent records ai as connected until it's cleaned up.
+func (h *Hub) trackAgent(ai *agentConn) {
+	key := ai.ID.SpecString()
+
+	h.mu.Lock()
+	h.agents[key] = ai
+	h.mu.Unlock()
+
+	ai.cleanups = append(ai.cleanups, func() {
 		h.mu.Lock()
-		for _, serv := range ai.preamble.Services {
-			delete(h.active, serv.ServiceId.SpecString())
-		}
+		delete(h.agents, key)
 		h.mu.Unlock()
 	})
+}
 
-	return nil
+func (ai *agentConn) addStream(id uint32) {
+	ai.streamMu.Lock()
+	defer ai.streamMu.Unlock()
+
+	ai.streams[id] = &agentStream{
+		ID:    id,
+		Start: time.Now(),
+	}
+}
+
+func (ai *agentConn) setStreamTarget(id uint32, target *pb.LabelSet) {
+	ai.streamMu.Lock()
+	defer ai.streamMu.Unlock()
+
+	if st, ok := ai.streams[id]; ok {
+		st.Target = target
+	}
+}
+
+func (ai *agentConn) removeStream(id uint32) {
+	ai.streamMu.Lock()
+	defer ai.streamMu.Unlock()
+
+	delete(ai.streams, id)
 }
 
 func (h *Hub) handleConn(ctx context.Context, conn net.Conn) {
@@ -489,6 +674,14 @@ func (h *Hub) handleConn(ctx context.Context, conn net.Conn) {
 
 	ai.sess = sess
 
+	h.trackAgent(ai)
+
+	// Added here rather than by registerAgent, because a connect-only agent
+	// registers when it first adds services, on another goroutine.
+	ai.cleanups = append(ai.cleanups, func() {
+		h.unregisterAgent(ai)
+	})
+
 	if !ai.connectOnly {
 		err = h.registerAgent(ai)
 		if err != nil {
@@ -555,40 +748,12 @@ func (h *Hub) handleConn(ctx context.Context, conn net.Conn) {
 		atomic.AddInt64(ai.ActiveStreams, 1)
 		atomic.AddInt64(ai.TotalStreams, 1)
 
-		h.sendAgentInfoFlow(ai)
-
-		h.L.Trace("stream accepted", "agent", ai.ID, "account", ai.Account, "id", stream.StreamID(), "lz4", ai.useLZ4)
-
-		var (
-			r io.Reader = stream
-			w io.Writer = stream
-		)
+		ai.addStream(stream.StreamID())
 
-		if ai.useLZ4 {
-			r = lz4.NewReader(stream)
-			w = lz4.NewWriter(stream)
-		}
-
-		fr, err := wire.NewFramingReader(r)
-		if err != nil {
-			h.L.Error("error creating frame reader", "error", err)
-			continue
-		}
-
-		defer fr.Recycle()
-
-		fw, err := wire.NewFramingWriter(w)
-		if err != nil {
-			h.L.Error("error creating framing writer", "error", err)
-			continue
-		}
-
-		defer fw.Recycle()
-
-		wctx := wire.NewContext(ai.token.Account(), fr, fw)
+		h.sendAgentInfoFlow(ai)
 
-		h.L.Trace("accepted yamux session", "id", stream.StreamID())
+		h.L.Trace("stream accepted", "agent", ai.ID, "account", ai.Account, "id", stream.StreamID(), "compression", ai.compression.Codec)
 
-		go h.handleAgentStream(ctx, ai, stream, wctx)
+		go h.handleAgentStream(ctx, ai, stream)
 	}
 }
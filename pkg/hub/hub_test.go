@@ -1,11 +1,16 @@
 package hub
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -142,6 +147,110 @@ func TestHub(t *testing.T) {
 		})
 	})
 
+	t.Run("exposes connected agents via the admin api", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			L := hclog.L()
+			hub, err := NewHub(L, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go hub.Run(ctx, setup.ClientListener)
+
+			time.Sleep(time.Second)
+
+			var clientTlsConfig tls.Config
+			clientTlsConfig.InsecureSkipVerify = true
+			clientTlsConfig.NextProtos = []string{"hzn"}
+
+			cconn, err := tls.Dial("tcp", setup.HubAddr, &clientTlsConfig)
+			require.NoError(t, err)
+
+			defer cconn.Close()
+
+			serviceId := pb.NewULID()
+
+			var preamble pb.Preamble
+			preamble.Token = setup.AgentToken
+			preamble.Services = []*pb.ServiceInfo{
+				{
+					ServiceId: serviceId,
+					Type:      "test",
+					Labels:    pb.ParseLabelSet("service=www,env=prod"),
+				},
+			}
+
+			fw, err := wire.NewFramingWriter(cconn)
+			require.NoError(t, err)
+
+			_, err = fw.WriteMarshal(1, &preamble)
+			require.NoError(t, err)
+
+			fr, err := wire.NewFramingReader(cconn)
+			require.NoError(t, err)
+
+			var confirmation pb.Confirmation
+
+			_, _, err = fr.ReadMarshal(&confirmation)
+			require.NoError(t, err)
+
+			require.Equal(t, "connected", confirmation.Status)
+
+			time.Sleep(100 * time.Millisecond)
+
+			admin := hub.AdminHandler("secret")
+
+			req := httptest.NewRequest("GET", "/agents", nil)
+			w := httptest.NewRecorder()
+
+			admin.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+			req.Header.Set("Authorization", "Bearer secret")
+			w = httptest.NewRecorder()
+
+			admin.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var agents []AdminAgent
+
+			err = json.NewDecoder(w.Body).Decode(&agents)
+			require.NoError(t, err)
+
+			require.Equal(t, 1, len(agents))
+
+			assert.Equal(t, setup.Account.SpecString(), agents[0].Account)
+			assert.Equal(t, []string{serviceId.SpecString()}, agents[0].Services)
+			assert.NotEmpty(t, agents[0].RemoteAddr)
+
+			req = httptest.NewRequest("DELETE", "/agents/"+agents[0].ID, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			w = httptest.NewRecorder()
+
+			admin.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+
+			cconn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+			_, err = cconn.Read(make([]byte, 1))
+			assert.Equal(t, io.EOF, err)
+
+			time.Sleep(100 * time.Millisecond)
+
+			req = httptest.NewRequest("GET", "/agents/"+agents[0].ID, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			w = httptest.NewRecorder()
+
+			admin.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+		})
+	})
+
 	t.Run("rejects hub tokens checks the serve capability", func(t *testing.T) {
 		central.Dev(t, func(setup *central.DevSetup) {
 			L := hclog.L()
@@ -318,6 +427,116 @@ func TestHub(t *testing.T) {
 		})
 	})
 
+	t.Run("fails over to another address of a hub", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			L := hclog.L()
+			L.SetLevel(hclog.Trace)
+
+			hub1, err := NewHub(L.Named("hub1"), setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go hub1.Run(ctx, setup.ClientListener)
+
+			// Nothing listens here, so connecting to it is refused.
+			dead, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+
+			dead.Close()
+
+			var netloc []*pb.NetworkLocation
+
+			netloc = append(netloc, &pb.NetworkLocation{
+				Addresses: []string{
+					dead.Addr().String(),
+					fmt.Sprintf("127.0.0.1:%d", setup.ClientListener.Addr().(*net.TCPAddr).Port),
+				},
+				Labels: pb.ParseLabelSet("dc=test"),
+			})
+
+			setup.ControlClient.SetLocations(netloc)
+
+			setup.ControlClient.BootstrapConfig(ctx)
+
+			go setup.ControlClient.Run(ctx)
+
+			time.Sleep(time.Second)
+
+			go hub1.Run(ctx, setup.ClientListener)
+
+			time.Sleep(time.Second)
+
+			g, err := agent.NewAgent(L.Named("agent"))
+			require.NoError(t, err)
+
+			g.Token = setup.AgentToken
+
+			serviceId, err := g.AddService(&agent.Service{
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("env=test,service=echo"),
+				Handler: agent.EchoHandler(),
+			})
+
+			require.NoError(t, err)
+
+			err = g.Start(ctx, discovery.HubConfigs(discovery.HubConfig{
+				Addr:     setup.HubAddr,
+				Insecure: true,
+			}))
+			require.NoError(t, err)
+
+			go g.Wait(ctx)
+
+			time.Sleep(time.Second)
+
+			setup.NewControlClient(t, func(nc *control.Client, li net.Listener) {
+				L = L.Named("testtest")
+
+				L.Info("configuring second hub and agent")
+
+				hub2, err := NewHub(L.Named("hub2"), nc, setup.HubServToken)
+				require.NoError(t, err)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				go hub2.Run(ctx, li)
+
+				go nc.Run(ctx)
+
+				time.Sleep(time.Second)
+
+				sess, err := connect.Connect(L, li.Addr().String(), setup.AgentToken)
+				require.NoError(t, err)
+
+				L.Info("connecting to service")
+
+				c, err := sess.ConnectToService(pb.ParseLabelSet("service=echo"))
+				require.NoError(t, err)
+
+				assert.Equal(t, serviceId, c.ServiceId())
+
+				mb := wire.MarshalBytes("hello hzn from fed")
+
+				err = c.WriteMarshal(30, &mb)
+				require.NoError(t, err)
+
+				var mb2 wire.MarshalBytes
+
+				tag, err := c.ReadMarshal(&mb2)
+				require.NoError(t, err)
+
+				assert.Equal(t, byte(30), tag)
+				assert.Equal(t, wire.MarshalBytes("hello hzn from fed"), mb2)
+
+				// The refused address is skipped until its backoff ends.
+				assert.False(t, hub2.peers.available(dead.Addr().String(), time.Now()))
+			})
+		})
+	})
+
 	t.Run("can connect to services on other hubs", func(t *testing.T) {
 		central.Dev(t, func(setup *central.DevSetup) {
 			L := hclog.L()
@@ -418,3 +637,122 @@ func TestHub(t *testing.T) {
 		})
 	})
 }
+
+func TestHandshake(t *testing.T) {
+	L := hclog.L()
+
+	handshake := func(t *testing.T, h *Hub, preamble *pb.Preamble) (*agentConn, *pb.Confirmation, error) {
+		var in, out bytes.Buffer
+
+		fw, err := wire.NewFramingWriter(&in)
+		require.NoError(t, err)
+
+		_, err = fw.WriteMarshal(1, preamble)
+		require.NoError(t, err)
+
+		fr, err := wire.NewFramingReader(&in)
+		require.NoError(t, err)
+
+		cw, err := wire.NewFramingWriter(&out)
+		require.NoError(t, err)
+
+		left, right := net.Pipe()
+		defer left.Close()
+		defer right.Close()
+
+		ai, herr := h.handshake(context.Background(), left, fr, cw)
+
+		cr, err := wire.NewFramingReader(&out)
+		require.NoError(t, err)
+
+		var wc pb.Confirmation
+
+		_, _, err = cr.ReadMarshal(&wc)
+		require.NoError(t, err)
+
+		return ai, &wc, herr
+	}
+
+	t.Run("rejects agents using unsupported versions", func(t *testing.T) {
+		h := &Hub{L: L, minProtocolVersion: wire.ProtocolVersion + 1}
+
+		_, wc, err := handshake(t, h, &pb.Preamble{
+			ProtocolVersion: wire.ProtocolVersion,
+		})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, wire.ErrUnsupportedVersion))
+		assert.Equal(t, "unsupported-version", wc.Status)
+		assert.Equal(t, int32(wire.ProtocolVersion), wc.ProtocolVersion)
+	})
+
+	t.Run("accepts agents that predate versioning", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			hub, err := NewHub(L, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ai, wc, err := handshake(t, hub, &pb.Preamble{
+				Token: setup.AgentToken,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, "connected", wc.Status)
+			assert.Equal(t, int32(wire.ProtocolVersion), wc.ProtocolVersion)
+			assert.Equal(t, 0, len(wc.Features))
+
+			assert.Equal(t, int32(1), ai.version)
+			assert.False(t, ai.features.Has(wire.FeatureRPC))
+		})
+	})
+
+	t.Run("negotiates features with newer agents", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			hub, err := NewHub(L, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ai, wc, err := handshake(t, hub, &pb.Preamble{
+				Token:           setup.AgentToken,
+				ProtocolVersion: wire.ProtocolVersion,
+				Features:        []string{wire.FeatureRPC, "future-feature"},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{wire.FeatureRPC}, wc.Features)
+
+			assert.Equal(t, int32(wire.ProtocolVersion), ai.version)
+			assert.True(t, ai.features.Has(wire.FeatureRPC))
+			assert.False(t, ai.features.Has("future-feature"))
+		})
+	})
+	t.Run("negotiates the compression codec", func(t *testing.T) {
+		central.Dev(t, func(setup *central.DevSetup) {
+			hub, err := NewHub(L, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			hub.SetCompression([]wire.Compression{
+				{Codec: wire.CompressionLZ4},
+				{Codec: wire.CompressionZstd, Level: 3},
+			})
+
+			ai, wc, err := handshake(t, hub, &pb.Preamble{
+				Token:             setup.AgentToken,
+				Compression:       wire.CompressionLZ4,
+				CompressionCodecs: []string{wire.CompressionZstd, wire.CompressionLZ4},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, wire.CompressionZstd, wc.Compression)
+			assert.Equal(t, wire.Compression{Codec: wire.CompressionZstd, Level: 3}, ai.compression)
+
+			// Agents that predate negotiation only ask for lz4.
+			ai, wc, err = handshake(t, hub, &pb.Preamble{
+				Token:       setup.AgentToken,
+				Compression: wire.CompressionLZ4,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, wire.CompressionLZ4, wc.Compression)
+			assert.Equal(t, wire.CompressionLZ4, ai.compression.Codec)
+		})
+	})
+}
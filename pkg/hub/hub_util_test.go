@@ -1,9 +1,14 @@
 package hub
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,10 +29,10 @@ func TestHubUtil(t *testing.T) {
 	t.Run("can pick when there is only one choise", func(t *testing.T) {
 		var h Hub
 
-		addr, err := h.pickAddress(mkLocs("127.0.0.1"))
+		locs, err := h.orderLocations(mkLocs("127.0.0.1"))
 		require.NoError(t, err)
 
-		assert.Equal(t, "127.0.0.1", addr)
+		assert.Equal(t, "127.0.0.1", locs[0].Addresses[0])
 	})
 
 	t.Run("can pick from an addr with the same labels", func(t *testing.T) {
@@ -39,10 +44,10 @@ func TestHubUtil(t *testing.T) {
 		tgt := mkLocs("2.2.2.2", "10.0.1.2")
 		tgt[1].Labels = pb.ParseLabelSet("type=private,dc=test")
 
-		addr, err := h.pickAddress(tgt)
+		locs, err := h.orderLocations(tgt)
 		require.NoError(t, err)
 
-		assert.Equal(t, "10.0.1.2", addr)
+		assert.Equal(t, "10.0.1.2", locs[0].Addresses[0])
 	})
 
 	t.Run("considers private addrs before public ones", func(t *testing.T) {
@@ -56,10 +61,10 @@ func TestHubUtil(t *testing.T) {
 		tgt[0].Labels = pb.ParseLabelSet("type=public,dc=test")
 		tgt[1].Labels = pb.ParseLabelSet("type=private,dc=test")
 
-		addr, err := h.pickAddress(tgt)
+		locs, err := h.orderLocations(tgt)
 		require.NoError(t, err)
 
-		assert.Equal(t, "10.0.1.2", addr)
+		assert.Equal(t, "10.0.1.2", locs[0].Addresses[0])
 	})
 
 	t.Run("uses a public one if there are no private", func(t *testing.T) {
@@ -73,10 +78,10 @@ func TestHubUtil(t *testing.T) {
 		tgt[0].Labels = pb.ParseLabelSet("type=public,dc=test")
 		tgt[1].Labels = pb.ParseLabelSet("type=private,dc=test")
 
-		addr, err := h.pickAddress(tgt)
+		locs, err := h.orderLocations(tgt)
 		require.NoError(t, err)
 
-		assert.Equal(t, "2.2.2.2", addr)
+		assert.Equal(t, "2.2.2.2", locs[0].Addresses[0])
 	})
 
 	t.Run("uses a public one when there are no matches", func(t *testing.T) {
@@ -90,10 +95,10 @@ func TestHubUtil(t *testing.T) {
 		tgt[0].Labels = pb.ParseLabelSet("type=public,dc=test")
 		tgt[1].Labels = pb.ParseLabelSet("type=private,dc=test")
 
-		addr, err := h.pickAddress(tgt)
+		locs, err := h.orderLocations(tgt)
 		require.NoError(t, err)
 
-		assert.Equal(t, "2.2.2.2", addr)
+		assert.Equal(t, "2.2.2.2", locs[0].Addresses[0])
 	})
 
 	t.Run("considers a priority label when picking between equal private addresses", func(t *testing.T) {
@@ -107,10 +112,10 @@ func TestHubUtil(t *testing.T) {
 		tgt[0].Labels = h.location[0].Labels
 		tgt[1].Labels = h.location[1].Labels
 
-		addr, err := h.pickAddress(tgt)
+		locs, err := h.orderLocations(tgt)
 		require.NoError(t, err)
 
-		assert.Equal(t, "10.0.1.2", addr)
+		assert.Equal(t, "10.0.1.2", locs[0].Addresses[0])
 	})
 
 	t.Run("picks a public address of the hub doesn't know it's location", func(t *testing.T) {
@@ -131,10 +136,10 @@ func TestHubUtil(t *testing.T) {
 			Value: "45.8491, -119.7143",
 		})
 
-		addr, err := h.pickAddress(target)
+		locs, err := h.orderLocations(target)
 		require.NoError(t, err)
 
-		assert.Equal(t, "54.149.212.61", addr)
+		assert.Equal(t, "54.149.212.61", locs[0].Addresses[0])
 	})
 
 	t.Run("works in the real words", func(t *testing.T) {
@@ -170,10 +175,166 @@ func TestHubUtil(t *testing.T) {
 			Value: "45.8491, -119.7143",
 		})
 
-		addr, err := h.pickAddress(target)
+		locs, err := h.orderLocations(target)
 		require.NoError(t, err)
 
-		assert.Equal(t, "54.149.212.61", addr)
+		assert.Equal(t, "54.149.212.61", locs[0].Addresses[0])
 	})
 
+	t.Run("skips locations of families the hub can't reach", func(t *testing.T) {
+		var h Hub
+
+		h.location = mkLocs("2001:db8::1")
+		h.location[0].Labels = pb.ParseLabelSet("type=public")
+		h.location[0].SetFamilies()
+
+		tgt := mkLocs("2.2.2.2", "2001:db8::2")
+		tgt[0].Labels = pb.ParseLabelSet("type=public,priority=10")
+		tgt[1].Labels = pb.ParseLabelSet("type=public")
+
+		for _, loc := range tgt {
+			loc.SetFamilies()
+		}
+
+		locs, err := h.orderLocations(tgt)
+		require.NoError(t, err)
+
+		assert.Equal(t, "2001:db8::2", locs[0].Addresses[0])
+	})
+
+	t.Run("errors when there are no addresses", func(t *testing.T) {
+		var h Hub
+
+		_, err := h.orderLocations([]*pb.NetworkLocation{{}})
+		assert.Equal(t, ErrNoAvailableAddresses, err)
+	})
+}
+
+func TestPeerAddrs(t *testing.T) {
+	t.Run("orders the addresses of each location", func(t *testing.T) {
+		var h Hub
+
+		h.location = []*pb.NetworkLocation{
+			{Addresses: []string{"10.0.1.1"}, Labels: pb.ParseLabelSet("type=private,dc=test")},
+		}
+
+		groups, err := h.peerLocations([]*pb.NetworkLocation{
+			{
+				Addresses: []string{"2.2.2.2", "2.2.2.3", "2001:db8::2"},
+				Labels:    pb.ParseLabelSet("type=public"),
+			},
+			{
+				Addresses: []string{"10.0.1.2", "10.0.1.3"},
+				Labels:    pb.ParseLabelSet("type=private,dc=test"),
+			},
+			{
+				Addresses: []string{"2.2.2.2"},
+				Labels:    pb.ParseLabelSet("type=public,priority=10"),
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]string{
+			{"10.0.1.2", "10.0.1.3"},
+			{"2.2.2.2"},
+			{"2001:db8::2", "2.2.2.3"},
+		}, groups)
+	})
+
+	t.Run("attributes failures to the address that was dialed", func(t *testing.T) {
+		addrs := []string{"10.0.1.2", "10.0.1.3:8443"}
+
+		assert.Equal(t, []string{"10.0.1.2"}, matchingAddrs(addrs, "10.0.1.2:443"))
+		assert.Equal(t, []string{"10.0.1.3:8443"}, matchingAddrs(addrs, "10.0.1.3:8443"))
+		assert.Equal(t, addrs, matchingAddrs(addrs, ""))
+	})
+
+	t.Run("backs off failed addresses exponentially", func(t *testing.T) {
+		var p peerAddrs
+
+		now := time.Now()
+
+		assert.True(t, p.available("10.0.1.2:443", now))
+
+		assert.Equal(t, peerBackoffBase, p.fail("10.0.1.2:443", now))
+		assert.False(t, p.available("10.0.1.2:443", now))
+		assert.True(t, p.available("10.0.1.3:443", now))
+
+		now = now.Add(peerBackoffBase)
+		assert.True(t, p.available("10.0.1.2:443", now))
+
+		assert.Equal(t, 2*peerBackoffBase, p.fail("10.0.1.2:443", now))
+		assert.Equal(t, 4*peerBackoffBase, p.fail("10.0.1.2:443", now))
+
+		for i := 0; i < 20; i++ {
+			p.fail("10.0.1.2:443", now)
+		}
+
+		assert.Equal(t, peerBackoffMax, p.fail("10.0.1.2:443", now))
+
+		p.succeed("10.0.1.2:443")
+		assert.True(t, p.available("10.0.1.2:443", now))
+		assert.Equal(t, peerBackoffBase, p.fail("10.0.1.2:443", now))
+	})
+
+	t.Run("reports a hub unreachable once every address failed", func(t *testing.T) {
+		var h Hub
+
+		dead, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		dead.Close()
+
+		locs := []*pb.NetworkLocation{
+			{Addresses: []string{dead.Addr().String()}},
+		}
+
+		_, err = h.connectToPeer(context.Background(), hclog.NewNullLogger(), pb.NewULID(), locs, "token")
+		assert.Equal(t, ErrHubUnreachable, errors.Cause(err))
+
+		assert.False(t, h.peers.available(dead.Addr().String(), time.Now()))
+
+		// Addresses that are backing off aren't tried again.
+		_, err = h.connectToPeer(context.Background(), hclog.NewNullLogger(), pb.NewULID(), locs, "token")
+		assert.Equal(t, ErrHubUnreachable, errors.Cause(err))
+	})
+
+	t.Run("bounds the handshake by the dial timeout", func(t *testing.T) {
+		defer func(d time.Duration) {
+			peerDialTimeout = d
+		}(peerDialTimeout)
+
+		peerDialTimeout = 100 * time.Millisecond
+
+		var h Hub
+
+		// Accepts connections but never completes the handshake.
+		silent, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer silent.Close()
+
+		go func() {
+			for {
+				conn, err := silent.Accept()
+				if err != nil {
+					return
+				}
+
+				defer conn.Close()
+			}
+		}()
+
+		locs := []*pb.NetworkLocation{
+			{Addresses: []string{silent.Addr().String()}},
+		}
+
+		start := time.Now()
+
+		_, err = h.connectToPeer(context.Background(), hclog.NewNullLogger(), pb.NewULID(), locs, "token")
+		assert.Equal(t, ErrHubUnreachable, errors.Cause(err))
+
+		assert.True(t, time.Since(start) < 5*time.Second)
+		assert.False(t, h.peers.available(silent.Addr().String(), time.Now()))
+	})
 }
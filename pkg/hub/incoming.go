@@ -3,17 +3,15 @@ package hub
 import (
 	"context"
 	"fmt"
-	"io"
-	"net"
+	"net/http"
+	"sort"
 	"strconv"
 	"sync/atomic"
 	"time"
 
-	"github.com/hashicorp/horizon/pkg/connect"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/hashicorp/yamux"
-	"github.com/pierrec/lz4/v3"
 	"github.com/pkg/errors"
 )
 
@@ -26,37 +24,102 @@ func (p *pivotAccountContext) AccountId() *pb.ULID {
 	return p.pa.AccountId
 }
 
-func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamux.Stream, wctx wire.Context) {
+func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamux.Stream) {
 	defer stream.Close()
 	defer func() {
+		ai.removeStream(stream.StreamID())
 		atomic.AddInt64(ai.ActiveStreams, -1)
 		h.sendAgentInfoFlow(ai)
 	}()
 
 	L := h.L
 
+	cs, err := wire.AcceptCompressedStream(stream, ai.compression, ai.features.Has(wire.FeatureStreamCompression))
+	if err != nil {
+		L.Error("error setting up stream compression", "error", err)
+		return
+	}
+
+	defer cs.Close()
+
+	fr, err := wire.NewFramingReader(cs)
+	if err != nil {
+		L.Error("error creating frame reader", "error", err)
+		return
+	}
+
+	defer fr.Recycle()
+
+	fw, err := wire.NewFramingWriter(cs)
+	if err != nil {
+		L.Error("error creating framing writer", "error", err)
+		return
+	}
+
+	defer fw.Recycle()
+
+	wctx := wire.NewContext(ai.token.Account(), fr, fw)
+
 	L.Trace("stream accepted", "hub", h.id, "id", stream.StreamID())
 	defer L.Trace("stream ended", "id", stream.StreamID())
 
-	var req pb.ConnectRequest
+	// The type of the request depends on the tag, so read the raw message
+	// first.
+	var raw wire.MarshalBytes
 
-	tag, err := wctx.ReadMarshal(&req)
+	tag, err := wctx.ReadMarshal(&raw)
 	if err != nil {
 		L.Error("error decoding request", "error", err)
 		return
 	}
 
-	if tag != 1 {
+	switch tag {
+	case 1:
+		// ConnectRequest, handled below
+	case 2:
+		var upd pb.ServicesUpdate
+
+		err = upd.Unmarshal(raw)
+		if err != nil {
+			L.Error("error decoding services update", "error", err)
+			return
+		}
+
+		h.handleServicesUpdate(ctx, ai, wctx, &upd)
+		return
+	case wire.RPCRequestTag:
+		var req pb.RPCRequest
+
+		err = req.Unmarshal(raw)
+		if err != nil {
+			L.Error("error decoding rpc request", "error", err)
+			return
+		}
+
+		h.handleRPC(ctx, wctx, &req)
+		return
+	default:
 		L.Error("incorrect message tag", "tag", tag)
 		return
 	}
 
+	var req pb.ConnectRequest
+
+	err = req.Unmarshal(raw)
+	if err != nil {
+		L.Error("error decoding request", "error", err)
+		return
+	}
+
+	ai.setStreamTarget(stream.StreamID(), req.Target)
+
 	if req.PivotAccount != nil {
 		if ai.token.AllowAccount(req.PivotAccount.Namespace) {
 			wctx = &pivotAccountContext{wctx, req.PivotAccount}
 		} else {
 			var resp pb.Response
 			resp.Error = "invalid account pivot"
+			resp.Code = http.StatusForbidden
 			wctx.WriteMarshal(255, &resp)
 			return
 		}
@@ -66,12 +129,25 @@ func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamu
 	if err != nil {
 		var resp pb.Response
 		resp.Error = err.Error()
+		resp.Code = http.StatusInternalServerError
 		wctx.WriteMarshal(255, &resp)
 		return
 	}
 
 	routes := calc.Services()
 
+	if req.ServiceId != nil {
+		var pinned []*pb.ServiceRoute
+
+		for _, route := range routes {
+			if route.Id.Equal(req.ServiceId) {
+				pinned = append(pinned, route)
+			}
+		}
+
+		routes = pinned
+	}
+
 	possible := len(routes)
 
 	if len(routes) == 0 {
@@ -81,6 +157,7 @@ func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamu
 			wctx.Account().SpecString(),
 			req.Target.SpecString(),
 		)
+		resp.Code = http.StatusNotFound
 
 		wctx.WriteMarshal(255, &resp)
 		return
@@ -93,6 +170,7 @@ func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamu
 		if err != nil {
 			var resp pb.Response
 			resp.Error = err.Error()
+			resp.Code = http.StatusInternalServerError
 			wctx.WriteMarshal(255, &resp)
 			return
 		}
@@ -107,12 +185,22 @@ func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamu
 		fs.StartedAt = pb.NewTimestamp(time.Now())
 
 		err = h.bridgeToTarget(ctx, ai, &fs, target, &req, wctx)
-		if err != nil {
-			var resp pb.Response
-			resp.Error = err.Error()
-			wctx.WriteMarshal(255, &resp)
+		if err == nil {
 			return
 		}
+
+		// Only once every address of the hub has failed, or it has none we
+		// can use, is the next route tried.
+		if cause := errors.Cause(err); cause == ErrHubUnreachable || cause == ErrNoAvailableAddresses {
+			L.Warn("unable to reach hub for route, trying next route", "hub", target.Hub, "service", target.Id)
+			continue
+		}
+
+		var resp pb.Response
+		resp.Error = err.Error()
+		resp.Code = http.StatusBadGateway
+		wctx.WriteMarshal(255, &resp)
+		return
 	}
 
 	var resp pb.Response
@@ -122,6 +210,7 @@ func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamu
 		wctx.Account().SpecString(),
 		req.Target.SpecString(),
 	)
+	resp.Code = http.StatusServiceUnavailable
 	wctx.WriteMarshal(255, &resp)
 }
 
@@ -172,23 +261,22 @@ func (h *Hub) bridgeToTarget(
 		return err
 	}
 
-	h.L.Trace("connecting to agent", "agent", ai.ID, "service", target.Id, "lz4", ac.useLZ4)
-
-	var (
-		r io.Reader = stream
-		w io.Writer = stream
-	)
+	h.L.Trace("connecting to agent", "agent", ai.ID, "service", target.Id, "compression", ac.compression.Codec)
 
-	if ac.useLZ4 {
-		r = lz4.NewReader(stream)
-		w = lz4.NewWriter(stream)
+	cs, err := wire.OpenCompressedStream(stream, ac.compression, ac.header)
+	if err != nil {
+		stream.Close()
+		return err
 	}
 
+	defer cs.Close()
+
 	var sid pb.SessionIdentification
 	sid.ServiceId = target.Id
 	sid.ProtocolId = req.ProtocolId
+	sid.SourceAccount, sid.SourceAgent = connectionSource(ai, req)
 
-	fw, err := wire.NewFramingWriter(w)
+	fw, err := wire.NewFramingWriter(cs)
 	if err != nil {
 		return err
 	}
@@ -200,7 +288,7 @@ func (h *Hub) bridgeToTarget(
 		return err
 	}
 
-	fr, err := wire.NewFramingReader(r)
+	fr, err := wire.NewFramingReader(cs)
 	if err != nil {
 		return err
 	}
@@ -212,6 +300,18 @@ func (h *Hub) bridgeToTarget(
 	return h.copyBetweenContexts(ctx, wctx, dsctx, fs, ai)
 }
 
+// connectionSource returns the agent that a connection request originated
+// from. Hubs forward requests on behalf of their agents, so their claim
+// about the source is trusted, while agents are always the source of their
+// own requests.
+func connectionSource(ai *agentConn, req *pb.ConnectRequest) (*pb.Account, *pb.ULID) {
+	if ai.token.Body.Role == pb.HUB && req.SourceAgent != nil {
+		return req.SourceAccount, req.SourceAgent
+	}
+
+	return ai.Account, ai.ID
+}
+
 func isPublic(labels *pb.LabelSet) bool {
 	if labels == nil {
 		return true
@@ -250,80 +350,106 @@ func findPrio(loc *pb.NetworkLocation) int {
 	return 0
 }
 
-func pickBestOf(locs []*pb.NetworkLocation) (string, error) {
-	if len(locs) == 1 {
-		return locs[0].Addresses[0], nil
+// sortByPrio orders locs by their priority label, highest first, keeping
+// the order of locations with the same priority.
+func sortByPrio(locs []*pb.NetworkLocation) []*pb.NetworkLocation {
+	sort.SliceStable(locs, func(i, j int) bool {
+		return findPrio(locs[i]) > findPrio(locs[j])
+	})
+
+	return locs
+}
+
+var ErrNoAvailableAddresses = errors.New("no addresses available for hub")
+
+// reachable returns the locations that have addresses of a family the hub
+// has addresses of, so that an IPv6-only hub doesn't try to reach IPv4-only
+// locations. If none are, or the families aren't known, locs is returned.
+func (h *Hub) reachable(locs []*pb.NetworkLocation) []*pb.NetworkLocation {
+	var fams []pb.AddressFamily
+
+	for _, loc := range h.location {
+		fams = append(fams, loc.Families...)
 	}
 
-	prio := 0
-	var pick *pb.NetworkLocation
+	if len(fams) == 0 {
+		return locs
+	}
 
-	for _, loc := range locs {
-		tp := findPrio(loc)
+	var out []*pb.NetworkLocation
 
-		if pick == nil || tp > prio {
-			pick = loc
-			prio = tp
+	for _, loc := range locs {
+		for _, fam := range fams {
+			if loc.HasFamily(fam) {
+				out = append(out, loc)
+				break
+			}
 		}
 	}
 
-	return pick.Addresses[0], nil
+	if len(out) == 0 {
+		return locs
+	}
+
+	return out
 }
 
-var ErrNoAvailableAddresses = errors.New("no addresses available for hub")
+// orderLocations returns the locations with addresses in the order they
+// should be tried. Private locations with the same labels as one of the
+// hub's come first, then public ones with the same labels, then any other
+// public locations, each ordered by priority. The rest follow in their
+// original order.
+func (h *Hub) orderLocations(locs []*pb.NetworkLocation) ([]*pb.NetworkLocation, error) {
+	var usable []*pb.NetworkLocation
 
-// Given a list of network locations, pick one to connect to and return
-// an address.
-func (h *Hub) pickAddress(locs []*pb.NetworkLocation) (string, error) {
-	// TODO: figure out a heiristic for when a location has multiple addresses
-	// and when we should use them. Maybe some backoff logic associated with each one?
+	for _, loc := range locs {
+		if len(loc.Addresses) > 0 {
+			usable = append(usable, loc)
+		}
+	}
 
-	switch len(locs) {
-	case 0:
-		return "", nil
-	case 1:
-		return locs[0].Addresses[0], nil
+	locs = h.reachable(usable)
+
+	if len(locs) == 0 {
+		return nil, ErrNoAvailableAddresses
 	}
 
 	var (
 		candidate []*pb.NetworkLocation
 		fallback  []*pb.NetworkLocation
 		publics   []*pb.NetworkLocation
+		rest      []*pb.NetworkLocation
 	)
 
 	for _, ploc := range locs {
-		if isPublic(ploc.Labels) {
-			publics = append(publics, ploc)
-		}
+		var matched bool
 
 		for _, sloc := range h.location {
 			if ploc.Labels != nil && ploc.Labels.Len() > 0 && sloc.Labels != nil && sloc.Labels.Len() > 0 {
 				if ploc.Labels.Equal(sloc.Labels) {
-					if isPublic(ploc.Labels) {
-						fallback = append(fallback, ploc)
-					} else {
-						candidate = append(candidate, ploc)
-					}
+					matched = true
+					break
 				}
 			}
 		}
-	}
-
-	if len(candidate) > 0 {
-		return pickBestOf(candidate)
-	}
-
-	if len(fallback) > 0 {
-		return pickBestOf(fallback)
-	}
 
-	if len(publics) > 0 {
-		return pickBestOf(publics)
+		switch {
+		case matched && !isPublic(ploc.Labels):
+			candidate = append(candidate, ploc)
+		case matched:
+			fallback = append(fallback, ploc)
+		case isPublic(ploc.Labels):
+			publics = append(publics, ploc)
+		default:
+			rest = append(rest, ploc)
+		}
 	}
 
-	// if all else fails, just pick the first one.
+	out := sortByPrio(candidate)
+	out = append(out, sortByPrio(fallback)...)
+	out = append(out, sortByPrio(publics)...)
 
-	return locs[0].Addresses[0], nil
+	return append(out, rest...), nil
 }
 
 func (h *Hub) forwardToTarget(
@@ -349,22 +475,7 @@ func (h *Hub) forwardToTarget(
 
 	L.Trace("locations for target hub", "hub", target.Hub, "locations", locs)
 
-	addr, err := h.pickAddress(locs)
-	if err != nil {
-		return err
-	}
-
-	host, port, err := net.SplitHostPort(addr)
-	if err != nil {
-		host = addr
-		port = "443"
-	}
-
-	addr = net.JoinHostPort(host, port)
-
-	L.Trace("spawning connection to peer hub", "hub", target.Hub, "addr", addr)
-
-	session, err := connect.Connect(L, addr, ai.stoken)
+	session, err := h.connectToPeer(ctx, L, target.Hub, locs, ai.stoken)
 	if err != nil {
 		return err
 	}
@@ -373,7 +484,13 @@ func (h *Hub) forwardToTarget(
 	// passing the service id we calculated here. The advantage is that things
 	// might have changed and the target has a better target (which would result
 	// in multiple relays).
-	conn, err := session.ConnectToService(req.Target)
+	var fwdreq pb.ConnectRequest
+	fwdreq.Target = req.Target
+	fwdreq.ProtocolId = req.ProtocolId
+	fwdreq.ServiceId = req.ServiceId
+	fwdreq.SourceAccount, fwdreq.SourceAgent = connectionSource(ai, req)
+
+	conn, err := session.Connect(&fwdreq)
 	if err != nil {
 		return err
 	}
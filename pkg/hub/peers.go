@@ -0,0 +1,248 @@
+package hub
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/connect"
+	"github.com/hashicorp/horizon/pkg/netloc"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+// ErrHubUnreachable is returned when none of the addresses of a peer hub
+// could be connected to. Routing moves on to the next route when it sees
+// it.
+var ErrHubUnreachable = errors.New("unable to connect to any address of hub")
+
+var (
+	// How long an address of a peer hub that failed is skipped for. It
+	// doubles with each consecutive failure up to peerBackoffMax.
+	peerBackoffBase = time.Second
+	peerBackoffMax  = 2 * time.Minute
+
+	// How long to wait for the addresses of each location of a peer hub to
+	// connect, including the handshake, before moving on to the next.
+	peerDialTimeout = 5 * time.Second
+)
+
+// The port used for addresses of peer hubs that don't include one.
+const peerDefaultPort = "443"
+
+type addrFailure struct {
+	failures int
+	retryAt  time.Time
+}
+
+// peerAddrs tracks connection failures to the addresses of peer hubs so
+// that addresses that don't work are skipped for a while rather than
+// tried on every connection.
+type peerAddrs struct {
+	mu     sync.Mutex
+	failed map[string]*addrFailure
+}
+
+// available returns false if addr failed recently and is backing off.
+func (p *peerAddrs) available(addr string, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	af, ok := p.failed[addr]
+
+	return !ok || !now.Before(af.retryAt)
+}
+
+// fail records that connecting to addr failed and returns how long it will
+// be skipped for.
+func (p *peerAddrs) fail(addr string, now time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failed == nil {
+		p.failed = make(map[string]*addrFailure)
+	}
+
+	af, ok := p.failed[addr]
+	if !ok {
+		af = &addrFailure{}
+		p.failed[addr] = af
+	}
+
+	backoff := peerBackoffBase
+	for i := 0; i < af.failures && backoff < peerBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > peerBackoffMax {
+		backoff = peerBackoffMax
+	}
+
+	af.failures++
+	af.retryAt = now.Add(backoff)
+
+	return backoff
+}
+
+// succeed forgets any failures of addr.
+func (p *peerAddrs) succeed(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.failed, addr)
+}
+
+// peerLocations returns the addresses of each of locs, in the order the
+// locations should be tried. The addresses of the best location come
+// first, and the families of the addresses of each location are
+// interleaved. Addresses listed by more than one location are only tried
+// with the first.
+func (h *Hub) peerLocations(locs []*pb.NetworkLocation) ([][]string, error) {
+	ordered, err := h.orderLocations(locs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out  [][]string
+		seen = make(map[string]bool)
+	)
+
+	for _, loc := range ordered {
+		var addrs []string
+
+		for _, addr := range netloc.InterleaveAddresses(loc.Addresses) {
+			if seen[addr] {
+				continue
+			}
+
+			seen[addr] = true
+			addrs = append(addrs, addr)
+		}
+
+		if len(addrs) > 0 {
+			out = append(out, addrs)
+		}
+	}
+
+	return out, nil
+}
+
+// dialPeer races addrs and establishes a session over the first to
+// connect. The address connected to is returned, even if the session
+// failed, so that failures can be attributed to it.
+func dialPeer(ctx context.Context, L hclog.Logger, addrs []string, token string) (*connect.Session, string, error) {
+	conn, err := netloc.DialAddresses(ctx, nil, addrs, peerDefaultPort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	remote := conn.RemoteAddr().String()
+
+	session, err := connect.NewSession(ctx, L, conn, token)
+
+	return session, remote, err
+}
+
+// matchingAddrs returns the addresses in addrs that were dialed as remote,
+// or all of them if remote is unknown or isn't one of them, such as when
+// addrs are names.
+func matchingAddrs(addrs []string, remote string) []string {
+	if remote == "" {
+		return addrs
+	}
+
+	for _, addr := range addrs {
+		hostport := addr
+
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			hostport = net.JoinHostPort(addr, peerDefaultPort)
+		}
+
+		if hostport == remote {
+			return []string{addr}
+		}
+	}
+
+	return addrs
+}
+
+// connectToPeer connects to the peer hub with the given locations. The
+// addresses of each location are raced, skipping those that are backing
+// off, and the next location is tried if they all fail. Addresses that
+// fail back off exponentially. ErrHubUnreachable is returned once all the
+// addresses have failed or are backing off.
+func (h *Hub) connectToPeer(
+	ctx context.Context,
+	L hclog.Logger,
+	hub *pb.ULID,
+	locs []*pb.NetworkLocation,
+	token string,
+) (*connect.Session, error) {
+	groups, err := h.peerLocations(locs)
+	if err != nil {
+		return nil, err
+	}
+
+	hubLabel := []metrics.Label{{Name: "hub", Value: hub.SpecString()}}
+
+	for _, group := range groups {
+		var addrs []string
+
+		for _, addr := range group {
+			if !h.peers.available(addr, time.Now()) {
+				L.Trace("skipping peer hub address that is backing off", "hub", hub, "address", addr)
+				metrics.IncrCounterWithLabels([]string{"hub", "peer", "skipped"}, 1, hubLabel)
+				continue
+			}
+
+			addrs = append(addrs, addr)
+		}
+
+		if len(addrs) == 0 {
+			continue
+		}
+
+		L.Trace("connecting to peer hub", "hub", hub, "addresses", addrs)
+
+		// Covers the TLS handshake and preamble as well as dialing.
+		dctx, cancel := context.WithTimeout(ctx, peerDialTimeout)
+		session, remote, err := dialPeer(dctx, L, addrs, token)
+		cancel()
+
+		if err == nil {
+			for _, addr := range matchingAddrs(addrs, remote) {
+				h.peers.succeed(addr)
+			}
+
+			metrics.IncrCounterWithLabels([]string{"hub", "peer", "connected"}, 1, hubLabel)
+			return session, nil
+		}
+
+		// The hub rejecting our token or us giving up isn't the fault of the
+		// addresses, so there's no point trying the others.
+		if err == connect.ErrInvalidToken {
+			return nil, err
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		for _, addr := range matchingAddrs(addrs, remote) {
+			backoff := h.peers.fail(addr, time.Now())
+
+			L.Warn("error connecting to peer hub address",
+				"hub", hub, "address", addr, "error", err, "backoff", backoff)
+		}
+
+		metrics.IncrCounterWithLabels([]string{"hub", "peer", "failed"}, 1, hubLabel)
+	}
+
+	metrics.IncrCounterWithLabels([]string{"hub", "peer", "unreachable"}, 1, hubLabel)
+
+	return nil, errors.Wrapf(ErrHubUnreachable, "hub %s", hub)
+}
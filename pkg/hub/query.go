@@ -0,0 +1,121 @@
+package hub
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+)
+
+// How often a watch of the peer services checks for changes.
+var peerWatchInterval = 2 * time.Second
+
+// handleQueryPeers returns the services visible to the account of the
+// agent. If the query is a watch, a new response is sent whenever the
+// services change until the agent closes the stream.
+func (h *Hub) handleQueryPeers(ctx context.Context, wctx wire.RPCContext) error {
+	var req pb.ServicesQuery
+
+	err := wctx.ReadRequest(&req)
+	if err != nil {
+		return err
+	}
+
+	if req.Labels == nil {
+		req.Labels = &pb.LabelSet{}
+	}
+
+	resp, err := h.peerServices(ctx, wctx.Account(), req.Labels)
+	if err != nil {
+		return err
+	}
+
+	err = wctx.WriteResponse(resp)
+	if err != nil || !req.Watch {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The agent doesn't send anything more than the marker that it has
+	// finished sending, so reading fails once the stream has been closed.
+	go func() {
+		defer cancel()
+
+		for {
+			var raw wire.MarshalBytes
+
+			_, err := wctx.ReadMarshal(&raw)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(peerWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		next, err := h.peerServices(ctx, wctx.Account(), req.Labels)
+		if err != nil {
+			return err
+		}
+
+		if next.Equal(resp) {
+			continue
+		}
+
+		resp = next
+
+		err = wctx.WriteResponse(resp)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (h *Hub) peerServices(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*pb.ServicesQueryResponse, error) {
+	calc, err := h.cc.LookupService(ctx, account, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp pb.ServicesQueryResponse
+		seen = make(map[string]struct{})
+	)
+
+	for _, route := range calc.All {
+		key := route.Id.SpecString()
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		resp.Services = append(resp.Services, &pb.PeerService{
+			Id:       route.Id,
+			Hub:      route.Hub,
+			Type:     route.Type,
+			Labels:   route.Labels,
+			Metadata: route.Metadata,
+		})
+	}
+
+	// Keep the order stable so that watches can detect changes.
+	sort.Slice(resp.Services, func(i, j int) bool {
+		return resp.Services[i].Id.SpecString() < resp.Services[j].Id.SpecString()
+	})
+
+	return &resp, nil
+}
@@ -0,0 +1,52 @@
+package hub
+
+import (
+	"context"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+	"github.com/pkg/errors"
+)
+
+// AddRPCMethod registers handler to serve RPCs that agents make to path on
+// host. An empty host matches any host.
+func (h *Hub) AddRPCMethod(host, path string, handler wire.RPCHandler) {
+	h.rpc.AddHostMethod(host, path, handler)
+}
+
+func (h *Hub) handleRPC(ctx context.Context, wctx wire.Context, req *pb.RPCRequest) {
+	err := h.rpc.HandleRequest(ctx, h.L, wctx, req)
+	if err != nil {
+		h.L.Error("error handling rpc", "host", req.Host, "path", req.Path, "error", err)
+	}
+}
+
+// AgentRPCClient returns a client to make an RPC to the agent with the
+// given id, which must be connected to this hub and support RPCs. Close the
+// client when done with it.
+func (h *Hub) AgentRPCClient(id *pb.ULID) (*wire.RPCClient, error) {
+	h.mu.RLock()
+	ai, ok := h.agents[id.SpecString()]
+	h.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchSession, "agent: %s", id.SpecString())
+	}
+
+	if !ai.features.Has(wire.FeatureRPC) {
+		return nil, errors.Wrapf(wire.ErrUnsupportedFeature, "agent does not support rpcs: %s", id.SpecString())
+	}
+
+	stream, err := ai.sess.OpenStream()
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := wire.OpenCompressedStream(stream, ai.compression, ai.features.Has(wire.FeatureStreamCompression))
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	return wire.NewRPCClient(cs, cs, cs), nil
+}
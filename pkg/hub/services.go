@@ -0,0 +1,139 @@
+package hub
+
+import (
+	"context"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+	"github.com/pkg/errors"
+)
+
+// handleServicesUpdate changes the services advertised by an agent without
+// the agent having to reconnect.
+func (h *Hub) handleServicesUpdate(ctx context.Context, ai *agentConn, wctx wire.Context, upd *pb.ServicesUpdate) {
+	var resp pb.Response
+
+	err := h.updateServices(ctx, ai, upd)
+	if err != nil {
+		h.L.Error("error updating agent services", "agent", ai.ID, "error", err)
+
+		resp.Error = err.Error()
+		wctx.WriteMarshal(255, &resp)
+		return
+	}
+
+	h.L.Info("updated agent services",
+		"agent", ai.ID,
+		"account", ai.Account,
+		"added", len(upd.Added),
+		"removed", len(upd.Removed),
+	)
+
+	wctx.WriteMarshal(1, &resp)
+}
+
+func (h *Hub) updateServices(ctx context.Context, ai *agentConn, upd *pb.ServicesUpdate) error {
+	if len(upd.Added) > 0 {
+		ok, _ := ai.token.HasCapability(pb.SERVE)
+		if !ok {
+			return errors.Wrapf(ErrProtocolError, "token not authorized to serve")
+		}
+
+		if !h.checkTooManyServices(ai.Account, len(upd.Added)) {
+			return errors.Wrapf(ErrTooManyServices, "account: %s", ai.Account.SpecString())
+		}
+	}
+
+	ai.mu.Lock()
+	defer ai.mu.Unlock()
+
+	if ai.closed {
+		h.removeAccountServices(ai.Account, len(upd.Added))
+		return errors.Wrapf(ErrProtocolError, "agent disconnected")
+	}
+
+	for _, id := range upd.Removed {
+		key := id.SpecString()
+
+		for i, serv := range ai.preamble.Services {
+			if serv.ServiceId.SpecString() != key {
+				continue
+			}
+
+			h.L.Debug("removing service",
+				"agent", ai.ID,
+				"hub", h.id,
+				"service", serv.ServiceId,
+				"account", ai.Account,
+			)
+
+			err := h.cc.RemoveService(ctx, &pb.ServiceRequest{
+				Account:  ai.Account,
+				Hub:      h.id,
+				Id:       serv.ServiceId,
+				Type:     serv.Type,
+				Labels:   serv.Labels,
+				Metadata: serv.Metadata,
+			})
+
+			if err != nil {
+				h.L.Error("error removing service", "error", err)
+				// we want to try all of them regardless of the error.
+			}
+
+			ai.preamble.Services = append(ai.preamble.Services[:i], ai.preamble.Services[i+1:]...)
+
+			h.removeAccountServices(ai.Account, 1)
+
+			h.mu.Lock()
+			delete(h.active, key)
+			h.mu.Unlock()
+
+			break
+		}
+	}
+
+	for i, serv := range upd.Added {
+		err := h.cc.AddService(ctx, &pb.ServiceRequest{
+			Account:  ai.Account,
+			Hub:      h.id,
+			Id:       serv.ServiceId,
+			Type:     serv.Type,
+			Labels:   serv.Labels,
+			Metadata: serv.Metadata,
+		})
+
+		if err != nil {
+			// Give back the slots of the services we didn't add.
+			h.removeAccountServices(ai.Account, len(upd.Added)-i)
+			return errors.Wrapf(err, "error adding services")
+		}
+
+		h.L.Debug("adding service",
+			"agent", ai.ID,
+			"hub", h.id,
+			"service", serv.ServiceId,
+			"labels", serv.Labels.SpecString(),
+			"account", ai.Account,
+		)
+
+		ai.preamble.Services = append(ai.preamble.Services, serv)
+
+		if !ai.connectOnly {
+			h.mu.Lock()
+			h.active[serv.ServiceId.SpecString()] = ai.serviceConnection(serv)
+			h.mu.Unlock()
+		}
+	}
+
+	ai.Services = int32(len(ai.preamble.Services))
+
+	// An agent that connected without services is now serving, so it needs
+	// to be registered, which also routes to the services just added.
+	if ai.connectOnly && len(ai.preamble.Services) > 0 {
+		ai.connectOnly = false
+		return h.registerAgent(ai)
+	}
+
+	return nil
+}
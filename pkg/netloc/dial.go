@@ -0,0 +1,168 @@
+package netloc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// How long to wait for a connection attempt before starting the next one,
+// as recommended by RFC 8305.
+var attemptDelay = 250 * time.Millisecond
+
+// InterleaveAddresses orders addrs so that address families alternate,
+// starting with the family of the first address, as described in RFC 8305.
+// The order of addresses within a family is kept. Hostnames are left at
+// the end since the dialer resolves them itself.
+func InterleaveAddresses(addrs []string) []string {
+	var (
+		first, second, hosts []string
+		firstFam             pb.AddressFamily
+	)
+
+	for _, addr := range addrs {
+		fam := pb.AddressFamilyOf(addr)
+
+		switch {
+		case fam == pb.UNKNOWN_FAMILY:
+			hosts = append(hosts, addr)
+		case firstFam == pb.UNKNOWN_FAMILY || fam == firstFam:
+			firstFam = fam
+			first = append(first, addr)
+		default:
+			second = append(second, addr)
+		}
+	}
+
+	out := make([]string, 0, len(addrs))
+
+	for i := 0; i < len(first) || i < len(second); i++ {
+		if i < len(first) {
+			out = append(out, first[i])
+		}
+
+		if i < len(second) {
+			out = append(out, second[i])
+		}
+	}
+
+	return append(out, hosts...)
+}
+
+type dialResult struct {
+	conn net.Conn
+	err  error
+}
+
+// closeResults waits for the n remaining dial results and closes the
+// connections among them.
+func closeResults(results chan dialResult, n int) {
+	for i := 0; i < n; i++ {
+		if res := <-results; res.err == nil {
+			res.conn.Close()
+		}
+	}
+}
+
+// DialAddresses connects to one of addrs over TCP, which can be addresses
+// of different families with optional ports. defPort is used for addresses
+// without a port. Addresses are tried in interleaved order, starting the
+// next attempt as soon as the previous fails or after a short delay, and
+// the first connection to succeed is returned (Happy Eyeballs, RFC 8305).
+func DialAddresses(ctx context.Context, dialer *net.Dialer, addrs []string, defPort string) (net.Conn, error) {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	if len(addrs) == 0 {
+		return nil, &net.AddrError{Err: "no addresses to dial"}
+	}
+
+	withPorts := make([]string, len(addrs))
+
+	for i, addr := range addrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			// JoinHostPort adds the brackets of IPv6 addresses itself.
+			if strings.HasPrefix(addr, "[") && strings.HasSuffix(addr, "]") {
+				addr = addr[1 : len(addr)-1]
+			}
+
+			addr = net.JoinHostPort(addr, defPort)
+		}
+
+		withPorts[i] = addr
+	}
+
+	addrs = InterleaveAddresses(withPorts)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan dialResult, len(addrs))
+
+	var (
+		next    int
+		pending int
+		errs    error
+		timer   = time.NewTimer(0)
+	)
+
+	defer timer.Stop()
+
+	for {
+		var timeout <-chan time.Time
+
+		if next < len(addrs) {
+			timeout = timer.C
+		} else if pending == 0 {
+			return nil, errs
+		}
+
+		select {
+		case <-ctx.Done():
+			// The pending dials fail now that ctx is done, but one may have
+			// connected just before.
+			go closeResults(results, pending)
+
+			return nil, ctx.Err()
+		case <-timeout:
+			addr := addrs[next]
+			next++
+			pending++
+
+			go func() {
+				conn, err := dialer.DialContext(ctx, "tcp", addr)
+				results <- dialResult{conn, err}
+			}()
+
+			timer.Reset(attemptDelay)
+		case res := <-results:
+			pending--
+
+			if res.err == nil {
+				// Close any connections that complete after this one.
+				go closeResults(results, pending)
+
+				return res.conn, nil
+			}
+
+			errs = multierror.Append(errs, res.err)
+
+			// Start the next attempt right away rather than waiting.
+			if next < len(addrs) {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+
+				timer.Reset(0)
+			}
+		}
+	}
+}
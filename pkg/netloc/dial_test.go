@@ -0,0 +1,139 @@
+package netloc
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDial(t *testing.T) {
+	t.Run("interleaves address families", func(t *testing.T) {
+		addrs := InterleaveAddresses([]string{
+			"2001:db8::1", "2001:db8::2", "2001:db8::3",
+			"hub.example.com",
+			"192.0.2.1:443", "192.0.2.2",
+		})
+
+		assert.Equal(t, []string{
+			"2001:db8::1", "192.0.2.1:443",
+			"2001:db8::2", "192.0.2.2",
+			"2001:db8::3",
+			"hub.example.com",
+		}, addrs)
+	})
+
+	t.Run("falls back to the next address", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		// Nothing listens here, so the connection is refused.
+		dead, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		deadAddr := dead.Addr().String()
+		dead.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := DialAddresses(ctx, nil, []string{deadAddr, l.Addr().String()}, "443")
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		assert.Equal(t, l.Addr().String(), conn.RemoteAddr().String())
+	})
+
+	t.Run("doesn't wait for an address that hangs", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+
+		// An address from TEST-NET-1, which doesn't answer.
+		dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		start := time.Now()
+
+		conn, err := DialAddresses(ctx, dialer, []string{"192.0.2.1:" + port, "127.0.0.1"}, port)
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		assert.Equal(t, l.Addr().String(), conn.RemoteAddr().String())
+		assert.True(t, time.Since(start) < 2*time.Second)
+	})
+
+	t.Run("adds the port to bracketed IPv6 addresses", func(t *testing.T) {
+		l, err := net.Listen("tcp6", "[::1]:0")
+		if err != nil {
+			t.Skip("IPv6 isn't available")
+		}
+
+		defer l.Close()
+
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := DialAddresses(ctx, nil, []string{"[::1]"}, port)
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		assert.Equal(t, l.Addr().String(), conn.RemoteAddr().String())
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		// Holds up the connection attempt past the deadline.
+		dialer := &net.Dialer{
+			Control: func(network, address string, c syscall.RawConn) error {
+				time.Sleep(time.Second)
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		_, err = DialAddresses(ctx, dialer, []string{l.Addr().String()}, "443")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("returns the errors when no address works", func(t *testing.T) {
+		dead, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		deadAddr := dead.Addr().String()
+		dead.Close()
+
+		_, err = DialAddresses(context.Background(), nil, []string{deadAddr}, "443")
+		assert.Error(t, err)
+
+		_, err = DialAddresses(context.Background(), nil, nil, "443")
+		assert.Error(t, err)
+	})
+}
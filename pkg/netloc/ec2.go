@@ -0,0 +1,96 @@
+package netloc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+func ec2MetaClient(endpoint string, timeout time.Duration) (*ec2metadata.EC2Metadata, error) {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: cleanhttp.DefaultTransport(),
+	}
+
+	c := aws.NewConfig().WithHTTPClient(client).WithMaxRetries(0)
+	if endpoint != "" {
+		c = c.WithEndpoint(endpoint)
+	}
+
+	session, err := session.NewSession(c)
+	if err != nil {
+		return nil, err
+	}
+	return ec2metadata.New(session, c), nil
+}
+
+// EC2Provider reads metadata from the EC2 instance metadata service.
+type EC2Provider struct {
+	// Defaults to the standard metadata endpoint.
+	Endpoint string
+}
+
+func (p *EC2Provider) Name() string {
+	return "ec2"
+}
+
+func (p *EC2Provider) Detect(ctx context.Context) (*CloudInfo, error) {
+	mdc, err := ec2MetaClient(p.Endpoint, metadataTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if !mdc.AvailableWithContext(ctx) {
+		return nil, ErrNotDetected
+	}
+
+	info := &CloudInfo{Cloud: "ec2"}
+
+	if ii, err := mdc.GetMetadataWithContext(ctx, "instance-id"); err == nil {
+		info.InstanceID = ii
+	}
+
+	if region, err := mdc.RegionWithContext(ctx); err == nil {
+		info.Region = region
+	}
+
+	if zone, err := mdc.GetMetadataWithContext(ctx, "placement/availability-zone"); err == nil {
+		info.Zone = zone
+	}
+
+	mac, err := mdc.GetMetadataWithContext(ctx, "mac")
+	if err != nil || mac == "" {
+		return info, nil
+	}
+
+	prefix := "network/interfaces/macs/" + mac + "/"
+
+	if si, err := mdc.GetMetadataWithContext(ctx, prefix+"subnet-id"); err == nil {
+		info.Subnet = si
+	}
+
+	if vi, err := mdc.GetMetadataWithContext(ctx, prefix+"vpc-id"); err == nil {
+		info.VPC = vi
+	}
+
+	ipKeys := []string{
+		prefix + "local-ipv4s",
+		prefix + "public-ipv4s",
+		prefix + "ipv6s",
+	}
+
+	for _, key := range ipKeys {
+		if addrs, err := mdc.GetMetadataWithContext(ctx, key); err == nil && addrs != "" {
+			for _, addr := range forgivingSplit(addrs) {
+				info.addAddr(addr)
+			}
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,253 @@
+package netloc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GCPProvider reads metadata from the Google Compute Engine metadata
+// server.
+type GCPProvider struct {
+	// Defaults to http://metadata.google.internal
+	Endpoint string
+}
+
+func (p *GCPProvider) Name() string {
+	return "gcp"
+}
+
+// lastSegment returns what follows the last / in s, such as the zone in
+// projects/123/zones/us-central1-a.
+func lastSegment(s string) string {
+	return s[strings.LastIndexByte(s, '/')+1:]
+}
+
+func (p *GCPProvider) Detect(ctx context.Context) (*CloudInfo, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "http://metadata.google.internal"
+	}
+
+	resp, data, err := getMetadata(ctx, metadataClient(),
+		endpoint+"/computeMetadata/v1/instance/?recursive=true",
+		map[string]string{"Metadata-Flavor": "Google"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The metadata server sets this on all responses, which tells it apart
+	// from anything else that answers on the name.
+	if resp.Header.Get("Metadata-Flavor") != "Google" {
+		return nil, ErrNotDetected
+	}
+
+	var md struct {
+		ID                json.Number `json:"id"`
+		Zone              string      `json:"zone"`
+		NetworkInterfaces []struct {
+			IP            string   `json:"ip"`
+			IPv6s         []string `json:"ipv6s"`
+			Network       string   `json:"network"`
+			Subnetwork    string   `json:"subnetwork"`
+			AccessConfigs []struct {
+				ExternalIP string `json:"externalIp"`
+			} `json:"accessConfigs"`
+		} `json:"networkInterfaces"`
+	}
+
+	err = json.Unmarshal(data, &md)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding gcp metadata")
+	}
+
+	info := &CloudInfo{
+		Cloud:      "gcp",
+		InstanceID: md.ID.String(),
+		Zone:       lastSegment(md.Zone),
+	}
+
+	// Zones are named after their region, such as us-central1-a.
+	if idx := strings.LastIndexByte(info.Zone, '-'); idx != -1 {
+		info.Region = info.Zone[:idx]
+	}
+
+	for i, iface := range md.NetworkInterfaces {
+		// Networks are global and named per project, so the full name is
+		// used to identify them.
+		if i == 0 {
+			info.VPC = iface.Network
+			info.Subnet = iface.Subnetwork
+		}
+
+		info.addAddr(iface.IP)
+
+		for _, addr := range iface.IPv6s {
+			info.addAddr(addr)
+		}
+
+		for _, ac := range iface.AccessConfigs {
+			info.addAddr(ac.ExternalIP)
+		}
+	}
+
+	return info, nil
+}
+
+// AzureProvider reads metadata from the Azure Instance Metadata Service.
+type AzureProvider struct {
+	// Defaults to http://169.254.169.254
+	Endpoint string
+}
+
+func (p *AzureProvider) Name() string {
+	return "azure"
+}
+
+func (p *AzureProvider) Detect(ctx context.Context) (*CloudInfo, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "http://169.254.169.254"
+	}
+
+	_, data, err := getMetadata(ctx, metadataClient(),
+		endpoint+"/metadata/instance?api-version=2021-02-01",
+		map[string]string{"Metadata": "true"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var md struct {
+		Compute struct {
+			Location string `json:"location"`
+			Zone     string `json:"zone"`
+			VMID     string `json:"vmId"`
+		} `json:"compute"`
+		Network struct {
+			Interface []struct {
+				IPv4 struct {
+					IPAddress []struct {
+						PrivateIPAddress string `json:"privateIpAddress"`
+						PublicIPAddress  string `json:"publicIpAddress"`
+					} `json:"ipAddress"`
+					Subnet []struct {
+						Address string `json:"address"`
+						Prefix  string `json:"prefix"`
+					} `json:"subnet"`
+				} `json:"ipv4"`
+				IPv6 struct {
+					IPAddress []struct {
+						PrivateIPAddress string `json:"privateIpAddress"`
+					} `json:"ipAddress"`
+				} `json:"ipv6"`
+			} `json:"interface"`
+		} `json:"network"`
+	}
+
+	err = json.Unmarshal(data, &md)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding azure metadata")
+	}
+
+	if md.Compute.VMID == "" {
+		return nil, ErrNotDetected
+	}
+
+	info := &CloudInfo{
+		Cloud:      "azure",
+		InstanceID: md.Compute.VMID,
+		Region:     md.Compute.Location,
+	}
+
+	// Availability zones are numbered within the region.
+	if md.Compute.Zone != "" {
+		info.Zone = md.Compute.Location + "-" + md.Compute.Zone
+	}
+
+	// The virtual network isn't in the metadata, so there's no VPC.
+	for i, iface := range md.Network.Interface {
+		if i == 0 && len(iface.IPv4.Subnet) != 0 {
+			sn := iface.IPv4.Subnet[0]
+			info.Subnet = sn.Address + "/" + sn.Prefix
+		}
+
+		for _, addr := range iface.IPv4.IPAddress {
+			info.addAddr(addr.PrivateIPAddress)
+			info.addAddr(addr.PublicIPAddress)
+		}
+
+		for _, addr := range iface.IPv6.IPAddress {
+			info.addAddr(addr.PrivateIPAddress)
+		}
+	}
+
+	return info, nil
+}
+
+// DigitalOceanProvider reads metadata from the DigitalOcean droplet
+// metadata service. Other clouds with the same style of metadata can use it
+// by setting Endpoint.
+type DigitalOceanProvider struct {
+	// Defaults to http://169.254.169.254
+	Endpoint string
+}
+
+func (p *DigitalOceanProvider) Name() string {
+	return "digitalocean"
+}
+
+func (p *DigitalOceanProvider) Detect(ctx context.Context) (*CloudInfo, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "http://169.254.169.254"
+	}
+
+	_, data, err := getMetadata(ctx, metadataClient(), endpoint+"/metadata/v1.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	type ifaceAddrs []struct {
+		IPv4 struct {
+			IPAddress string `json:"ip_address"`
+		} `json:"ipv4"`
+		IPv6 struct {
+			IPAddress string `json:"ip_address"`
+		} `json:"ipv6"`
+	}
+
+	var md struct {
+		DropletID  json.Number `json:"droplet_id"`
+		Region     string      `json:"region"`
+		Interfaces struct {
+			Public  ifaceAddrs `json:"public"`
+			Private ifaceAddrs `json:"private"`
+		} `json:"interfaces"`
+	}
+
+	err = json.Unmarshal(data, &md)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding digitalocean metadata")
+	}
+
+	if md.DropletID == "" {
+		return nil, ErrNotDetected
+	}
+
+	info := &CloudInfo{
+		Cloud:      "digitalocean",
+		InstanceID: md.DropletID.String(),
+		Region:     md.Region,
+	}
+
+	for _, iface := range append(md.Interfaces.Public, md.Interfaces.Private...) {
+		info.addAddr(iface.IPv4.IPAddress)
+		info.addAddr(iface.IPv6.IPAddress)
+	}
+
+	return info, nil
+}
@@ -3,20 +3,12 @@ package netloc
 import (
 	"context"
 	"fmt"
-	"io"
 	"net"
-	"net/http"
 	"regexp"
 	"sort"
-	"syscall"
-	"time"
 
 	"gortc.io/stun"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/ec2metadata"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/horizon/pkg/pb"
 )
 
@@ -24,24 +16,6 @@ import (
 // for the ip in question.
 var LookupURL = "https://ifconfig.co/json"
 
-func ec2MetaClient(endpoint string, timeout time.Duration) (*ec2metadata.EC2Metadata, error) {
-	client := &http.Client{
-		Timeout:   timeout,
-		Transport: cleanhttp.DefaultTransport(),
-	}
-
-	c := aws.NewConfig().WithHTTPClient(client).WithMaxRetries(0)
-	if endpoint != "" {
-		c = c.WithEndpoint(endpoint)
-	}
-
-	session, err := session.NewSession(c)
-	if err != nil {
-		return nil, err
-	}
-	return ec2metadata.New(session, c), nil
-}
-
 var splitRe = regexp.MustCompile(`[\s,]+`)
 
 func forgivingSplit(str string) []string {
@@ -89,25 +63,14 @@ func isPrivateIP(ip net.IP) bool {
 	return false
 }
 
-func v4onlyDial() func(context.Context, string, string) (net.Conn, error) {
-	return (&net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
-		DualStack: false,
-		Control: func(network, address string, c syscall.RawConn) error {
-			if network != "tcp4" {
-				return io.EOF
-			}
-			return nil
-		},
-	}).DialContext
-}
-
 const (
 	stunHost = "stun1.l.google.com"
 	stunPort = "19302"
 )
 
+// gatherIPsViaStun asks the STUN server for our public address over each
+// address family, so that both the IPv4 and IPv6 addresses are found on
+// dual-stack hosts.
 func gatherIPsViaStun(c chan []net.IP) {
 	defer close(c)
 
@@ -116,10 +79,23 @@ func gatherIPsViaStun(c chan []net.IP) {
 		return
 	}
 
-	var mine []net.IP
+	var (
+		mine []net.IP
+		seen = make(map[string]bool)
+	)
 
 	for _, ip := range ips {
-		c, err := stun.Dial("udp", net.JoinHostPort(ip.String(), stunPort))
+		network := "udp6"
+		if ip.To4() != nil {
+			network = "udp4"
+		}
+
+		// One answer per family is enough.
+		if seen[network] {
+			continue
+		}
+
+		c, err := stun.Dial(network, net.JoinHostPort(ip.String(), stunPort))
 		if err != nil {
 			continue
 		}
@@ -149,6 +125,8 @@ func gatherIPsViaStun(c chan []net.IP) {
 			ip = xorAddr.IP
 		})
 
+		c.Close()
+
 		if err != nil {
 			continue
 		}
@@ -157,94 +135,14 @@ func gatherIPsViaStun(c chan []net.IP) {
 			continue
 		}
 
+		seen[network] = true
+
 		mine = append(mine, ip)
 	}
 
 	c <- mine
 }
 
-type ec2Info struct {
-	privateAddrs []string
-	publicAddrs  []string
-	publicAddrs6 []string
-
-	privateLabels []*pb.Label
-	publicLabels  []*pb.Label
-}
-
-func getEC2Labels(c chan *ec2Info) {
-	defer close(c)
-
-	mdc, err := ec2MetaClient("", 2*time.Second)
-	if err != nil {
-		return
-	}
-
-	info := &ec2Info{}
-
-	if mdc.Available() {
-		if ii, err := mdc.GetMetadata("instance-id"); err == nil && ii != "" {
-			info.privateLabels = append(info.privateLabels, &pb.Label{
-				Name:  "instance-id",
-				Value: ii,
-			})
-		}
-
-		if mac, err := mdc.GetMetadata("mac"); err == nil && mac != "" {
-			if si, err := mdc.GetMetadata("network/interfaces/macs/" + mac + "/subnet-id"); err == nil && si != "" {
-				info.privateLabels = append(info.privateLabels, &pb.Label{
-					Name:  "subnet-id",
-					Value: si,
-				})
-			}
-
-			if vi, err := mdc.GetMetadata("network/interfaces/macs/" + mac + "/vpc-id"); err == nil && vi != "" {
-				info.privateLabels = append(info.privateLabels, &pb.Label{
-					Name:  "vpc-id",
-					Value: vi,
-				})
-
-				info.publicLabels = append(info.publicLabels, &pb.Label{
-					Name:  "vpc-id",
-					Value: vi,
-				})
-			}
-
-			ipKeys := []string{
-				"network/interfaces/macs/" + mac + "/local-ipv4s",
-			}
-
-			for _, key := range ipKeys {
-				if addrs, err := mdc.GetMetadata(key); err == nil && addrs != "" {
-					info.privateAddrs = append(info.privateAddrs, forgivingSplit(addrs)...)
-				}
-			}
-
-			ipKeys = []string{
-				"network/interfaces/macs/" + mac + "/public-ipv4s",
-			}
-
-			for _, key := range ipKeys {
-				if addrs, err := mdc.GetMetadata(key); err == nil && addrs != "" {
-					info.publicAddrs = append(info.publicAddrs, forgivingSplit(addrs)...)
-				}
-			}
-
-			ipKeys = []string{
-				"network/interfaces/macs/" + mac + "/ipv6s",
-			}
-
-			for _, key := range ipKeys {
-				if addrs, err := mdc.GetMetadata(key); err == nil && addrs != "" {
-					info.publicAddrs6 = append(info.publicAddrs6, forgivingSplit(addrs)...)
-				}
-			}
-		}
-	}
-
-	c <- info
-}
-
 // Sorts it's input
 func uniq(input []string) []string {
 	sort.Strings(input)
@@ -363,13 +261,32 @@ func FastLocate(defaultLabels *pb.LabelSet) ([]*pb.NetworkLocation, error) {
 		}
 	}
 
+	for _, loc := range netlocs {
+		loc.SetFamilies()
+	}
+
 	return netlocs, nil
 }
 
+// Locate finds the network locations of the host, using the metadata of
+// the cloud the host is detected in.
 func Locate(defaultLabels *pb.LabelSet) ([]*pb.NetworkLocation, error) {
-	ec2InfoC := make(chan *ec2Info, 1)
+	return LocateWith(defaultLabels, DefaultProviders())
+}
 
-	go getEC2Labels(ec2InfoC)
+// LocateWith finds the network locations of the host like Locate, using the
+// metadata of the first of providers that is detected. Its labels are added
+// to the locations and its addresses to those of the host.
+func LocateWith(defaultLabels *pb.LabelSet, providers []Provider) ([]*pb.NetworkLocation, error) {
+	cloudC := make(chan *CloudInfo, 1)
+
+	go func() {
+		defer close(cloudC)
+
+		if info, err := DetectCloud(context.Background(), providers); err == nil {
+			cloudC <- info
+		}
+	}()
 
 	stunIPsC := make(chan []net.IP, 1)
 
@@ -428,14 +345,22 @@ func Locate(defaultLabels *pb.LabelSet) ([]*pb.NetworkLocation, error) {
 		pubLabels6.Labels = append(pubLabels6.Labels, defaultLabels.Labels...)
 	}
 
-	if info, ok := <-ec2InfoC; ok {
-		privateLabels.Labels = append(privateLabels.Labels, info.privateLabels...)
-		pubLabels.Labels = append(pubLabels.Labels, info.publicLabels...)
-		pubLabels6.Labels = append(pubLabels6.Labels, info.publicLabels...)
+	if info, ok := <-cloudC; ok {
+		private, public := info.labels()
+
+		privateLabels.Labels = append(privateLabels.Labels, private...)
+		pubLabels.Labels = append(pubLabels.Labels, public...)
+		pubLabels6.Labels = append(pubLabels6.Labels, public...)
 
-		privateAddrs = append(privateAddrs, info.privateAddrs...)
-		publicAddrs = append(publicAddrs, info.publicAddrs...)
-		publicAddrs6 = append(publicAddrs6, info.publicAddrs...)
+		privateAddrs = append(privateAddrs, info.PrivateAddrs...)
+
+		for _, addr := range info.PublicAddrs {
+			if pb.AddressFamilyOf(addr) == pb.IPV6 {
+				publicAddrs6 = append(publicAddrs6, addr)
+			} else {
+				publicAddrs = append(publicAddrs, addr)
+			}
+		}
 	}
 
 	if ips, ok := <-stunIPsC; ok {
@@ -491,5 +416,9 @@ func Locate(defaultLabels *pb.LabelSet) ([]*pb.NetworkLocation, error) {
 		}
 	}
 
+	for _, loc := range netlocs {
+		loc.SetFamilies()
+	}
+
 	return netlocs, nil
 }
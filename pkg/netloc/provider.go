@@ -0,0 +1,303 @@
+package netloc
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+// ErrNotDetected is returned by a Provider when the host isn't running
+// where the provider looks.
+var ErrNotDetected = errors.New("metadata provider not detected")
+
+// How long to wait for a metadata service to answer. Metadata services are
+// local to the host so they answer quickly when they're there at all.
+var metadataTimeout = 2 * time.Second
+
+// CloudInfo is what a metadata provider knows about where the host is.
+type CloudInfo struct {
+	// The name of the cloud, such as ec2 or gcp.
+	Cloud string
+
+	Region     string
+	Zone       string
+	VPC        string
+	Subnet     string
+	InstanceID string
+
+	PrivateAddrs []string
+
+	// Public addresses of either address family.
+	PublicAddrs []string
+}
+
+func addLabel(labels []*pb.Label, name, value string) []*pb.Label {
+	if value == "" {
+		return labels
+	}
+
+	return append(labels, &pb.Label{Name: name, Value: value})
+}
+
+// labels returns the labels for the private and the public locations of the
+// host. The instance and subnet only identify the host on the private
+// network, so they're left out of the public labels.
+func (ci *CloudInfo) labels() (private, public []*pb.Label) {
+	for _, kv := range [][2]string{
+		{"cloud", ci.Cloud},
+		{"region", ci.Region},
+		{"zone", ci.Zone},
+		{"vpc-id", ci.VPC},
+	} {
+		private = addLabel(private, kv[0], kv[1])
+		public = addLabel(public, kv[0], kv[1])
+	}
+
+	private = addLabel(private, "subnet-id", ci.Subnet)
+	private = addLabel(private, "instance-id", ci.InstanceID)
+
+	return private, public
+}
+
+// Provider reads where the host is from a source of metadata, usually the
+// metadata service of the cloud the host runs in.
+type Provider interface {
+	// The name of the provider, which ParseProviders selects it by.
+	Name() string
+
+	// Detect returns the metadata, or ErrNotDetected if the source isn't
+	// available.
+	Detect(ctx context.Context) (*CloudInfo, error)
+}
+
+// DefaultProviders returns the metadata providers for the clouds that are
+// detected automatically.
+func DefaultProviders() []Provider {
+	return []Provider{
+		&EC2Provider{},
+		&GCPProvider{},
+		&AzureProvider{},
+		&DigitalOceanProvider{},
+	}
+}
+
+// ParseProviders returns the providers named in spec, a comma separated
+// list such as "gcp,ec2". An entry of the form file=<path> reads the
+// metadata from the env file at path (see FileProvider). An empty spec
+// returns DefaultProviders.
+func ParseProviders(spec string) ([]Provider, error) {
+	if strings.TrimSpace(spec) == "" {
+		return DefaultProviders(), nil
+	}
+
+	var out []Provider
+
+	for _, name := range forgivingSplit(strings.TrimSpace(spec)) {
+		if strings.HasPrefix(name, "file=") {
+			out = append(out, &FileProvider{Path: name[len("file="):]})
+			continue
+		}
+
+		var found bool
+
+		for _, p := range DefaultProviders() {
+			if p.Name() == name {
+				out = append(out, p)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, errors.Errorf("unknown metadata provider: %s", name)
+		}
+	}
+
+	return out, nil
+}
+
+// DetectCloud asks all the providers for metadata at once and returns the
+// metadata of the first provider in the list that detected it. It returns
+// ErrNotDetected if none did.
+func DetectCloud(ctx context.Context, providers []Provider) (*CloudInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	infos := make([]chan *CloudInfo, len(providers))
+
+	for i, p := range providers {
+		infos[i] = make(chan *CloudInfo, 1)
+
+		go func(p Provider, c chan *CloudInfo) {
+			defer close(c)
+
+			info, err := p.Detect(ctx)
+			if err == nil {
+				c <- info
+			}
+		}(p, infos[i])
+	}
+
+	for _, c := range infos {
+		if info, ok := <-c; ok {
+			return info, nil
+		}
+	}
+
+	return nil, ErrNotDetected
+}
+
+func metadataClient() *http.Client {
+	return &http.Client{
+		Timeout:   metadataTimeout,
+		Transport: cleanhttp.DefaultTransport(),
+	}
+}
+
+// getMetadata fetches url with the given request headers. Any failure to
+// reach the service or an unsuccessful response is reported as
+// ErrNotDetected, since that's how a missing metadata service shows up.
+func getMetadata(ctx context.Context, client *http.Client, url string, headers map[string]string) (*http.Response, []byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, ErrNotDetected
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, nil, ErrNotDetected
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, data, nil
+}
+
+// addAddr adds addr to the private or public addresses of ci depending on
+// whether it's a private address. Empty and invalid addresses are ignored.
+func (ci *CloudInfo) addAddr(addr string) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return
+	}
+
+	if isPrivateIP(ip) {
+		ci.PrivateAddrs = append(ci.PrivateAddrs, ip.String())
+	} else {
+		ci.PublicAddrs = append(ci.PublicAddrs, ip.String())
+	}
+}
+
+// StaticProvider returns metadata that was configured up front.
+type StaticProvider struct {
+	Info CloudInfo
+}
+
+func (p *StaticProvider) Name() string {
+	return "static"
+}
+
+func (p *StaticProvider) Detect(ctx context.Context) (*CloudInfo, error) {
+	info := p.Info
+	return &info, nil
+}
+
+// FileProvider reads metadata from an env file of KEY=VALUE lines, for
+// hosts where there is no metadata service. The keys are CLOUD, REGION,
+// ZONE, VPC_ID, SUBNET_ID, INSTANCE_ID and ADDRS, which is a comma or space
+// separated list of addresses that are sorted into private and public ones.
+// Blank lines and lines starting with # are ignored, and values can be
+// quoted.
+type FileProvider struct {
+	Path string
+}
+
+func (p *FileProvider) Name() string {
+	return "file"
+}
+
+func (p *FileProvider) Detect(ctx context.Context) (*CloudInfo, error) {
+	f, err := os.Open(p.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotDetected
+		}
+
+		return nil, err
+	}
+
+	defer f.Close()
+
+	info := &CloudInfo{}
+
+	fields := map[string]*string{
+		"CLOUD":       &info.Cloud,
+		"REGION":      &info.Region,
+		"ZONE":        &info.Zone,
+		"VPC_ID":      &info.VPC,
+		"SUBNET_ID":   &info.Subnet,
+		"INSTANCE_ID": &info.InstanceID,
+	}
+
+	scanner := bufio.NewScanner(f)
+
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		idx := strings.IndexByte(line, '=')
+		if idx == -1 {
+			return nil, errors.Errorf("%s:%d: expected KEY=VALUE", p.Path, lineno)
+		}
+
+		key := strings.TrimSpace(line[:idx])
+		val := strings.Trim(strings.TrimSpace(line[idx+1:]), `"'`)
+
+		if key == "ADDRS" {
+			for _, addr := range forgivingSplit(val) {
+				info.addAddr(addr)
+			}
+
+			continue
+		}
+
+		field, ok := fields[key]
+		if !ok {
+			return nil, errors.Errorf("%s:%d: unknown key: %s", p.Path, lineno, key)
+		}
+
+		*field = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
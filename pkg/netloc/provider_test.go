@@ -0,0 +1,237 @@
+package netloc
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetadata serves body at path when the request has the header, like a
+// metadata service does.
+func fakeMetadata(path, header, value, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != path || (header != "" && req.Header.Get(header) != value) {
+			http.NotFound(w, req)
+			return
+		}
+
+		if header == "Metadata-Flavor" {
+			w.Header().Set(header, value)
+		}
+
+		w.Write([]byte(body))
+	}))
+}
+
+func TestProviders(t *testing.T) {
+	t.Run("reads gcp metadata", func(t *testing.T) {
+		serv := fakeMetadata("/computeMetadata/v1/instance/", "Metadata-Flavor", "Google", `{
+			"id": 4520031799277581759,
+			"zone": "projects/123456/zones/us-central1-a",
+			"networkInterfaces": [{
+				"ip": "10.128.0.2",
+				"network": "projects/123456/networks/default",
+				"subnetwork": "projects/123456/regions/us-central1/subnetworks/default",
+				"ipv6s": ["2600:1900:4000:1::"],
+				"accessConfigs": [{"externalIp": "203.0.113.10"}]
+			}]
+		}`)
+		defer serv.Close()
+
+		info, err := (&GCPProvider{Endpoint: serv.URL}).Detect(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, &CloudInfo{
+			Cloud:        "gcp",
+			Region:       "us-central1",
+			Zone:         "us-central1-a",
+			VPC:          "projects/123456/networks/default",
+			Subnet:       "projects/123456/regions/us-central1/subnetworks/default",
+			InstanceID:   "4520031799277581759",
+			PrivateAddrs: []string{"10.128.0.2"},
+			PublicAddrs:  []string{"2600:1900:4000:1::", "203.0.113.10"},
+		}, info)
+	})
+
+	t.Run("reads azure metadata", func(t *testing.T) {
+		serv := fakeMetadata("/metadata/instance", "Metadata", "true", `{
+			"compute": {
+				"location": "westus2",
+				"zone": "1",
+				"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6"
+			},
+			"network": {
+				"interface": [{
+					"ipv4": {
+						"ipAddress": [{"privateIpAddress": "10.144.133.132", "publicIpAddress": "203.0.113.20"}],
+						"subnet": [{"address": "10.144.133.128", "prefix": "26"}]
+					},
+					"ipv6": {"ipAddress": []}
+				}]
+			}
+		}`)
+		defer serv.Close()
+
+		info, err := (&AzureProvider{Endpoint: serv.URL}).Detect(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, &CloudInfo{
+			Cloud:        "azure",
+			Region:       "westus2",
+			Zone:         "westus2-1",
+			Subnet:       "10.144.133.128/26",
+			InstanceID:   "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			PrivateAddrs: []string{"10.144.133.132"},
+			PublicAddrs:  []string{"203.0.113.20"},
+		}, info)
+	})
+
+	t.Run("reads digitalocean metadata", func(t *testing.T) {
+		serv := fakeMetadata("/metadata/v1.json", "", "", `{
+			"droplet_id": 2756294,
+			"region": "nyc3",
+			"interfaces": {
+				"public": [{
+					"ipv4": {"ip_address": "203.0.113.30"},
+					"ipv6": {"ip_address": "2604:a880:800:10::1"}
+				}],
+				"private": [{
+					"ipv4": {"ip_address": "10.132.255.113"}
+				}]
+			}
+		}`)
+		defer serv.Close()
+
+		info, err := (&DigitalOceanProvider{Endpoint: serv.URL}).Detect(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, &CloudInfo{
+			Cloud:        "digitalocean",
+			Region:       "nyc3",
+			InstanceID:   "2756294",
+			PrivateAddrs: []string{"10.132.255.113"},
+			PublicAddrs:  []string{"203.0.113.30", "2604:a880:800:10::1"},
+		}, info)
+	})
+
+	t.Run("reads an env file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "netloc")
+		require.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "location.env")
+
+		err = ioutil.WriteFile(path, []byte(`
+# Where this hub runs.
+CLOUD=onprem
+REGION="dc1"
+ZONE=dc1-row4
+ADDRS=10.0.0.5, 198.51.100.5
+`), 0644)
+		require.NoError(t, err)
+
+		info, err := (&FileProvider{Path: path}).Detect(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, &CloudInfo{
+			Cloud:        "onprem",
+			Region:       "dc1",
+			Zone:         "dc1-row4",
+			PrivateAddrs: []string{"10.0.0.5"},
+			PublicAddrs:  []string{"198.51.100.5"},
+		}, info)
+
+		_, err = (&FileProvider{Path: filepath.Join(dir, "missing.env")}).Detect(context.Background())
+		assert.Equal(t, ErrNotDetected, err)
+	})
+
+	t.Run("isn't detected where the metadata service isn't", func(t *testing.T) {
+		// Answers like a metadata service of another cloud.
+		serv := fakeMetadata("/latest/meta-data/instance-id", "", "", "i-1234")
+		defer serv.Close()
+
+		for _, p := range []Provider{
+			&GCPProvider{Endpoint: serv.URL},
+			&AzureProvider{Endpoint: serv.URL},
+			&DigitalOceanProvider{Endpoint: serv.URL},
+		} {
+			_, err := p.Detect(context.Background())
+			assert.Equal(t, ErrNotDetected, err, p.Name())
+		}
+	})
+
+	t.Run("uses the first provider that is detected", func(t *testing.T) {
+		serv := fakeMetadata("/metadata/v1.json", "", "", `{"droplet_id": 1, "region": "ams3"}`)
+		defer serv.Close()
+
+		info, err := DetectCloud(context.Background(), []Provider{
+			&FileProvider{Path: "/nonexistent/location.env"},
+			&DigitalOceanProvider{Endpoint: serv.URL},
+			&StaticProvider{Info: CloudInfo{Cloud: "static"}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "digitalocean", info.Cloud)
+
+		_, err = DetectCloud(context.Background(), []Provider{
+			&FileProvider{Path: "/nonexistent/location.env"},
+		})
+		assert.Equal(t, ErrNotDetected, err)
+	})
+
+	t.Run("selects providers by name", func(t *testing.T) {
+		providers, err := ParseProviders("gcp, file=/etc/hzn/location.env")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Provider{
+			&GCPProvider{},
+			&FileProvider{Path: "/etc/hzn/location.env"},
+		}, providers)
+
+		providers, err = ParseProviders("")
+		require.NoError(t, err)
+
+		assert.Equal(t, DefaultProviders(), providers)
+
+		_, err = ParseProviders("gcp,openstack")
+		assert.Error(t, err)
+	})
+
+	t.Run("labels the private and public locations", func(t *testing.T) {
+		info := &CloudInfo{
+			Cloud:      "ec2",
+			Region:     "us-west-2",
+			Zone:       "us-west-2a",
+			VPC:        "vpc-1",
+			Subnet:     "subnet-1",
+			InstanceID: "i-1",
+		}
+
+		private, public := info.labels()
+
+		set := func(labels []*pb.Label) *pb.LabelSet {
+			ls := &pb.LabelSet{Labels: labels}
+			ls.Finalize()
+			return ls
+		}
+
+		assert.Equal(t,
+			pb.ParseLabelSet("cloud=ec2,region=us-west-2,zone=us-west-2a,vpc-id=vpc-1,subnet-id=subnet-1,instance-id=i-1"),
+			set(private),
+		)
+
+		assert.Equal(t,
+			pb.ParseLabelSet("cloud=ec2,region=us-west-2,zone=us-west-2a,vpc-id=vpc-1"),
+			set(public),
+		)
+	})
+}
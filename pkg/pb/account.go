@@ -63,17 +63,22 @@ func AccountFromKey(k []byte) (*Account, error) {
 	}, nil
 }
 
+// AccountFromStringKey parses the form returned by StringKey.
 func AccountFromStringKey(k []byte) (*Account, error) {
-	pos := bytes.IndexRune(k, '!')
+	pos := bytes.LastIndexByte(k, '!')
 	if pos == -1 {
 		return nil, ErrInvalidAccount
 	}
 
 	namespace := k[:pos]
-	id := k[pos+1:]
+
+	id, err := ParseULID(string(k[pos+1:]))
+	if err != nil {
+		return nil, ErrInvalidAccount
+	}
 
 	return &Account{
 		Namespace: string(namespace),
-		AccountId: ULIDFromBytes(id),
+		AccountId: id,
 	}, nil
 }
@@ -0,0 +1,29 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount(t *testing.T) {
+	t.Run("parses the string key it returns", func(t *testing.T) {
+		acc := &Account{
+			Namespace: "/foo!bar",
+			AccountId: NewULID(),
+		}
+
+		out, err := AccountFromStringKey([]byte(acc.StringKey()))
+		require.NoError(t, err)
+
+		assert.Equal(t, acc.Namespace, out.Namespace)
+		assert.Equal(t, acc.AccountId.String(), out.AccountId.String())
+
+		_, err = AccountFromStringKey([]byte("/foo"))
+		assert.Equal(t, ErrInvalidAccount, err)
+
+		_, err = AccountFromStringKey([]byte("/foo!nope"))
+		assert.Equal(t, ErrInvalidAccount, err)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
+	strconv "strconv"
 	strings "strings"
 )
 
@@ -30,6 +31,36 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type ServiceEvent_Type int32
+
+const (
+	UNKNOWN_EVENT      ServiceEvent_Type = 0
+	SERVICE_ADDED      ServiceEvent_Type = 1
+	SERVICE_REMOVED    ServiceEvent_Type = 2
+	LABEL_LINK_ADDED   ServiceEvent_Type = 3
+	LABEL_LINK_REMOVED ServiceEvent_Type = 4
+)
+
+var ServiceEvent_Type_name = map[int32]string{
+	0: "UNKNOWN_EVENT",
+	1: "SERVICE_ADDED",
+	2: "SERVICE_REMOVED",
+	3: "LABEL_LINK_ADDED",
+	4: "LABEL_LINK_REMOVED",
+}
+
+var ServiceEvent_Type_value = map[string]int32{
+	"UNKNOWN_EVENT":      0,
+	"SERVICE_ADDED":      1,
+	"SERVICE_REMOVED":    2,
+	"LABEL_LINK_ADDED":   3,
+	"LABEL_LINK_REMOVED": 4,
+}
+
+func (ServiceEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{37, 0}
+}
+
 type ServiceRequest struct {
 	Account  *Account  `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	Hub      *ULID     `protobuf:"bytes,2,opt,name=hub,proto3" json:"hub,omitempty"`
@@ -259,10 +290,11 @@ func (m *LabelLinks) GetLabelLinks() []*LabelLink {
 }
 
 type ServiceRoute struct {
-	Hub    *ULID     `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
-	Id     *ULID     `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Type   string    `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Labels *LabelSet `protobuf:"bytes,4,opt,name=labels,proto3" json:"labels,omitempty"`
+	Hub      *ULID     `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
+	Id       *ULID     `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type     string    `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Labels   *LabelSet `protobuf:"bytes,4,opt,name=labels,proto3" json:"labels,omitempty"`
+	Metadata []*KVPair `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *ServiceRoute) Reset()      { *m = ServiceRoute{} }
@@ -325,6 +357,13 @@ func (m *ServiceRoute) GetLabels() *LabelSet {
 	return nil
 }
 
+func (m *ServiceRoute) GetMetadata() []*KVPair {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type AccountServices struct {
 	Account  *Account        `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	Services []*ServiceRoute `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
@@ -377,8 +416,12 @@ func (m *AccountServices) GetServices() []*ServiceRoute {
 }
 
 type ActivityEntry struct {
-	RouteAdded   *AccountServices `protobuf:"bytes,1,opt,name=route_added,json=routeAdded,proto3" json:"route_added,omitempty"`
-	RouteRemoved *ULID            `protobuf:"bytes,2,opt,name=route_removed,json=routeRemoved,proto3" json:"route_removed,omitempty"`
+	RouteAdded       *AccountServices `protobuf:"bytes,1,opt,name=route_added,json=routeAdded,proto3" json:"route_added,omitempty"`
+	RouteRemoved     *ULID            `protobuf:"bytes,2,opt,name=route_removed,json=routeRemoved,proto3" json:"route_removed,omitempty"`
+	ServiceAdded     *ServiceChange   `protobuf:"bytes,3,opt,name=service_added,json=serviceAdded,proto3" json:"service_added,omitempty"`
+	ServiceRemoved   *ServiceChange   `protobuf:"bytes,4,opt,name=service_removed,json=serviceRemoved,proto3" json:"service_removed,omitempty"`
+	LabelLinkAdded   *LabelLink       `protobuf:"bytes,5,opt,name=label_link_added,json=labelLinkAdded,proto3" json:"label_link_added,omitempty"`
+	LabelLinkRemoved *LabelLink       `protobuf:"bytes,6,opt,name=label_link_removed,json=labelLinkRemoved,proto3" json:"label_link_removed,omitempty"`
 }
 
 func (m *ActivityEntry) Reset()      { *m = ActivityEntry{} }
@@ -427,6 +470,85 @@ func (m *ActivityEntry) GetRouteRemoved() *ULID {
 	return nil
 }
 
+func (m *ActivityEntry) GetServiceAdded() *ServiceChange {
+	if m != nil {
+		return m.ServiceAdded
+	}
+	return nil
+}
+
+func (m *ActivityEntry) GetServiceRemoved() *ServiceChange {
+	if m != nil {
+		return m.ServiceRemoved
+	}
+	return nil
+}
+
+func (m *ActivityEntry) GetLabelLinkAdded() *LabelLink {
+	if m != nil {
+		return m.LabelLinkAdded
+	}
+	return nil
+}
+
+func (m *ActivityEntry) GetLabelLinkRemoved() *LabelLink {
+	if m != nil {
+		return m.LabelLinkRemoved
+	}
+	return nil
+}
+
+type ServiceChange struct {
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Service *Service `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (m *ServiceChange) Reset()      { *m = ServiceChange{} }
+func (*ServiceChange) ProtoMessage() {}
+func (*ServiceChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{7}
+}
+func (m *ServiceChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServiceChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServiceChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ServiceChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceChange.Merge(m, src)
+}
+func (m *ServiceChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServiceChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceChange proto.InternalMessageInfo
+
+func (m *ServiceChange) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *ServiceChange) GetService() *Service {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
 type ConfigRequest struct {
 	StableId   *ULID              `protobuf:"bytes,1,opt,name=stable_id,json=stableId,proto3" json:"stable_id,omitempty"`
 	InstanceId *ULID              `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
@@ -436,7 +558,7 @@ type ConfigRequest struct {
 func (m *ConfigRequest) Reset()      { *m = ConfigRequest{} }
 func (*ConfigRequest) ProtoMessage() {}
 func (*ConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{7}
+	return fileDescriptor_0c5120591600887d, []int{8}
 }
 func (m *ConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -499,7 +621,7 @@ type ConfigResponse struct {
 func (m *ConfigResponse) Reset()      { *m = ConfigResponse{} }
 func (*ConfigResponse) ProtoMessage() {}
 func (*ConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{8}
+	return fileDescriptor_0c5120591600887d, []int{9}
 }
 func (m *ConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -585,7 +707,7 @@ type HubChange struct {
 func (m *HubChange) Reset()      { *m = HubChange{} }
 func (*HubChange) ProtoMessage() {}
 func (*HubChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{9}
+	return fileDescriptor_0c5120591600887d, []int{10}
 }
 func (m *HubChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -638,7 +760,7 @@ type CentralActivity struct {
 func (m *CentralActivity) Reset()      { *m = CentralActivity{} }
 func (*CentralActivity) ProtoMessage() {}
 func (*CentralActivity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{10}
+	return fileDescriptor_0c5120591600887d, []int{11}
 }
 func (m *CentralActivity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -705,7 +827,7 @@ type HubActivity struct {
 func (m *HubActivity) Reset()      { *m = HubActivity{} }
 func (*HubActivity) ProtoMessage() {}
 func (*HubActivity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{11}
+	return fileDescriptor_0c5120591600887d, []int{12}
 }
 func (m *HubActivity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -771,7 +893,7 @@ type HubActivity_HubRegistration struct {
 func (m *HubActivity_HubRegistration) Reset()      { *m = HubActivity_HubRegistration{} }
 func (*HubActivity_HubRegistration) ProtoMessage() {}
 func (*HubActivity_HubRegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{11, 0}
+	return fileDescriptor_0c5120591600887d, []int{12, 0}
 }
 func (m *HubActivity_HubRegistration) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -833,7 +955,7 @@ type HubActivity_HubStats struct {
 func (m *HubActivity_HubStats) Reset()      { *m = HubActivity_HubStats{} }
 func (*HubActivity_HubStats) ProtoMessage() {}
 func (*HubActivity_HubStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{11, 1}
+	return fileDescriptor_0c5120591600887d, []int{12, 1}
 }
 func (m *HubActivity_HubStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -912,7 +1034,7 @@ type HubInfo struct {
 func (m *HubInfo) Reset()      { *m = HubInfo{} }
 func (*HubInfo) ProtoMessage() {}
 func (*HubInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{12}
+	return fileDescriptor_0c5120591600887d, []int{13}
 }
 func (m *HubInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -962,7 +1084,7 @@ type ListOfHubs struct {
 func (m *ListOfHubs) Reset()      { *m = ListOfHubs{} }
 func (*ListOfHubs) ProtoMessage() {}
 func (*ListOfHubs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{13}
+	return fileDescriptor_0c5120591600887d, []int{14}
 }
 func (m *ListOfHubs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1007,7 +1129,7 @@ type HubSync struct {
 func (m *HubSync) Reset()      { *m = HubSync{} }
 func (*HubSync) ProtoMessage() {}
 func (*HubSync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{14}
+	return fileDescriptor_0c5120591600887d, []int{15}
 }
 func (m *HubSync) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1064,7 +1186,7 @@ type HubSyncResponse struct {
 func (m *HubSyncResponse) Reset()      { *m = HubSyncResponse{} }
 func (*HubSyncResponse) ProtoMessage() {}
 func (*HubSyncResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{15}
+	return fileDescriptor_0c5120591600887d, []int{16}
 }
 func (m *HubSyncResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1109,7 +1231,7 @@ type HubRegisterRequest struct {
 func (m *HubRegisterRequest) Reset()      { *m = HubRegisterRequest{} }
 func (*HubRegisterRequest) ProtoMessage() {}
 func (*HubRegisterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{16}
+	return fileDescriptor_0c5120591600887d, []int{17}
 }
 func (m *HubRegisterRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1166,7 +1288,7 @@ type HubRegisterResponse struct {
 func (m *HubRegisterResponse) Reset()      { *m = HubRegisterResponse{} }
 func (*HubRegisterResponse) ProtoMessage() {}
 func (*HubRegisterResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{17}
+	return fileDescriptor_0c5120591600887d, []int{18}
 }
 func (m *HubRegisterResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1210,7 +1332,7 @@ type HubDisconnectRequest struct {
 func (m *HubDisconnectRequest) Reset()      { *m = HubDisconnectRequest{} }
 func (*HubDisconnectRequest) ProtoMessage() {}
 func (*HubDisconnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{18}
+	return fileDescriptor_0c5120591600887d, []int{19}
 }
 func (m *HubDisconnectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1260,7 +1382,7 @@ type ServiceTokenRequest struct {
 func (m *ServiceTokenRequest) Reset()      { *m = ServiceTokenRequest{} }
 func (*ServiceTokenRequest) ProtoMessage() {}
 func (*ServiceTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{19}
+	return fileDescriptor_0c5120591600887d, []int{20}
 }
 func (m *ServiceTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1303,7 +1425,7 @@ type ServiceTokenResponse struct {
 func (m *ServiceTokenResponse) Reset()      { *m = ServiceTokenResponse{} }
 func (*ServiceTokenResponse) ProtoMessage() {}
 func (*ServiceTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{20}
+	return fileDescriptor_0c5120591600887d, []int{21}
 }
 func (m *ServiceTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1346,7 +1468,7 @@ type ListServicesRequest struct {
 func (m *ListServicesRequest) Reset()      { *m = ListServicesRequest{} }
 func (*ListServicesRequest) ProtoMessage() {}
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{21}
+	return fileDescriptor_0c5120591600887d, []int{22}
 }
 func (m *ListServicesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1389,7 +1511,7 @@ type ListServicesResponse struct {
 func (m *ListServicesResponse) Reset()      { *m = ListServicesResponse{} }
 func (*ListServicesResponse) ProtoMessage() {}
 func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{22}
+	return fileDescriptor_0c5120591600887d, []int{23}
 }
 func (m *ListServicesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1436,7 +1558,7 @@ type Service struct {
 func (m *Service) Reset()      { *m = Service{} }
 func (*Service) ProtoMessage() {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{23}
+	return fileDescriptor_0c5120591600887d, []int{24}
 }
 func (m *Service) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1508,7 +1630,7 @@ type AddAccountRequest struct {
 func (m *AddAccountRequest) Reset()      { *m = AddAccountRequest{} }
 func (*AddAccountRequest) ProtoMessage() {}
 func (*AddAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{24}
+	return fileDescriptor_0c5120591600887d, []int{25}
 }
 func (m *AddAccountRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1560,7 +1682,7 @@ type AddLabelLinkRequest struct {
 func (m *AddLabelLinkRequest) Reset()      { *m = AddLabelLinkRequest{} }
 func (*AddLabelLinkRequest) ProtoMessage() {}
 func (*AddLabelLinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{25}
+	return fileDescriptor_0c5120591600887d, []int{26}
 }
 func (m *AddLabelLinkRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1616,7 +1738,7 @@ type Noop struct {
 func (m *Noop) Reset()      { *m = Noop{} }
 func (*Noop) ProtoMessage() {}
 func (*Noop) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{26}
+	return fileDescriptor_0c5120591600887d, []int{27}
 }
 func (m *Noop) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1653,7 +1775,7 @@ type RemoveLabelLinkRequest struct {
 func (m *RemoveLabelLinkRequest) Reset()      { *m = RemoveLabelLinkRequest{} }
 func (*RemoveLabelLinkRequest) ProtoMessage() {}
 func (*RemoveLabelLinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{27}
+	return fileDescriptor_0c5120591600887d, []int{28}
 }
 func (m *RemoveLabelLinkRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1705,7 +1827,7 @@ type CreateTokenRequest struct {
 func (m *CreateTokenRequest) Reset()      { *m = CreateTokenRequest{} }
 func (*CreateTokenRequest) ProtoMessage() {}
 func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{28}
+	return fileDescriptor_0c5120591600887d, []int{29}
 }
 func (m *CreateTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1762,7 +1884,7 @@ type CreateTokenResponse struct {
 func (m *CreateTokenResponse) Reset()      { *m = CreateTokenResponse{} }
 func (*CreateTokenResponse) ProtoMessage() {}
 func (*CreateTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{29}
+	return fileDescriptor_0c5120591600887d, []int{30}
 }
 func (m *CreateTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1805,7 +1927,7 @@ type ControlRegister struct {
 func (m *ControlRegister) Reset()      { *m = ControlRegister{} }
 func (*ControlRegister) ProtoMessage() {}
 func (*ControlRegister) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{30}
+	return fileDescriptor_0c5120591600887d, []int{31}
 }
 func (m *ControlRegister) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1848,7 +1970,7 @@ type ControlToken struct {
 func (m *ControlToken) Reset()      { *m = ControlToken{} }
 func (*ControlToken) ProtoMessage() {}
 func (*ControlToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{31}
+	return fileDescriptor_0c5120591600887d, []int{32}
 }
 func (m *ControlToken) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1891,7 +2013,7 @@ type TokenInfo struct {
 func (m *TokenInfo) Reset()      { *m = TokenInfo{} }
 func (*TokenInfo) ProtoMessage() {}
 func (*TokenInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{32}
+	return fileDescriptor_0c5120591600887d, []int{33}
 }
 func (m *TokenInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1935,7 +2057,7 @@ type ListAccountsRequest struct {
 func (m *ListAccountsRequest) Reset()      { *m = ListAccountsRequest{} }
 func (*ListAccountsRequest) ProtoMessage() {}
 func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{33}
+	return fileDescriptor_0c5120591600887d, []int{34}
 }
 func (m *ListAccountsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1986,7 +2108,7 @@ type ListAccountsResponse struct {
 func (m *ListAccountsResponse) Reset()      { *m = ListAccountsResponse{} }
 func (*ListAccountsResponse) ProtoMessage() {}
 func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{34}
+	return fileDescriptor_0c5120591600887d, []int{35}
 }
 func (m *ListAccountsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2029,432 +2151,867 @@ func (m *ListAccountsResponse) GetNextMarker() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ServiceRequest)(nil), "pb.ServiceRequest")
-	proto.RegisterType((*ServiceResponse)(nil), "pb.ServiceResponse")
-	proto.RegisterType((*LabelLink)(nil), "pb.LabelLink")
-	proto.RegisterType((*LabelLinks)(nil), "pb.LabelLinks")
-	proto.RegisterType((*ServiceRoute)(nil), "pb.ServiceRoute")
-	proto.RegisterType((*AccountServices)(nil), "pb.AccountServices")
-	proto.RegisterType((*ActivityEntry)(nil), "pb.ActivityEntry")
-	proto.RegisterType((*ConfigRequest)(nil), "pb.ConfigRequest")
-	proto.RegisterType((*ConfigResponse)(nil), "pb.ConfigResponse")
-	proto.RegisterType((*HubChange)(nil), "pb.HubChange")
-	proto.RegisterType((*CentralActivity)(nil), "pb.CentralActivity")
-	proto.RegisterType((*HubActivity)(nil), "pb.HubActivity")
-	proto.RegisterType((*HubActivity_HubRegistration)(nil), "pb.HubActivity.HubRegistration")
-	proto.RegisterType((*HubActivity_HubStats)(nil), "pb.HubActivity.HubStats")
-	proto.RegisterType((*HubInfo)(nil), "pb.HubInfo")
-	proto.RegisterType((*ListOfHubs)(nil), "pb.ListOfHubs")
-	proto.RegisterType((*HubSync)(nil), "pb.HubSync")
-	proto.RegisterType((*HubSyncResponse)(nil), "pb.HubSyncResponse")
-	proto.RegisterType((*HubRegisterRequest)(nil), "pb.HubRegisterRequest")
-	proto.RegisterType((*HubRegisterResponse)(nil), "pb.HubRegisterResponse")
-	proto.RegisterType((*HubDisconnectRequest)(nil), "pb.HubDisconnectRequest")
-	proto.RegisterType((*ServiceTokenRequest)(nil), "pb.ServiceTokenRequest")
-	proto.RegisterType((*ServiceTokenResponse)(nil), "pb.ServiceTokenResponse")
-	proto.RegisterType((*ListServicesRequest)(nil), "pb.ListServicesRequest")
-	proto.RegisterType((*ListServicesResponse)(nil), "pb.ListServicesResponse")
-	proto.RegisterType((*Service)(nil), "pb.Service")
-	proto.RegisterType((*AddAccountRequest)(nil), "pb.AddAccountRequest")
-	proto.RegisterType((*AddLabelLinkRequest)(nil), "pb.AddLabelLinkRequest")
-	proto.RegisterType((*Noop)(nil), "pb.Noop")
-	proto.RegisterType((*RemoveLabelLinkRequest)(nil), "pb.RemoveLabelLinkRequest")
-	proto.RegisterType((*CreateTokenRequest)(nil), "pb.CreateTokenRequest")
-	proto.RegisterType((*CreateTokenResponse)(nil), "pb.CreateTokenResponse")
-	proto.RegisterType((*ControlRegister)(nil), "pb.ControlRegister")
-	proto.RegisterType((*ControlToken)(nil), "pb.ControlToken")
-	proto.RegisterType((*TokenInfo)(nil), "pb.TokenInfo")
-	proto.RegisterType((*ListAccountsRequest)(nil), "pb.ListAccountsRequest")
-	proto.RegisterType((*ListAccountsResponse)(nil), "pb.ListAccountsResponse")
+type WatchServicesRequest struct {
+	// The resume_token of the last event seen, to receive the events after
+	// it. When empty, only events from now on are sent. If those events are
+	// no longer retained, the call fails with OUT_OF_RANGE and the services
+	// need to be listed again.
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
-func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
-
-var fileDescriptor_0c5120591600887d = []byte{
-	// 1880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcd, 0x93, 0x1b, 0x47,
-	0x15, 0xd7, 0x48, 0x2b, 0xad, 0xf4, 0x24, 0xad, 0xbc, 0xad, 0x8d, 0x2d, 0x14, 0xd0, 0x9a, 0x8e,
-	0x89, 0x4d, 0x62, 0xaf, 0xc3, 0xae, 0x31, 0x1f, 0x65, 0x3e, 0x64, 0x99, 0x64, 0x17, 0xaf, 0x43,
-	0x6a, 0xd6, 0xc9, 0x75, 0xe8, 0x99, 0xe9, 0x95, 0xa6, 0x76, 0x34, 0x23, 0xa6, 0x7b, 0x76, 0x11,
-	0x07, 0x8a, 0xe2, 0x04, 0x37, 0x0e, 0x5c, 0xe0, 0xc6, 0x8d, 0xe2, 0x94, 0x3f, 0x23, 0x37, 0x7c,
-	0xa2, 0x72, 0xa0, 0x28, 0xbc, 0xbe, 0x70, 0xcc, 0x9f, 0x40, 0xf5, 0xc7, 0x7c, 0x69, 0x65, 0xd9,
-	0x71, 0x95, 0xab, 0x72, 0x53, 0xbf, 0xf7, 0x7b, 0xaf, 0xdf, 0x7b, 0xfd, 0xbe, 0x46, 0xd0, 0x76,
-	0xc2, 0x80, 0x47, 0xa1, 0xbf, 0x33, 0x8b, 0x42, 0x1e, 0xa2, 0xf2, 0xcc, 0xee, 0x77, 0x5c, 0x7a,
-	0xcc, 0x6e, 0x8f, 0xc3, 0x71, 0xa8, 0x88, 0xfd, 0xfa, 0xc9, 0xa9, 0xfe, 0xd5, 0xf4, 0x89, 0x4d,
-	0x35, 0xb6, 0xdf, 0x26, 0x8e, 0x13, 0xc6, 0x01, 0xd7, 0x47, 0x88, 0x7d, 0xcf, 0x4d, 0x70, 0x3c,
-	0x3c, 0xa1, 0x81, 0x3e, 0x74, 0xb8, 0x37, 0xa5, 0x8c, 0x93, 0xe9, 0x2c, 0x41, 0x1e, 0xfb, 0xe1,
-	0x59, 0xa2, 0x24, 0xa0, 0xfc, 0x2c, 0x8c, 0x4e, 0xd4, 0x11, 0xff, 0xd3, 0x80, 0x8d, 0x23, 0x1a,
-	0x9d, 0x7a, 0x0e, 0x35, 0xe9, 0xaf, 0x62, 0xca, 0x38, 0xfa, 0x16, 0xac, 0xeb, 0x8b, 0x7a, 0xc6,
-	0x55, 0xe3, 0x46, 0x73, 0xb7, 0xb9, 0x33, 0xb3, 0x77, 0x86, 0x8a, 0x64, 0x26, 0x3c, 0xd4, 0x87,
-	0xca, 0x24, 0xb6, 0x7b, 0x65, 0x09, 0xa9, 0x0b, 0xc8, 0xc7, 0x87, 0x07, 0x0f, 0x4c, 0x41, 0x44,
-	0x3d, 0x28, 0x7b, 0x6e, 0xaf, 0xb2, 0xc0, 0x2a, 0x7b, 0x2e, 0x42, 0xb0, 0xc6, 0xe7, 0x33, 0xda,
-	0x5b, 0xbb, 0x6a, 0xdc, 0x68, 0x98, 0xf2, 0x37, 0xba, 0x06, 0x35, 0xe9, 0x26, 0xeb, 0x55, 0xa5,
-	0x44, 0x4b, 0x48, 0x1c, 0x0a, 0xca, 0x11, 0xe5, 0xa6, 0xe6, 0xa1, 0xb7, 0xa1, 0x3e, 0xa5, 0x9c,
-	0xb8, 0x84, 0x93, 0x5e, 0xed, 0x6a, 0xe5, 0x46, 0x73, 0x17, 0x04, 0xee, 0xe1, 0x27, 0x1f, 0x11,
-	0x2f, 0x32, 0x53, 0x1e, 0xde, 0x84, 0x4e, 0xea, 0x10, 0x9b, 0x85, 0x01, 0xa3, 0xf8, 0x1f, 0x06,
-	0x34, 0xa4, 0xbe, 0x43, 0x2f, 0x38, 0x79, 0x59, 0xff, 0x32, 0xab, 0xca, 0x2b, 0xac, 0xba, 0x06,
-	0x35, 0x4e, 0xa2, 0x31, 0xe5, 0xda, 0xdb, 0x05, 0x94, 0xe2, 0xa1, 0x77, 0xa0, 0xe6, 0x7b, 0x53,
-	0x8f, 0x33, 0xe9, 0x77, 0x73, 0x17, 0xe5, 0x6e, 0xdc, 0x39, 0x94, 0x1c, 0x53, 0x23, 0xf0, 0x3d,
-	0x80, 0xd4, 0x56, 0x86, 0x76, 0x40, 0xa5, 0x80, 0xe5, 0x8b, 0x63, 0xcf, 0x90, 0x8e, 0xb7, 0xd3,
-	0x4b, 0x04, 0xc8, 0x04, 0x3f, 0xc5, 0xe3, 0xdf, 0x42, 0x2b, 0xf1, 0x3e, 0x8c, 0x39, 0x4d, 0x5e,
-	0xc9, 0x78, 0xfe, 0x2b, 0x95, 0x57, 0xbc, 0x52, 0x65, 0xe9, 0x2b, 0xad, 0x3d, 0x3f, 0x1e, 0xf8,
-	0x18, 0x3a, 0xda, 0x2f, 0x6d, 0x06, 0x7b, 0xd9, 0x78, 0xdf, 0x84, 0x3a, 0xd3, 0x22, 0xbd, 0xb2,
-	0x74, 0xf3, 0x92, 0xc0, 0xe5, 0xbd, 0x31, 0x53, 0x04, 0xe6, 0xd0, 0x1e, 0x3a, 0xdc, 0x3b, 0xf5,
-	0xf8, 0xfc, 0x67, 0x01, 0x8f, 0xe6, 0xe8, 0x0e, 0x34, 0x23, 0x81, 0xb1, 0x88, 0xeb, 0x52, 0x57,
-	0xdf, 0xd4, 0xcd, 0xdd, 0x94, 0xd8, 0x63, 0x82, 0xc4, 0x0d, 0x05, 0x0c, 0xdd, 0x82, 0xb6, 0x92,
-	0x8a, 0xe8, 0x34, 0x3c, 0xa5, 0x17, 0xa3, 0xd1, 0x92, 0x6c, 0x53, 0x71, 0xf1, 0x9f, 0x0d, 0x68,
-	0x8f, 0xc2, 0xe0, 0xd8, 0x1b, 0x67, 0xc5, 0xd2, 0x60, 0x9c, 0xd8, 0x3e, 0xb5, 0x3c, 0xf7, 0x42,
-	0x94, 0xeb, 0x8a, 0x75, 0xe0, 0xa2, 0x6f, 0x43, 0xd3, 0x0b, 0x18, 0x27, 0x81, 0x23, 0x81, 0x8b,
-	0xb7, 0x40, 0xc2, 0x3c, 0x70, 0xd1, 0x77, 0xa0, 0xe1, 0x87, 0x0e, 0xe1, 0x5e, 0x18, 0xb0, 0x5e,
-	0x45, 0x06, 0x42, 0xba, 0xf1, 0xa1, 0xaa, 0xdb, 0x43, 0xcd, 0x33, 0x33, 0x14, 0x7e, 0x66, 0xc0,
-	0x46, 0x62, 0x96, 0x4a, 0x79, 0x74, 0x05, 0xd6, 0xb9, 0xcf, 0xac, 0x13, 0x3a, 0x97, 0x56, 0xb5,
-	0xcc, 0x1a, 0xf7, 0xd9, 0x43, 0x3a, 0x47, 0x5f, 0x83, 0xba, 0x60, 0x38, 0x34, 0xe2, 0xd2, 0x8c,
-	0x96, 0x29, 0x80, 0x23, 0x1a, 0x71, 0xf4, 0x26, 0x34, 0x64, 0x1b, 0xb1, 0x66, 0xb1, 0x2d, 0x9f,
-	0xbe, 0x65, 0xd6, 0x25, 0xe1, 0xa3, 0xd8, 0x46, 0x18, 0xda, 0x6c, 0xcf, 0x22, 0x8e, 0x43, 0x99,
-	0x52, 0xab, 0x2a, 0xb8, 0xc9, 0xf6, 0x86, 0x92, 0x26, 0x74, 0x2b, 0x0c, 0xa3, 0x4e, 0x44, 0xb9,
-	0xc4, 0x54, 0x13, 0xcc, 0x91, 0xa4, 0x09, 0xcc, 0x9b, 0xd0, 0x60, 0x7b, 0x96, 0x1d, 0x3b, 0x27,
-	0x94, 0xf7, 0x6a, 0x92, 0x5f, 0x67, 0x7b, 0xf7, 0xe5, 0x59, 0x30, 0xbd, 0x29, 0x19, 0x53, 0x8b,
-	0x93, 0x71, 0x6f, 0x5d, 0x31, 0x25, 0xe1, 0x31, 0x19, 0xe3, 0x47, 0xd0, 0xd8, 0x8f, 0xed, 0xd1,
-	0x84, 0x04, 0x63, 0x8a, 0xb6, 0xa1, 0x16, 0xfa, 0xee, 0xb2, 0xa0, 0x57, 0x43, 0xdf, 0x3d, 0x70,
-	0x05, 0x20, 0xa0, 0x67, 0xcb, 0x82, 0x5d, 0x0d, 0xe8, 0xd9, 0x81, 0x8b, 0xff, 0x6d, 0x40, 0x67,
-	0x44, 0x03, 0x1e, 0x11, 0x3f, 0xc9, 0x24, 0xf4, 0x63, 0xb8, 0xa4, 0xd3, 0xd1, 0x4a, 0x73, 0xd1,
-	0xc8, 0x9e, 0x60, 0x31, 0x93, 0x3a, 0x64, 0x21, 0xd5, 0xdf, 0x82, 0x76, 0xa4, 0x12, 0xc3, 0x62,
-	0x9c, 0x70, 0xd5, 0x3a, 0xea, 0x66, 0x4b, 0x13, 0x8f, 0x04, 0x0d, 0xdd, 0x85, 0x8e, 0xb0, 0x2c,
-	0x5f, 0xd6, 0xaa, 0x77, 0x6c, 0x14, 0xca, 0x9a, 0x99, 0xed, 0x80, 0x9e, 0xe5, 0x5a, 0xc1, 0x4d,
-	0x80, 0x49, 0x6c, 0x5b, 0x8e, 0x0c, 0x80, 0x2e, 0x42, 0xd9, 0x09, 0xd2, 0xa8, 0x98, 0x8d, 0x49,
-	0xf2, 0x13, 0xff, 0xbe, 0x0a, 0xcd, 0xfd, 0xd8, 0x4e, 0x5d, 0xfb, 0x3e, 0xac, 0x0b, 0xe9, 0x88,
-	0x8e, 0x75, 0xc4, 0xb6, 0xb5, 0x68, 0x82, 0x10, 0xbf, 0x4d, 0x3a, 0xf6, 0x18, 0x8f, 0x54, 0x82,
-	0xd5, 0x26, 0x92, 0x80, 0xde, 0x86, 0x75, 0x46, 0x03, 0x6e, 0x11, 0xae, 0x43, 0x29, 0x2f, 0x7d,
-	0x9c, 0xcc, 0x18, 0xb3, 0x26, 0xb8, 0x43, 0x8e, 0x76, 0xa0, 0xaa, 0x9c, 0x56, 0xde, 0xf4, 0x96,
-	0xe8, 0x97, 0x01, 0x30, 0x15, 0x0c, 0x61, 0x58, 0x13, 0x73, 0xa9, 0xb7, 0x26, 0x03, 0x2c, 0x9d,
-	0x7f, 0xdf, 0x0f, 0xcf, 0x4c, 0xea, 0x84, 0x91, 0x6b, 0x4a, 0x5e, 0xff, 0x8f, 0x06, 0x74, 0x16,
-	0xec, 0x5a, 0xd9, 0xd2, 0xae, 0x03, 0xe8, 0x72, 0x5c, 0x36, 0x9b, 0x74, 0xa9, 0xee, 0xc7, 0xf6,
-	0x2b, 0x54, 0x59, 0xff, 0xd3, 0x32, 0xd4, 0x13, 0x1f, 0xd0, 0xbb, 0xb0, 0x49, 0xc6, 0x22, 0x2a,
-	0x4e, 0x18, 0x04, 0xd4, 0x51, 0x7a, 0x84, 0x49, 0x15, 0xf3, 0x92, 0x64, 0x8c, 0x32, 0xba, 0x48,
-	0x0b, 0x9d, 0x29, 0xcc, 0x62, 0x94, 0x06, 0xd2, 0xb0, 0x8a, 0xd9, 0x4a, 0x88, 0x47, 0x94, 0x06,
-	0xe8, 0x3a, 0x74, 0x52, 0x90, 0x43, 0x9c, 0x09, 0x55, 0x03, 0xb4, 0x62, 0x6e, 0x24, 0xe4, 0x91,
-	0xa4, 0xa2, 0x6f, 0x42, 0x4b, 0xf1, 0x2d, 0x7b, 0xce, 0xa9, 0x6a, 0xc7, 0x15, 0xb3, 0xa9, 0x68,
-	0xf7, 0x05, 0x09, 0x8d, 0xe0, 0xb2, 0x4f, 0x44, 0x12, 0xc6, 0xb2, 0x36, 0x8f, 0x63, 0xdf, 0x8a,
-	0x67, 0x2e, 0xe1, 0x54, 0x4f, 0xd8, 0x85, 0x17, 0xdc, 0x12, 0xe0, 0xa3, 0x14, 0xfb, 0xb1, 0x84,
-	0xa2, 0x21, 0xbc, 0x21, 0x95, 0x10, 0xce, 0xe9, 0x74, 0xc6, 0xa9, 0x9b, 0xe8, 0xa8, 0x2d, 0xd3,
-	0xd1, 0x15, 0xd8, 0x61, 0x02, 0x55, 0x2a, 0xf0, 0x27, 0xb0, 0xbe, 0x1f, 0xdb, 0x07, 0xc1, 0x71,
-	0xa8, 0x87, 0x8d, 0xb1, 0x64, 0xd8, 0x14, 0x9e, 0xa2, 0xfc, 0x52, 0x0d, 0xef, 0x16, 0xc0, 0xa1,
-	0xc7, 0xf8, 0x2f, 0x8e, 0xf7, 0x63, 0x9b, 0xa1, 0x6d, 0x58, 0x9b, 0xc4, 0x76, 0x52, 0xa9, 0x4d,
-	0x9d, 0x77, 0xe2, 0x56, 0x53, 0x32, 0xf0, 0x6f, 0xa4, 0x19, 0x47, 0xf3, 0xc0, 0x59, 0x61, 0x46,
-	0xa1, 0x93, 0x97, 0x9f, 0xdb, 0xc9, 0x77, 0x72, 0x63, 0x4a, 0xe5, 0x0d, 0xca, 0x8f, 0x29, 0x55,
-	0xe8, 0xb9, 0x41, 0x75, 0x57, 0x26, 0xb0, 0xb8, 0x3b, 0xed, 0xcd, 0x6f, 0x41, 0x5b, 0xb3, 0xad,
-	0x6c, 0x2c, 0x56, 0xcc, 0x96, 0x26, 0x8e, 0x04, 0x0d, 0xff, 0xc5, 0x00, 0x94, 0x66, 0x3e, 0x8d,
-	0xbe, 0x52, 0xf3, 0xe6, 0x03, 0xe8, 0x16, 0x4c, 0xd3, 0x7e, 0xbd, 0x07, 0x2d, 0xbd, 0xdc, 0x5a,
-	0x62, 0x03, 0xd5, 0xe6, 0x2d, 0xe4, 0x49, 0x53, 0x43, 0x04, 0x05, 0x4f, 0x60, 0x6b, 0x3f, 0xb6,
-	0x1f, 0x78, 0x4c, 0x57, 0xd1, 0x6b, 0xf3, 0x12, 0xef, 0x41, 0x57, 0x3f, 0xd1, 0x63, 0x31, 0xd1,
-	0x92, 0x8b, 0xbe, 0x0e, 0x8d, 0x80, 0x4c, 0x29, 0x9b, 0x11, 0x47, 0xd9, 0xdb, 0x30, 0x33, 0x02,
-	0xbe, 0x09, 0x5b, 0x45, 0x21, 0xed, 0xe8, 0x16, 0x54, 0xe5, 0x5c, 0xd4, 0x12, 0xea, 0x80, 0xef,
-	0x41, 0x57, 0x24, 0x65, 0x3a, 0x1d, 0xbe, 0xd4, 0x3a, 0x8d, 0x7f, 0x02, 0x5b, 0x45, 0x69, 0x7d,
-	0xd7, 0xf5, 0x5c, 0xbe, 0xe5, 0x12, 0x3c, 0xc9, 0xb7, 0x2c, 0xd1, 0xfe, 0x66, 0xc0, 0xba, 0xa6,
-	0xae, 0xc8, 0xf2, 0x55, 0x5b, 0xfb, 0x2b, 0x6f, 0x7d, 0x85, 0xdd, 0xbc, 0xba, 0x62, 0x37, 0x3f,
-	0x86, 0xcd, 0xa1, 0xeb, 0x26, 0xbe, 0x7f, 0xb9, 0xef, 0x8d, 0x6c, 0x87, 0x2e, 0xbf, 0x70, 0x87,
-	0xfe, 0x83, 0x01, 0xdd, 0xa1, 0xeb, 0x66, 0x2b, 0xb2, 0xbe, 0x2a, 0xf3, 0xc6, 0x58, 0xe1, 0x4d,
-	0xce, 0xa0, 0xf2, 0xea, 0x0f, 0x84, 0x17, 0xaf, 0xfe, 0xb8, 0x06, 0x6b, 0x1f, 0x86, 0xe1, 0x0c,
-	0x53, 0xb8, 0xac, 0xb6, 0xc8, 0xd7, 0x6a, 0x14, 0xfe, 0xd4, 0x00, 0x34, 0x8a, 0x28, 0xe1, 0xc5,
-	0x3c, 0x7f, 0xc9, 0x18, 0xff, 0x48, 0x8c, 0x96, 0x19, 0xb1, 0x3d, 0xdf, 0xe3, 0x1e, 0x2d, 0x74,
-	0x63, 0xa9, 0x6e, 0x94, 0x30, 0xe7, 0xf7, 0xd7, 0x3e, 0xfb, 0xcf, 0x76, 0xc9, 0x2c, 0xc0, 0xd1,
-	0x1d, 0xd8, 0x38, 0x25, 0xbe, 0xe7, 0x5a, 0x6e, 0xac, 0x66, 0xb5, 0x8e, 0xcc, 0x42, 0x0b, 0x68,
-	0x4b, 0xd0, 0x03, 0x8d, 0xc1, 0xef, 0x42, 0xb7, 0x60, 0xf1, 0xca, 0x22, 0xbb, 0x0d, 0x9d, 0x91,
-	0x6a, 0x20, 0x49, 0xfb, 0x79, 0x41, 0x0d, 0x5f, 0x83, 0x96, 0x16, 0x90, 0xea, 0x9f, 0xa3, 0xf6,
-	0x1d, 0x68, 0x48, 0xb6, 0x1c, 0x55, 0xdf, 0x00, 0x98, 0xc5, 0xb6, 0xef, 0x39, 0xb9, 0xf5, 0xb9,
-	0xa1, 0x28, 0x0f, 0xe9, 0x1c, 0x8f, 0x54, 0x9d, 0xeb, 0xe0, 0xa5, 0x75, 0xbe, 0x05, 0x55, 0x99,
-	0x7d, 0x52, 0xa0, 0x6a, 0xaa, 0x03, 0xba, 0x0c, 0xb5, 0x29, 0x89, 0x4e, 0x68, 0xa4, 0x97, 0x6d,
-	0x7d, 0xc2, 0xbf, 0x54, 0xe5, 0x9e, 0x29, 0xc9, 0xca, 0x3d, 0x19, 0xf7, 0xf9, 0x72, 0x4f, 0x5e,
-	0x2a, 0x65, 0xa2, 0x6d, 0x68, 0x06, 0xf4, 0xd7, 0xdc, 0x2a, 0x68, 0x07, 0x41, 0x7a, 0x24, 0x29,
-	0xbb, 0x7f, 0x5d, 0x4b, 0x43, 0x95, 0xee, 0xa7, 0xdf, 0x03, 0x18, 0xba, 0x6e, 0xd2, 0x25, 0x96,
-	0x0c, 0xae, 0x7e, 0xb7, 0x40, 0xd3, 0xdf, 0xcf, 0x25, 0xf4, 0x43, 0x68, 0xab, 0xec, 0x7d, 0x05,
-	0xd9, 0x11, 0xb4, 0xf2, 0x9d, 0x0d, 0x5d, 0x91, 0xf9, 0x7d, 0xb1, 0x53, 0xf6, 0x7b, 0x17, 0x19,
-	0xa9, 0x92, 0xbb, 0xd0, 0x7c, 0x9f, 0x72, 0x67, 0xa2, 0x3e, 0x73, 0xd0, 0xa6, 0x80, 0x16, 0xbe,
-	0xc4, 0xfa, 0x28, 0x4f, 0x4a, 0xe5, 0xee, 0xc1, 0xc6, 0x11, 0x8f, 0x28, 0x99, 0xa6, 0x8b, 0x70,
-	0x67, 0x61, 0x2f, 0x55, 0x66, 0x2f, 0x7c, 0x09, 0xe0, 0xd2, 0x0d, 0xe3, 0x3d, 0x03, 0xdd, 0x82,
-	0x75, 0x31, 0xb9, 0xc5, 0xc2, 0x98, 0xac, 0x15, 0xe2, 0xac, 0x44, 0x16, 0xc6, 0x3a, 0x2e, 0xa1,
-	0xef, 0x42, 0xbb, 0x30, 0xce, 0x50, 0xb2, 0x03, 0x5f, 0x98, 0x70, 0x7d, 0xd9, 0x7a, 0x65, 0x63,
-	0x28, 0x89, 0xe2, 0x1c, 0xfa, 0xbe, 0x5c, 0x65, 0x52, 0x72, 0x7f, 0x23, 0x09, 0x86, 0x5a, 0x72,
-	0x70, 0x09, 0xfd, 0x1c, 0xba, 0x5a, 0x3a, 0x3f, 0x94, 0x54, 0x38, 0x97, 0xcc, 0x36, 0x15, 0xce,
-	0x65, 0xf3, 0x0b, 0x97, 0x76, 0xff, 0x55, 0x81, 0x4d, 0x9d, 0x1c, 0x8f, 0x48, 0x40, 0xc6, 0x74,
-	0x4a, 0x03, 0x8e, 0xf6, 0xa0, 0x9e, 0x56, 0x55, 0x57, 0x87, 0x33, 0x5f, 0x6a, 0xfd, 0x4b, 0x39,
-	0xa2, 0x54, 0x89, 0x4b, 0xe8, 0xb6, 0xcc, 0x29, 0x9d, 0xa0, 0xe8, 0x0d, 0x99, 0xad, 0x8b, 0x3d,
-	0xbe, 0xe0, 0xee, 0x1e, 0xb4, 0xf2, 0xbd, 0x59, 0x39, 0xb0, 0xa4, 0x5b, 0x17, 0x84, 0x7e, 0x00,
-	0x9d, 0x85, 0xf6, 0x89, 0xfa, 0x82, 0xbd, 0xbc, 0xa7, 0x16, 0x44, 0x7f, 0x0a, 0xcd, 0x5c, 0x7f,
-	0x41, 0x97, 0xa5, 0x0f, 0x17, 0x5a, 0x64, 0xff, 0xca, 0x05, 0x7a, 0xfa, 0xae, 0x77, 0xa0, 0x7d,
-	0xc0, 0x58, 0x2c, 0x3e, 0x1c, 0x94, 0x8e, 0xec, 0x99, 0x56, 0x48, 0xed, 0xc0, 0xe6, 0x07, 0x94,
-	0x3f, 0xd6, 0x1f, 0xd0, 0xaa, 0x79, 0xe4, 0x24, 0xdb, 0x69, 0x57, 0x15, 0x4d, 0x27, 0xab, 0x93,
-	0xa4, 0x25, 0x64, 0x75, 0xb2, 0xd0, 0x69, 0xb2, 0x3a, 0x59, 0xec, 0x1e, 0xb8, 0x74, 0xff, 0xce,
-	0x93, 0xa7, 0x83, 0xd2, 0xe7, 0x4f, 0x07, 0xa5, 0x2f, 0x9e, 0x0e, 0x8c, 0xdf, 0x9d, 0x0f, 0x8c,
-	0xbf, 0x9f, 0x0f, 0x8c, 0xcf, 0xce, 0x07, 0xc6, 0x93, 0xf3, 0x81, 0xf1, 0xdf, 0xf3, 0x81, 0xf1,
-	0xbf, 0xf3, 0x41, 0xe9, 0x8b, 0xf3, 0x81, 0xf1, 0xa7, 0x67, 0x83, 0xd2, 0x93, 0x67, 0x83, 0xd2,
-	0xe7, 0xcf, 0x06, 0x25, 0xbb, 0x26, 0xff, 0x0c, 0xdc, 0xfb, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x58, 0x5f, 0x88, 0x84, 0x9d, 0x14, 0x00, 0x00,
+func (m *WatchServicesRequest) Reset()      { *m = WatchServicesRequest{} }
+func (*WatchServicesRequest) ProtoMessage() {}
+func (*WatchServicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{36}
+}
+func (m *WatchServicesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchServicesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchServicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchServicesRequest.Merge(m, src)
+}
+func (m *WatchServicesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchServicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchServicesRequest.DiscardUnknown(m)
 }
 
-func (this *ServiceRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+var xxx_messageInfo_WatchServicesRequest proto.InternalMessageInfo
+
+func (m *WatchServicesRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
 	}
+	return ""
+}
 
-	that1, ok := that.(*ServiceRequest)
-	if !ok {
-		that2, ok := that.(ServiceRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ServiceEvent struct {
+	Type        ServiceEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=pb.ServiceEvent_Type" json:"type,omitempty"`
+	ResumeToken string            `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Account     *Account          `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Service     *Service          `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	LabelLink   *LabelLink        `protobuf:"bytes,5,opt,name=label_link,json=labelLink,proto3" json:"label_link,omitempty"`
+}
+
+func (m *ServiceEvent) Reset()      { *m = ServiceEvent{} }
+func (*ServiceEvent) ProtoMessage() {}
+func (*ServiceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{37}
+}
+func (m *ServiceEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServiceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServiceEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Account.Equal(that1.Account) {
-		return false
-	}
-	if !this.Hub.Equal(that1.Hub) {
-		return false
+}
+func (m *ServiceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceEvent.Merge(m, src)
+}
+func (m *ServiceEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServiceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceEvent proto.InternalMessageInfo
+
+func (m *ServiceEvent) GetType() ServiceEvent_Type {
+	if m != nil {
+		return m.Type
 	}
-	if !this.Id.Equal(that1.Id) {
-		return false
+	return UNKNOWN_EVENT
+}
+
+func (m *ServiceEvent) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
 	}
-	if this.Type != that1.Type {
-		return false
+	return ""
+}
+
+func (m *ServiceEvent) GetAccount() *Account {
+	if m != nil {
+		return m.Account
 	}
-	if !this.Labels.Equal(that1.Labels) {
-		return false
+	return nil
+}
+
+func (m *ServiceEvent) GetService() *Service {
+	if m != nil {
+		return m.Service
 	}
-	if len(this.Metadata) != len(that1.Metadata) {
-		return false
+	return nil
+}
+
+func (m *ServiceEvent) GetLabelLink() *LabelLink {
+	if m != nil {
+		return m.LabelLink
 	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
-			return false
-		}
-	}
-	return true
+	return nil
 }
-func (this *ServiceResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ServiceResponse)
-	if !ok {
-		that2, ok := that.(ServiceResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	return true
+type Webhook struct {
+	Id        *ULID  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Url       string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// The event types delivered to the webhook, all of them if empty.
+	Events    []string   `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	CreatedAt *Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
-func (this *LabelLink) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelLink)
-	if !ok {
-		that2, ok := that.(LabelLink)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *Webhook) Reset()      { *m = Webhook{} }
+func (*Webhook) ProtoMessage() {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{38}
+}
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Account.Equal(that1.Account) {
-		return false
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return m.Size()
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() *ULID {
+	if m != nil {
+		return m.Id
 	}
-	if !this.Labels.Equal(that1.Labels) {
-		return false
+	return nil
+}
+
+func (m *Webhook) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	if !this.Target.Equal(that1.Target) {
-		return false
+	return ""
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
 	}
-	if !this.Limits.Equal(that1.Limits) {
-		return false
+	return ""
+}
+
+func (m *Webhook) GetEvents() []string {
+	if m != nil {
+		return m.Events
 	}
-	return true
+	return nil
 }
-func (this *LabelLinks) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Webhook) GetCreatedAt() *Timestamp {
+	if m != nil {
+		return m.CreatedAt
 	}
+	return nil
+}
 
-	that1, ok := that.(*LabelLinks)
-	if !ok {
-		that2, ok := that.(LabelLinks)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type AddWebhookRequest struct {
+	Namespace string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Url       string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Events    []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (m *AddWebhookRequest) Reset()      { *m = AddWebhookRequest{} }
+func (*AddWebhookRequest) ProtoMessage() {}
+func (*AddWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{39}
+}
+func (m *AddWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddWebhookRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.LabelLinks) != len(that1.LabelLinks) {
-		return false
+}
+func (m *AddWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddWebhookRequest.Merge(m, src)
+}
+func (m *AddWebhookRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddWebhookRequest proto.InternalMessageInfo
+
+func (m *AddWebhookRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	for i := range this.LabelLinks {
-		if !this.LabelLinks[i].Equal(that1.LabelLinks[i]) {
-			return false
-		}
+	return ""
+}
+
+func (m *AddWebhookRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
 	}
-	return true
+	return ""
 }
-func (this *ServiceRoute) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *AddWebhookRequest) GetEvents() []string {
+	if m != nil {
+		return m.Events
 	}
+	return nil
+}
 
-	that1, ok := that.(*ServiceRoute)
-	if !ok {
-		that2, ok := that.(ServiceRoute)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type AddWebhookResponse struct {
+	Webhook *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	// Used to sign the deliveries to the webhook. It's only returned when the
+	// webhook is created.
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (m *AddWebhookResponse) Reset()      { *m = AddWebhookResponse{} }
+func (*AddWebhookResponse) ProtoMessage() {}
+func (*AddWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{40}
+}
+func (m *AddWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddWebhookResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Hub.Equal(that1.Hub) {
-		return false
-	}
-	if !this.Id.Equal(that1.Id) {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
-	}
-	if !this.Labels.Equal(that1.Labels) {
-		return false
+}
+func (m *AddWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddWebhookResponse.Merge(m, src)
+}
+func (m *AddWebhookResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddWebhookResponse proto.InternalMessageInfo
+
+func (m *AddWebhookResponse) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
 	}
-	return true
+	return nil
 }
-func (this *AccountServices) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *AddWebhookResponse) GetSecret() string {
+	if m != nil {
+		return m.Secret
 	}
+	return ""
+}
 
-	that1, ok := that.(*AccountServices)
-	if !ok {
-		that2, ok := that.(AccountServices)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type RemoveWebhookRequest struct {
+	Id *ULID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *RemoveWebhookRequest) Reset()      { *m = RemoveWebhookRequest{} }
+func (*RemoveWebhookRequest) ProtoMessage() {}
+func (*RemoveWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{41}
+}
+func (m *RemoveWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoveWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveWebhookRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Account.Equal(that1.Account) {
-		return false
-	}
-	if len(this.Services) != len(that1.Services) {
-		return false
+}
+func (m *RemoveWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveWebhookRequest.Merge(m, src)
+}
+func (m *RemoveWebhookRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoveWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveWebhookRequest proto.InternalMessageInfo
+
+func (m *RemoveWebhookRequest) GetId() *ULID {
+	if m != nil {
+		return m.Id
 	}
-	for i := range this.Services {
-		if !this.Services[i].Equal(that1.Services[i]) {
-			return false
+	return nil
+}
+
+type ListWebhooksRequest struct {
+}
+
+func (m *ListWebhooksRequest) Reset()      { *m = ListWebhooksRequest{} }
+func (*ListWebhooksRequest) ProtoMessage() {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{42}
+}
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ActivityEntry) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*ActivityEntry)
-	if !ok {
-		that2, ok := that.(ActivityEntry)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+type ListWebhooksResponse struct {
+	Webhooks []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+}
+
+func (m *ListWebhooksResponse) Reset()      { *m = ListWebhooksResponse{} }
+func (*ListWebhooksResponse) ProtoMessage() {}
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{43}
+}
+func (m *ListWebhooksResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListWebhooksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListWebhooksResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *ListWebhooksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResponse.Merge(m, src)
+}
+func (m *ListWebhooksResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListWebhooksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResponse proto.InternalMessageInfo
+
+func (m *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
 	}
-	if !this.RouteAdded.Equal(that1.RouteAdded) {
-		return false
+	return nil
+}
+
+type WebhookDelivery struct {
+	Id          *ULID      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId   *ULID      `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Event       string     `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Status      string     `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts    int32      `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	StatusCode  int32      `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error       string     `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt   *Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt *Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+}
+
+func (m *WebhookDelivery) Reset()      { *m = WebhookDelivery{} }
+func (*WebhookDelivery) ProtoMessage() {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{44}
+}
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if !this.RouteRemoved.Equal(that1.RouteRemoved) {
-		return false
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return m.Size()
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() *ULID {
+	if m != nil {
+		return m.Id
 	}
-	return true
+	return nil
 }
-func (this *ConfigRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *WebhookDelivery) GetWebhookId() *ULID {
+	if m != nil {
+		return m.WebhookId
 	}
+	return nil
+}
 
-	that1, ok := that.(*ConfigRequest)
-	if !ok {
-		that2, ok := that.(ConfigRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *WebhookDelivery) GetEvent() string {
+	if m != nil {
+		return m.Event
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
 	}
-	if !this.StableId.Equal(that1.StableId) {
-		return false
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
 	}
-	if !this.InstanceId.Equal(that1.InstanceId) {
-		return false
+	return 0
+}
+
+func (m *WebhookDelivery) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
 	}
-	if len(this.Locations) != len(that1.Locations) {
-		return false
+	return 0
+}
+
+func (m *WebhookDelivery) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
-			return false
+	return ""
+}
+
+func (m *WebhookDelivery) GetCreatedAt() *Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetDeliveredAt() *Timestamp {
+	if m != nil {
+		return m.DeliveredAt
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	WebhookId *ULID `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Limit     int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *ListWebhookDeliveriesRequest) Reset()      { *m = ListWebhookDeliveriesRequest{} }
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{45}
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListWebhookDeliveriesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ConfigResponse) Equal(that interface{}) bool {
+func (m *ListWebhookDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListWebhookDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesRequest proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesRequest) GetWebhookId() *ULID {
+	if m != nil {
+		return m.WebhookId
+	}
+	return nil
+}
+
+func (m *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+}
+
+func (m *ListWebhookDeliveriesResponse) Reset()      { *m = ListWebhookDeliveriesResponse{} }
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{46}
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListWebhookDeliveriesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListWebhookDeliveriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesResponse proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("pb.ServiceEvent_Type", ServiceEvent_Type_name, ServiceEvent_Type_value)
+	proto.RegisterType((*ServiceRequest)(nil), "pb.ServiceRequest")
+	proto.RegisterType((*ServiceResponse)(nil), "pb.ServiceResponse")
+	proto.RegisterType((*LabelLink)(nil), "pb.LabelLink")
+	proto.RegisterType((*LabelLinks)(nil), "pb.LabelLinks")
+	proto.RegisterType((*ServiceRoute)(nil), "pb.ServiceRoute")
+	proto.RegisterType((*AccountServices)(nil), "pb.AccountServices")
+	proto.RegisterType((*ActivityEntry)(nil), "pb.ActivityEntry")
+	proto.RegisterType((*ServiceChange)(nil), "pb.ServiceChange")
+	proto.RegisterType((*ConfigRequest)(nil), "pb.ConfigRequest")
+	proto.RegisterType((*ConfigResponse)(nil), "pb.ConfigResponse")
+	proto.RegisterType((*HubChange)(nil), "pb.HubChange")
+	proto.RegisterType((*CentralActivity)(nil), "pb.CentralActivity")
+	proto.RegisterType((*HubActivity)(nil), "pb.HubActivity")
+	proto.RegisterType((*HubActivity_HubRegistration)(nil), "pb.HubActivity.HubRegistration")
+	proto.RegisterType((*HubActivity_HubStats)(nil), "pb.HubActivity.HubStats")
+	proto.RegisterType((*HubInfo)(nil), "pb.HubInfo")
+	proto.RegisterType((*ListOfHubs)(nil), "pb.ListOfHubs")
+	proto.RegisterType((*HubSync)(nil), "pb.HubSync")
+	proto.RegisterType((*HubSyncResponse)(nil), "pb.HubSyncResponse")
+	proto.RegisterType((*HubRegisterRequest)(nil), "pb.HubRegisterRequest")
+	proto.RegisterType((*HubRegisterResponse)(nil), "pb.HubRegisterResponse")
+	proto.RegisterType((*HubDisconnectRequest)(nil), "pb.HubDisconnectRequest")
+	proto.RegisterType((*ServiceTokenRequest)(nil), "pb.ServiceTokenRequest")
+	proto.RegisterType((*ServiceTokenResponse)(nil), "pb.ServiceTokenResponse")
+	proto.RegisterType((*ListServicesRequest)(nil), "pb.ListServicesRequest")
+	proto.RegisterType((*ListServicesResponse)(nil), "pb.ListServicesResponse")
+	proto.RegisterType((*Service)(nil), "pb.Service")
+	proto.RegisterType((*AddAccountRequest)(nil), "pb.AddAccountRequest")
+	proto.RegisterType((*AddLabelLinkRequest)(nil), "pb.AddLabelLinkRequest")
+	proto.RegisterType((*Noop)(nil), "pb.Noop")
+	proto.RegisterType((*RemoveLabelLinkRequest)(nil), "pb.RemoveLabelLinkRequest")
+	proto.RegisterType((*CreateTokenRequest)(nil), "pb.CreateTokenRequest")
+	proto.RegisterType((*CreateTokenResponse)(nil), "pb.CreateTokenResponse")
+	proto.RegisterType((*ControlRegister)(nil), "pb.ControlRegister")
+	proto.RegisterType((*ControlToken)(nil), "pb.ControlToken")
+	proto.RegisterType((*TokenInfo)(nil), "pb.TokenInfo")
+	proto.RegisterType((*ListAccountsRequest)(nil), "pb.ListAccountsRequest")
+	proto.RegisterType((*ListAccountsResponse)(nil), "pb.ListAccountsResponse")
+	proto.RegisterType((*WatchServicesRequest)(nil), "pb.WatchServicesRequest")
+	proto.RegisterType((*ServiceEvent)(nil), "pb.ServiceEvent")
+	proto.RegisterType((*Webhook)(nil), "pb.Webhook")
+	proto.RegisterType((*AddWebhookRequest)(nil), "pb.AddWebhookRequest")
+	proto.RegisterType((*AddWebhookResponse)(nil), "pb.AddWebhookResponse")
+	proto.RegisterType((*RemoveWebhookRequest)(nil), "pb.RemoveWebhookRequest")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "pb.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResponse)(nil), "pb.ListWebhooksResponse")
+	proto.RegisterType((*WebhookDelivery)(nil), "pb.WebhookDelivery")
+	proto.RegisterType((*ListWebhookDeliveriesRequest)(nil), "pb.ListWebhookDeliveriesRequest")
+	proto.RegisterType((*ListWebhookDeliveriesResponse)(nil), "pb.ListWebhookDeliveriesResponse")
+}
+
+func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
+
+var fileDescriptor_0c5120591600887d = []byte{
+	// 2467 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcb, 0x73, 0x23, 0x47,
+	0x19, 0xd7, 0xe8, 0x65, 0xe9, 0x93, 0x64, 0xc9, 0x2d, 0xaf, 0x33, 0x28, 0x89, 0xd6, 0x99, 0x3c,
+	0x76, 0xf3, 0x72, 0x16, 0x7b, 0xd9, 0x90, 0xb0, 0x21, 0x68, 0x65, 0x27, 0x36, 0xeb, 0xf5, 0xa6,
+	0xc6, 0xde, 0xdd, 0x03, 0x15, 0xc4, 0x68, 0xa6, 0x2d, 0x4f, 0x79, 0x34, 0x23, 0x66, 0x7a, 0x6c,
+	0xcc, 0x89, 0xe2, 0x04, 0x37, 0x0e, 0x5c, 0xe0, 0xc6, 0x85, 0xa2, 0x38, 0xa5, 0xa8, 0xe2, 0x7f,
+	0x08, 0x27, 0xf6, 0x98, 0x03, 0x95, 0x62, 0xbd, 0x17, 0x8e, 0xf9, 0x13, 0xa8, 0x7e, 0xcd, 0x43,
+	0x1a, 0x6b, 0x9d, 0x54, 0x85, 0xe2, 0xa6, 0xfe, 0xbe, 0x5f, 0xf7, 0xf7, 0x75, 0x7f, 0xef, 0x11,
+	0x34, 0x4c, 0xcf, 0x25, 0xbe, 0xe7, 0xac, 0x4d, 0x7c, 0x8f, 0x78, 0x28, 0x3f, 0x19, 0x76, 0x9a,
+	0x16, 0x3e, 0x0c, 0xde, 0x19, 0x79, 0x23, 0x8f, 0x13, 0x3b, 0x95, 0xe3, 0x13, 0xf1, 0xab, 0xe6,
+	0x18, 0x43, 0x2c, 0xb0, 0x9d, 0x86, 0x61, 0x9a, 0x5e, 0xe8, 0x12, 0xb1, 0x84, 0xd0, 0xb1, 0x2d,
+	0x89, 0x23, 0xde, 0x31, 0x76, 0xc5, 0xa2, 0x49, 0xec, 0x31, 0x0e, 0x88, 0x31, 0x9e, 0x48, 0xe4,
+	0xa1, 0xe3, 0x9d, 0xca, 0x43, 0x5c, 0x4c, 0x4e, 0x3d, 0xff, 0x98, 0x2f, 0xb5, 0x7f, 0x2a, 0xb0,
+	0xb8, 0x8f, 0xfd, 0x13, 0xdb, 0xc4, 0x3a, 0xfe, 0x79, 0x88, 0x03, 0x82, 0x5e, 0x85, 0x05, 0x21,
+	0x48, 0x55, 0x56, 0x95, 0xeb, 0xb5, 0xf5, 0xda, 0xda, 0x64, 0xb8, 0xd6, 0xe3, 0x24, 0x5d, 0xf2,
+	0x50, 0x07, 0x0a, 0x47, 0xe1, 0x50, 0xcd, 0x33, 0x48, 0x85, 0x42, 0x1e, 0xec, 0xee, 0x6c, 0xea,
+	0x94, 0x88, 0x54, 0xc8, 0xdb, 0x96, 0x5a, 0x98, 0x62, 0xe5, 0x6d, 0x0b, 0x21, 0x28, 0x92, 0xb3,
+	0x09, 0x56, 0x8b, 0xab, 0xca, 0xf5, 0xaa, 0xce, 0x7e, 0xa3, 0x57, 0xa0, 0xcc, 0xae, 0x19, 0xa8,
+	0x25, 0xb6, 0xa3, 0x4e, 0x77, 0xec, 0x52, 0xca, 0x3e, 0x26, 0xba, 0xe0, 0xa1, 0xd7, 0xa0, 0x32,
+	0xc6, 0xc4, 0xb0, 0x0c, 0x62, 0xa8, 0xe5, 0xd5, 0xc2, 0xf5, 0xda, 0x3a, 0x50, 0xdc, 0xdd, 0x87,
+	0x9f, 0x18, 0xb6, 0xaf, 0x47, 0x3c, 0x6d, 0x09, 0x9a, 0xd1, 0x85, 0x82, 0x89, 0xe7, 0x06, 0x58,
+	0xfb, 0xab, 0x02, 0x55, 0x76, 0xde, 0xae, 0xed, 0x1e, 0x5f, 0xf6, 0x7e, 0xb1, 0x56, 0xf9, 0x39,
+	0x5a, 0xbd, 0x02, 0x65, 0x62, 0xf8, 0x23, 0x4c, 0xd4, 0x42, 0x16, 0x8a, 0xf3, 0xd0, 0x1b, 0x50,
+	0x76, 0xec, 0xb1, 0x4d, 0x02, 0x76, 0xef, 0xda, 0x3a, 0x4a, 0x48, 0x5c, 0xdb, 0x65, 0x1c, 0x5d,
+	0x20, 0xb4, 0xdb, 0x00, 0x91, 0xae, 0x01, 0x5a, 0x03, 0xee, 0x02, 0x03, 0x87, 0x2e, 0x55, 0x85,
+	0x5d, 0xbc, 0x11, 0x09, 0xa1, 0x20, 0x1d, 0x9c, 0x08, 0xaf, 0xfd, 0x59, 0x81, 0xba, 0xbc, 0xbe,
+	0x17, 0x12, 0x2c, 0xcd, 0xa4, 0x5c, 0x6c, 0xa6, 0xfc, 0x1c, 0x33, 0x15, 0x32, 0xcd, 0x54, 0xbc,
+	0xa4, 0x99, 0x4a, 0x73, 0xcc, 0x74, 0x08, 0x4d, 0xf1, 0x00, 0x42, 0xdd, 0xe0, 0xb2, 0x86, 0x79,
+	0x0b, 0x2a, 0x81, 0xd8, 0xa2, 0xe6, 0x99, 0x84, 0x16, 0xc5, 0x25, 0x6f, 0xad, 0x47, 0x08, 0xed,
+	0xcb, 0x3c, 0x34, 0x7a, 0x26, 0xb1, 0x4f, 0x6c, 0x72, 0xb6, 0xe5, 0x12, 0xff, 0x0c, 0xdd, 0x84,
+	0x9a, 0x4f, 0x41, 0x03, 0xc3, 0xb2, 0xb0, 0x25, 0x44, 0xb5, 0x13, 0xa2, 0xa4, 0x42, 0x3a, 0x30,
+	0x5c, 0x8f, 0xc2, 0xd0, 0xdb, 0xd0, 0xe0, 0xbb, 0x7c, 0x3c, 0xf6, 0x4e, 0xf0, 0xec, 0xb3, 0xd5,
+	0x19, 0x5b, 0xe7, 0x5c, 0x74, 0x0b, 0x1a, 0x42, 0x05, 0x21, 0x86, 0xbb, 0xc7, 0x52, 0x42, 0xd3,
+	0xfe, 0x91, 0xe1, 0x8e, 0xb0, 0x5e, 0x17, 0x38, 0x2e, 0xe6, 0x7d, 0x68, 0xca, 0x7d, 0x52, 0x50,
+	0xf1, 0xa2, 0x9d, 0x8b, 0x81, 0xf4, 0x73, 0x2e, 0xf3, 0x5d, 0x68, 0xc5, 0xbe, 0x22, 0xc4, 0xf2,
+	0x88, 0x9a, 0x72, 0x98, 0xc5, 0xc8, 0x61, 0xb8, 0xd0, 0x1f, 0x00, 0x4a, 0x6c, 0x94, 0x72, 0xcb,
+	0x59, 0x5b, 0x5b, 0xd1, 0x56, 0x21, 0x55, 0xfb, 0x14, 0x1a, 0x29, 0xb5, 0x2e, 0x6b, 0xc6, 0x57,
+	0x61, 0x41, 0xe8, 0xaf, 0xe6, 0x63, 0x98, 0xb4, 0xa2, 0xe4, 0x69, 0xbf, 0x57, 0xa0, 0xd1, 0xf7,
+	0xdc, 0x43, 0x7b, 0x14, 0xe7, 0xa7, 0x6a, 0x40, 0x8c, 0xa1, 0x83, 0x07, 0xb6, 0x35, 0xe3, 0xd7,
+	0x15, 0xce, 0xda, 0xb1, 0xd0, 0xeb, 0x50, 0xb3, 0xdd, 0x80, 0x18, 0xae, 0xc9, 0x80, 0xd3, 0xe6,
+	0x02, 0xc9, 0xdc, 0xb1, 0xd0, 0x77, 0xa1, 0xea, 0x78, 0xa6, 0x41, 0x6c, 0xcf, 0x0d, 0xd4, 0xc2,
+	0x6a, 0x41, 0xfa, 0xc3, 0x1e, 0x4f, 0x95, 0xbb, 0x82, 0xa7, 0xc7, 0x28, 0xed, 0xa9, 0x02, 0x8b,
+	0x52, 0x2d, 0x9e, 0x65, 0xd0, 0x73, 0xb0, 0x40, 0x9c, 0x60, 0x70, 0x8c, 0xcf, 0x98, 0x56, 0x75,
+	0xbd, 0x4c, 0x9c, 0xe0, 0x2e, 0x3e, 0x43, 0xdf, 0x81, 0x0a, 0x65, 0x98, 0xd8, 0x27, 0x4c, 0x8d,
+	0xba, 0x4e, 0x81, 0x7d, 0xec, 0x13, 0xf4, 0x3c, 0x54, 0x59, 0xe6, 0x1e, 0x4c, 0xc2, 0x21, 0x73,
+	0x91, 0xba, 0x5e, 0x61, 0x84, 0x4f, 0xc2, 0x21, 0xd2, 0xa0, 0x11, 0x6c, 0x0c, 0x0c, 0xd3, 0xc4,
+	0x01, 0x3f, 0x96, 0x27, 0xcd, 0x5a, 0xb0, 0xd1, 0x63, 0x34, 0x7a, 0x36, 0xc7, 0x04, 0xd8, 0xf4,
+	0x31, 0x61, 0x98, 0x92, 0xc4, 0xec, 0x33, 0x1a, 0xc5, 0x3c, 0x0f, 0xd5, 0x60, 0x63, 0x30, 0x0c,
+	0xcd, 0x63, 0x4c, 0x98, 0x55, 0xab, 0x7a, 0x25, 0xd8, 0xb8, 0xc3, 0xd6, 0x94, 0x69, 0x8f, 0x8d,
+	0x11, 0x1e, 0x10, 0x63, 0xa4, 0x2e, 0x70, 0x26, 0x23, 0x1c, 0x18, 0x23, 0xed, 0x1e, 0x54, 0xb7,
+	0xc3, 0xa1, 0xb0, 0xeb, 0x55, 0x28, 0x7b, 0x8e, 0x95, 0xf5, 0xe8, 0x25, 0xcf, 0xb1, 0x76, 0x2c,
+	0x0a, 0x70, 0xf1, 0x69, 0xd6, 0x63, 0x97, 0x5c, 0x7c, 0xba, 0x63, 0x69, 0xff, 0x52, 0xa0, 0xd9,
+	0xc7, 0x2e, 0xf1, 0x0d, 0x47, 0x86, 0x24, 0xfa, 0x21, 0xb4, 0x84, 0x47, 0x0c, 0xa2, 0xa8, 0x56,
+	0x62, 0x13, 0x4c, 0x87, 0x64, 0xd3, 0x48, 0x13, 0xd0, 0xcb, 0xd0, 0xf0, 0xb9, 0x63, 0x0c, 0x02,
+	0x62, 0x10, 0x9e, 0xad, 0x2b, 0x7a, 0x5d, 0x10, 0xf7, 0x29, 0x0d, 0xdd, 0x82, 0x26, 0xd5, 0x2c,
+	0x99, 0x49, 0x79, 0x3c, 0x2e, 0xa6, 0xbc, 0x3b, 0xd0, 0x1b, 0x2e, 0x3e, 0x8d, 0x97, 0xe8, 0x2d,
+	0x80, 0xa3, 0x70, 0x38, 0x30, 0xd9, 0x03, 0xa8, 0xc5, 0x38, 0x20, 0xa2, 0x57, 0xd1, 0xab, 0x47,
+	0xf2, 0xa7, 0xf6, 0xeb, 0x12, 0xd4, 0xb6, 0xc3, 0x61, 0x74, 0xb5, 0xef, 0xc3, 0x02, 0xdd, 0xed,
+	0xe3, 0x91, 0x78, 0xb1, 0xab, 0x62, 0xab, 0x44, 0xd0, 0xdf, 0x3a, 0x1e, 0xd9, 0x01, 0xf1, 0xb9,
+	0x83, 0x95, 0x8f, 0x18, 0x01, 0xbd, 0x46, 0x63, 0xc3, 0x25, 0x03, 0x83, 0xa8, 0xf9, 0x58, 0xe8,
+	0x81, 0x2c, 0xeb, 0x7a, 0x99, 0x72, 0x7b, 0x04, 0xad, 0x41, 0x89, 0x5f, 0x9a, 0xdf, 0x46, 0xcd,
+	0x38, 0x9f, 0x3d, 0x80, 0xce, 0x61, 0x48, 0x83, 0x22, 0x6d, 0x05, 0xd4, 0xe2, 0x6a, 0x41, 0x5e,
+	0xfe, 0x23, 0xc7, 0x3b, 0xd5, 0xb1, 0xe9, 0xf9, 0x96, 0xce, 0x78, 0x9d, 0xdf, 0x2a, 0xd0, 0x9c,
+	0xd2, 0x6b, 0x6e, 0x11, 0xb9, 0x06, 0x20, 0xc2, 0x31, 0xab, 0x1d, 0x10, 0xa1, 0xba, 0x1d, 0x0e,
+	0xbf, 0x41, 0x94, 0x75, 0x3e, 0xcb, 0x43, 0x45, 0xde, 0x01, 0xbd, 0x09, 0x4b, 0xc6, 0x88, 0xbe,
+	0x8a, 0xe9, 0xb9, 0x2e, 0x36, 0xf9, 0x39, 0x54, 0xa5, 0x82, 0xde, 0x62, 0x8c, 0x7e, 0x4c, 0xa7,
+	0x6e, 0x21, 0x3c, 0x25, 0x18, 0x04, 0x18, 0xbb, 0x4c, 0xb1, 0x82, 0x5e, 0x97, 0xc4, 0x7d, 0x8c,
+	0x5d, 0x74, 0x0d, 0x9a, 0x11, 0xc8, 0x34, 0xcc, 0x23, 0x91, 0xa6, 0x0b, 0xfa, 0xa2, 0x24, 0xf7,
+	0x19, 0x15, 0xbd, 0x04, 0x75, 0xce, 0x1f, 0x0c, 0xcf, 0x08, 0xe6, 0x05, 0xb0, 0xa0, 0xd7, 0x38,
+	0xed, 0x0e, 0x25, 0xa1, 0x3e, 0xac, 0x38, 0x06, 0x75, 0xc2, 0x90, 0xc5, 0xe6, 0x61, 0xe8, 0x0c,
+	0xc2, 0x89, 0x65, 0x10, 0xac, 0x96, 0xb2, 0x2c, 0xb8, 0x4c, 0xc1, 0xfb, 0x11, 0xf6, 0x01, 0x83,
+	0xa2, 0x1e, 0x5c, 0x61, 0x87, 0x18, 0x84, 0xe0, 0xf1, 0x84, 0x60, 0x4b, 0x9e, 0x51, 0xce, 0x3a,
+	0xa3, 0x4d, 0xb1, 0x3d, 0x09, 0xe5, 0x47, 0x68, 0x0f, 0x61, 0x61, 0x3b, 0x1c, 0xee, 0xb8, 0x87,
+	0x9e, 0x28, 0xef, 0x4a, 0x46, 0x79, 0x4f, 0x99, 0x22, 0x7f, 0xa9, 0x84, 0xf7, 0x36, 0xc0, 0xae,
+	0x1d, 0x90, 0xfb, 0x87, 0xdb, 0xe1, 0x30, 0x40, 0x57, 0xa1, 0x78, 0x14, 0x0e, 0x65, 0xa4, 0xd6,
+	0x84, 0xdf, 0x51, 0xa9, 0x3a, 0x63, 0x68, 0xbf, 0x64, 0x6a, 0xec, 0x9f, 0xb9, 0xe6, 0x1c, 0x35,
+	0x52, 0x99, 0x3c, 0x7f, 0x61, 0x26, 0x5f, 0x4b, 0x14, 0x7c, 0xee, 0x37, 0x28, 0x59, 0x2a, 0x78,
+	0xa0, 0x27, 0x4a, 0xfe, 0x2d, 0x68, 0x0a, 0xd9, 0x51, 0x6e, 0x7e, 0x39, 0x2e, 0xc7, 0x71, 0x65,
+	0x2a, 0x44, 0xb5, 0xb7, 0x4f, 0x69, 0xda, 0x1f, 0x14, 0x40, 0x91, 0xe7, 0x63, 0xff, 0xff, 0xaa,
+	0xde, 0x7c, 0x0c, 0xed, 0x94, 0x6a, 0xe2, 0x5e, 0x37, 0xa0, 0x2e, 0xe6, 0x89, 0x01, 0x6d, 0xfa,
+	0x55, 0x25, 0xcb, 0x4f, 0x6a, 0x02, 0x42, 0x29, 0xda, 0x11, 0x2c, 0x6f, 0x87, 0xc3, 0x4d, 0x3b,
+	0x10, 0x51, 0xf4, 0xad, 0xdd, 0x52, 0xdb, 0x80, 0xb6, 0x30, 0xd1, 0x01, 0xad, 0x68, 0x52, 0xd0,
+	0x0b, 0x50, 0x75, 0x8d, 0x31, 0x0e, 0x26, 0x86, 0xc9, 0xf5, 0xad, 0xea, 0x31, 0x41, 0x7b, 0x0b,
+	0x96, 0xd3, 0x9b, 0xc4, 0x45, 0x97, 0xa1, 0xc4, 0xea, 0xa2, 0xd8, 0xc1, 0x17, 0xda, 0x6d, 0x68,
+	0x53, 0xa7, 0x8c, 0xaa, 0xc3, 0xd7, 0x9a, 0x60, 0xb4, 0x0f, 0x61, 0x39, 0xbd, 0x5b, 0xc8, 0xba,
+	0x96, 0xf0, 0xb7, 0x84, 0x83, 0x4b, 0x7f, 0x8b, 0x1d, 0xed, 0x4f, 0x0a, 0x2c, 0x08, 0xea, 0x1c,
+	0x2f, 0x9f, 0x37, 0x28, 0xfd, 0x2f, 0xfa, 0xec, 0xa5, 0x9e, 0x65, 0xc9, 0xbb, 0x7f, 0xbd, 0x11,
+	0x2f, 0x1e, 0x5b, 0xf2, 0xcf, 0x1c, 0x5b, 0x7e, 0xa3, 0x40, 0xbb, 0x67, 0x59, 0x71, 0xa7, 0x28,
+	0x44, 0xc5, 0xb7, 0x51, 0xe6, 0xdc, 0x26, 0xa1, 0x50, 0x7e, 0xfe, 0x4c, 0xf6, 0xec, 0x69, 0x4b,
+	0x2b, 0x43, 0x71, 0xcf, 0xf3, 0x26, 0x1a, 0x86, 0x15, 0xde, 0xa4, 0x7e, 0xab, 0x4a, 0x69, 0x9f,
+	0x29, 0x80, 0xfa, 0x3e, 0x36, 0x48, 0xda, 0xcf, 0x2f, 0xf9, 0xc6, 0x1f, 0xd0, 0xd2, 0x32, 0x31,
+	0x86, 0xb6, 0x63, 0x13, 0x1b, 0xa7, 0xb2, 0x31, 0x3b, 0xae, 0x2f, 0x99, 0x67, 0x77, 0x8a, 0x9f,
+	0x7f, 0x79, 0x35, 0xa7, 0xa7, 0xe0, 0xe8, 0x26, 0x2c, 0x9e, 0x18, 0x8e, 0x6d, 0x0d, 0xac, 0x90,
+	0xd7, 0x6a, 0xb5, 0x90, 0x95, 0x02, 0x1a, 0x0c, 0xb4, 0x29, 0x30, 0xda, 0x9b, 0xd0, 0x4e, 0x69,
+	0x3c, 0x37, 0xc8, 0xde, 0x81, 0x66, 0x9f, 0x27, 0x10, 0x99, 0x7e, 0x9e, 0x11, 0xc3, 0xaf, 0x40,
+	0x5d, 0x6c, 0x60, 0xc7, 0x5f, 0x70, 0xec, 0x1b, 0x50, 0x65, 0x6c, 0x56, 0xaa, 0x5e, 0x04, 0x98,
+	0x84, 0x43, 0xc7, 0x36, 0x13, 0xed, 0x73, 0x95, 0x53, 0xee, 0xe2, 0x33, 0xad, 0xcf, 0xe3, 0x5c,
+	0x3c, 0x5e, 0x14, 0xe7, 0xcb, 0x50, 0x62, 0xde, 0xc7, 0x36, 0x94, 0x74, 0xbe, 0x40, 0x2b, 0x50,
+	0x1e, 0x1b, 0xfe, 0x31, 0xf6, 0x45, 0xb3, 0x2d, 0x56, 0xda, 0xcf, 0x60, 0x39, 0x7d, 0x48, 0x1c,
+	0xee, 0xb2, 0xdc, 0x27, 0xc3, 0x5d, 0x5a, 0x2a, 0x62, 0xa2, 0xab, 0x50, 0x73, 0xf1, 0x2f, 0xc8,
+	0x20, 0x75, 0x3a, 0x50, 0xd2, 0x3d, 0x2e, 0xe1, 0x3d, 0x58, 0x7e, 0x64, 0x10, 0xf3, 0x68, 0x3a,
+	0x1f, 0xbd, 0x04, 0x75, 0x1f, 0x07, 0xe1, 0x18, 0x0f, 0x92, 0xef, 0x50, 0xe3, 0x34, 0xf6, 0x08,
+	0xda, 0x3f, 0xf2, 0xd1, 0xdc, 0xbe, 0x75, 0x82, 0x5d, 0x82, 0x5e, 0x17, 0x99, 0x81, 0x62, 0x17,
+	0xd7, 0xaf, 0x24, 0x12, 0x10, 0xe3, 0xaf, 0x1d, 0x9c, 0x4d, 0xb0, 0x48, 0x18, 0xd3, 0xc7, 0xe7,
+	0x67, 0x8e, 0x4f, 0x3a, 0x63, 0xe1, 0x72, 0x33, 0x59, 0xf1, 0xe2, 0x99, 0x8c, 0xb6, 0xc5, 0x71,
+	0x2b, 0x9d, 0x3d, 0x62, 0x56, 0xa3, 0x39, 0x51, 0xf3, 0xa0, 0x48, 0x95, 0x45, 0x4b, 0xd0, 0x78,
+	0xb0, 0x77, 0x77, 0xef, 0xfe, 0xa3, 0xbd, 0xc1, 0xd6, 0xc3, 0xad, 0xbd, 0x83, 0x56, 0x8e, 0x92,
+	0xf6, 0xb7, 0xf4, 0x87, 0x3b, 0xfd, 0xad, 0x41, 0x6f, 0x73, 0x73, 0x6b, 0xb3, 0xa5, 0xa0, 0x36,
+	0x34, 0x25, 0x49, 0xdf, 0xba, 0x77, 0xff, 0xe1, 0xd6, 0x66, 0x2b, 0x8f, 0x96, 0xa1, 0xb5, 0xdb,
+	0xbb, 0xb3, 0xb5, 0x3b, 0xd8, 0xdd, 0xd9, 0xbb, 0x2b, 0xa0, 0x05, 0xb4, 0x02, 0x28, 0x41, 0x95,
+	0xe8, 0x22, 0xad, 0xe3, 0x0b, 0x8f, 0xf0, 0xf0, 0xc8, 0xf3, 0x8e, 0xe7, 0xa4, 0xe5, 0x94, 0x0f,
+	0xe7, 0xa7, 0x7c, 0x18, 0xb5, 0xa0, 0x10, 0xfa, 0x8e, 0xc8, 0xcb, 0xf4, 0x27, 0x75, 0x2b, 0x4c,
+	0x5f, 0x3e, 0x60, 0xdd, 0x73, 0x55, 0x17, 0x2b, 0xfa, 0x18, 0x26, 0x8b, 0x25, 0x8b, 0xb6, 0xeb,
+	0x99, 0xcd, 0x5e, 0x55, 0x00, 0x7a, 0x44, 0xfb, 0x09, 0x4b, 0xc7, 0x42, 0xbb, 0x4b, 0x95, 0x44,
+	0xa9, 0x4a, 0x3e, 0x4b, 0x95, 0x42, 0x52, 0x15, 0x6d, 0x1f, 0x50, 0xf2, 0x70, 0xe1, 0xdf, 0xaf,
+	0xc2, 0xc2, 0x29, 0x27, 0x25, 0x13, 0x91, 0x44, 0x49, 0x1e, 0x3d, 0x94, 0x8f, 0x91, 0x42, 0x92,
+	0x58, 0x69, 0x37, 0x60, 0x99, 0x67, 0xd1, 0x29, 0xa5, 0x2f, 0x7c, 0x59, 0xed, 0x0a, 0x8f, 0x56,
+	0x81, 0x97, 0x51, 0x20, 0xcb, 0x6d, 0x4c, 0x8e, 0xe3, 0x4f, 0xe8, 0x90, 0x8a, 0x3f, 0x29, 0x2e,
+	0x62, 0x6a, 0x7f, 0xcf, 0x43, 0x53, 0x50, 0x37, 0xb1, 0x63, 0x9f, 0x60, 0xff, 0x6c, 0x8e, 0x7d,
+	0xaf, 0x01, 0x88, 0x9d, 0x59, 0x8d, 0x4a, 0x55, 0xf0, 0x76, 0x2c, 0x9a, 0x45, 0xd8, 0xfb, 0x09,
+	0x63, 0xf3, 0x05, 0x7b, 0x0e, 0x62, 0x90, 0x30, 0x10, 0x53, 0xb7, 0x58, 0xa1, 0x0e, 0x54, 0x44,
+	0x77, 0xce, 0x3f, 0x57, 0x96, 0xf4, 0x68, 0x4d, 0x13, 0x04, 0x47, 0x0d, 0x4c, 0xcf, 0xe2, 0x4d,
+	0x7b, 0x49, 0x07, 0x4e, 0xea, 0x7b, 0x16, 0x4b, 0xb0, 0xd8, 0xf7, 0x3d, 0x5f, 0x0c, 0xda, 0x7c,
+	0x31, 0xe5, 0x41, 0x95, 0xf9, 0x1e, 0x44, 0x5b, 0x3e, 0x8b, 0xdf, 0x9e, 0xe3, 0xab, 0x59, 0xf8,
+	0x5a, 0x04, 0xe9, 0x11, 0xed, 0x53, 0x78, 0x21, 0xf1, 0xf0, 0xe2, 0xe9, 0xec, 0x38, 0x3d, 0xa5,
+	0x5f, 0x4a, 0x99, 0xfb, 0x52, 0x3c, 0xdf, 0xe6, 0x13, 0xf9, 0x56, 0x3b, 0x80, 0x17, 0x2f, 0x38,
+	0x5e, 0x18, 0x78, 0x03, 0xc0, 0x8a, 0xa8, 0xc9, 0xe1, 0x7e, 0xca, 0x98, 0x7a, 0x02, 0xb6, 0xfe,
+	0xc7, 0x62, 0x54, 0x76, 0xa2, 0x59, 0xff, 0x5d, 0x80, 0x9e, 0x65, 0x89, 0x25, 0xca, 0x18, 0x02,
+	0x3a, 0xed, 0x14, 0x4d, 0x7c, 0xfe, 0xcd, 0xa1, 0xf7, 0xa1, 0xc1, 0x7d, 0xf8, 0x1b, 0xec, 0xed,
+	0x43, 0x3d, 0xd9, 0x25, 0xa2, 0xe7, 0x58, 0xa2, 0x9b, 0xed, 0x3a, 0x3b, 0xea, 0x2c, 0x23, 0x3a,
+	0xe4, 0x16, 0xd4, 0x3e, 0xc2, 0xc4, 0x3c, 0xe2, 0x9f, 0x8c, 0x10, 0xfb, 0x98, 0x97, 0xfa, 0xaa,
+	0xd5, 0x41, 0x49, 0x52, 0xb4, 0xef, 0x36, 0x2c, 0xee, 0x13, 0x1f, 0x1b, 0xe3, 0xe8, 0xa3, 0x42,
+	0x73, 0x6a, 0xc6, 0xe7, 0x6a, 0x4f, 0x7d, 0x55, 0xd1, 0x72, 0xd7, 0x95, 0x1b, 0x0a, 0x7a, 0x1b,
+	0x16, 0xe8, 0x14, 0x44, 0x87, 0x6f, 0x39, 0xa2, 0xd1, 0x75, 0xa7, 0x9d, 0x58, 0x24, 0x84, 0x7d,
+	0x0f, 0x1a, 0xa9, 0xd1, 0x00, 0xc9, 0xef, 0x09, 0x33, 0xd3, 0x42, 0x87, 0xb9, 0x07, 0x6b, 0xb2,
+	0x72, 0x34, 0xbf, 0xf4, 0x1c, 0x87, 0x8d, 0x85, 0x11, 0xb9, 0xb3, 0x28, 0x1f, 0x83, 0x0f, 0x8c,
+	0x5a, 0x0e, 0xfd, 0x18, 0xda, 0x62, 0x77, 0xb2, 0xc1, 0xe7, 0xcf, 0x99, 0x31, 0x27, 0x74, 0xd4,
+	0x59, 0x86, 0xd4, 0x74, 0xfd, 0x6f, 0x65, 0x58, 0x12, 0xce, 0x71, 0xcf, 0x70, 0x8d, 0x11, 0x1e,
+	0xd3, 0x90, 0xdd, 0x80, 0x4a, 0xd4, 0xa1, 0xb4, 0xc5, 0x73, 0x26, 0xdb, 0x96, 0x4e, 0x2b, 0x41,
+	0xe4, 0x65, 0x37, 0x87, 0xde, 0x61, 0x3e, 0x25, 0x2a, 0x21, 0x62, 0x75, 0x76, 0xa6, 0x5f, 0x4e,
+	0x5d, 0x77, 0x03, 0xea, 0xc9, 0x3e, 0x97, 0x5f, 0x20, 0xa3, 0xf3, 0x4d, 0x6d, 0x7a, 0x0f, 0x9a,
+	0x53, 0xad, 0x28, 0xea, 0x50, 0x76, 0x76, 0x7f, 0x9a, 0xda, 0xfa, 0x23, 0xa8, 0x25, 0x7a, 0x35,
+	0xb4, 0xc2, 0xee, 0x30, 0xd3, 0x6e, 0x76, 0x9e, 0x9b, 0xa1, 0x47, 0x76, 0xbd, 0x09, 0x8d, 0x9d,
+	0x20, 0x08, 0xe9, 0x47, 0x18, 0x7e, 0x46, 0x6c, 0xa6, 0x39, 0xbb, 0xd6, 0x60, 0xe9, 0x63, 0x4c,
+	0x0e, 0xc4, 0xc7, 0x48, 0xde, 0x88, 0x25, 0x76, 0x36, 0xa2, 0x0e, 0x95, 0x36, 0x70, 0x71, 0x9c,
+	0xc8, 0xf6, 0x2a, 0x8e, 0x93, 0xa9, 0xae, 0xad, 0xa3, 0xce, 0x32, 0x22, 0xa1, 0x1f, 0x42, 0x23,
+	0xd5, 0x41, 0x71, 0x17, 0xcc, 0x6a, 0xaa, 0x3a, 0xad, 0xe9, 0x96, 0x48, 0xcb, 0xdd, 0x50, 0xd0,
+	0x07, 0xcc, 0x9c, 0xb2, 0xfa, 0x4b, 0x73, 0xa6, 0x4b, 0x57, 0x67, 0x65, 0x9a, 0x9c, 0x0c, 0x81,
+	0x54, 0xb1, 0xe3, 0xf2, 0xb3, 0xea, 0x5f, 0xca, 0x46, 0xe2, 0xee, 0x02, 0x91, 0xb8, 0xfb, 0x54,
+	0x0d, 0xec, 0xa8, 0xb3, 0x8c, 0x48, 0xf6, 0x4f, 0xe1, 0x4a, 0x66, 0x1e, 0x45, 0xab, 0x53, 0x9b,
+	0x66, 0x32, 0x78, 0xe7, 0xa5, 0x39, 0x08, 0x79, 0xfe, 0x9d, 0x9b, 0x8f, 0x9f, 0x74, 0x73, 0x5f,
+	0x3c, 0xe9, 0xe6, 0xbe, 0x7a, 0xd2, 0x55, 0x7e, 0x75, 0xde, 0x55, 0xfe, 0x72, 0xde, 0x55, 0x3e,
+	0x3f, 0xef, 0x2a, 0x8f, 0xcf, 0xbb, 0xca, 0xbf, 0xcf, 0xbb, 0xca, 0x7f, 0xce, 0xbb, 0xb9, 0xaf,
+	0xce, 0xbb, 0xca, 0xef, 0x9e, 0x76, 0x73, 0x8f, 0x9f, 0x76, 0x73, 0x5f, 0x3c, 0xed, 0xe6, 0x86,
+	0x65, 0xf6, 0x3f, 0xe1, 0xc6, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xae, 0x97, 0x6c, 0x38, 0xb8,
+	0x1c, 0x00, 0x00,
+}
+
+func (x ServiceEvent_Type) String() string {
+	s, ok := ServiceEvent_Type_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *ServiceRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ConfigResponse)
+	that1, ok := that.(*ServiceRequest)
 	if !ok {
-		that2, ok := that.(ConfigResponse)
+		that2, ok := that.(ServiceRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2466,37 +3023,39 @@ func (this *ConfigResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.TlsKey, that1.TlsKey) {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	if !bytes.Equal(this.TlsCert, that1.TlsCert) {
+	if !this.Hub.Equal(that1.Hub) {
 		return false
 	}
-	if !bytes.Equal(this.TokenPub, that1.TokenPub) {
+	if !this.Id.Equal(that1.Id) {
 		return false
 	}
-	if this.S3AccessKey != that1.S3AccessKey {
+	if this.Type != that1.Type {
 		return false
 	}
-	if this.S3SecretKey != that1.S3SecretKey {
+	if !this.Labels.Equal(that1.Labels) {
 		return false
 	}
-	if this.S3Bucket != that1.S3Bucket {
+	if len(this.Metadata) != len(that1.Metadata) {
 		return false
 	}
-	if this.ImageTag != that1.ImageTag {
-		return false
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *HubChange) Equal(that interface{}) bool {
+func (this *ServiceResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubChange)
+	that1, ok := that.(*ServiceResponse)
 	if !ok {
-		that2, ok := that.(HubChange)
+		that2, ok := that.(ServiceResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2508,22 +3067,16 @@ func (this *HubChange) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.OldId.Equal(that1.OldId) {
-		return false
-	}
-	if !this.NewId.Equal(that1.NewId) {
-		return false
-	}
 	return true
 }
-func (this *CentralActivity) Equal(that interface{}) bool {
+func (this *LabelLink) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CentralActivity)
+	that1, ok := that.(*LabelLink)
 	if !ok {
-		that2, ok := that.(CentralActivity)
+		that2, ok := that.(LabelLink)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2535,33 +3088,28 @@ func (this *CentralActivity) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.AccountServices) != len(that1.AccountServices) {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	for i := range this.AccountServices {
-		if !this.AccountServices[i].Equal(that1.AccountServices[i]) {
-			return false
-		}
-	}
-	if this.RequestStats != that1.RequestStats {
+	if !this.Labels.Equal(that1.Labels) {
 		return false
 	}
-	if !this.NewLabelLinks.Equal(that1.NewLabelLinks) {
+	if !this.Target.Equal(that1.Target) {
 		return false
 	}
-	if !this.HubChange.Equal(that1.HubChange) {
+	if !this.Limits.Equal(that1.Limits) {
 		return false
 	}
 	return true
 }
-func (this *HubActivity) Equal(that interface{}) bool {
+func (this *LabelLinks) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubActivity)
+	that1, ok := that.(*LabelLinks)
 	if !ok {
-		that2, ok := that.(HubActivity)
+		that2, ok := that.(LabelLinks)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2573,33 +3121,24 @@ func (this *HubActivity) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.HubReg.Equal(that1.HubReg) {
-		return false
-	}
-	if !this.SentAt.Equal(that1.SentAt) {
-		return false
-	}
-	if !this.Stats.Equal(that1.Stats) {
-		return false
-	}
-	if len(this.Flow) != len(that1.Flow) {
+	if len(this.LabelLinks) != len(that1.LabelLinks) {
 		return false
 	}
-	for i := range this.Flow {
-		if !this.Flow[i].Equal(that1.Flow[i]) {
+	for i := range this.LabelLinks {
+		if !this.LabelLinks[i].Equal(that1.LabelLinks[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *HubActivity_HubRegistration) Equal(that interface{}) bool {
+func (this *ServiceRoute) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubActivity_HubRegistration)
+	that1, ok := that.(*ServiceRoute)
 	if !ok {
-		that2, ok := that.(HubActivity_HubRegistration)
+		that2, ok := that.(ServiceRoute)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2614,27 +3153,33 @@ func (this *HubActivity_HubRegistration) Equal(that interface{}) bool {
 	if !this.Hub.Equal(that1.Hub) {
 		return false
 	}
-	if !this.StableHub.Equal(that1.StableHub) {
+	if !this.Id.Equal(that1.Id) {
 		return false
 	}
-	if len(this.Locations) != len(that1.Locations) {
+	if this.Type != that1.Type {
 		return false
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
+	}
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *HubActivity_HubStats) Equal(that interface{}) bool {
+func (this *AccountServices) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubActivity_HubStats)
+	that1, ok := that.(*AccountServices)
 	if !ok {
-		that2, ok := that.(HubActivity_HubStats)
+		that2, ok := that.(AccountServices)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2646,34 +3191,27 @@ func (this *HubActivity_HubStats) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.AgentConnections != that1.AgentConnections {
-		return false
-	}
-	if this.AccountsSeen != that1.AccountsSeen {
-		return false
-	}
-	if this.AccountsCached != that1.AccountsCached {
-		return false
-	}
-	if this.CachedBytes != that1.CachedBytes {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	if !this.LastSuccessfulUpdate.Equal(that1.LastSuccessfulUpdate) {
+	if len(this.Services) != len(that1.Services) {
 		return false
 	}
-	if !this.LastAttemptedUpdate.Equal(that1.LastAttemptedUpdate) {
-		return false
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *HubInfo) Equal(that interface{}) bool {
+func (this *ActivityEntry) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubInfo)
+	that1, ok := that.(*ActivityEntry)
 	if !ok {
-		that2, ok := that.(HubInfo)
+		that2, ok := that.(ActivityEntry)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2685,27 +3223,34 @@ func (this *HubInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Id.Equal(that1.Id) {
+	if !this.RouteAdded.Equal(that1.RouteAdded) {
 		return false
 	}
-	if len(this.Locations) != len(that1.Locations) {
+	if !this.RouteRemoved.Equal(that1.RouteRemoved) {
 		return false
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
-			return false
-		}
+	if !this.ServiceAdded.Equal(that1.ServiceAdded) {
+		return false
+	}
+	if !this.ServiceRemoved.Equal(that1.ServiceRemoved) {
+		return false
+	}
+	if !this.LabelLinkAdded.Equal(that1.LabelLinkAdded) {
+		return false
+	}
+	if !this.LabelLinkRemoved.Equal(that1.LabelLinkRemoved) {
+		return false
 	}
 	return true
 }
-func (this *ListOfHubs) Equal(that interface{}) bool {
+func (this *ServiceChange) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListOfHubs)
+	that1, ok := that.(*ServiceChange)
 	if !ok {
-		that2, ok := that.(ListOfHubs)
+		that2, ok := that.(ServiceChange)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2717,24 +3262,22 @@ func (this *ListOfHubs) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Hubs) != len(that1.Hubs) {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	for i := range this.Hubs {
-		if !this.Hubs[i].Equal(that1.Hubs[i]) {
-			return false
-		}
+	if !this.Service.Equal(that1.Service) {
+		return false
 	}
 	return true
 }
-func (this *HubSync) Equal(that interface{}) bool {
+func (this *ConfigRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubSync)
+	that1, ok := that.(*ConfigRequest)
 	if !ok {
-		that2, ok := that.(HubSync)
+		that2, ok := that.(ConfigRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2746,30 +3289,30 @@ func (this *HubSync) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Id.Equal(that1.Id) {
+	if !this.StableId.Equal(that1.StableId) {
 		return false
 	}
-	if !this.StableId.Equal(that1.StableId) {
+	if !this.InstanceId.Equal(that1.InstanceId) {
 		return false
 	}
-	if len(this.Services) != len(that1.Services) {
+	if len(this.Locations) != len(that1.Locations) {
 		return false
 	}
-	for i := range this.Services {
-		if !this.Services[i].Equal(that1.Services[i]) {
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *HubSyncResponse) Equal(that interface{}) bool {
+func (this *ConfigResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubSyncResponse)
+	that1, ok := that.(*ConfigResponse)
 	if !ok {
-		that2, ok := that.(HubSyncResponse)
+		that2, ok := that.(ConfigResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2781,54 +3324,37 @@ func (this *HubSyncResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.ServiceCount != that1.ServiceCount {
+	if !bytes.Equal(this.TlsKey, that1.TlsKey) {
 		return false
 	}
-	return true
-}
-func (this *HubRegisterRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*HubRegisterRequest)
-	if !ok {
-		that2, ok := that.(HubRegisterRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+	if !bytes.Equal(this.TlsCert, that1.TlsCert) {
+		return false
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
+	if !bytes.Equal(this.TokenPub, that1.TokenPub) {
 		return false
 	}
-	if !this.StableId.Equal(that1.StableId) {
+	if this.S3AccessKey != that1.S3AccessKey {
 		return false
 	}
-	if !this.InstanceId.Equal(that1.InstanceId) {
+	if this.S3SecretKey != that1.S3SecretKey {
 		return false
 	}
-	if len(this.Locations) != len(that1.Locations) {
+	if this.S3Bucket != that1.S3Bucket {
 		return false
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
-			return false
-		}
+	if this.ImageTag != that1.ImageTag {
+		return false
 	}
 	return true
 }
-func (this *HubRegisterResponse) Equal(that interface{}) bool {
+func (this *HubChange) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubRegisterResponse)
+	that1, ok := that.(*HubChange)
 	if !ok {
-		that2, ok := that.(HubRegisterResponse)
+		that2, ok := that.(HubChange)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2840,19 +3366,22 @@ func (this *HubRegisterResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.ControlTime.Equal(that1.ControlTime) {
+	if !this.OldId.Equal(that1.OldId) {
+		return false
+	}
+	if !this.NewId.Equal(that1.NewId) {
 		return false
 	}
 	return true
 }
-func (this *HubDisconnectRequest) Equal(that interface{}) bool {
+func (this *CentralActivity) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubDisconnectRequest)
+	that1, ok := that.(*CentralActivity)
 	if !ok {
-		that2, ok := that.(HubDisconnectRequest)
+		that2, ok := that.(CentralActivity)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2864,22 +3393,33 @@ func (this *HubDisconnectRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.StableId.Equal(that1.StableId) {
+	if len(this.AccountServices) != len(that1.AccountServices) {
 		return false
 	}
-	if !this.InstanceId.Equal(that1.InstanceId) {
+	for i := range this.AccountServices {
+		if !this.AccountServices[i].Equal(that1.AccountServices[i]) {
+			return false
+		}
+	}
+	if this.RequestStats != that1.RequestStats {
+		return false
+	}
+	if !this.NewLabelLinks.Equal(that1.NewLabelLinks) {
+		return false
+	}
+	if !this.HubChange.Equal(that1.HubChange) {
 		return false
 	}
 	return true
 }
-func (this *ServiceTokenRequest) Equal(that interface{}) bool {
+func (this *HubActivity) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ServiceTokenRequest)
+	that1, ok := that.(*HubActivity)
 	if !ok {
-		that2, ok := that.(ServiceTokenRequest)
+		that2, ok := that.(HubActivity)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2891,19 +3431,33 @@ func (this *ServiceTokenRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Namespace != that1.Namespace {
+	if !this.HubReg.Equal(that1.HubReg) {
+		return false
+	}
+	if !this.SentAt.Equal(that1.SentAt) {
+		return false
+	}
+	if !this.Stats.Equal(that1.Stats) {
+		return false
+	}
+	if len(this.Flow) != len(that1.Flow) {
 		return false
 	}
+	for i := range this.Flow {
+		if !this.Flow[i].Equal(that1.Flow[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ServiceTokenResponse) Equal(that interface{}) bool {
+func (this *HubActivity_HubRegistration) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ServiceTokenResponse)
+	that1, ok := that.(*HubActivity_HubRegistration)
 	if !ok {
-		that2, ok := that.(ServiceTokenResponse)
+		that2, ok := that.(HubActivity_HubRegistration)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2915,19 +3469,30 @@ func (this *ServiceTokenResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if !this.Hub.Equal(that1.Hub) {
+		return false
+	}
+	if !this.StableHub.Equal(that1.StableHub) {
+		return false
+	}
+	if len(this.Locations) != len(that1.Locations) {
 		return false
 	}
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ListServicesRequest) Equal(that interface{}) bool {
+func (this *HubActivity_HubStats) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListServicesRequest)
+	that1, ok := that.(*HubActivity_HubStats)
 	if !ok {
-		that2, ok := that.(ListServicesRequest)
+		that2, ok := that.(HubActivity_HubStats)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2939,19 +3504,34 @@ func (this *ListServicesRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if this.AgentConnections != that1.AgentConnections {
+		return false
+	}
+	if this.AccountsSeen != that1.AccountsSeen {
+		return false
+	}
+	if this.AccountsCached != that1.AccountsCached {
+		return false
+	}
+	if this.CachedBytes != that1.CachedBytes {
+		return false
+	}
+	if !this.LastSuccessfulUpdate.Equal(that1.LastSuccessfulUpdate) {
+		return false
+	}
+	if !this.LastAttemptedUpdate.Equal(that1.LastAttemptedUpdate) {
 		return false
 	}
 	return true
 }
-func (this *ListServicesResponse) Equal(that interface{}) bool {
+func (this *HubInfo) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListServicesResponse)
+	that1, ok := that.(*HubInfo)
 	if !ok {
-		that2, ok := that.(ListServicesResponse)
+		that2, ok := that.(HubInfo)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2963,24 +3543,27 @@ func (this *ListServicesResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Services) != len(that1.Services) {
+	if !this.Id.Equal(that1.Id) {
 		return false
 	}
-	for i := range this.Services {
-		if !this.Services[i].Equal(that1.Services[i]) {
+	if len(this.Locations) != len(that1.Locations) {
+		return false
+	}
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *Service) Equal(that interface{}) bool {
+func (this *ListOfHubs) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Service)
+	that1, ok := that.(*ListOfHubs)
 	if !ok {
-		that2, ok := that.(Service)
+		that2, ok := that.(ListOfHubs)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2992,36 +3575,59 @@ func (this *Service) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Id.Equal(that1.Id) {
+	if len(this.Hubs) != len(that1.Hubs) {
 		return false
 	}
-	if !this.Hub.Equal(that1.Hub) {
+	for i := range this.Hubs {
+		if !this.Hubs[i].Equal(that1.Hubs[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *HubSync) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*HubSync)
+	if !ok {
+		that2, ok := that.(HubSync)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if !this.Id.Equal(that1.Id) {
 		return false
 	}
-	if !this.Labels.Equal(that1.Labels) {
+	if !this.StableId.Equal(that1.StableId) {
 		return false
 	}
-	if len(this.Metadata) != len(that1.Metadata) {
+	if len(this.Services) != len(that1.Services) {
 		return false
 	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *AddAccountRequest) Equal(that interface{}) bool {
+func (this *HubSyncResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AddAccountRequest)
+	that1, ok := that.(*HubSyncResponse)
 	if !ok {
-		that2, ok := that.(AddAccountRequest)
+		that2, ok := that.(HubSyncResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3033,22 +3639,19 @@ func (this *AddAccountRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
-		return false
-	}
-	if !this.Limits.Equal(that1.Limits) {
+	if this.ServiceCount != that1.ServiceCount {
 		return false
 	}
 	return true
 }
-func (this *AddLabelLinkRequest) Equal(that interface{}) bool {
+func (this *HubRegisterRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AddLabelLinkRequest)
+	that1, ok := that.(*HubRegisterRequest)
 	if !ok {
-		that2, ok := that.(AddLabelLinkRequest)
+		that2, ok := that.(HubRegisterRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3060,25 +3663,30 @@ func (this *AddLabelLinkRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Labels.Equal(that1.Labels) {
+	if !this.StableId.Equal(that1.StableId) {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if !this.InstanceId.Equal(that1.InstanceId) {
 		return false
 	}
-	if !this.Target.Equal(that1.Target) {
+	if len(this.Locations) != len(that1.Locations) {
 		return false
 	}
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *Noop) Equal(that interface{}) bool {
+func (this *HubRegisterResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Noop)
+	that1, ok := that.(*HubRegisterResponse)
 	if !ok {
-		that2, ok := that.(Noop)
+		that2, ok := that.(HubRegisterResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3090,16 +3698,19 @@ func (this *Noop) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if !this.ControlTime.Equal(that1.ControlTime) {
+		return false
+	}
 	return true
 }
-func (this *RemoveLabelLinkRequest) Equal(that interface{}) bool {
+func (this *HubDisconnectRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*RemoveLabelLinkRequest)
+	that1, ok := that.(*HubDisconnectRequest)
 	if !ok {
-		that2, ok := that.(RemoveLabelLinkRequest)
+		that2, ok := that.(HubDisconnectRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3111,22 +3722,22 @@ func (this *RemoveLabelLinkRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Labels.Equal(that1.Labels) {
+	if !this.StableId.Equal(that1.StableId) {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if !this.InstanceId.Equal(that1.InstanceId) {
 		return false
 	}
 	return true
 }
-func (this *CreateTokenRequest) Equal(that interface{}) bool {
+func (this *ServiceTokenRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CreateTokenRequest)
+	that1, ok := that.(*ServiceTokenRequest)
 	if !ok {
-		that2, ok := that.(CreateTokenRequest)
+		that2, ok := that.(ServiceTokenRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3138,30 +3749,43 @@ func (this *CreateTokenRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if this.Namespace != that1.Namespace {
 		return false
 	}
-	if len(this.Capabilities) != len(that1.Capabilities) {
-		return false
+	return true
+}
+func (this *ServiceTokenResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	for i := range this.Capabilities {
-		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+
+	that1, ok := that.(*ServiceTokenResponse)
+	if !ok {
+		that2, ok := that.(ServiceTokenResponse)
+		if ok {
+			that1 = &that2
+		} else {
 			return false
 		}
 	}
-	if !this.ValidDuration.Equal(that1.ValidDuration) {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Token != that1.Token {
 		return false
 	}
 	return true
 }
-func (this *CreateTokenResponse) Equal(that interface{}) bool {
+func (this *ListServicesRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CreateTokenResponse)
+	that1, ok := that.(*ListServicesRequest)
 	if !ok {
-		that2, ok := that.(CreateTokenResponse)
+		that2, ok := that.(ListServicesRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3173,19 +3797,19 @@ func (this *CreateTokenResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
 	return true
 }
-func (this *ControlRegister) Equal(that interface{}) bool {
+func (this *ListServicesResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ControlRegister)
+	that1, ok := that.(*ListServicesResponse)
 	if !ok {
-		that2, ok := that.(ControlRegister)
+		that2, ok := that.(ListServicesResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3197,19 +3821,24 @@ func (this *ControlRegister) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Namespace != that1.Namespace {
+	if len(this.Services) != len(that1.Services) {
 		return false
 	}
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ControlToken) Equal(that interface{}) bool {
+func (this *Service) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ControlToken)
+	that1, ok := that.(*Service)
 	if !ok {
-		that2, ok := that.(ControlToken)
+		that2, ok := that.(Service)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3221,19 +3850,36 @@ func (this *ControlToken) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if !this.Id.Equal(that1.Id) {
+		return false
+	}
+	if !this.Hub.Equal(that1.Hub) {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if len(this.Metadata) != len(that1.Metadata) {
 		return false
 	}
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *TokenInfo) Equal(that interface{}) bool {
+func (this *AddAccountRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TokenInfo)
+	that1, ok := that.(*AddAccountRequest)
 	if !ok {
-		that2, ok := that.(TokenInfo)
+		that2, ok := that.(AddAccountRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3245,19 +3891,22 @@ func (this *TokenInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+	if !this.Account.Equal(that1.Account) {
+		return false
+	}
+	if !this.Limits.Equal(that1.Limits) {
 		return false
 	}
 	return true
 }
-func (this *ListAccountsRequest) Equal(that interface{}) bool {
+func (this *AddLabelLinkRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListAccountsRequest)
+	that1, ok := that.(*AddLabelLinkRequest)
 	if !ok {
-		that2, ok := that.(ListAccountsRequest)
+		that2, ok := that.(AddLabelLinkRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3269,22 +3918,25 @@ func (this *ListAccountsRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Limit != that1.Limit {
+	if !this.Labels.Equal(that1.Labels) {
 		return false
 	}
-	if !bytes.Equal(this.Marker, that1.Marker) {
+	if !this.Account.Equal(that1.Account) {
+		return false
+	}
+	if !this.Target.Equal(that1.Target) {
 		return false
 	}
 	return true
 }
-func (this *ListAccountsResponse) Equal(that interface{}) bool {
+func (this *Noop) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListAccountsResponse)
+	that1, ok := that.(*Noop)
 	if !ok {
-		that2, ok := that.(ListAccountsResponse)
+		that2, ok := that.(Noop)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3296,417 +3948,645 @@ func (this *ListAccountsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Accounts) != len(that1.Accounts) {
-		return false
+	return true
+}
+func (this *RemoveLabelLinkRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	for i := range this.Accounts {
-		if !this.Accounts[i].Equal(that1.Accounts[i]) {
+
+	that1, ok := that.(*RemoveLabelLinkRequest)
+	if !ok {
+		that2, ok := that.(RemoveLabelLinkRequest)
+		if ok {
+			that1 = &that2
+		} else {
 			return false
 		}
 	}
-	if !bytes.Equal(this.NextMarker, that1.NextMarker) {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
 	return true
 }
-func (this *ServiceRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *CreateTokenRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&pb.ServiceRequest{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+
+	that1, ok := that.(*CreateTokenRequest)
+	if !ok {
+		that2, ok := that.(CreateTokenRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if len(this.Capabilities) != len(that1.Capabilities) {
+		return false
 	}
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	for i := range this.Capabilities {
+		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ServiceResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.ValidDuration.Equal(that1.ValidDuration) {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&pb.ServiceResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelLink) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.LabelLink{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+func (this *CreateTokenResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+
+	that1, ok := that.(*CreateTokenResponse)
+	if !ok {
+		that2, ok := that.(CreateTokenResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Target != nil {
-		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Limits != nil {
-		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
+	if this.Token != that1.Token {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelLinks) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ControlRegister) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.LabelLinks{")
-	if this.LabelLinks != nil {
-		s = append(s, "LabelLinks: "+fmt.Sprintf("%#v", this.LabelLinks)+",\n")
+
+	that1, ok := that.(*ControlRegister)
+	if !ok {
+		that2, ok := that.(ControlRegister)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	return true
 }
-func (this *ServiceRoute) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ControlToken) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.ServiceRoute{")
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+
+	that1, ok := that.(*ControlToken)
+	if !ok {
+		that2, ok := that.(ControlToken)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Token != that1.Token {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *AccountServices) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *TokenInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.AccountServices{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+
+	that1, ok := that.(*TokenInfo)
+	if !ok {
+		that2, ok := that.(TokenInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Services != nil {
-		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+		return false
+	}
+	return true
 }
-func (this *ActivityEntry) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ListAccountsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ActivityEntry{")
-	if this.RouteAdded != nil {
-		s = append(s, "RouteAdded: "+fmt.Sprintf("%#v", this.RouteAdded)+",\n")
+
+	that1, ok := that.(*ListAccountsRequest)
+	if !ok {
+		that2, ok := that.(ListAccountsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.RouteRemoved != nil {
-		s = append(s, "RouteRemoved: "+fmt.Sprintf("%#v", this.RouteRemoved)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if this.Limit != that1.Limit {
+		return false
+	}
+	if !bytes.Equal(this.Marker, that1.Marker) {
+		return false
+	}
+	return true
 }
-func (this *ConfigRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ListAccountsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.ConfigRequest{")
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+
+	that1, ok := that.(*ListAccountsResponse)
+	if !ok {
+		that2, ok := that.(ListAccountsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.InstanceId != nil {
-		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if len(this.Accounts) != len(that1.Accounts) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ConfigResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Accounts {
+		if !this.Accounts[i].Equal(that1.Accounts[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 11)
-	s = append(s, "&pb.ConfigResponse{")
-	s = append(s, "TlsKey: "+fmt.Sprintf("%#v", this.TlsKey)+",\n")
-	s = append(s, "TlsCert: "+fmt.Sprintf("%#v", this.TlsCert)+",\n")
-	s = append(s, "TokenPub: "+fmt.Sprintf("%#v", this.TokenPub)+",\n")
-	s = append(s, "S3AccessKey: "+fmt.Sprintf("%#v", this.S3AccessKey)+",\n")
-	s = append(s, "S3SecretKey: "+fmt.Sprintf("%#v", this.S3SecretKey)+",\n")
-	s = append(s, "S3Bucket: "+fmt.Sprintf("%#v", this.S3Bucket)+",\n")
-	s = append(s, "ImageTag: "+fmt.Sprintf("%#v", this.ImageTag)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if !bytes.Equal(this.NextMarker, that1.NextMarker) {
+		return false
+	}
+	return true
 }
-func (this *HubChange) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *WatchServicesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.HubChange{")
-	if this.OldId != nil {
-		s = append(s, "OldId: "+fmt.Sprintf("%#v", this.OldId)+",\n")
+
+	that1, ok := that.(*WatchServicesRequest)
+	if !ok {
+		that2, ok := that.(WatchServicesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.NewId != nil {
-		s = append(s, "NewId: "+fmt.Sprintf("%#v", this.NewId)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *CentralActivity) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.ResumeToken != that1.ResumeToken {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.CentralActivity{")
-	if this.AccountServices != nil {
-		s = append(s, "AccountServices: "+fmt.Sprintf("%#v", this.AccountServices)+",\n")
+	return true
+}
+func (this *ServiceEvent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "RequestStats: "+fmt.Sprintf("%#v", this.RequestStats)+",\n")
-	if this.NewLabelLinks != nil {
-		s = append(s, "NewLabelLinks: "+fmt.Sprintf("%#v", this.NewLabelLinks)+",\n")
+
+	that1, ok := that.(*ServiceEvent)
+	if !ok {
+		that2, ok := that.(ServiceEvent)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.HubChange != nil {
-		s = append(s, "HubChange: "+fmt.Sprintf("%#v", this.HubChange)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubActivity) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Type != that1.Type {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.HubActivity{")
-	if this.HubReg != nil {
-		s = append(s, "HubReg: "+fmt.Sprintf("%#v", this.HubReg)+",\n")
+	if this.ResumeToken != that1.ResumeToken {
+		return false
 	}
-	if this.SentAt != nil {
-		s = append(s, "SentAt: "+fmt.Sprintf("%#v", this.SentAt)+",\n")
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	if this.Stats != nil {
-		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	if !this.Service.Equal(that1.Service) {
+		return false
 	}
-	if this.Flow != nil {
-		s = append(s, "Flow: "+fmt.Sprintf("%#v", this.Flow)+",\n")
+	if !this.LabelLink.Equal(that1.LabelLink) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *HubActivity_HubRegistration) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.HubActivity_HubRegistration{")
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+func (this *Webhook) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.StableHub != nil {
-		s = append(s, "StableHub: "+fmt.Sprintf("%#v", this.StableHub)+",\n")
+
+	that1, ok := that.(*Webhook)
+	if !ok {
+		that2, ok := that.(Webhook)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubActivity_HubStats) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Id.Equal(that1.Id) {
+		return false
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&pb.HubActivity_HubStats{")
-	s = append(s, "AgentConnections: "+fmt.Sprintf("%#v", this.AgentConnections)+",\n")
-	s = append(s, "AccountsSeen: "+fmt.Sprintf("%#v", this.AccountsSeen)+",\n")
-	s = append(s, "AccountsCached: "+fmt.Sprintf("%#v", this.AccountsCached)+",\n")
-	s = append(s, "CachedBytes: "+fmt.Sprintf("%#v", this.CachedBytes)+",\n")
-	if this.LastSuccessfulUpdate != nil {
-		s = append(s, "LastSuccessfulUpdate: "+fmt.Sprintf("%#v", this.LastSuccessfulUpdate)+",\n")
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	if this.LastAttemptedUpdate != nil {
-		s = append(s, "LastAttemptedUpdate: "+fmt.Sprintf("%#v", this.LastAttemptedUpdate)+",\n")
+	if this.Url != that1.Url {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubInfo) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Events) != len(that1.Events) {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.HubInfo{")
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	for i := range this.Events {
+		if this.Events[i] != that1.Events[i] {
+			return false
+		}
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if !this.CreatedAt.Equal(that1.CreatedAt) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ListOfHubs) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *AddWebhookRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListOfHubs{")
-	if this.Hubs != nil {
-		s = append(s, "Hubs: "+fmt.Sprintf("%#v", this.Hubs)+",\n")
+
+	that1, ok := that.(*AddWebhookRequest)
+	if !ok {
+		that2, ok := that.(AddWebhookRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubSync) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.HubSync{")
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+	if this.Url != that1.Url {
+		return false
 	}
-	if this.Services != nil {
-		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	if len(this.Events) != len(that1.Events) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubSyncResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Events {
+		if this.Events[i] != that1.Events[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.HubSyncResponse{")
-	s = append(s, "ServiceCount: "+fmt.Sprintf("%#v", this.ServiceCount)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *HubRegisterRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.HubRegisterRequest{")
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+func (this *AddWebhookResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.InstanceId != nil {
-		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+
+	that1, ok := that.(*AddWebhookResponse)
+	if !ok {
+		that2, ok := that.(AddWebhookResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubRegisterResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Webhook.Equal(that1.Webhook) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.HubRegisterResponse{")
-	if this.ControlTime != nil {
-		s = append(s, "ControlTime: "+fmt.Sprintf("%#v", this.ControlTime)+",\n")
+	if this.Secret != that1.Secret {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *HubDisconnectRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *RemoveWebhookRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.HubDisconnectRequest{")
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+
+	that1, ok := that.(*RemoveWebhookRequest)
+	if !ok {
+		that2, ok := that.(RemoveWebhookRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.InstanceId != nil {
-		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if !this.Id.Equal(that1.Id) {
+		return false
+	}
+	return true
 }
-func (this *ServiceTokenRequest) GoString() string {
+func (this *ListWebhooksRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListWebhooksRequest)
+	if !ok {
+		that2, ok := that.(ListWebhooksRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *ListWebhooksResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListWebhooksResponse)
+	if !ok {
+		that2, ok := that.(ListWebhooksResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Webhooks) != len(that1.Webhooks) {
+		return false
+	}
+	for i := range this.Webhooks {
+		if !this.Webhooks[i].Equal(that1.Webhooks[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *WebhookDelivery) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*WebhookDelivery)
+	if !ok {
+		that2, ok := that.(WebhookDelivery)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Id.Equal(that1.Id) {
+		return false
+	}
+	if !this.WebhookId.Equal(that1.WebhookId) {
+		return false
+	}
+	if this.Event != that1.Event {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if this.Attempts != that1.Attempts {
+		return false
+	}
+	if this.StatusCode != that1.StatusCode {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	if !this.CreatedAt.Equal(that1.CreatedAt) {
+		return false
+	}
+	if !this.DeliveredAt.Equal(that1.DeliveredAt) {
+		return false
+	}
+	return true
+}
+func (this *ListWebhookDeliveriesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListWebhookDeliveriesRequest)
+	if !ok {
+		that2, ok := that.(ListWebhookDeliveriesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.WebhookId.Equal(that1.WebhookId) {
+		return false
+	}
+	if this.Limit != that1.Limit {
+		return false
+	}
+	return true
+}
+func (this *ListWebhookDeliveriesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListWebhookDeliveriesResponse)
+	if !ok {
+		that2, ok := that.(ListWebhookDeliveriesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Deliveries) != len(that1.Deliveries) {
+		return false
+	}
+	for i := range this.Deliveries {
+		if !this.Deliveries[i].Equal(that1.Deliveries[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *ServiceRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ServiceTokenRequest{")
-	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.ServiceRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	}
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	}
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	}
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	}
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ServiceTokenResponse) GoString() string {
+func (this *ServiceResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ServiceTokenResponse{")
-	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.ServiceResponse{")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ListServicesRequest) GoString() string {
+func (this *LabelLink) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListServicesRequest{")
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.LabelLink{")
 	if this.Account != nil {
 		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	}
+	if this.Target != nil {
+		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
+	}
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ListServicesResponse) GoString() string {
+func (this *LabelLinks) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListServicesResponse{")
-	if this.Services != nil {
-		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	s = append(s, "&pb.LabelLinks{")
+	if this.LabelLinks != nil {
+		s = append(s, "LabelLinks: "+fmt.Sprintf("%#v", this.LabelLinks)+",\n")
 	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *Service) GoString() string {
+func (this *ServiceRoute) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 9)
-	s = append(s, "&pb.Service{")
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
-	}
+	s = append(s, "&pb.ServiceRoute{")
 	if this.Hub != nil {
 		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
 	}
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	}
 	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
 	if this.Labels != nil {
 		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
@@ -3717,1520 +4597,1598 @@ func (this *Service) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *AddAccountRequest) GoString() string {
+func (this *AccountServices) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 6)
-	s = append(s, "&pb.AddAccountRequest{")
+	s = append(s, "&pb.AccountServices{")
 	if this.Account != nil {
 		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if this.Limits != nil {
-		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
 	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *AddLabelLinkRequest) GoString() string {
+func (this *ActivityEntry) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.AddLabelLinkRequest{")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.ActivityEntry{")
+	if this.RouteAdded != nil {
+		s = append(s, "RouteAdded: "+fmt.Sprintf("%#v", this.RouteAdded)+",\n")
 	}
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	if this.RouteRemoved != nil {
+		s = append(s, "RouteRemoved: "+fmt.Sprintf("%#v", this.RouteRemoved)+",\n")
 	}
-	if this.Target != nil {
-		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
+	if this.ServiceAdded != nil {
+		s = append(s, "ServiceAdded: "+fmt.Sprintf("%#v", this.ServiceAdded)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *Noop) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.ServiceRemoved != nil {
+		s = append(s, "ServiceRemoved: "+fmt.Sprintf("%#v", this.ServiceRemoved)+",\n")
+	}
+	if this.LabelLinkAdded != nil {
+		s = append(s, "LabelLinkAdded: "+fmt.Sprintf("%#v", this.LabelLinkAdded)+",\n")
+	}
+	if this.LabelLinkRemoved != nil {
+		s = append(s, "LabelLinkRemoved: "+fmt.Sprintf("%#v", this.LabelLinkRemoved)+",\n")
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&pb.Noop{")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *RemoveLabelLinkRequest) GoString() string {
+func (this *ServiceChange) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 6)
-	s = append(s, "&pb.RemoveLabelLinkRequest{")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	}
+	s = append(s, "&pb.ServiceChange{")
 	if this.Account != nil {
 		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
+	if this.Service != nil {
+		s = append(s, "Service: "+fmt.Sprintf("%#v", this.Service)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *CreateTokenRequest) GoString() string {
+func (this *ConfigRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 7)
-	s = append(s, "&pb.CreateTokenRequest{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	s = append(s, "&pb.ConfigRequest{")
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
 	}
-	if this.Capabilities != nil {
-		vs := make([]TokenCapability, len(this.Capabilities))
-		for i := range vs {
-			vs[i] = this.Capabilities[i]
-		}
-		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
+	if this.InstanceId != nil {
+		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
 	}
-	if this.ValidDuration != nil {
-		s = append(s, "ValidDuration: "+fmt.Sprintf("%#v", this.ValidDuration)+",\n")
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
 	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *CreateTokenResponse) GoString() string {
+func (this *ConfigResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.CreateTokenResponse{")
-	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s := make([]string, 0, 11)
+	s = append(s, "&pb.ConfigResponse{")
+	s = append(s, "TlsKey: "+fmt.Sprintf("%#v", this.TlsKey)+",\n")
+	s = append(s, "TlsCert: "+fmt.Sprintf("%#v", this.TlsCert)+",\n")
+	s = append(s, "TokenPub: "+fmt.Sprintf("%#v", this.TokenPub)+",\n")
+	s = append(s, "S3AccessKey: "+fmt.Sprintf("%#v", this.S3AccessKey)+",\n")
+	s = append(s, "S3SecretKey: "+fmt.Sprintf("%#v", this.S3SecretKey)+",\n")
+	s = append(s, "S3Bucket: "+fmt.Sprintf("%#v", this.S3Bucket)+",\n")
+	s = append(s, "ImageTag: "+fmt.Sprintf("%#v", this.ImageTag)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ControlRegister) GoString() string {
+func (this *HubChange) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ControlRegister{")
-	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.HubChange{")
+	if this.OldId != nil {
+		s = append(s, "OldId: "+fmt.Sprintf("%#v", this.OldId)+",\n")
+	}
+	if this.NewId != nil {
+		s = append(s, "NewId: "+fmt.Sprintf("%#v", this.NewId)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ControlToken) GoString() string {
+func (this *CentralActivity) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ControlToken{")
-	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.CentralActivity{")
+	if this.AccountServices != nil {
+		s = append(s, "AccountServices: "+fmt.Sprintf("%#v", this.AccountServices)+",\n")
+	}
+	s = append(s, "RequestStats: "+fmt.Sprintf("%#v", this.RequestStats)+",\n")
+	if this.NewLabelLinks != nil {
+		s = append(s, "NewLabelLinks: "+fmt.Sprintf("%#v", this.NewLabelLinks)+",\n")
+	}
+	if this.HubChange != nil {
+		s = append(s, "HubChange: "+fmt.Sprintf("%#v", this.HubChange)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *TokenInfo) GoString() string {
+func (this *HubActivity) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.TokenInfo{")
-	s = append(s, "PublicKey: "+fmt.Sprintf("%#v", this.PublicKey)+",\n")
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.HubActivity{")
+	if this.HubReg != nil {
+		s = append(s, "HubReg: "+fmt.Sprintf("%#v", this.HubReg)+",\n")
+	}
+	if this.SentAt != nil {
+		s = append(s, "SentAt: "+fmt.Sprintf("%#v", this.SentAt)+",\n")
+	}
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	}
+	if this.Flow != nil {
+		s = append(s, "Flow: "+fmt.Sprintf("%#v", this.Flow)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ListAccountsRequest) GoString() string {
+func (this *HubActivity_HubRegistration) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ListAccountsRequest{")
-	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
-	s = append(s, "Marker: "+fmt.Sprintf("%#v", this.Marker)+",\n")
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubActivity_HubRegistration{")
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	}
+	if this.StableHub != nil {
+		s = append(s, "StableHub: "+fmt.Sprintf("%#v", this.StableHub)+",\n")
+	}
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *ListAccountsResponse) GoString() string {
+func (this *HubActivity_HubStats) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ListAccountsResponse{")
-	if this.Accounts != nil {
-		s = append(s, "Accounts: "+fmt.Sprintf("%#v", this.Accounts)+",\n")
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.HubActivity_HubStats{")
+	s = append(s, "AgentConnections: "+fmt.Sprintf("%#v", this.AgentConnections)+",\n")
+	s = append(s, "AccountsSeen: "+fmt.Sprintf("%#v", this.AccountsSeen)+",\n")
+	s = append(s, "AccountsCached: "+fmt.Sprintf("%#v", this.AccountsCached)+",\n")
+	s = append(s, "CachedBytes: "+fmt.Sprintf("%#v", this.CachedBytes)+",\n")
+	if this.LastSuccessfulUpdate != nil {
+		s = append(s, "LastSuccessfulUpdate: "+fmt.Sprintf("%#v", this.LastSuccessfulUpdate)+",\n")
+	}
+	if this.LastAttemptedUpdate != nil {
+		s = append(s, "LastAttemptedUpdate: "+fmt.Sprintf("%#v", this.LastAttemptedUpdate)+",\n")
 	}
-	s = append(s, "NextMarker: "+fmt.Sprintf("%#v", this.NextMarker)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func valueToGoStringControl(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+func (this *HubInfo) GoString() string {
+	if this == nil {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// ControlServicesClient is the client API for ControlServices service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ControlServicesClient interface {
-	AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
-	FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
-	StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error)
-	SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error)
-	HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error)
-	AllHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ListOfHubs, error)
-	RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error)
-}
-
-type controlServicesClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewControlServicesClient(cc *grpc.ClientConn) ControlServicesClient {
-	return &controlServicesClient{cc}
-}
-
-func (c *controlServicesClient) AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/AddService", in, out, opts...)
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.HubInfo{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	return out, nil
-}
-
-func (c *controlServicesClient) RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/RemoveService", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
 	}
-	return out, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *controlServicesClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
-	out := new(ListServicesResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/ListServices", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *ListOfHubs) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
-}
-
-func (c *controlServicesClient) FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
-	out := new(ConfigResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/FetchConfig", in, out, opts...)
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListOfHubs{")
+	if this.Hubs != nil {
+		s = append(s, "Hubs: "+fmt.Sprintf("%#v", this.Hubs)+",\n")
 	}
-	return out, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *controlServicesClient) StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ControlServices_serviceDesc.Streams[0], "/pb.ControlServices/StreamActivity", opts...)
-	if err != nil {
-		return nil, err
+func (this *HubSync) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	x := &controlServicesStreamActivityClient{stream}
-	return x, nil
-}
-
-type ControlServices_StreamActivityClient interface {
-	Send(*HubActivity) error
-	Recv() (*CentralActivity, error)
-	grpc.ClientStream
-}
-
-type controlServicesStreamActivityClient struct {
-	grpc.ClientStream
-}
-
-func (x *controlServicesStreamActivityClient) Send(m *HubActivity) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *controlServicesStreamActivityClient) Recv() (*CentralActivity, error) {
-	m := new(CentralActivity)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubSync{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	return m, nil
-}
-
-func (c *controlServicesClient) SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error) {
-	out := new(HubSyncResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/SyncHub", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
 	}
-	return out, nil
-}
-
-func (c *controlServicesClient) HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/HubDisconnect", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
 	}
-	return out, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *controlServicesClient) AllHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ListOfHubs, error) {
-	out := new(ListOfHubs)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/AllHubs", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *HubSyncResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.HubSyncResponse{")
+	s = append(s, "ServiceCount: "+fmt.Sprintf("%#v", this.ServiceCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *controlServicesClient) RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error) {
-	out := new(ServiceTokenResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/RequestServiceToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *HubRegisterRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
-}
-
-// ControlServicesServer is the server API for ControlServices service.
-type ControlServicesServer interface {
-	AddService(context.Context, *ServiceRequest) (*ServiceResponse, error)
-	RemoveService(context.Context, *ServiceRequest) (*ServiceResponse, error)
-	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
-	FetchConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
-	StreamActivity(ControlServices_StreamActivityServer) error
-	SyncHub(context.Context, *HubSync) (*HubSyncResponse, error)
-	HubDisconnect(context.Context, *HubDisconnectRequest) (*Noop, error)
-	AllHubs(context.Context, *Noop) (*ListOfHubs, error)
-	RequestServiceToken(context.Context, *ServiceTokenRequest) (*ServiceTokenResponse, error)
-}
-
-// UnimplementedControlServicesServer can be embedded to have forward compatible implementations.
-type UnimplementedControlServicesServer struct {
-}
-
-func (*UnimplementedControlServicesServer) AddService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddService not implemented")
-}
-func (*UnimplementedControlServicesServer) RemoveService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveService not implemented")
-}
-func (*UnimplementedControlServicesServer) ListServices(ctx context.Context, req *ListServicesRequest) (*ListServicesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
-}
-func (*UnimplementedControlServicesServer) FetchConfig(ctx context.Context, req *ConfigRequest) (*ConfigResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FetchConfig not implemented")
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubRegisterRequest{")
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+	}
+	if this.InstanceId != nil {
+		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	}
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (*UnimplementedControlServicesServer) StreamActivity(srv ControlServices_StreamActivityServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamActivity not implemented")
+func (this *HubRegisterResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.HubRegisterResponse{")
+	if this.ControlTime != nil {
+		s = append(s, "ControlTime: "+fmt.Sprintf("%#v", this.ControlTime)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (*UnimplementedControlServicesServer) SyncHub(ctx context.Context, req *HubSync) (*HubSyncResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SyncHub not implemented")
+func (this *HubDisconnectRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.HubDisconnectRequest{")
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+	}
+	if this.InstanceId != nil {
+		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (*UnimplementedControlServicesServer) HubDisconnect(ctx context.Context, req *HubDisconnectRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HubDisconnect not implemented")
+func (this *ServiceTokenRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ServiceTokenRequest{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (*UnimplementedControlServicesServer) AllHubs(ctx context.Context, req *Noop) (*ListOfHubs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AllHubs not implemented")
+func (this *ServiceTokenResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ServiceTokenResponse{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (*UnimplementedControlServicesServer) RequestServiceToken(ctx context.Context, req *ServiceTokenRequest) (*ServiceTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RequestServiceToken not implemented")
+func (this *ListServicesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListServicesRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func RegisterControlServicesServer(s *grpc.Server, srv ControlServicesServer) {
-	s.RegisterService(&_ControlServices_serviceDesc, srv)
+func (this *ListServicesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListServicesResponse{")
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_AddService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServiceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *Service) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).AddService(ctx, in)
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.Service{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/AddService",
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).AddService(ctx, req.(*ServiceRequest))
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ControlServices_RemoveService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServiceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).RemoveService(ctx, in)
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddAccountRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/RemoveService",
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.AddAccountRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).RemoveService(ctx, req.(*ServiceRequest))
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListServicesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *AddLabelLinkRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).ListServices(ctx, in)
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.AddLabelLinkRequest{")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/ListServices",
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).ListServices(ctx, req.(*ListServicesRequest))
+	if this.Target != nil {
+		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_FetchConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConfigRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *Noop) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).FetchConfig(ctx, in)
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.Noop{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RemoveLabelLinkRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/FetchConfig",
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.RemoveLabelLinkRequest{")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).FetchConfig(ctx, req.(*ConfigRequest))
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_StreamActivity_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ControlServicesServer).StreamActivity(&controlServicesStreamActivityServer{stream})
+func (this *CreateTokenRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.CreateTokenRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	}
+	if this.Capabilities != nil {
+		vs := make([]TokenCapability, len(this.Capabilities))
+		for i := range vs {
+			vs[i] = this.Capabilities[i]
+		}
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.ValidDuration != nil {
+		s = append(s, "ValidDuration: "+fmt.Sprintf("%#v", this.ValidDuration)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-type ControlServices_StreamActivityServer interface {
-	Send(*CentralActivity) error
-	Recv() (*HubActivity, error)
-	grpc.ServerStream
+func (this *CreateTokenResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.CreateTokenResponse{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-type controlServicesStreamActivityServer struct {
-	grpc.ServerStream
+func (this *ControlRegister) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ControlRegister{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (x *controlServicesStreamActivityServer) Send(m *CentralActivity) error {
-	return x.ServerStream.SendMsg(m)
+func (this *ControlToken) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ControlToken{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (x *controlServicesStreamActivityServer) Recv() (*HubActivity, error) {
-	m := new(HubActivity)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (this *TokenInfo) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return m, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.TokenInfo{")
+	s = append(s, "PublicKey: "+fmt.Sprintf("%#v", this.PublicKey)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_SyncHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HubSync)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *ListAccountsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).SyncHub(ctx, in)
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListAccountsRequest{")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "Marker: "+fmt.Sprintf("%#v", this.Marker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListAccountsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/SyncHub",
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListAccountsResponse{")
+	if this.Accounts != nil {
+		s = append(s, "Accounts: "+fmt.Sprintf("%#v", this.Accounts)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).SyncHub(ctx, req.(*HubSync))
+	s = append(s, "NextMarker: "+fmt.Sprintf("%#v", this.NextMarker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *WatchServicesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.WatchServicesRequest{")
+	s = append(s, "ResumeToken: "+fmt.Sprintf("%#v", this.ResumeToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_HubDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HubDisconnectRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *ServiceEvent) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).HubDisconnect(ctx, in)
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.ServiceEvent{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "ResumeToken: "+fmt.Sprintf("%#v", this.ResumeToken)+",\n")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/HubDisconnect",
+	if this.Service != nil {
+		s = append(s, "Service: "+fmt.Sprintf("%#v", this.Service)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).HubDisconnect(ctx, req.(*HubDisconnectRequest))
+	if this.LabelLink != nil {
+		s = append(s, "LabelLink: "+fmt.Sprintf("%#v", this.LabelLink)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_AllHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Noop)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *Webhook) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).AllHubs(ctx, in)
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.Webhook{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/AllHubs",
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "Url: "+fmt.Sprintf("%#v", this.Url)+",\n")
+	s = append(s, "Events: "+fmt.Sprintf("%#v", this.Events)+",\n")
+	if this.CreatedAt != nil {
+		s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).AllHubs(ctx, req.(*Noop))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddWebhookRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.AddWebhookRequest{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "Url: "+fmt.Sprintf("%#v", this.Url)+",\n")
+	s = append(s, "Events: "+fmt.Sprintf("%#v", this.Events)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _ControlServices_RequestServiceToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServiceTokenRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *AddWebhookResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).RequestServiceToken(ctx, in)
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.AddWebhookResponse{")
+	if this.Webhook != nil {
+		s = append(s, "Webhook: "+fmt.Sprintf("%#v", this.Webhook)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/RequestServiceToken",
+	s = append(s, "Secret: "+fmt.Sprintf("%#v", this.Secret)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RemoveWebhookRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).RequestServiceToken(ctx, req.(*ServiceTokenRequest))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.RemoveWebhookRequest{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-var _ControlServices_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ControlServices",
-	HandlerType: (*ControlServicesServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddService",
-			Handler:    _ControlServices_AddService_Handler,
-		},
-		{
-			MethodName: "RemoveService",
-			Handler:    _ControlServices_RemoveService_Handler,
-		},
-		{
-			MethodName: "ListServices",
-			Handler:    _ControlServices_ListServices_Handler,
-		},
-		{
-			MethodName: "FetchConfig",
-			Handler:    _ControlServices_FetchConfig_Handler,
-		},
-		{
-			MethodName: "SyncHub",
-			Handler:    _ControlServices_SyncHub_Handler,
-		},
-		{
-			MethodName: "HubDisconnect",
-			Handler:    _ControlServices_HubDisconnect_Handler,
-		},
-		{
-			MethodName: "AllHubs",
-			Handler:    _ControlServices_AllHubs_Handler,
-		},
-		{
-			MethodName: "RequestServiceToken",
-			Handler:    _ControlServices_RequestServiceToken_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "StreamActivity",
-			Handler:       _ControlServices_StreamActivity_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "control.proto",
+func (this *ListWebhooksRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.ListWebhooksRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-// ControlManagementClient is the client API for ControlManagement service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ControlManagementClient interface {
-	Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error)
-	AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error)
-	AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
-	RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
-	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
-	IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error)
-	GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error)
-	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+func (this *ListWebhooksResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListWebhooksResponse{")
+	if this.Webhooks != nil {
+		s = append(s, "Webhooks: "+fmt.Sprintf("%#v", this.Webhooks)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *WebhookDelivery) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&pb.WebhookDelivery{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	}
+	if this.WebhookId != nil {
+		s = append(s, "WebhookId: "+fmt.Sprintf("%#v", this.WebhookId)+",\n")
+	}
+	s = append(s, "Event: "+fmt.Sprintf("%#v", this.Event)+",\n")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "Attempts: "+fmt.Sprintf("%#v", this.Attempts)+",\n")
+	s = append(s, "StatusCode: "+fmt.Sprintf("%#v", this.StatusCode)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	if this.CreatedAt != nil {
+		s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
+	}
+	if this.DeliveredAt != nil {
+		s = append(s, "DeliveredAt: "+fmt.Sprintf("%#v", this.DeliveredAt)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListWebhookDeliveriesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListWebhookDeliveriesRequest{")
+	if this.WebhookId != nil {
+		s = append(s, "WebhookId: "+fmt.Sprintf("%#v", this.WebhookId)+",\n")
+	}
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListWebhookDeliveriesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListWebhookDeliveriesResponse{")
+	if this.Deliveries != nil {
+		s = append(s, "Deliveries: "+fmt.Sprintf("%#v", this.Deliveries)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringControl(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
 
-type controlManagementClient struct {
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ControlServicesClient is the client API for ControlServices service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlServicesClient interface {
+	AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error)
+	SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error)
+	HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error)
+	AllHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ListOfHubs, error)
+	RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error)
+}
+
+type controlServicesClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewControlManagementClient(cc *grpc.ClientConn) ControlManagementClient {
-	return &controlManagementClient{cc}
+func NewControlServicesClient(cc *grpc.ClientConn) ControlServicesClient {
+	return &controlServicesClient{cc}
 }
 
-func (c *controlManagementClient) Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error) {
-	out := new(ControlToken)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/Register", in, out, opts...)
+func (c *controlServicesClient) AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/AddService", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddAccount", in, out, opts...)
+func (c *controlServicesClient) RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/RemoveService", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddLabelLink", in, out, opts...)
+func (c *controlServicesClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	out := new(ListServicesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/ListServices", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/RemoveLabelLink", in, out, opts...)
+func (c *controlServicesClient) FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/FetchConfig", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
-	out := new(CreateTokenResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/CreateToken", in, out, opts...)
+func (c *controlServicesClient) StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ControlServices_serviceDesc.Streams[0], "/pb.ControlServices/StreamActivity", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlServicesStreamActivityClient{stream}
+	return x, nil
+}
+
+type ControlServices_StreamActivityClient interface {
+	Send(*HubActivity) error
+	Recv() (*CentralActivity, error)
+	grpc.ClientStream
+}
+
+type controlServicesStreamActivityClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlServicesStreamActivityClient) Send(m *HubActivity) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *controlServicesStreamActivityClient) Recv() (*CentralActivity, error) {
+	m := new(CentralActivity)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlServicesClient) SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error) {
+	out := new(HubSyncResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/SyncHub", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
-	out := new(CreateTokenResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/IssueHubToken", in, out, opts...)
+func (c *controlServicesClient) HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/HubDisconnect", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error) {
-	out := new(TokenInfo)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/GetTokenPublicKey", in, out, opts...)
+func (c *controlServicesClient) AllHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ListOfHubs, error) {
+	out := new(ListOfHubs)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/AllHubs", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *controlManagementClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
-	out := new(ListAccountsResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListAccounts", in, out, opts...)
+func (c *controlServicesClient) RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error) {
+	out := new(ServiceTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/RequestServiceToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ControlManagementServer is the server API for ControlManagement service.
-type ControlManagementServer interface {
-	Register(context.Context, *ControlRegister) (*ControlToken, error)
-	AddAccount(context.Context, *AddAccountRequest) (*Noop, error)
-	AddLabelLink(context.Context, *AddLabelLinkRequest) (*Noop, error)
-	RemoveLabelLink(context.Context, *RemoveLabelLinkRequest) (*Noop, error)
-	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
-	IssueHubToken(context.Context, *Noop) (*CreateTokenResponse, error)
-	GetTokenPublicKey(context.Context, *Noop) (*TokenInfo, error)
-	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+// ControlServicesServer is the server API for ControlServices service.
+type ControlServicesServer interface {
+	AddService(context.Context, *ServiceRequest) (*ServiceResponse, error)
+	RemoveService(context.Context, *ServiceRequest) (*ServiceResponse, error)
+	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
+	FetchConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
+	StreamActivity(ControlServices_StreamActivityServer) error
+	SyncHub(context.Context, *HubSync) (*HubSyncResponse, error)
+	HubDisconnect(context.Context, *HubDisconnectRequest) (*Noop, error)
+	AllHubs(context.Context, *Noop) (*ListOfHubs, error)
+	RequestServiceToken(context.Context, *ServiceTokenRequest) (*ServiceTokenResponse, error)
 }
 
-// UnimplementedControlManagementServer can be embedded to have forward compatible implementations.
-type UnimplementedControlManagementServer struct {
+// UnimplementedControlServicesServer can be embedded to have forward compatible implementations.
+type UnimplementedControlServicesServer struct {
 }
 
-func (*UnimplementedControlManagementServer) Register(ctx context.Context, req *ControlRegister) (*ControlToken, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+func (*UnimplementedControlServicesServer) AddService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddService not implemented")
 }
-func (*UnimplementedControlManagementServer) AddAccount(ctx context.Context, req *AddAccountRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddAccount not implemented")
+func (*UnimplementedControlServicesServer) RemoveService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveService not implemented")
 }
-func (*UnimplementedControlManagementServer) AddLabelLink(ctx context.Context, req *AddLabelLinkRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddLabelLink not implemented")
+func (*UnimplementedControlServicesServer) ListServices(ctx context.Context, req *ListServicesRequest) (*ListServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
 }
-func (*UnimplementedControlManagementServer) RemoveLabelLink(ctx context.Context, req *RemoveLabelLinkRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveLabelLink not implemented")
+func (*UnimplementedControlServicesServer) FetchConfig(ctx context.Context, req *ConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchConfig not implemented")
 }
-func (*UnimplementedControlManagementServer) CreateToken(ctx context.Context, req *CreateTokenRequest) (*CreateTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateToken not implemented")
+func (*UnimplementedControlServicesServer) StreamActivity(srv ControlServices_StreamActivityServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamActivity not implemented")
 }
-func (*UnimplementedControlManagementServer) IssueHubToken(ctx context.Context, req *Noop) (*CreateTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IssueHubToken not implemented")
+func (*UnimplementedControlServicesServer) SyncHub(ctx context.Context, req *HubSync) (*HubSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncHub not implemented")
 }
-func (*UnimplementedControlManagementServer) GetTokenPublicKey(ctx context.Context, req *Noop) (*TokenInfo, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTokenPublicKey not implemented")
+func (*UnimplementedControlServicesServer) HubDisconnect(ctx context.Context, req *HubDisconnectRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HubDisconnect not implemented")
 }
-func (*UnimplementedControlManagementServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+func (*UnimplementedControlServicesServer) AllHubs(ctx context.Context, req *Noop) (*ListOfHubs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllHubs not implemented")
+}
+func (*UnimplementedControlServicesServer) RequestServiceToken(ctx context.Context, req *ServiceTokenRequest) (*ServiceTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestServiceToken not implemented")
 }
 
-func RegisterControlManagementServer(s *grpc.Server, srv ControlManagementServer) {
-	s.RegisterService(&_ControlManagement_serviceDesc, srv)
+func RegisterControlServicesServer(s *grpc.Server, srv ControlServicesServer) {
+	s.RegisterService(&_ControlServices_serviceDesc, srv)
 }
 
-func _ControlManagement_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ControlRegister)
+func _ControlServices_AddService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).Register(ctx, in)
+		return srv.(ControlServicesServer).AddService(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/Register",
+		FullMethod: "/pb.ControlServices/AddService",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).Register(ctx, req.(*ControlRegister))
+		return srv.(ControlServicesServer).AddService(ctx, req.(*ServiceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_AddAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddAccountRequest)
+func _ControlServices_RemoveService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).AddAccount(ctx, in)
+		return srv.(ControlServicesServer).RemoveService(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/AddAccount",
+		FullMethod: "/pb.ControlServices/RemoveService",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).AddAccount(ctx, req.(*AddAccountRequest))
+		return srv.(ControlServicesServer).RemoveService(ctx, req.(*ServiceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_AddLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddLabelLinkRequest)
+func _ControlServices_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServicesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).AddLabelLink(ctx, in)
+		return srv.(ControlServicesServer).ListServices(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/AddLabelLink",
+		FullMethod: "/pb.ControlServices/ListServices",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).AddLabelLink(ctx, req.(*AddLabelLinkRequest))
+		return srv.(ControlServicesServer).ListServices(ctx, req.(*ListServicesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_RemoveLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveLabelLinkRequest)
+func _ControlServices_FetchConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).RemoveLabelLink(ctx, in)
+		return srv.(ControlServicesServer).FetchConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/RemoveLabelLink",
+		FullMethod: "/pb.ControlServices/FetchConfig",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).RemoveLabelLink(ctx, req.(*RemoveLabelLinkRequest))
+		return srv.(ControlServicesServer).FetchConfig(ctx, req.(*ConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTokenRequest)
+func _ControlServices_StreamActivity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControlServicesServer).StreamActivity(&controlServicesStreamActivityServer{stream})
+}
+
+type ControlServices_StreamActivityServer interface {
+	Send(*CentralActivity) error
+	Recv() (*HubActivity, error)
+	grpc.ServerStream
+}
+
+type controlServicesStreamActivityServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlServicesStreamActivityServer) Send(m *CentralActivity) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *controlServicesStreamActivityServer) Recv() (*HubActivity, error) {
+	m := new(HubActivity)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ControlServices_SyncHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HubSync)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).CreateToken(ctx, in)
+		return srv.(ControlServicesServer).SyncHub(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/CreateToken",
+		FullMethod: "/pb.ControlServices/SyncHub",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).CreateToken(ctx, req.(*CreateTokenRequest))
+		return srv.(ControlServicesServer).SyncHub(ctx, req.(*HubSync))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_IssueHubToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Noop)
+func _ControlServices_HubDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HubDisconnectRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).IssueHubToken(ctx, in)
+		return srv.(ControlServicesServer).HubDisconnect(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/IssueHubToken",
+		FullMethod: "/pb.ControlServices/HubDisconnect",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).IssueHubToken(ctx, req.(*Noop))
+		return srv.(ControlServicesServer).HubDisconnect(ctx, req.(*HubDisconnectRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_GetTokenPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ControlServices_AllHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Noop)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, in)
+		return srv.(ControlServicesServer).AllHubs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/GetTokenPublicKey",
+		FullMethod: "/pb.ControlServices/AllHubs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, req.(*Noop))
+		return srv.(ControlServicesServer).AllHubs(ctx, req.(*Noop))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ControlManagement_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListAccountsRequest)
+func _ControlServices_RequestServiceToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlManagementServer).ListAccounts(ctx, in)
+		return srv.(ControlServicesServer).RequestServiceToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ControlManagement/ListAccounts",
+		FullMethod: "/pb.ControlServices/RequestServiceToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+		return srv.(ControlServicesServer).RequestServiceToken(ctx, req.(*ServiceTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ControlManagement_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ControlManagement",
-	HandlerType: (*ControlManagementServer)(nil),
+var _ControlServices_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ControlServices",
+	HandlerType: (*ControlServicesServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Register",
-			Handler:    _ControlManagement_Register_Handler,
+			MethodName: "AddService",
+			Handler:    _ControlServices_AddService_Handler,
 		},
 		{
-			MethodName: "AddAccount",
-			Handler:    _ControlManagement_AddAccount_Handler,
+			MethodName: "RemoveService",
+			Handler:    _ControlServices_RemoveService_Handler,
 		},
 		{
-			MethodName: "AddLabelLink",
-			Handler:    _ControlManagement_AddLabelLink_Handler,
+			MethodName: "ListServices",
+			Handler:    _ControlServices_ListServices_Handler,
 		},
 		{
-			MethodName: "RemoveLabelLink",
-			Handler:    _ControlManagement_RemoveLabelLink_Handler,
+			MethodName: "FetchConfig",
+			Handler:    _ControlServices_FetchConfig_Handler,
 		},
 		{
-			MethodName: "CreateToken",
-			Handler:    _ControlManagement_CreateToken_Handler,
+			MethodName: "SyncHub",
+			Handler:    _ControlServices_SyncHub_Handler,
 		},
 		{
-			MethodName: "IssueHubToken",
-			Handler:    _ControlManagement_IssueHubToken_Handler,
+			MethodName: "HubDisconnect",
+			Handler:    _ControlServices_HubDisconnect_Handler,
 		},
 		{
-			MethodName: "GetTokenPublicKey",
-			Handler:    _ControlManagement_GetTokenPublicKey_Handler,
+			MethodName: "AllHubs",
+			Handler:    _ControlServices_AllHubs_Handler,
 		},
 		{
-			MethodName: "ListAccounts",
-			Handler:    _ControlManagement_ListAccounts_Handler,
+			MethodName: "RequestServiceToken",
+			Handler:    _ControlServices_RequestServiceToken_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamActivity",
+			Handler:       _ControlServices_StreamActivity_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "control.proto",
 }
 
-func (m *ServiceRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// ControlManagementClient is the client API for ControlManagement service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlManagementClient interface {
+	Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error)
+	AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error)
+	AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
+	RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
+	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	WatchServices(ctx context.Context, in *WatchServicesRequest, opts ...grpc.CallOption) (ControlManagement_WatchServicesClient, error)
+	AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*AddWebhookResponse, error)
+	RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*Noop, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
 }
 
-func (m *ServiceRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type controlManagementClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *ServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
-		}
-	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func NewControlManagementClient(cc *grpc.ClientConn) ControlManagementClient {
+	return &controlManagementClient{cc}
 }
 
-func (m *ServiceResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error) {
+	out := new(ControlToken)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/Register", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *ServiceResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *ServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (c *controlManagementClient) AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddLabelLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *LabelLink) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/RemoveLabelLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *LabelLink) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LabelLink) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Limits != nil {
-		{
-			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Target != nil {
-		{
-			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (c *controlManagementClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/CreateToken", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *LabelLinks) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/IssueHubToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *LabelLinks) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error) {
+	out := new(TokenInfo)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/GetTokenPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *LabelLinks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelLinks) > 0 {
-		for iNdEx := len(m.LabelLinks) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.LabelLinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (c *controlManagementClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *ServiceRoute) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) WatchServices(ctx context.Context, in *WatchServicesRequest, opts ...grpc.CallOption) (ControlManagement_WatchServicesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ControlManagement_serviceDesc.Streams[0], "/pb.ControlManagement/WatchServices", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &controlManagementWatchServicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *ServiceRoute) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ControlManagement_WatchServicesClient interface {
+	Recv() (*ServiceEvent, error)
+	grpc.ClientStream
 }
 
-func (m *ServiceRoute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+type controlManagementWatchServicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlManagementWatchServicesClient) Recv() (*ServiceEvent, error) {
+	m := new(ServiceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *AccountServices) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*AddWebhookResponse, error) {
+	out := new(AddWebhookResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddWebhook", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AccountServices) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/RemoveWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *AccountServices) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (c *controlManagementClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *ActivityEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *controlManagementClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListWebhookDeliveries", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *ActivityEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// ControlManagementServer is the server API for ControlManagement service.
+type ControlManagementServer interface {
+	Register(context.Context, *ControlRegister) (*ControlToken, error)
+	AddAccount(context.Context, *AddAccountRequest) (*Noop, error)
+	AddLabelLink(context.Context, *AddLabelLinkRequest) (*Noop, error)
+	RemoveLabelLink(context.Context, *RemoveLabelLinkRequest) (*Noop, error)
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	IssueHubToken(context.Context, *Noop) (*CreateTokenResponse, error)
+	GetTokenPublicKey(context.Context, *Noop) (*TokenInfo, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	WatchServices(*WatchServicesRequest, ControlManagement_WatchServicesServer) error
+	AddWebhook(context.Context, *AddWebhookRequest) (*AddWebhookResponse, error)
+	RemoveWebhook(context.Context, *RemoveWebhookRequest) (*Noop, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
 }
 
-func (m *ActivityEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.RouteRemoved != nil {
-		{
-			size, err := m.RouteRemoved.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.RouteAdded != nil {
-		{
-			size, err := m.RouteAdded.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+// UnimplementedControlManagementServer can be embedded to have forward compatible implementations.
+type UnimplementedControlManagementServer struct {
 }
 
-func (m *ConfigRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedControlManagementServer) Register(ctx context.Context, req *ControlRegister) (*ControlToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (*UnimplementedControlManagementServer) AddAccount(ctx context.Context, req *AddAccountRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAccount not implemented")
+}
+func (*UnimplementedControlManagementServer) AddLabelLink(ctx context.Context, req *AddLabelLinkRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLabelLink not implemented")
+}
+func (*UnimplementedControlManagementServer) RemoveLabelLink(ctx context.Context, req *RemoveLabelLinkRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLabelLink not implemented")
+}
+func (*UnimplementedControlManagementServer) CreateToken(ctx context.Context, req *CreateTokenRequest) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateToken not implemented")
+}
+func (*UnimplementedControlManagementServer) IssueHubToken(ctx context.Context, req *Noop) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueHubToken not implemented")
+}
+func (*UnimplementedControlManagementServer) GetTokenPublicKey(ctx context.Context, req *Noop) (*TokenInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokenPublicKey not implemented")
+}
+func (*UnimplementedControlManagementServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (*UnimplementedControlManagementServer) WatchServices(req *WatchServicesRequest, srv ControlManagement_WatchServicesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchServices not implemented")
+}
+func (*UnimplementedControlManagementServer) AddWebhook(ctx context.Context, req *AddWebhookRequest) (*AddWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWebhook not implemented")
+}
+func (*UnimplementedControlManagementServer) RemoveWebhook(ctx context.Context, req *RemoveWebhookRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhook not implemented")
+}
+func (*UnimplementedControlManagementServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedControlManagementServer) ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
 }
 
-func (m *ConfigRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func RegisterControlManagementServer(s *grpc.Server, srv ControlManagementServer) {
+	s.RegisterService(&_ControlManagement_serviceDesc, srv)
 }
 
-func (m *ConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func _ControlManagement_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlRegister)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.InstanceId != nil {
-		{
-			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if interceptor == nil {
+		return srv.(ControlManagementServer).Register(ctx, in)
 	}
-	if m.StableId != nil {
-		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/Register",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).Register(ctx, req.(*ControlRegister))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ConfigResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _ControlManagement_AddAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAccountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if interceptor == nil {
+		return srv.(ControlManagementServer).AddAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/AddAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).AddAccount(ctx, req.(*AddAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ImageTag) > 0 {
-		i -= len(m.ImageTag)
-		copy(dAtA[i:], m.ImageTag)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.ImageTag)))
-		i--
-		dAtA[i] = 0x3a
+func _ControlManagement_AddLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLabelLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.S3Bucket) > 0 {
-		i -= len(m.S3Bucket)
-		copy(dAtA[i:], m.S3Bucket)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.S3Bucket)))
-		i--
-		dAtA[i] = 0x32
+	if interceptor == nil {
+		return srv.(ControlManagementServer).AddLabelLink(ctx, in)
 	}
-	if len(m.S3SecretKey) > 0 {
-		i -= len(m.S3SecretKey)
-		copy(dAtA[i:], m.S3SecretKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.S3SecretKey)))
-		i--
-		dAtA[i] = 0x2a
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/AddLabelLink",
 	}
-	if len(m.S3AccessKey) > 0 {
-		i -= len(m.S3AccessKey)
-		copy(dAtA[i:], m.S3AccessKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.S3AccessKey)))
-		i--
-		dAtA[i] = 0x22
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).AddLabelLink(ctx, req.(*AddLabelLinkRequest))
 	}
-	if len(m.TokenPub) > 0 {
-		i -= len(m.TokenPub)
-		copy(dAtA[i:], m.TokenPub)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.TokenPub)))
-		i--
-		dAtA[i] = 0x1a
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_RemoveLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLabelLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.TlsCert) > 0 {
-		i -= len(m.TlsCert)
-		copy(dAtA[i:], m.TlsCert)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsCert)))
-		i--
-		dAtA[i] = 0x12
+	if interceptor == nil {
+		return srv.(ControlManagementServer).RemoveLabelLink(ctx, in)
 	}
-	if len(m.TlsKey) > 0 {
-		i -= len(m.TlsKey)
-		copy(dAtA[i:], m.TlsKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsKey)))
-		i--
-		dAtA[i] = 0xa
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/RemoveLabelLink",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).RemoveLabelLink(ctx, req.(*RemoveLabelLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HubChange) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _ControlManagement_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(ControlManagementServer).CreateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/CreateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HubChange) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _ControlManagement_IssueHubToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).IssueHubToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/IssueHubToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).IssueHubToken(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HubChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.NewId != nil {
-		{
-			size, err := m.NewId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func _ControlManagement_GetTokenPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.OldId != nil {
-		{
-			size, err := m.OldId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if interceptor == nil {
+		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, in)
 	}
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/GetTokenPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *CentralActivity) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _ControlManagement_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ListAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *CentralActivity) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _ControlManagement_WatchServices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchServicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlManagementServer).WatchServices(m, &controlManagementWatchServicesServer{stream})
 }
 
-func (m *CentralActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.HubChange != nil {
-		{
-			size, err := m.HubChange.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+type ControlManagement_WatchServicesServer interface {
+	Send(*ServiceEvent) error
+	grpc.ServerStream
+}
+
+type controlManagementWatchServicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlManagementWatchServicesServer) Send(m *ServiceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ControlManagement_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.NewLabelLinks != nil {
-		{
-			size, err := m.NewLabelLinks.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	if interceptor == nil {
+		return srv.(ControlManagementServer).AddWebhook(ctx, in)
 	}
-	if m.RequestStats {
-		i--
-		if m.RequestStats {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x10
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/AddWebhook",
 	}
-	if len(m.AccountServices) > 0 {
-		for iNdEx := len(m.AccountServices) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.AccountServices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).AddWebhook(ctx, req.(*AddWebhookRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HubActivity) Marshal() (dAtA []byte, err error) {
+func _ControlManagement_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).RemoveWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/RemoveWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).RemoveWebhook(ctx, req.(*RemoveWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ControlManagement_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ControlManagement",
+	HandlerType: (*ControlManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _ControlManagement_Register_Handler,
+		},
+		{
+			MethodName: "AddAccount",
+			Handler:    _ControlManagement_AddAccount_Handler,
+		},
+		{
+			MethodName: "AddLabelLink",
+			Handler:    _ControlManagement_AddLabelLink_Handler,
+		},
+		{
+			MethodName: "RemoveLabelLink",
+			Handler:    _ControlManagement_RemoveLabelLink_Handler,
+		},
+		{
+			MethodName: "CreateToken",
+			Handler:    _ControlManagement_CreateToken_Handler,
+		},
+		{
+			MethodName: "IssueHubToken",
+			Handler:    _ControlManagement_IssueHubToken_Handler,
+		},
+		{
+			MethodName: "GetTokenPublicKey",
+			Handler:    _ControlManagement_GetTokenPublicKey_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _ControlManagement_ListAccounts_Handler,
+		},
+		{
+			MethodName: "AddWebhook",
+			Handler:    _ControlManagement_AddWebhook_Handler,
+		},
+		{
+			MethodName: "RemoveWebhook",
+			Handler:    _ControlManagement_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ControlManagement_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _ControlManagement_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchServices",
+			Handler:       _ControlManagement_WatchServices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "control.proto",
+}
+
+func (m *ServiceRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5240,20 +6198,20 @@ func (m *HubActivity) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubActivity) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Flow) > 0 {
-		for iNdEx := len(m.Flow) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Flow[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5261,12 +6219,12 @@ func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintControl(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x22
+			dAtA[i] = 0x32
 		}
 	}
-	if m.Stats != nil {
+	if m.Labels != nil {
 		{
-			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5274,11 +6232,18 @@ func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x2a
 	}
-	if m.SentAt != nil {
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Id != nil {
 		{
-			size, err := m.SentAt.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5286,11 +6251,11 @@ func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1a
 	}
-	if m.HubReg != nil {
+	if m.Hub != nil {
 		{
-			size, err := m.HubReg.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5298,12 +6263,24 @@ func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *HubActivity_HubRegistration) Marshal() (dAtA []byte, err error) {
+func (m *ServiceResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5313,58 +6290,20 @@ func (m *HubActivity_HubRegistration) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubActivity_HubRegistration) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubActivity_HubRegistration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.StableHub != nil {
-		{
-			size, err := m.StableHub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *HubActivity_HubStats) Marshal() (dAtA []byte, err error) {
+func (m *LabelLink) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5374,19 +6313,19 @@ func (m *HubActivity_HubStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubActivity_HubStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelLink) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubActivity_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelLink) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.LastAttemptedUpdate != nil {
+	if m.Limits != nil {
 		{
-			size, err := m.LastAttemptedUpdate.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5394,11 +6333,11 @@ func (m *HubActivity_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x32
+		dAtA[i] = 0x22
 	}
-	if m.LastSuccessfulUpdate != nil {
+	if m.Target != nil {
 		{
-			size, err := m.LastSuccessfulUpdate.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5406,68 +6345,23 @@ func (m *HubActivity_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x2a
-	}
-	if m.CachedBytes != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.CachedBytes))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.AccountsCached != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.AccountsCached))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.AccountsSeen != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.AccountsSeen))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.AgentConnections != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.AgentConnections))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *HubInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+		dAtA[i] = 0x1a
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HubInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			i--
-			dAtA[i] = 0x12
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Id != nil {
+	if m.Account != nil {
 		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5480,7 +6374,7 @@ func (m *HubInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ListOfHubs) Marshal() (dAtA []byte, err error) {
+func (m *LabelLinks) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5490,20 +6384,20 @@ func (m *ListOfHubs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListOfHubs) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelLinks) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListOfHubs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelLinks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Hubs) > 0 {
-		for iNdEx := len(m.Hubs) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.LabelLinks) > 0 {
+		for iNdEx := len(m.LabelLinks) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Hubs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.LabelLinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5517,7 +6411,7 @@ func (m *ListOfHubs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *HubSync) Marshal() (dAtA []byte, err error) {
+func (m *ServiceRoute) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5527,20 +6421,20 @@ func (m *HubSync) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubSync) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceRoute) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ServiceRoute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5548,12 +6442,12 @@ func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintControl(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x1a
+			dAtA[i] = 0x2a
 		}
 	}
-	if m.StableId != nil {
+	if m.Labels != nil {
 		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5561,7 +6455,14 @@ func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
 	}
 	if m.Id != nil {
 		{
@@ -5573,40 +6474,24 @@ func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *HubSyncResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+		dAtA[i] = 0x12
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubSyncResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HubSyncResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ServiceCount != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.ServiceCount))
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *HubRegisterRequest) Marshal() (dAtA []byte, err error) {
+func (m *AccountServices) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5616,20 +6501,20 @@ func (m *HubRegisterRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubRegisterRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *AccountServices) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AccountServices) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5637,24 +6522,12 @@ func (m *HubRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintControl(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.InstanceId != nil {
-		{
-			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+			dAtA[i] = 0x12
 		}
-		i--
-		dAtA[i] = 0x12
 	}
-	if m.StableId != nil {
+	if m.Account != nil {
 		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5667,7 +6540,7 @@ func (m *HubRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *HubRegisterResponse) Marshal() (dAtA []byte, err error) {
+func (m *ActivityEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5677,19 +6550,79 @@ func (m *HubRegisterResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubRegisterResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ActivityEntry) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ActivityEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.ControlTime != nil {
+	if m.LabelLinkRemoved != nil {
 		{
-			size, err := m.ControlTime.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.LabelLinkRemoved.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.LabelLinkAdded != nil {
+		{
+			size, err := m.LabelLinkAdded.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ServiceRemoved != nil {
+		{
+			size, err := m.ServiceRemoved.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ServiceAdded != nil {
+		{
+			size, err := m.ServiceAdded.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.RouteRemoved != nil {
+		{
+			size, err := m.RouteRemoved.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.RouteAdded != nil {
+		{
+			size, err := m.RouteAdded.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5702,7 +6635,7 @@ func (m *HubRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *HubDisconnectRequest) Marshal() (dAtA []byte, err error) {
+func (m *ServiceChange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5712,19 +6645,19 @@ func (m *HubDisconnectRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HubDisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceChange) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubDisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ServiceChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.InstanceId != nil {
+	if m.Service != nil {
 		{
-			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Service.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5734,9 +6667,9 @@ func (m *HubDisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.StableId != nil {
+	if m.Account != nil {
 		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5749,7 +6682,7 @@ func (m *HubDisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ServiceTokenRequest) Marshal() (dAtA []byte, err error) {
+func (m *ConfigRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5759,27 +6692,58 @@ func (m *ServiceTokenRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServiceTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ServiceTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Namespace) > 0 {
-		i -= len(m.Namespace)
-		copy(dAtA[i:], m.Namespace)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.InstanceId != nil {
+		{
+			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ServiceTokenResponse) Marshal() (dAtA []byte, err error) {
+func (m *ConfigResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5789,27 +6753,69 @@ func (m *ServiceTokenResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServiceTokenResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ServiceTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+	if len(m.ImageTag) > 0 {
+		i -= len(m.ImageTag)
+		copy(dAtA[i:], m.ImageTag)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ImageTag)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.S3Bucket) > 0 {
+		i -= len(m.S3Bucket)
+		copy(dAtA[i:], m.S3Bucket)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.S3Bucket)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.S3SecretKey) > 0 {
+		i -= len(m.S3SecretKey)
+		copy(dAtA[i:], m.S3SecretKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.S3SecretKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.S3AccessKey) > 0 {
+		i -= len(m.S3AccessKey)
+		copy(dAtA[i:], m.S3AccessKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.S3AccessKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TokenPub) > 0 {
+		i -= len(m.TokenPub)
+		copy(dAtA[i:], m.TokenPub)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.TokenPub)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TlsCert) > 0 {
+		i -= len(m.TlsCert)
+		copy(dAtA[i:], m.TlsCert)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsCert)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TlsKey) > 0 {
+		i -= len(m.TlsKey)
+		copy(dAtA[i:], m.TlsKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsKey)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ListServicesRequest) Marshal() (dAtA []byte, err error) {
+func (m *HubChange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5819,19 +6825,31 @@ func (m *ListServicesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubChange) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Account != nil {
+	if m.NewId != nil {
 		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.NewId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.OldId != nil {
+		{
+			size, err := m.OldId.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5844,7 +6862,7 @@ func (m *ListServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ListServicesResponse) Marshal() (dAtA []byte, err error) {
+func (m *CentralActivity) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5854,20 +6872,54 @@ func (m *ListServicesResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListServicesResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *CentralActivity) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListServicesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CentralActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+	if m.HubChange != nil {
+		{
+			size, err := m.HubChange.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.NewLabelLinks != nil {
+		{
+			size, err := m.NewLabelLinks.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.RequestStats {
+		i--
+		if m.RequestStats {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.AccountServices) > 0 {
+		for iNdEx := len(m.AccountServices) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.AccountServices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5881,7 +6933,7 @@ func (m *ListServicesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Service) Marshal() (dAtA []byte, err error) {
+func (m *HubActivity) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5891,20 +6943,20 @@ func (m *Service) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Service) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubActivity) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Flow) > 0 {
+		for iNdEx := len(m.Flow) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Flow[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5912,12 +6964,12 @@ func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintControl(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x2a
+			dAtA[i] = 0x22
 		}
 	}
-	if m.Labels != nil {
+	if m.Stats != nil {
 		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5925,18 +6977,11 @@ func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x1a
 	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Hub != nil {
+	if m.SentAt != nil {
 		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.SentAt.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5946,9 +6991,9 @@ func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.Id != nil {
+	if m.HubReg != nil {
 		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.HubReg.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5961,7 +7006,7 @@ func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AddAccountRequest) Marshal() (dAtA []byte, err error) {
+func (m *HubActivity_HubRegistration) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5971,19 +7016,33 @@ func (m *AddAccountRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddAccountRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubActivity_HubRegistration) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AddAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubActivity_HubRegistration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Limits != nil {
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.StableHub != nil {
 		{
-			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.StableHub.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5993,9 +7052,9 @@ func (m *AddAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.Account != nil {
+	if m.Hub != nil {
 		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6008,7 +7067,7 @@ func (m *AddAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AddLabelLinkRequest) Marshal() (dAtA []byte, err error) {
+func (m *HubActivity_HubStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6018,19 +7077,19 @@ func (m *AddLabelLinkRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubActivity_HubStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubActivity_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Target != nil {
+	if m.LastAttemptedUpdate != nil {
 		{
-			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.LastAttemptedUpdate.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6038,11 +7097,11 @@ func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x32
 	}
-	if m.Account != nil {
+	if m.LastSuccessfulUpdate != nil {
 		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.LastSuccessfulUpdate.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6050,11 +7109,68 @@ func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x2a
 	}
-	if m.Labels != nil {
+	if m.CachedBytes != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.CachedBytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.AccountsCached != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.AccountsCached))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AccountsSeen != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.AccountsSeen))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.AgentConnections != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.AgentConnections))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id != nil {
 		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6067,7 +7183,7 @@ func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Noop) Marshal() (dAtA []byte, err error) {
+func (m *ListOfHubs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6077,20 +7193,34 @@ func (m *Noop) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Noop) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListOfHubs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Noop) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListOfHubs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if len(m.Hubs) > 0 {
+		for iNdEx := len(m.Hubs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hubs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RemoveLabelLinkRequest) Marshal() (dAtA []byte, err error) {
+func (m *HubSync) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6100,19 +7230,33 @@ func (m *RemoveLabelLinkRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubSync) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RemoveLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Account != nil {
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.StableId != nil {
 		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6122,9 +7266,9 @@ func (m *RemoveLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.Labels != nil {
+	if m.Id != nil {
 		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6137,7 +7281,7 @@ func (m *RemoveLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateTokenRequest) Marshal() (dAtA []byte, err error) {
+func (m *HubSyncResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6147,32 +7291,48 @@ func (m *CreateTokenRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubSyncResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubSyncResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.ValidDuration != nil {
-		{
-			size, err := m.ValidDuration.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
+	if m.ServiceCount != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.ServiceCount))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x8
 	}
-	if len(m.Capabilities) > 0 {
-		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+	return len(dAtA) - i, nil
+}
+
+func (m *HubRegisterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubRegisterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -6180,12 +7340,24 @@ func (m *CreateTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintControl(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x1a
 		}
 	}
-	if m.Account != nil {
+	if m.InstanceId != nil {
 		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -6198,7 +7370,7 @@ func (m *CreateTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateTokenResponse) Marshal() (dAtA []byte, err error) {
+func (m *HubRegisterResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6208,27 +7380,32 @@ func (m *CreateTokenResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateTokenResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubRegisterResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+	if m.ControlTime != nil {
+		{
+			size, err := m.ControlTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ControlRegister) Marshal() (dAtA []byte, err error) {
+func (m *HubDisconnectRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6238,27 +7415,44 @@ func (m *ControlRegister) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ControlRegister) MarshalTo(dAtA []byte) (int, error) {
+func (m *HubDisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ControlRegister) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HubDisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Namespace) > 0 {
-		i -= len(m.Namespace)
-		copy(dAtA[i:], m.Namespace)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+	if m.InstanceId != nil {
+		{
+			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ControlToken) Marshal() (dAtA []byte, err error) {
+func (m *ServiceTokenRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6268,27 +7462,27 @@ func (m *ControlToken) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ControlToken) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceTokenRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ControlToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ServiceTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
+func (m *ServiceTokenResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6298,27 +7492,27 @@ func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TokenInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceTokenResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ServiceTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.PublicKey) > 0 {
-		i -= len(m.PublicKey)
-		copy(dAtA[i:], m.PublicKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.PublicKey)))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ListAccountsRequest) Marshal() (dAtA []byte, err error) {
+func (m *ListServicesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6328,32 +7522,32 @@ func (m *ListAccountsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListAccountsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListServicesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListAccountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Marker) > 0 {
-		i -= len(m.Marker)
-		copy(dAtA[i:], m.Marker)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Marker)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Limit != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ListAccountsResponse) Marshal() (dAtA []byte, err error) {
+func (m *ListServicesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6363,27 +7557,20 @@ func (m *ListAccountsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListAccountsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListServicesResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListAccountsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListServicesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.NextMarker) > 0 {
-		i -= len(m.NextMarker)
-		copy(dAtA[i:], m.NextMarker)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.NextMarker)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Accounts) > 0 {
-		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -6397,1236 +7584,4618 @@ func (m *ListAccountsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintControl(dAtA []byte, offset int, v uint64) int {
-	offset -= sovControl(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Service) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ServiceRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *Service) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
 	}
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Metadata) > 0 {
-		for _, e := range m.Metadata {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ServiceResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AddAccountRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *LabelLink) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Target != nil {
-		l = m.Target.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Limits != nil {
-		l = m.Limits.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+func (m *AddAccountRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LabelLinks) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *AddAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.LabelLinks) > 0 {
-		for _, e := range m.LabelLinks {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
-	}
-	return n
-}
-
-func (m *ServiceRoute) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if m.Limits != nil {
+		{
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *AccountServices) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AddLabelLinkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AddLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if m.Target != nil {
+		{
+			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ActivityEntry) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Noop) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Noop) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Noop) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.RouteAdded != nil {
-		l = m.RouteAdded.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.RouteRemoved != nil {
-		l = m.RouteRemoved.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ConfigRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RemoveLabelLinkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.InstanceId != nil {
-		l = m.InstanceId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ConfigResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.TlsKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.TlsCert)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.TokenPub)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.S3AccessKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.S3SecretKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.S3Bucket)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.ImageTag)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+func (m *CreateTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *HubChange) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.OldId != nil {
-		l = m.OldId.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.NewId != nil {
-		l = m.NewId.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+func (m *CreateTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CentralActivity) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CreateTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.AccountServices) > 0 {
-		for _, e := range m.AccountServices {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if m.ValidDuration != nil {
+		{
+			size, err := m.ValidDuration.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.RequestStats {
-		n += 2
-	}
-	if m.NewLabelLinks != nil {
-		l = m.NewLabelLinks.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	if m.HubChange != nil {
-		l = m.HubChange.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HubActivity) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.HubReg != nil {
-		l = m.HubReg.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.SentAt != nil {
-		l = m.SentAt.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Stats != nil {
-		l = m.Stats.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if len(m.Flow) > 0 {
-		for _, e := range m.Flow {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+func (m *CreateTokenResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *HubActivity_HubRegistration) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.StableHub != nil {
-		l = m.StableHub.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
-	}
-	return n
+func (m *CreateTokenResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HubActivity_HubStats) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CreateTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.AgentConnections != 0 {
-		n += 1 + sovControl(uint64(m.AgentConnections))
-	}
-	if m.AccountsSeen != 0 {
-		n += 1 + sovControl(uint64(m.AccountsSeen))
-	}
-	if m.AccountsCached != 0 {
-		n += 1 + sovControl(uint64(m.AccountsCached))
-	}
-	if m.CachedBytes != 0 {
-		n += 1 + sovControl(uint64(m.CachedBytes))
-	}
-	if m.LastSuccessfulUpdate != nil {
-		l = m.LastSuccessfulUpdate.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.LastAttemptedUpdate != nil {
-		l = m.LastAttemptedUpdate.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HubInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+func (m *ControlRegister) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ListOfHubs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ControlRegister) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControlRegister) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Hubs) > 0 {
-		for _, e := range m.Hubs {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HubSync) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ControlToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ControlToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControlToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HubSyncResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ServiceCount != 0 {
-		n += 1 + sovControl(uint64(m.ServiceCount))
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HubRegisterRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListAccountsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAccountsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAccountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.InstanceId != nil {
-		l = m.InstanceId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Marker)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	if m.Limit != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HubRegisterResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ControlTime != nil {
-		l = m.ControlTime.Size()
-		n += 1 + l + sovControl(uint64(l))
+func (m *ListAccountsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *HubDisconnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ListAccountsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAccountsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.InstanceId != nil {
-		l = m.InstanceId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Accounts) > 0 {
+		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ServiceTokenRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+func (m *WatchServicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ServiceTokenResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+func (m *WatchServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListServicesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *WatchServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.ResumeToken) > 0 {
+		i -= len(m.ResumeToken)
+		copy(dAtA[i:], m.ResumeToken)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ResumeToken)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ListServicesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+func (m *ServiceEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Service) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ServiceEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServiceEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.LabelLink != nil {
+		{
+			size, err := m.LabelLink.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.Service != nil {
+		{
+			size, err := m.Service.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.ResumeToken) > 0 {
+		i -= len(m.ResumeToken)
+		copy(dAtA[i:], m.ResumeToken)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ResumeToken)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Metadata) > 0 {
-		for _, e := range m.Metadata {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	if m.Type != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *AddAccountRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Limits != nil {
-		l = m.Limits.Size()
-		n += 1 + l + sovControl(uint64(l))
+func (m *Webhook) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AddLabelLinkRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Webhook) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Webhook) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if m.CreatedAt != nil {
+		{
+			size, err := m.CreatedAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.Target != nil {
-		l = m.Target.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Events[iNdEx])
+			copy(dAtA[i:], m.Events[iNdEx])
+			i = encodeVarintControl(dAtA, i, uint64(len(m.Events[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	return n
+	if len(m.Url) > 0 {
+		i -= len(m.Url)
+		copy(dAtA[i:], m.Url)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Url)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Noop) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AddWebhookRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RemoveLabelLinkRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *AddWebhookRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddWebhookRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Events[iNdEx])
+			copy(dAtA[i:], m.Events[iNdEx])
+			i = encodeVarintControl(dAtA, i, uint64(len(m.Events[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Url) > 0 {
+		i -= len(m.Url)
+		copy(dAtA[i:], m.Url)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Url)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateTokenRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AddWebhookResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AddWebhookResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddWebhookResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if len(m.Secret) > 0 {
+		i -= len(m.Secret)
+		copy(dAtA[i:], m.Secret)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Secret)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Capabilities) > 0 {
-		for _, e := range m.Capabilities {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if m.Webhook != nil {
+		{
+			size, err := m.Webhook.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	if m.ValidDuration != nil {
-		l = m.ValidDuration.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateTokenResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+func (m *RemoveWebhookRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ControlRegister) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+func (m *RemoveWebhookRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ControlToken) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *RemoveWebhookRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *TokenInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PublicKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+func (m *ListWebhooksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ListAccountsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ListWebhooksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListWebhooksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Limit != 0 {
-		n += 1 + sovControl(uint64(m.Limit))
-	}
-	l = len(m.Marker)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ListAccountsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListWebhooksResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListWebhooksResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListWebhooksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Accounts) > 0 {
-		for _, e := range m.Accounts {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if len(m.Webhooks) > 0 {
+		for iNdEx := len(m.Webhooks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Webhooks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	l = len(m.NextMarker)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func sovControl(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *WebhookDelivery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozControl(x uint64) (n int) {
-	return sovControl(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *WebhookDelivery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *ServiceRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *WebhookDelivery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DeliveredAt != nil {
+		{
+			size, err := m.DeliveredAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
 	}
-	repeatedStringForMetadata := "[]*KVPair{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	if m.CreatedAt != nil {
+		{
+			size, err := m.CreatedAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
 	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&ServiceRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ServiceResponse) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	s := strings.Join([]string{`&ServiceResponse{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelLink) String() string {
-	if this == nil {
-		return "nil"
+	if m.StatusCode != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.StatusCode))
+		i--
+		dAtA[i] = 0x30
 	}
-	s := strings.Join([]string{`&LabelLink{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
-		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelLinks) String() string {
-	if this == nil {
-		return "nil"
+	if m.Attempts != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Attempts))
+		i--
+		dAtA[i] = 0x28
 	}
-	repeatedStringForLabelLinks := "[]*LabelLink{"
-	for _, f := range this.LabelLinks {
-		repeatedStringForLabelLinks += strings.Replace(f.String(), "LabelLink", "LabelLink", 1) + ","
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0x22
 	}
-	repeatedStringForLabelLinks += "}"
-	s := strings.Join([]string{`&LabelLinks{`,
-		`LabelLinks:` + repeatedStringForLabelLinks + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ServiceRoute) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Event) > 0 {
+		i -= len(m.Event)
+		copy(dAtA[i:], m.Event)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Event)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	s := strings.Join([]string{`&ServiceRoute{`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AccountServices) String() string {
-	if this == nil {
-		return "nil"
+	if m.WebhookId != nil {
+		{
+			size, err := m.WebhookId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	repeatedStringForServices := "[]*ServiceRoute{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "ServiceRoute", "ServiceRoute", 1) + ","
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&AccountServices{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *ActivityEntry) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ListWebhookDeliveriesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ActivityEntry{`,
-		`RouteAdded:` + strings.Replace(this.RouteAdded.String(), "AccountServices", "AccountServices", 1) + `,`,
-		`RouteRemoved:` + strings.Replace(fmt.Sprintf("%v", this.RouteRemoved), "ULID", "ULID", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *ConfigRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ListWebhookDeliveriesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListWebhookDeliveriesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	if m.WebhookId != nil {
+		{
+			size, err := m.WebhookId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&ConfigRequest{`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *ConfigResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ListWebhookDeliveriesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ConfigResponse{`,
-		`TlsKey:` + fmt.Sprintf("%v", this.TlsKey) + `,`,
-		`TlsCert:` + fmt.Sprintf("%v", this.TlsCert) + `,`,
-		`TokenPub:` + fmt.Sprintf("%v", this.TokenPub) + `,`,
-		`S3AccessKey:` + fmt.Sprintf("%v", this.S3AccessKey) + `,`,
-		`S3SecretKey:` + fmt.Sprintf("%v", this.S3SecretKey) + `,`,
-		`S3Bucket:` + fmt.Sprintf("%v", this.S3Bucket) + `,`,
-		`ImageTag:` + fmt.Sprintf("%v", this.ImageTag) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *HubChange) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ListWebhookDeliveriesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListWebhookDeliveriesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Deliveries) > 0 {
+		for iNdEx := len(m.Deliveries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Deliveries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	s := strings.Join([]string{`&HubChange{`,
-		`OldId:` + strings.Replace(fmt.Sprintf("%v", this.OldId), "ULID", "ULID", 1) + `,`,
-		`NewId:` + strings.Replace(fmt.Sprintf("%v", this.NewId), "ULID", "ULID", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *CentralActivity) String() string {
-	if this == nil {
-		return "nil"
+
+func encodeVarintControl(dAtA []byte, offset int, v uint64) int {
+	offset -= sovControl(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
 	}
-	repeatedStringForAccountServices := "[]*AccountServices{"
-	for _, f := range this.AccountServices {
-		repeatedStringForAccountServices += strings.Replace(f.String(), "AccountServices", "AccountServices", 1) + ","
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ServiceRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForAccountServices += "}"
-	s := strings.Join([]string{`&CentralActivity{`,
-		`AccountServices:` + repeatedStringForAccountServices + `,`,
-		`RequestStats:` + fmt.Sprintf("%v", this.RequestStats) + `,`,
-		`NewLabelLinks:` + strings.Replace(this.NewLabelLinks.String(), "LabelLinks", "LabelLinks", 1) + `,`,
-		`HubChange:` + strings.Replace(this.HubChange.String(), "HubChange", "HubChange", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubActivity) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForFlow := "[]*FlowRecord{"
-	for _, f := range this.Flow {
-		repeatedStringForFlow += strings.Replace(fmt.Sprintf("%v", f), "FlowRecord", "FlowRecord", 1) + ","
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForFlow += "}"
-	s := strings.Join([]string{`&HubActivity{`,
-		`HubReg:` + strings.Replace(fmt.Sprintf("%v", this.HubReg), "HubActivity_HubRegistration", "HubActivity_HubRegistration", 1) + `,`,
-		`SentAt:` + strings.Replace(fmt.Sprintf("%v", this.SentAt), "Timestamp", "Timestamp", 1) + `,`,
-		`Stats:` + strings.Replace(fmt.Sprintf("%v", this.Stats), "HubActivity_HubStats", "HubActivity_HubStats", 1) + `,`,
-		`Flow:` + repeatedStringForFlow + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubActivity_HubRegistration) String() string {
-	if this == nil {
-		return "nil"
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&HubActivity_HubRegistration{`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`StableHub:` + strings.Replace(fmt.Sprintf("%v", this.StableHub), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubActivity_HubStats) String() string {
-	if this == nil {
-		return "nil"
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&HubActivity_HubStats{`,
-		`AgentConnections:` + fmt.Sprintf("%v", this.AgentConnections) + `,`,
-		`AccountsSeen:` + fmt.Sprintf("%v", this.AccountsSeen) + `,`,
-		`AccountsCached:` + fmt.Sprintf("%v", this.AccountsCached) + `,`,
-		`CachedBytes:` + fmt.Sprintf("%v", this.CachedBytes) + `,`,
-		`LastSuccessfulUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastSuccessfulUpdate), "Timestamp", "Timestamp", 1) + `,`,
-		`LastAttemptedUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastAttemptedUpdate), "Timestamp", "Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubInfo) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	return n
+}
+
+func (m *ServiceResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&HubInfo{`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	return n
 }
-func (this *ListOfHubs) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelLink) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForHubs := "[]*HubInfo{"
-	for _, f := range this.Hubs {
-		repeatedStringForHubs += strings.Replace(f.String(), "HubInfo", "HubInfo", 1) + ","
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForHubs += "}"
-	s := strings.Join([]string{`&ListOfHubs{`,
-		`Hubs:` + repeatedStringForHubs + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubSync) String() string {
-	if this == nil {
-		return "nil"
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForServices := "[]*ServiceRequest{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "ServiceRequest", "ServiceRequest", 1) + ","
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&HubSync{`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubSyncResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&HubSyncResponse{`,
-		`ServiceCount:` + fmt.Sprintf("%v", this.ServiceCount) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *HubRegisterRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelLinks) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	var l int
+	_ = l
+	if len(m.LabelLinks) > 0 {
+		for _, e := range m.LabelLinks {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&HubRegisterRequest{`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *HubRegisterResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ServiceRoute) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&HubRegisterResponse{`,
-		`ControlTime:` + strings.Replace(fmt.Sprintf("%v", this.ControlTime), "Timestamp", "Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubDisconnectRequest) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&HubDisconnectRequest{`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ServiceTokenRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&ServiceTokenRequest{`,
-		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ServiceTokenResponse) String() string {
-	if this == nil {
-		return "nil"
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&ServiceTokenResponse{`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ListServicesRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&ListServicesRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
 }
-func (this *ListServicesResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *AccountServices) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForServices := "[]*Service{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "Service", "Service", 1) + ","
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&ListServicesResponse{`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
 }
-func (this *Service) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ActivityEntry) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForMetadata := "[]*KVPair{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	var l int
+	_ = l
+	if m.RouteAdded != nil {
+		l = m.RouteAdded.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&Service{`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AddAccountRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.RouteRemoved != nil {
+		l = m.RouteRemoved.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&AddAccountRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AddLabelLinkRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ServiceAdded != nil {
+		l = m.ServiceAdded.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&AddLabelLinkRequest{`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Noop) String() string {
-	if this == nil {
-		return "nil"
+	if m.ServiceRemoved != nil {
+		l = m.ServiceRemoved.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&Noop{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *RemoveLabelLinkRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.LabelLinkAdded != nil {
+		l = m.LabelLinkAdded.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&RemoveLabelLinkRequest{`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.LabelLinkRemoved != nil {
+		l = m.LabelLinkRemoved.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
 }
-func (this *CreateTokenRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ServiceChange) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForCapabilities := "[]TokenCapability{"
-	for _, f := range this.Capabilities {
-		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	repeatedStringForCapabilities += "}"
-	s := strings.Join([]string{`&CreateTokenRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Capabilities:` + repeatedStringForCapabilities + `,`,
-		`ValidDuration:` + strings.Replace(fmt.Sprintf("%v", this.ValidDuration), "Timestamp", "Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CreateTokenResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.Service != nil {
+		l = m.Service.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&CreateTokenResponse{`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *ControlRegister) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&ControlRegister{`,
-		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ControlToken) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
 	}
-	s := strings.Join([]string{`&ControlToken{`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.InstanceId != nil {
+		l = m.InstanceId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
 }
-func (this *TokenInfo) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ConfigResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&TokenInfo{`,
-		`PublicKey:` + fmt.Sprintf("%v", this.PublicKey) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	l = len(m.TlsKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.TlsCert)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.TokenPub)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.S3AccessKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.S3SecretKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.S3Bucket)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.ImageTag)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
 }
-func (this *ListAccountsRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *HubChange) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&ListAccountsRequest{`,
-		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
-		`Marker:` + fmt.Sprintf("%v", this.Marker) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	if m.OldId != nil {
+		l = m.OldId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.NewId != nil {
+		l = m.NewId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
 }
-func (this *ListAccountsResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *CentralActivity) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForAccounts := "[]*Account{"
-	for _, f := range this.Accounts {
-		repeatedStringForAccounts += strings.Replace(fmt.Sprintf("%v", f), "Account", "Account", 1) + ","
+	var l int
+	_ = l
+	if len(m.AccountServices) > 0 {
+		for _, e := range m.AccountServices {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.RequestStats {
+		n += 2
+	}
+	if m.NewLabelLinks != nil {
+		l = m.NewLabelLinks.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.HubChange != nil {
+		l = m.HubChange.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *HubActivity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HubReg != nil {
+		l = m.HubReg.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.SentAt != nil {
+		l = m.SentAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Flow) > 0 {
+		for _, e := range m.Flow {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubActivity_HubRegistration) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.StableHub != nil {
+		l = m.StableHub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubActivity_HubStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentConnections != 0 {
+		n += 1 + sovControl(uint64(m.AgentConnections))
+	}
+	if m.AccountsSeen != 0 {
+		n += 1 + sovControl(uint64(m.AccountsSeen))
+	}
+	if m.AccountsCached != 0 {
+		n += 1 + sovControl(uint64(m.AccountsCached))
+	}
+	if m.CachedBytes != 0 {
+		n += 1 + sovControl(uint64(m.CachedBytes))
+	}
+	if m.LastSuccessfulUpdate != nil {
+		l = m.LastSuccessfulUpdate.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.LastAttemptedUpdate != nil {
+		l = m.LastAttemptedUpdate.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *HubInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ListOfHubs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hubs) > 0 {
+		for _, e := range m.Hubs {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubSync) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubSyncResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ServiceCount != 0 {
+		n += 1 + sovControl(uint64(m.ServiceCount))
+	}
+	return n
+}
+
+func (m *HubRegisterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.InstanceId != nil {
+		l = m.InstanceId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubRegisterResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ControlTime != nil {
+		l = m.ControlTime.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *HubDisconnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.InstanceId != nil {
+		l = m.InstanceId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceTokenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceTokenResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListServicesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Service) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AddAccountRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *AddLabelLinkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *Noop) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RemoveLabelLinkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateTokenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, e := range m.Capabilities {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.ValidDuration != nil {
+		l = m.ValidDuration.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateTokenResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ControlRegister) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ControlToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *TokenInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListAccountsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		n += 1 + sovControl(uint64(m.Limit))
+	}
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListAccountsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Accounts) > 0 {
+		for _, e := range m.Accounts {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ResumeToken)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovControl(uint64(m.Type))
+	}
+	l = len(m.ResumeToken)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Service != nil {
+		l = m.Service.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.LabelLink != nil {
+		l = m.LabelLink.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *Webhook) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Events) > 0 {
+		for _, s := range m.Events {
+			l = len(s)
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.CreatedAt != nil {
+		l = m.CreatedAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *AddWebhookRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Events) > 0 {
+		for _, s := range m.Events {
+			l = len(s)
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AddWebhookResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Webhook != nil {
+		l = m.Webhook.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *RemoveWebhookRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListWebhooksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListWebhooksResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Webhooks) > 0 {
+		for _, e := range m.Webhooks {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WebhookDelivery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.WebhookId != nil {
+		l = m.WebhookId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Event)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Attempts != 0 {
+		n += 1 + sovControl(uint64(m.Attempts))
+	}
+	if m.StatusCode != 0 {
+		n += 1 + sovControl(uint64(m.StatusCode))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.CreatedAt != nil {
+		l = m.CreatedAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.DeliveredAt != nil {
+		l = m.DeliveredAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListWebhookDeliveriesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.WebhookId != nil {
+		l = m.WebhookId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovControl(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *ListWebhookDeliveriesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Deliveries) > 0 {
+		for _, e := range m.Deliveries {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovControl(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozControl(x uint64) (n int) {
+	return sovControl(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ServiceRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&ServiceRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelLink) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelLink{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelLinks) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLabelLinks := "[]*LabelLink{"
+	for _, f := range this.LabelLinks {
+		repeatedStringForLabelLinks += strings.Replace(f.String(), "LabelLink", "LabelLink", 1) + ","
+	}
+	repeatedStringForLabelLinks += "}"
+	s := strings.Join([]string{`&LabelLinks{`,
+		`LabelLinks:` + repeatedStringForLabelLinks + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceRoute) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&ServiceRoute{`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AccountServices) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*ServiceRoute{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceRoute", "ServiceRoute", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&AccountServices{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActivityEntry) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActivityEntry{`,
+		`RouteAdded:` + strings.Replace(this.RouteAdded.String(), "AccountServices", "AccountServices", 1) + `,`,
+		`RouteRemoved:` + strings.Replace(fmt.Sprintf("%v", this.RouteRemoved), "ULID", "ULID", 1) + `,`,
+		`ServiceAdded:` + strings.Replace(this.ServiceAdded.String(), "ServiceChange", "ServiceChange", 1) + `,`,
+		`ServiceRemoved:` + strings.Replace(this.ServiceRemoved.String(), "ServiceChange", "ServiceChange", 1) + `,`,
+		`LabelLinkAdded:` + strings.Replace(this.LabelLinkAdded.String(), "LabelLink", "LabelLink", 1) + `,`,
+		`LabelLinkRemoved:` + strings.Replace(this.LabelLinkRemoved.String(), "LabelLink", "LabelLink", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceChange) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceChange{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Service:` + strings.Replace(this.Service.String(), "Service", "Service", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConfigRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&ConfigRequest{`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConfigResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConfigResponse{`,
+		`TlsKey:` + fmt.Sprintf("%v", this.TlsKey) + `,`,
+		`TlsCert:` + fmt.Sprintf("%v", this.TlsCert) + `,`,
+		`TokenPub:` + fmt.Sprintf("%v", this.TokenPub) + `,`,
+		`S3AccessKey:` + fmt.Sprintf("%v", this.S3AccessKey) + `,`,
+		`S3SecretKey:` + fmt.Sprintf("%v", this.S3SecretKey) + `,`,
+		`S3Bucket:` + fmt.Sprintf("%v", this.S3Bucket) + `,`,
+		`ImageTag:` + fmt.Sprintf("%v", this.ImageTag) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubChange) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubChange{`,
+		`OldId:` + strings.Replace(fmt.Sprintf("%v", this.OldId), "ULID", "ULID", 1) + `,`,
+		`NewId:` + strings.Replace(fmt.Sprintf("%v", this.NewId), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CentralActivity) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAccountServices := "[]*AccountServices{"
+	for _, f := range this.AccountServices {
+		repeatedStringForAccountServices += strings.Replace(f.String(), "AccountServices", "AccountServices", 1) + ","
+	}
+	repeatedStringForAccountServices += "}"
+	s := strings.Join([]string{`&CentralActivity{`,
+		`AccountServices:` + repeatedStringForAccountServices + `,`,
+		`RequestStats:` + fmt.Sprintf("%v", this.RequestStats) + `,`,
+		`NewLabelLinks:` + strings.Replace(this.NewLabelLinks.String(), "LabelLinks", "LabelLinks", 1) + `,`,
+		`HubChange:` + strings.Replace(this.HubChange.String(), "HubChange", "HubChange", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubActivity) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForFlow := "[]*FlowRecord{"
+	for _, f := range this.Flow {
+		repeatedStringForFlow += strings.Replace(fmt.Sprintf("%v", f), "FlowRecord", "FlowRecord", 1) + ","
+	}
+	repeatedStringForFlow += "}"
+	s := strings.Join([]string{`&HubActivity{`,
+		`HubReg:` + strings.Replace(fmt.Sprintf("%v", this.HubReg), "HubActivity_HubRegistration", "HubActivity_HubRegistration", 1) + `,`,
+		`SentAt:` + strings.Replace(fmt.Sprintf("%v", this.SentAt), "Timestamp", "Timestamp", 1) + `,`,
+		`Stats:` + strings.Replace(fmt.Sprintf("%v", this.Stats), "HubActivity_HubStats", "HubActivity_HubStats", 1) + `,`,
+		`Flow:` + repeatedStringForFlow + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubActivity_HubRegistration) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&HubActivity_HubRegistration{`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`StableHub:` + strings.Replace(fmt.Sprintf("%v", this.StableHub), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubActivity_HubStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubActivity_HubStats{`,
+		`AgentConnections:` + fmt.Sprintf("%v", this.AgentConnections) + `,`,
+		`AccountsSeen:` + fmt.Sprintf("%v", this.AccountsSeen) + `,`,
+		`AccountsCached:` + fmt.Sprintf("%v", this.AccountsCached) + `,`,
+		`CachedBytes:` + fmt.Sprintf("%v", this.CachedBytes) + `,`,
+		`LastSuccessfulUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastSuccessfulUpdate), "Timestamp", "Timestamp", 1) + `,`,
+		`LastAttemptedUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastAttemptedUpdate), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&HubInfo{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListOfHubs) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHubs := "[]*HubInfo{"
+	for _, f := range this.Hubs {
+		repeatedStringForHubs += strings.Replace(f.String(), "HubInfo", "HubInfo", 1) + ","
+	}
+	repeatedStringForHubs += "}"
+	s := strings.Join([]string{`&ListOfHubs{`,
+		`Hubs:` + repeatedStringForHubs + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubSync) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*ServiceRequest{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceRequest", "ServiceRequest", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&HubSync{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubSyncResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubSyncResponse{`,
+		`ServiceCount:` + fmt.Sprintf("%v", this.ServiceCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubRegisterRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&HubRegisterRequest{`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubRegisterResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubRegisterResponse{`,
+		`ControlTime:` + strings.Replace(fmt.Sprintf("%v", this.ControlTime), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubDisconnectRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubDisconnectRequest{`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceTokenRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceTokenRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceTokenResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceTokenResponse{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListServicesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListServicesRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListServicesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*Service{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "Service", "Service", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&ListServicesResponse{`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Service) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&Service{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddAccountRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddAccountRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddLabelLinkRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddLabelLinkRequest{`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Noop) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Noop{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RemoveLabelLinkRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RemoveLabelLinkRequest{`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokenRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForCapabilities := "[]TokenCapability{"
+	for _, f := range this.Capabilities {
+		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForCapabilities += "}"
+	s := strings.Join([]string{`&CreateTokenRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Capabilities:` + repeatedStringForCapabilities + `,`,
+		`ValidDuration:` + strings.Replace(fmt.Sprintf("%v", this.ValidDuration), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokenResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CreateTokenResponse{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ControlRegister) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ControlRegister{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ControlToken) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ControlToken{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TokenInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TokenInfo{`,
+		`PublicKey:` + fmt.Sprintf("%v", this.PublicKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListAccountsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListAccountsRequest{`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`Marker:` + fmt.Sprintf("%v", this.Marker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListAccountsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAccounts := "[]*Account{"
+	for _, f := range this.Accounts {
+		repeatedStringForAccounts += strings.Replace(fmt.Sprintf("%v", f), "Account", "Account", 1) + ","
+	}
+	repeatedStringForAccounts += "}"
+	s := strings.Join([]string{`&ListAccountsResponse{`,
+		`Accounts:` + repeatedStringForAccounts + `,`,
+		`NextMarker:` + fmt.Sprintf("%v", this.NextMarker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WatchServicesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchServicesRequest{`,
+		`ResumeToken:` + fmt.Sprintf("%v", this.ResumeToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceEvent{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`ResumeToken:` + fmt.Sprintf("%v", this.ResumeToken) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Service:` + strings.Replace(this.Service.String(), "Service", "Service", 1) + `,`,
+		`LabelLink:` + strings.Replace(this.LabelLink.String(), "LabelLink", "LabelLink", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Webhook) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Webhook{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Url:` + fmt.Sprintf("%v", this.Url) + `,`,
+		`Events:` + fmt.Sprintf("%v", this.Events) + `,`,
+		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddWebhookRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddWebhookRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Url:` + fmt.Sprintf("%v", this.Url) + `,`,
+		`Events:` + fmt.Sprintf("%v", this.Events) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddWebhookResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddWebhookResponse{`,
+		`Webhook:` + strings.Replace(this.Webhook.String(), "Webhook", "Webhook", 1) + `,`,
+		`Secret:` + fmt.Sprintf("%v", this.Secret) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RemoveWebhookRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RemoveWebhookRequest{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListWebhooksRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListWebhooksRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListWebhooksResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForWebhooks := "[]*Webhook{"
+	for _, f := range this.Webhooks {
+		repeatedStringForWebhooks += strings.Replace(f.String(), "Webhook", "Webhook", 1) + ","
+	}
+	repeatedStringForWebhooks += "}"
+	s := strings.Join([]string{`&ListWebhooksResponse{`,
+		`Webhooks:` + repeatedStringForWebhooks + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WebhookDelivery) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WebhookDelivery{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`WebhookId:` + strings.Replace(fmt.Sprintf("%v", this.WebhookId), "ULID", "ULID", 1) + `,`,
+		`Event:` + fmt.Sprintf("%v", this.Event) + `,`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Attempts:` + fmt.Sprintf("%v", this.Attempts) + `,`,
+		`StatusCode:` + fmt.Sprintf("%v", this.StatusCode) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "Timestamp", 1) + `,`,
+		`DeliveredAt:` + strings.Replace(fmt.Sprintf("%v", this.DeliveredAt), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListWebhookDeliveriesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListWebhookDeliveriesRequest{`,
+		`WebhookId:` + strings.Replace(fmt.Sprintf("%v", this.WebhookId), "ULID", "ULID", 1) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListWebhookDeliveriesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForDeliveries := "[]*WebhookDelivery{"
+	for _, f := range this.Deliveries {
+		repeatedStringForDeliveries += strings.Replace(f.String(), "WebhookDelivery", "WebhookDelivery", 1) + ","
+	}
+	repeatedStringForDeliveries += "}"
+	s := strings.Join([]string{`&ListWebhookDeliveriesResponse{`,
+		`Deliveries:` + repeatedStringForDeliveries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringControl(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelLink) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelLink: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelLink: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
+			}
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelLinks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelLinks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelLinks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelLinks = append(m.LabelLinks, &LabelLink{})
+			if err := m.LabelLinks[len(m.LabelLinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceRoute: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceRoute: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountServices) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountServices: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountServices: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, &ServiceRoute{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivityEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivityEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RouteAdded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RouteAdded == nil {
+				m.RouteAdded = &AccountServices{}
+			}
+			if err := m.RouteAdded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RouteRemoved", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RouteRemoved == nil {
+				m.RouteRemoved = &ULID{}
+			}
+			if err := m.RouteRemoved.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAdded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ServiceAdded == nil {
+				m.ServiceAdded = &ServiceChange{}
+			}
+			if err := m.ServiceAdded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceRemoved", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ServiceRemoved == nil {
+				m.ServiceRemoved = &ServiceChange{}
+			}
+			if err := m.ServiceRemoved.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinkAdded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelLinkAdded == nil {
+				m.LabelLinkAdded = &LabelLink{}
+			}
+			if err := m.LabelLinkAdded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinkRemoved", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelLinkRemoved == nil {
+				m.LabelLinkRemoved = &LabelLink{}
+			}
+			if err := m.LabelLinkRemoved.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Service == nil {
+				m.Service = &Service{}
+			}
+			if err := m.Service.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
+			}
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstanceId == nil {
+				m.InstanceId = &ULID{}
+			}
+			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TlsKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TlsKey = append(m.TlsKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TlsKey == nil {
+				m.TlsKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TlsCert", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TlsCert = append(m.TlsCert[:0], dAtA[iNdEx:postIndex]...)
+			if m.TlsCert == nil {
+				m.TlsCert = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenPub", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenPub = append(m.TokenPub[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenPub == nil {
+				m.TokenPub = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3AccessKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S3AccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3SecretKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S3SecretKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S3Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImageTag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ImageTag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OldId == nil {
+				m.OldId = &ULID{}
+			}
+			if err := m.OldId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NewId == nil {
+				m.NewId = &ULID{}
+			}
+			if err := m.NewId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	repeatedStringForAccounts += "}"
-	s := strings.Join([]string{`&ListAccountsResponse{`,
-		`Accounts:` + repeatedStringForAccounts + `,`,
-		`NextMarker:` + fmt.Sprintf("%v", this.NextMarker) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringControl(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
+func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7649,15 +12218,15 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CentralActivity: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CentralActivity: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountServices", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7684,16 +12253,34 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
-			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.AccountServices = append(m.AccountServices, &AccountServices{})
+			if err := m.AccountServices[len(m.AccountServices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestStats", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequestStats = bool(v != 0)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewLabelLinks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7720,16 +12307,16 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
+			if m.NewLabelLinks == nil {
+				m.NewLabelLinks = &LabelLinks{}
 			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.NewLabelLinks.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HubChange", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7756,18 +12343,71 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
+			if m.HubChange == nil {
+				m.HubChange = &HubChange{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.HubChange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubActivity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubActivity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HubReg", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -7777,27 +12417,67 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HubReg == nil {
+				m.HubReg = &HubActivity_HubRegistration{}
+			}
+			if err := m.HubReg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SentAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			if m.SentAt == nil {
+				m.SentAt = &Timestamp{}
+			}
+			if err := m.SentAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7824,16 +12504,16 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.Stats == nil {
+				m.Stats = &HubActivity_HubStats{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flow", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7860,8 +12540,8 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata, &KVPair{})
-			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Flow = append(m.Flow, &FlowRecord{})
+			if err := m.Flow[len(m.Flow)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7889,60 +12569,7 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LabelLink) Unmarshal(dAtA []byte) error {
+func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7965,15 +12592,15 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelLink: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubRegistration: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelLink: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubRegistration: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8000,16 +12627,16 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StableHub", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8036,16 +12663,16 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.StableHub == nil {
+				m.StableHub = &ULID{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.StableHub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8072,18 +12699,69 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Target == nil {
-				m.Target = &LabelSet{}
-			}
-			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnections", wireType)
+			}
+			m.AgentConnections = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8093,84 +12771,107 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.AgentConnections |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountsSeen", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
+			m.AccountsSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccountsSeen |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountsCached", wireType)
 			}
-			if m.Limits == nil {
-				m.Limits = &Account_Limits{}
+			m.AccountsCached = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccountsCached |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CachedBytes", wireType)
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			m.CachedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CachedBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSuccessfulUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LabelLinks) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.LastSuccessfulUpdate == nil {
+				m.LastSuccessfulUpdate = &Timestamp{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.LastSuccessfulUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelLinks: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelLinks: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastAttemptedUpdate", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8197,8 +12898,10 @@ func (m *LabelLinks) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelLinks = append(m.LabelLinks, &LabelLink{})
-			if err := m.LabelLinks[len(m.LabelLinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.LastAttemptedUpdate == nil {
+				m.LastAttemptedUpdate = &Timestamp{}
+			}
+			if err := m.LastAttemptedUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -8226,7 +12929,7 @@ func (m *LabelLinks) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
+func (m *HubInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8249,15 +12952,15 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceRoute: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceRoute: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8284,16 +12987,16 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
+			if m.Id == nil {
+				m.Id = &ULID{}
 			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8320,48 +13023,67 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListOfHubs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListOfHubs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hubs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8388,10 +13110,8 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
-			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hubs = append(m.Hubs, &HubInfo{})
+			if err := m.Hubs[len(m.Hubs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -8419,7 +13139,7 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccountServices) Unmarshal(dAtA []byte) error {
+func (m *HubSync) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8442,15 +13162,15 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccountServices: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubSync: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccountServices: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubSync: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8477,14 +13197,50 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
+			if m.Id == nil {
+				m.Id = &ULID{}
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
+			}
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
@@ -8513,7 +13269,7 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Services = append(m.Services, &ServiceRoute{})
+			m.Services = append(m.Services, &ServiceRequest{})
 			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -8542,7 +13298,7 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
+func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8565,53 +13321,17 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ActivityEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubSyncResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivityEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubSyncResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RouteAdded", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.RouteAdded == nil {
-				m.RouteAdded = &AccountServices{}
-			}
-			if err := m.RouteAdded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RouteRemoved", wireType)
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceCount", wireType)
 			}
-			var msglen int
+			m.ServiceCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8621,28 +13341,11 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ServiceCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.RouteRemoved == nil {
-				m.RouteRemoved = &ULID{}
-			}
-			if err := m.RouteRemoved.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -8667,7 +13370,7 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
+func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8690,10 +13393,10 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubRegisterRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubRegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8826,7 +13529,7 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
+func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8849,183 +13552,17 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubRegisterResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubRegisterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TlsKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TlsKey = append(m.TlsKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.TlsKey == nil {
-				m.TlsKey = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TlsCert", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TlsCert = append(m.TlsCert[:0], dAtA[iNdEx:postIndex]...)
-			if m.TlsCert == nil {
-				m.TlsCert = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenPub", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TokenPub = append(m.TokenPub[:0], dAtA[iNdEx:postIndex]...)
-			if m.TokenPub == nil {
-				m.TokenPub = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field S3AccessKey", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.S3AccessKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field S3SecretKey", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.S3SecretKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field S3Bucket", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ControlTime", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9035,55 +13572,27 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.S3Bucket = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ImageTag", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
+			if m.ControlTime == nil {
+				m.ControlTime = &Timestamp{}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.ControlTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.ImageTag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9109,7 +13618,7 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubChange) Unmarshal(dAtA []byte) error {
+func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9132,15 +13641,15 @@ func (m *HubChange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubChange: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubDisconnectRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubChange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubDisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9167,16 +13676,16 @@ func (m *HubChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OldId == nil {
-				m.OldId = &ULID{}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
 			}
-			if err := m.OldId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9203,10 +13712,10 @@ func (m *HubChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NewId == nil {
-				m.NewId = &ULID{}
+			if m.InstanceId == nil {
+				m.InstanceId = &ULID{}
 			}
-			if err := m.NewId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9234,7 +13743,7 @@ func (m *HubChange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CentralActivity) Unmarshal(dAtA []byte) error {
+func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9257,17 +13766,17 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CentralActivity: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceTokenRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CentralActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountServices", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9277,87 +13786,82 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AccountServices = append(m.AccountServices, &AccountServices{})
-			if err := m.AccountServices[len(m.AccountServices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestStats", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.RequestStats = bool(v != 0)
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewLabelLinks", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NewLabelLinks == nil {
-				m.NewLabelLinks = &LabelLinks{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
 			}
-			if err := m.NewLabelLinks.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 4:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceTokenResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HubChange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9367,27 +13871,23 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.HubChange == nil {
-				m.HubChange = &HubChange{}
-			}
-			if err := m.HubChange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9413,7 +13913,7 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubActivity) Unmarshal(dAtA []byte) error {
+func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9436,15 +13936,15 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubActivity: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListServicesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HubReg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9471,88 +13971,69 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.HubReg == nil {
-				m.HubReg = &HubActivity_HubRegistration{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.HubReg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SentAt", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SentAt == nil {
-				m.SentAt = &Timestamp{}
-			}
-			if err := m.SentAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Stats == nil {
-				m.Stats = &HubActivity_HubStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListServicesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListServicesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flow", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9579,8 +14060,8 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Flow = append(m.Flow, &FlowRecord{})
-			if err := m.Flow[len(m.Flow)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Services = append(m.Services, &Service{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9608,7 +14089,7 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
+func (m *Service) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9631,13 +14112,49 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubRegistration: wiretype end group for non-group")
+			return fmt.Errorf("proto: Service: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubRegistration: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Service: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
 			}
@@ -9673,9 +14190,41 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableHub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9702,16 +14251,16 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableHub == nil {
-				m.StableHub = &ULID{}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
 			}
-			if err := m.StableHub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9738,8 +14287,8 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9767,7 +14316,7 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
+func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9790,91 +14339,15 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddAccountRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnections", wireType)
-			}
-			m.AgentConnections = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.AgentConnections |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountsSeen", wireType)
-			}
-			m.AccountsSeen = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.AccountsSeen |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountsCached", wireType)
-			}
-			m.AccountsCached = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.AccountsCached |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CachedBytes", wireType)
-			}
-			m.CachedBytes = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CachedBytes |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastSuccessfulUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9901,16 +14374,16 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LastSuccessfulUpdate == nil {
-				m.LastSuccessfulUpdate = &Timestamp{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.LastSuccessfulUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastAttemptedUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9937,10 +14410,10 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LastAttemptedUpdate == nil {
-				m.LastAttemptedUpdate = &Timestamp{}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
 			}
-			if err := m.LastAttemptedUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9968,7 +14441,7 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubInfo) Unmarshal(dAtA []byte) error {
+func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9991,15 +14464,15 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddLabelLinkRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10026,16 +14499,52 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10062,8 +14571,10 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10091,7 +14602,7 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
+func (m *Noop) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10114,46 +14625,12 @@ func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListOfHubs: wiretype end group for non-group")
+			return fmt.Errorf("proto: Noop: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListOfHubs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Noop: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hubs", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hubs = append(m.Hubs, &HubInfo{})
-			if err := m.Hubs[len(m.Hubs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -10178,7 +14655,7 @@ func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubSync) Unmarshal(dAtA []byte) error {
+func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10201,15 +14678,15 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubSync: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveLabelLinkRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubSync: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10236,16 +14713,16 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10272,44 +14749,10 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
-			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			m.Services = append(m.Services, &ServiceRequest{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10337,7 +14780,7 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
+func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10360,17 +14803,17 @@ func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubSyncResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateTokenRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubSyncResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceCount", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
-			m.ServiceCount = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10380,67 +14823,31 @@ func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ServiceCount |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: HubRegisterRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubRegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10467,16 +14874,14 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
-			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Capabilities = append(m.Capabilities, TokenCapability{})
+			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidDuration", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10503,18 +14908,71 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InstanceId == nil {
-				m.InstanceId = &ULID{}
+			if m.ValidDuration == nil {
+				m.ValidDuration = &Timestamp{}
 			}
-			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ValidDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateTokenResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10524,25 +14982,23 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10568,7 +15024,7 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
+func (m *ControlRegister) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10591,17 +15047,17 @@ func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubRegisterResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ControlRegister: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubRegisterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ControlRegister: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ControlTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10611,27 +15067,23 @@ func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ControlTime == nil {
-				m.ControlTime = &Timestamp{}
-			}
-			if err := m.ControlTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10657,7 +15109,7 @@ func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
+func (m *ControlToken) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10680,53 +15132,17 @@ func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubDisconnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ControlToken: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubDisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ControlToken: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
-			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10736,27 +15152,23 @@ func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InstanceId == nil {
-				m.InstanceId = &ULID{}
-			}
-			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10782,7 +15194,7 @@ func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
+func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10805,17 +15217,17 @@ func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceTokenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10825,23 +15237,25 @@ func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10867,7 +15281,7 @@ func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
+func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10890,17 +15304,36 @@ func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceTokenResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListAccountsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListAccountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10910,23 +15343,25 @@ func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Marker == nil {
+				m.Marker = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10952,7 +15387,7 @@ func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
+func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10975,15 +15410,15 @@ func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListServicesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListAccountsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListAccountsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11010,13 +15445,45 @@ func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
-			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Accounts = append(m.Accounts, &Account{})
+			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextMarker == nil {
+				m.NextMarker = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11041,7 +15508,7 @@ func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
+func (m *WatchServicesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11064,17 +15531,17 @@ func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListServicesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: WatchServicesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListServicesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WatchServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeToken", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11084,25 +15551,23 @@ func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Services = append(m.Services, &Service{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ResumeToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11128,7 +15593,7 @@ func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Service) Unmarshal(dAtA []byte) error {
+func (m *ServiceEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11151,53 +15616,36 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Service: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Service: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Id == nil {
-				m.Id = &ULID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ServiceEvent_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeToken", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11207,33 +15655,29 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
-			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ResumeToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11243,27 +15687,31 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11290,16 +15738,16 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.Service == nil {
+				m.Service = &Service{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Service.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelLink", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11326,8 +15774,10 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata, &KVPair{})
-			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.LabelLink == nil {
+				m.LabelLink = &LabelLink{}
+			}
+			if err := m.LabelLink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11355,7 +15805,7 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
+func (m *Webhook) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11378,15 +15828,15 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddAccountRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Webhook: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Webhook: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11413,16 +15863,112 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
+			if m.Id == nil {
+				m.Id = &ULID{}
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11449,10 +15995,10 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Limits == nil {
-				m.Limits = &Account_Limits{}
+			if m.CreatedAt == nil {
+				m.CreatedAt = &Timestamp{}
 			}
-			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CreatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11480,7 +16026,7 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
+func (m *AddWebhookRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11503,17 +16049,17 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddLabelLinkRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddWebhookRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddWebhookRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11523,33 +16069,29 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
-			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11559,33 +16101,29 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
-			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Url = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11595,81 +16133,24 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Target == nil {
-				m.Target = &LabelSet{}
-			}
-			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Noop) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Noop: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Noop: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Events = append(m.Events, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11694,7 +16175,7 @@ func (m *Noop) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
+func (m *AddWebhookResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11717,15 +16198,15 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveLabelLinkRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddWebhookResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddWebhookResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Webhook", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11752,18 +16233,18 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.Webhook == nil {
+				m.Webhook = &Webhook{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Webhook.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11773,27 +16254,23 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
-			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Secret = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11819,7 +16296,7 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
+func (m *RemoveWebhookRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11842,15 +16319,15 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateTokenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveWebhookRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveWebhookRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11877,83 +16354,66 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
+			if m.Id == nil {
+				m.Id = &ULID{}
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Capabilities = append(m.Capabilities, TokenCapability{})
-			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidDuration", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListWebhooksRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ValidDuration == nil {
-				m.ValidDuration = &Timestamp{}
-			}
-			if err := m.ValidDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListWebhooksRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListWebhooksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11978,7 +16438,7 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
+func (m *ListWebhooksResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12001,17 +16461,17 @@ func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateTokenResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListWebhooksResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListWebhooksResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Webhooks", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -12021,23 +16481,25 @@ func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Webhooks = append(m.Webhooks, &Webhook{})
+			if err := m.Webhooks[len(m.Webhooks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12063,7 +16525,7 @@ func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ControlRegister) Unmarshal(dAtA []byte) error {
+func (m *WebhookDelivery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12086,15 +16548,119 @@ func (m *ControlRegister) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ControlRegister: wiretype end group for non-group")
+			return fmt.Errorf("proto: WebhookDelivery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ControlRegister: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WebhookDelivery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WebhookId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.WebhookId == nil {
+				m.WebhookId = &ULID{}
+			}
+			if err := m.WebhookId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Event = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12122,64 +16688,49 @@ func (m *ControlRegister) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			m.Status = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ControlToken) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StatusCode", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.StatusCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StatusCode |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ControlToken: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ControlToken: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12207,66 +16758,49 @@ func (m *ControlToken) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TokenInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.CreatedAt == nil {
+				m.CreatedAt = &Timestamp{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.CreatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliveredAt", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -12276,24 +16810,26 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.PublicKey == nil {
-				m.PublicKey = []byte{}
+			if m.DeliveredAt == nil {
+				m.DeliveredAt = &Timestamp{}
+			}
+			if err := m.DeliveredAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -12320,7 +16856,7 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
+func (m *ListWebhookDeliveriesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12343,17 +16879,17 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListAccountsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListWebhookDeliveriesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListAccountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListWebhookDeliveriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WebhookId", wireType)
 			}
-			m.Limit = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -12363,16 +16899,33 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Limit |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.WebhookId == nil {
+				m.WebhookId = &ULID{}
+			}
+			if err := m.WebhookId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var byteLen int
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -12382,26 +16935,11 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
-			if m.Marker == nil {
-				m.Marker = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -12426,7 +16964,7 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
+func (m *ListWebhookDeliveriesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12449,15 +16987,15 @@ func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListAccountsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListWebhookDeliveriesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListAccountsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListWebhookDeliveriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Deliveries", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12484,45 +17022,11 @@ func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Accounts = append(m.Accounts, &Account{})
-			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Deliveries = append(m.Deliveries, &WebhookDelivery{})
+			if err := m.Deliveries[len(m.Deliveries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
-			if m.NextMarker == nil {
-				m.NextMarker = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -152,9 +152,10 @@ func (m *FlowStream) GetDuration() int64 {
 }
 
 type FlowRecord struct {
-	Agent    *FlowRecord_AgentConnection `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
-	Stream   *FlowStream                 `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
-	HubStats *FlowRecord_HubStats        `protobuf:"bytes,3,opt,name=hub_stats,json=hubStats,proto3" json:"hub_stats,omitempty"`
+	Agent     *FlowRecord_AgentConnection `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Stream    *FlowStream                 `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
+	HubStats  *FlowRecord_HubStats        `protobuf:"bytes,3,opt,name=hub_stats,json=hubStats,proto3" json:"hub_stats,omitempty"`
+	RateLimit *FlowRecord_RateLimit       `protobuf:"bytes,4,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
 }
 
 func (m *FlowRecord) Reset()      { *m = FlowRecord{} }
@@ -210,6 +211,13 @@ func (m *FlowRecord) GetHubStats() *FlowRecord_HubStats {
 	return nil
 }
 
+func (m *FlowRecord) GetRateLimit() *FlowRecord_RateLimit {
+	if m != nil {
+		return m.RateLimit
+	}
+	return nil
+}
+
 type FlowRecord_AgentConnection struct {
 	HubId         *ULID      `protobuf:"bytes,1,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
 	AgentId       *ULID      `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -368,6 +376,74 @@ func (m *FlowRecord_HubStats) GetServices() int64 {
 	return 0
 }
 
+type FlowRecord_RateLimit struct {
+	HubId   *ULID    `protobuf:"bytes,1,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
+	Account *Account `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Which limit was hit, either "http-requests" or "bandwidth".
+	Limit      string     `protobuf:"bytes,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	OccurredAt *Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+}
+
+func (m *FlowRecord_RateLimit) Reset()      { *m = FlowRecord_RateLimit{} }
+func (*FlowRecord_RateLimit) ProtoMessage() {}
+func (*FlowRecord_RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bb3fc33c49933823, []int{1, 2}
+}
+func (m *FlowRecord_RateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FlowRecord_RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FlowRecord_RateLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FlowRecord_RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowRecord_RateLimit.Merge(m, src)
+}
+func (m *FlowRecord_RateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *FlowRecord_RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowRecord_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowRecord_RateLimit proto.InternalMessageInfo
+
+func (m *FlowRecord_RateLimit) GetHubId() *ULID {
+	if m != nil {
+		return m.HubId
+	}
+	return nil
+}
+
+func (m *FlowRecord_RateLimit) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *FlowRecord_RateLimit) GetLimit() string {
+	if m != nil {
+		return m.Limit
+	}
+	return ""
+}
+
+func (m *FlowRecord_RateLimit) GetOccurredAt() *Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
 type FlowTopSnapshot struct {
 	Records []*FlowStream `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
 }
@@ -459,6 +535,7 @@ func init() {
 	proto.RegisterType((*FlowRecord)(nil), "pb.FlowRecord")
 	proto.RegisterType((*FlowRecord_AgentConnection)(nil), "pb.FlowRecord.AgentConnection")
 	proto.RegisterType((*FlowRecord_HubStats)(nil), "pb.FlowRecord.HubStats")
+	proto.RegisterType((*FlowRecord_RateLimit)(nil), "pb.FlowRecord.RateLimit")
 	proto.RegisterType((*FlowTopSnapshot)(nil), "pb.FlowTopSnapshot")
 	proto.RegisterType((*FlowTopRequest)(nil), "pb.FlowTopRequest")
 }
@@ -466,48 +543,52 @@ func init() {
 func init() { proto.RegisterFile("flow.proto", fileDescriptor_bb3fc33c49933823) }
 
 var fileDescriptor_bb3fc33c49933823 = []byte{
-	// 647 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xcb, 0x6e, 0xd3, 0x4e,
-	0x14, 0xc6, 0x3d, 0x71, 0x93, 0x38, 0x27, 0x97, 0x4a, 0xf3, 0x5f, 0xfc, 0x2d, 0x23, 0x4d, 0xdb,
-	0x40, 0xa1, 0x0b, 0x14, 0x89, 0xd2, 0x5d, 0x57, 0x69, 0x11, 0x22, 0x52, 0x61, 0xe1, 0x94, 0x75,
-	0x34, 0x8e, 0x87, 0x26, 0x52, 0xec, 0x31, 0x9e, 0x71, 0x5b, 0x76, 0xbc, 0x01, 0xbc, 0x01, 0x2c,
-	0x79, 0x14, 0x96, 0xdd, 0x20, 0x75, 0x49, 0xdd, 0x0d, 0xcb, 0x3e, 0x02, 0x9a, 0x8b, 0xdb, 0x2a,
-	0x2a, 0x97, 0x0d, 0xbb, 0x9e, 0xf3, 0xfd, 0x8e, 0xe7, 0xf4, 0xfb, 0x8e, 0x02, 0xf0, 0x66, 0xc1,
-	0x4f, 0x06, 0x59, 0xce, 0x25, 0xc7, 0xb5, 0x2c, 0x0a, 0xa0, 0x58, 0xcc, 0x63, 0x53, 0x07, 0xab,
-	0x72, 0x9e, 0x30, 0x21, 0x69, 0x92, 0xd9, 0x46, 0x7b, 0x41, 0x23, 0xb6, 0xb0, 0x45, 0x97, 0x4e,
-	0xa7, 0xbc, 0x48, 0xa5, 0x29, 0xfb, 0x9f, 0x5c, 0x80, 0xe7, 0x0b, 0x7e, 0x32, 0x96, 0x39, 0xa3,
-	0x09, 0xde, 0x80, 0xa6, 0xfa, 0xf2, 0x64, 0x1e, 0xfb, 0x68, 0x1d, 0x6d, 0xb5, 0xb7, 0xbd, 0x41,
-	0x16, 0x0d, 0x5e, 0x1f, 0x8c, 0x9e, 0x85, 0x0d, 0x25, 0x8c, 0x62, 0xbc, 0x06, 0x8d, 0x59, 0x11,
-	0x29, 0xa2, 0xb6, 0x44, 0xd4, 0x67, 0x45, 0x34, 0x8a, 0xf1, 0x7d, 0xf0, 0xe8, 0x11, 0x4b, 0xa5,
-	0x42, 0xdc, 0x25, 0xa4, 0xa9, 0x95, 0x51, 0x8c, 0x1f, 0x01, 0x08, 0x96, 0x1f, 0xcf, 0xa7, 0x4c,
-	0x61, 0x2b, 0x4b, 0x58, 0xcb, 0x6a, 0xa3, 0x18, 0x6f, 0x42, 0xd3, 0x6e, 0xec, 0xd7, 0x35, 0xd5,
-	0x56, 0xd4, 0xd0, 0xb4, 0xc2, 0x4a, 0xc3, 0x0f, 0xa0, 0xa1, 0xff, 0x4b, 0xe1, 0x37, 0x34, 0xd5,
-	0x51, 0xd4, 0x81, 0xea, 0x8c, 0x99, 0x0c, 0xad, 0x86, 0x1f, 0x03, 0x08, 0x49, 0x73, 0xc9, 0xe2,
-	0x09, 0x95, 0x3e, 0x68, 0xb2, 0xab, 0xc8, 0xc3, 0xca, 0xb2, 0xb0, 0x65, 0x81, 0xa1, 0xc4, 0x5b,
-	0xe0, 0xb1, 0x34, 0x36, 0x6c, 0xfb, 0x2e, 0xb6, 0xa9, 0xe5, 0xa1, 0xc4, 0x1b, 0xd0, 0x49, 0x8b,
-	0x64, 0x92, 0x30, 0x21, 0xe8, 0x11, 0x13, 0x7e, 0x67, 0x1d, 0x6d, 0xb9, 0x61, 0x3b, 0x2d, 0x92,
-	0x97, 0xb6, 0x85, 0xef, 0x41, 0x4b, 0x21, 0xd1, 0x3b, 0xc9, 0x84, 0xdf, 0xd5, 0xba, 0x97, 0x16,
-	0xc9, 0x9e, 0xaa, 0x71, 0x00, 0x5e, 0x5c, 0xe4, 0x54, 0xce, 0x79, 0xea, 0xf7, 0x8c, 0x56, 0xd5,
-	0xfd, 0x6f, 0x2b, 0x26, 0xa1, 0x90, 0x4d, 0x79, 0x1e, 0xe3, 0x1d, 0xa8, 0x6b, 0x0f, 0x6d, 0x3e,
-	0x44, 0x6d, 0x74, 0x23, 0x0f, 0x86, 0x4a, 0xdb, 0xe7, 0x69, 0xca, 0xa6, 0x6a, 0x3a, 0x34, 0x30,
-	0x7e, 0x08, 0x0d, 0xa1, 0x13, 0xb6, 0xa1, 0xf5, 0xaa, 0x31, 0x93, 0x7b, 0x68, 0x55, 0xbc, 0x03,
-	0x2d, 0x15, 0xae, 0x90, 0x54, 0x0a, 0x1b, 0xde, 0xff, 0x4b, 0x2f, 0xbc, 0x28, 0xa2, 0xb1, 0x92,
-	0x43, 0x6f, 0x66, 0xff, 0x0a, 0x3e, 0xd7, 0x60, 0x75, 0xe9, 0xe1, 0x5b, 0x67, 0x82, 0xfe, 0x7c,
-	0x26, 0xb5, 0x5f, 0x9d, 0xc9, 0xad, 0xf4, 0xdd, 0xdf, 0xa4, 0xff, 0x8f, 0x73, 0xb5, 0xd7, 0x68,
-	0x72, 0xad, 0xeb, 0x5c, 0xc7, 0xb6, 0x85, 0x37, 0xa1, 0x47, 0xa7, 0x72, 0x7e, 0xcc, 0x26, 0xc6,
-	0xc2, 0x2a, 0xdc, 0xae, 0xe9, 0x1a, 0x7f, 0x45, 0xf0, 0x01, 0x81, 0x57, 0x39, 0xf7, 0x37, 0xde,
-	0xd8, 0xf1, 0x89, 0x36, 0x42, 0x68, 0x83, 0xdc, 0xb0, 0x63, 0x9a, 0xda, 0x6a, 0xa1, 0x96, 0x93,
-	0x5c, 0xd2, 0x45, 0xc5, 0xb8, 0xe6, 0xe8, 0x74, 0xcf, 0x22, 0x01, 0x78, 0xd7, 0xbb, 0xaf, 0x98,
-	0xbb, 0xaa, 0xea, 0xfe, 0x2e, 0xac, 0xaa, 0x54, 0x0f, 0x79, 0x36, 0x4e, 0x69, 0x26, 0x66, 0x5c,
-	0x19, 0xd3, 0xcc, 0x75, 0xc8, 0xc2, 0x47, 0xeb, 0xee, 0x1d, 0x67, 0x52, 0xc9, 0xfd, 0x27, 0xd0,
-	0xb3, 0xc3, 0x21, 0x7b, 0x5b, 0x30, 0x21, 0xf1, 0x1a, 0xb4, 0x13, 0x7a, 0x3a, 0xb9, 0x99, 0x57,
-	0x4e, 0x41, 0x42, 0x4f, 0xcd, 0xd9, 0x88, 0xed, 0x57, 0xd7, 0xef, 0x85, 0x2c, 0xe3, 0xb9, 0x64,
-	0x39, 0xde, 0x85, 0xde, 0x7e, 0x91, 0xe7, 0x2c, 0x95, 0x56, 0xc1, 0xb8, 0x7a, 0xf0, 0xe6, 0xcb,
-	0xc1, 0x7f, 0xb7, 0x7a, 0xd5, 0xaa, 0x7d, 0x67, 0x6f, 0xe7, 0xec, 0x82, 0x38, 0xe7, 0x17, 0xc4,
-	0xb9, 0xba, 0x20, 0xe8, 0x7d, 0x49, 0xd0, 0x97, 0x92, 0xa0, 0xaf, 0x25, 0x41, 0x67, 0x25, 0x41,
-	0xdf, 0x4b, 0x82, 0x7e, 0x94, 0xc4, 0xb9, 0x2a, 0x09, 0xfa, 0x78, 0x49, 0x9c, 0xb3, 0x4b, 0xe2,
-	0x9c, 0x5f, 0x12, 0x27, 0x6a, 0xe8, 0x9f, 0xbd, 0xa7, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x49,
-	0xf4, 0xca, 0xc4, 0x41, 0x05, 0x00, 0x00,
+	// 714 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xcf, 0x6e, 0xd3, 0x4a,
+	0x14, 0xc6, 0x33, 0x49, 0x93, 0x38, 0x27, 0x7f, 0x2a, 0xcd, 0xbd, 0xd2, 0xb5, 0x7c, 0xa5, 0xb9,
+	0x6d, 0x2e, 0x85, 0x2e, 0x50, 0x24, 0x4a, 0x25, 0x16, 0x5d, 0xa5, 0x45, 0x88, 0x48, 0x85, 0xc5,
+	0xa4, 0xac, 0xa3, 0xb1, 0x3d, 0x34, 0x96, 0x62, 0x3b, 0x78, 0xc6, 0x6d, 0xd9, 0xf1, 0x06, 0xb0,
+	0x65, 0x05, 0x4b, 0xc4, 0x93, 0xb0, 0xec, 0xb2, 0x4b, 0xea, 0x6e, 0x58, 0xf6, 0x11, 0xd0, 0xfc,
+	0x71, 0x1b, 0x45, 0xa5, 0x65, 0xc3, 0x2e, 0xe7, 0x7c, 0xbf, 0x63, 0x1f, 0x9f, 0xef, 0x53, 0x00,
+	0x5e, 0xcf, 0xd2, 0xe3, 0xc1, 0x3c, 0x4b, 0x65, 0x8a, 0xab, 0x73, 0xdf, 0x83, 0x7c, 0x16, 0x85,
+	0xa6, 0xf6, 0x56, 0x65, 0x14, 0x73, 0x21, 0x59, 0x3c, 0xb7, 0x8d, 0xf6, 0x8c, 0xf9, 0x7c, 0x66,
+	0x8b, 0x2e, 0x0b, 0x82, 0x34, 0x4f, 0xa4, 0x29, 0xfb, 0x9f, 0x6a, 0x00, 0xcf, 0x66, 0xe9, 0xf1,
+	0x58, 0x66, 0x9c, 0xc5, 0x78, 0x1d, 0x9a, 0xea, 0xc9, 0x93, 0x28, 0x74, 0xd1, 0x1a, 0xda, 0x6c,
+	0x6f, 0x39, 0x83, 0xb9, 0x3f, 0x78, 0xb5, 0x3f, 0x7a, 0x4a, 0x1b, 0x4a, 0x18, 0x85, 0xf8, 0x3f,
+	0x68, 0x4c, 0x73, 0x5f, 0x11, 0xd5, 0x25, 0xa2, 0x3e, 0xcd, 0xfd, 0x51, 0x88, 0xff, 0x07, 0x87,
+	0x1d, 0xf2, 0x44, 0x2a, 0xa4, 0xb6, 0x84, 0x34, 0xb5, 0x32, 0x0a, 0xf1, 0x03, 0x00, 0xc1, 0xb3,
+	0xa3, 0x28, 0xe0, 0x0a, 0x5b, 0x59, 0xc2, 0x5a, 0x56, 0x1b, 0x85, 0x78, 0x03, 0x9a, 0x76, 0x63,
+	0xb7, 0xae, 0xa9, 0xb6, 0xa2, 0x86, 0xa6, 0x45, 0x4b, 0x0d, 0xdf, 0x83, 0x86, 0xfe, 0x4a, 0xe1,
+	0x36, 0x34, 0xd5, 0x51, 0xd4, 0xbe, 0xea, 0x8c, 0xb9, 0xa4, 0x56, 0xc3, 0x0f, 0x01, 0x84, 0x64,
+	0x99, 0xe4, 0xe1, 0x84, 0x49, 0x17, 0x34, 0xd9, 0x55, 0xe4, 0x41, 0x79, 0x32, 0xda, 0xb2, 0xc0,
+	0x50, 0xe2, 0x4d, 0x70, 0x78, 0x12, 0x1a, 0xb6, 0x7d, 0x13, 0xdb, 0xd4, 0xf2, 0x50, 0xe2, 0x75,
+	0xe8, 0x24, 0x79, 0x3c, 0x89, 0xb9, 0x10, 0xec, 0x90, 0x0b, 0xb7, 0xb3, 0x86, 0x36, 0x6b, 0xb4,
+	0x9d, 0xe4, 0xf1, 0x0b, 0xdb, 0xc2, 0xff, 0x42, 0x4b, 0x21, 0xfe, 0x5b, 0xc9, 0x85, 0xdb, 0xd5,
+	0xba, 0x93, 0xe4, 0xf1, 0xae, 0xaa, 0xb1, 0x07, 0x4e, 0x98, 0x67, 0x4c, 0x46, 0x69, 0xe2, 0xf6,
+	0x8c, 0x56, 0xd6, 0xfd, 0xaf, 0x0d, 0xe3, 0x10, 0xe5, 0x41, 0x9a, 0x85, 0x78, 0x1b, 0xea, 0xfa,
+	0x86, 0xd6, 0x1f, 0xa2, 0x36, 0xba, 0x96, 0x07, 0x43, 0xa5, 0xed, 0xa5, 0x49, 0xc2, 0x03, 0x35,
+	0x4d, 0x0d, 0x8c, 0xef, 0x43, 0x43, 0x68, 0x87, 0xad, 0x69, 0xbd, 0x72, 0xcc, 0xf8, 0x4e, 0xad,
+	0x8a, 0xb7, 0xa1, 0xa5, 0xcc, 0x15, 0x92, 0x49, 0x61, 0xcd, 0xfb, 0x67, 0xe9, 0x0d, 0xcf, 0x73,
+	0x7f, 0xac, 0x64, 0xea, 0x4c, 0xed, 0x2f, 0xfc, 0x04, 0x20, 0x63, 0x92, 0x4f, 0x66, 0x51, 0x1c,
+	0x49, 0x6b, 0xa6, 0xbb, 0x34, 0x46, 0x99, 0xe4, 0xfb, 0x4a, 0xa7, 0xad, 0xac, 0xfc, 0xe9, 0x7d,
+	0xae, 0xc2, 0xea, 0xd2, 0xc6, 0x0b, 0xf9, 0x42, 0x77, 0xe7, 0xab, 0xfa, 0xab, 0x7c, 0x2d, 0xc4,
+	0xa6, 0x76, 0x4b, 0x6c, 0xfe, 0x70, 0x20, 0x6c, 0x8c, 0x4d, 0x20, 0xea, 0x3a, 0x10, 0x63, 0xdb,
+	0xc2, 0x1b, 0xd0, 0x63, 0x81, 0x8c, 0x8e, 0xf8, 0xc4, 0xdc, 0xbe, 0x4c, 0x45, 0xd7, 0x74, 0x8d,
+	0x31, 0xc2, 0x7b, 0x8f, 0xc0, 0x29, 0x4f, 0xfe, 0x3b, 0xb7, 0xb1, 0xe3, 0x13, 0x7d, 0x08, 0xa1,
+	0x0f, 0x54, 0xa3, 0x1d, 0xd3, 0xd4, 0xa7, 0x16, 0x6a, 0x39, 0x99, 0x4a, 0x36, 0x2b, 0x99, 0x9a,
+	0x49, 0xab, 0xee, 0x59, 0xc4, 0x03, 0xe7, 0x6a, 0xf7, 0x15, 0x13, 0xc8, 0xb2, 0xf6, 0x3e, 0x22,
+	0x68, 0x5d, 0xb9, 0x79, 0xf7, 0x4a, 0x0b, 0x4e, 0x54, 0x6f, 0x71, 0xe2, 0x6f, 0xa8, 0x9b, 0xf8,
+	0xa8, 0x6d, 0x5a, 0xd4, 0x14, 0x78, 0x00, 0xed, 0x34, 0x08, 0xf2, 0x2c, 0x33, 0x47, 0x5f, 0xb9,
+	0xe9, 0xe8, 0x50, 0x12, 0x43, 0xd9, 0xdf, 0x81, 0x55, 0x95, 0xb9, 0x83, 0x74, 0x3e, 0x4e, 0xd8,
+	0x5c, 0x4c, 0x53, 0x65, 0x5a, 0x33, 0xd3, 0x11, 0x14, 0x2e, 0x5a, 0xab, 0xdd, 0x90, 0xfd, 0x52,
+	0xee, 0x3f, 0x82, 0x9e, 0x1d, 0xa6, 0xfc, 0x4d, 0xce, 0x85, 0xfa, 0xb8, 0x76, 0xcc, 0x4e, 0x26,
+	0xd7, 0xf3, 0xca, 0x45, 0x88, 0xd9, 0x89, 0x09, 0xb5, 0xd8, 0x7a, 0x79, 0xf5, 0x3e, 0xca, 0xe7,
+	0x69, 0x26, 0x79, 0x86, 0x77, 0xa0, 0xb7, 0xa7, 0xd6, 0x49, 0xa4, 0x55, 0x30, 0x2e, 0x5f, 0x78,
+	0xfd, 0x64, 0xef, 0xaf, 0x85, 0x5e, 0xb9, 0x6a, 0xbf, 0xb2, 0xbb, 0x7d, 0x7a, 0x4e, 0x2a, 0x67,
+	0xe7, 0xa4, 0x72, 0x79, 0x4e, 0xd0, 0xbb, 0x82, 0xa0, 0x2f, 0x05, 0x41, 0xdf, 0x0a, 0x82, 0x4e,
+	0x0b, 0x82, 0xbe, 0x17, 0x04, 0xfd, 0x28, 0x48, 0xe5, 0xb2, 0x20, 0xe8, 0xc3, 0x05, 0xa9, 0x9c,
+	0x5e, 0x90, 0xca, 0xd9, 0x05, 0xa9, 0xf8, 0x0d, 0xfd, 0x5f, 0xfe, 0xf8, 0x67, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x2b, 0x76, 0x5f, 0x17, 0x16, 0x06, 0x00, 0x00,
 }
 
 func (this *FlowStream) Equal(that interface{}) bool {
@@ -592,6 +673,9 @@ func (this *FlowRecord) Equal(that interface{}) bool {
 	if !this.HubStats.Equal(that1.HubStats) {
 		return false
 	}
+	if !this.RateLimit.Equal(that1.RateLimit) {
+		return false
+	}
 	return true
 }
 func (this *FlowRecord_AgentConnection) Equal(that interface{}) bool {
@@ -669,6 +753,39 @@ func (this *FlowRecord_HubStats) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *FlowRecord_RateLimit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FlowRecord_RateLimit)
+	if !ok {
+		that2, ok := that.(FlowRecord_RateLimit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.HubId.Equal(that1.HubId) {
+		return false
+	}
+	if !this.Account.Equal(that1.Account) {
+		return false
+	}
+	if this.Limit != that1.Limit {
+		return false
+	}
+	if !this.OccurredAt.Equal(that1.OccurredAt) {
+		return false
+	}
+	return true
+}
 func (this *FlowTopSnapshot) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -762,7 +879,7 @@ func (this *FlowRecord) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.FlowRecord{")
 	if this.Agent != nil {
 		s = append(s, "Agent: "+fmt.Sprintf("%#v", this.Agent)+",\n")
@@ -773,6 +890,9 @@ func (this *FlowRecord) GoString() string {
 	if this.HubStats != nil {
 		s = append(s, "HubStats: "+fmt.Sprintf("%#v", this.HubStats)+",\n")
 	}
+	if this.RateLimit != nil {
+		s = append(s, "RateLimit: "+fmt.Sprintf("%#v", this.RateLimit)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -817,6 +937,25 @@ func (this *FlowRecord_HubStats) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *FlowRecord_RateLimit) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.FlowRecord_RateLimit{")
+	if this.HubId != nil {
+		s = append(s, "HubId: "+fmt.Sprintf("%#v", this.HubId)+",\n")
+	}
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	}
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	if this.OccurredAt != nil {
+		s = append(s, "OccurredAt: "+fmt.Sprintf("%#v", this.OccurredAt)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *FlowTopSnapshot) GoString() string {
 	if this == nil {
 		return "nil"
@@ -1082,6 +1221,18 @@ func (m *FlowRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RateLimit != nil {
+		{
+			size, err := m.RateLimit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFlow(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.HubStats != nil {
 		{
 			size, err := m.HubStats.MarshalToSizedBuffer(dAtA[:i])
@@ -1264,6 +1415,72 @@ func (m *FlowRecord_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FlowRecord_RateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FlowRecord_RateLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FlowRecord_RateLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.OccurredAt != nil {
+		{
+			size, err := m.OccurredAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFlow(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Limit) > 0 {
+		i -= len(m.Limit)
+		copy(dAtA[i:], m.Limit)
+		i = encodeVarintFlow(dAtA, i, uint64(len(m.Limit)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFlow(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.HubId != nil {
+		{
+			size, err := m.HubId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFlow(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *FlowTopSnapshot) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1408,6 +1625,10 @@ func (m *FlowRecord) Size() (n int) {
 		l = m.HubStats.Size()
 		n += 1 + l + sovFlow(uint64(l))
 	}
+	if m.RateLimit != nil {
+		l = m.RateLimit.Size()
+		n += 1 + l + sovFlow(uint64(l))
+	}
 	return n
 }
 
@@ -1468,6 +1689,31 @@ func (m *FlowRecord_HubStats) Size() (n int) {
 	return n
 }
 
+func (m *FlowRecord_RateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HubId != nil {
+		l = m.HubId.Size()
+		n += 1 + l + sovFlow(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovFlow(uint64(l))
+	}
+	l = len(m.Limit)
+	if l > 0 {
+		n += 1 + l + sovFlow(uint64(l))
+	}
+	if m.OccurredAt != nil {
+		l = m.OccurredAt.Size()
+		n += 1 + l + sovFlow(uint64(l))
+	}
+	return n
+}
+
 func (m *FlowTopSnapshot) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1529,6 +1775,7 @@ func (this *FlowRecord) String() string {
 		`Agent:` + strings.Replace(fmt.Sprintf("%v", this.Agent), "FlowRecord_AgentConnection", "FlowRecord_AgentConnection", 1) + `,`,
 		`Stream:` + strings.Replace(this.Stream.String(), "FlowStream", "FlowStream", 1) + `,`,
 		`HubStats:` + strings.Replace(fmt.Sprintf("%v", this.HubStats), "FlowRecord_HubStats", "FlowRecord_HubStats", 1) + `,`,
+		`RateLimit:` + strings.Replace(fmt.Sprintf("%v", this.RateLimit), "FlowRecord_RateLimit", "FlowRecord_RateLimit", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1562,6 +1809,19 @@ func (this *FlowRecord_HubStats) String() string {
 	}, "")
 	return s
 }
+func (this *FlowRecord_RateLimit) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FlowRecord_RateLimit{`,
+		`HubId:` + strings.Replace(fmt.Sprintf("%v", this.HubId), "ULID", "ULID", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`OccurredAt:` + strings.Replace(fmt.Sprintf("%v", this.OccurredAt), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *FlowTopSnapshot) String() string {
 	if this == nil {
 		return "nil"
@@ -2130,6 +2390,42 @@ func (m *FlowRecord) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFlow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFlow
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RateLimit == nil {
+				m.RateLimit = &FlowRecord_RateLimit{}
+			}
+			if err := m.RateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFlow(dAtA[iNdEx:])
@@ -2571,6 +2867,199 @@ func (m *FlowRecord_HubStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FlowRecord_RateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFlow
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HubId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFlow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFlow
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HubId == nil {
+				m.HubId = &ULID{}
+			}
+			if err := m.HubId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFlow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFlow
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFlow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFlow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Limit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OccurredAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFlow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFlow
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OccurredAt == nil {
+				m.OccurredAt = &Timestamp{}
+			}
+			if err := m.OccurredAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFlow(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthFlow
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *FlowTopSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
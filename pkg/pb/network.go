@@ -1,5 +1,7 @@
 package pb
 
+import "net"
+
 func (l *NetworkLocation) SameLabels(r *NetworkLocation) bool {
 	if l.Labels == nil || r.Labels == nil {
 		return false
@@ -35,3 +37,53 @@ func (l *NetworkLocation) IsPublic() bool {
 
 	return false
 }
+
+// AddressFamilyOf returns the family of addr, which is an IP address with
+// an optional port. Hostnames are of UNKNOWN_FAMILY.
+func AddressFamilyOf(addr string) AddressFamily {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	ip := net.ParseIP(addr)
+
+	switch {
+	case ip == nil:
+		return UNKNOWN_FAMILY
+	case ip.To4() != nil:
+		return IPV4
+	default:
+		return IPV6
+	}
+}
+
+// SetFamilies sets Families from the addresses of the location.
+func (l *NetworkLocation) SetFamilies() {
+	l.Families = nil
+
+	for _, fam := range []AddressFamily{IPV4, IPV6} {
+		for _, addr := range l.Addresses {
+			if AddressFamilyOf(addr) == fam {
+				l.Families = append(l.Families, fam)
+				break
+			}
+		}
+	}
+}
+
+// HasFamily returns true if the location has addresses of the given family.
+// Locations that don't list their families are assumed to have addresses
+// of any family.
+func (l *NetworkLocation) HasFamily(fam AddressFamily) bool {
+	if len(l.Families) == 0 {
+		return true
+	}
+
+	for _, f := range l.Families {
+		if f == fam {
+			return true
+		}
+	}
+
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
+	strconv "strconv"
 	strings "strings"
 )
 
@@ -24,10 +25,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type AddressFamily int32
+
+const (
+	UNKNOWN_FAMILY AddressFamily = 0
+	IPV4           AddressFamily = 1
+	IPV6           AddressFamily = 2
+)
+
+var AddressFamily_name = map[int32]string{
+	0: "UNKNOWN_FAMILY",
+	1: "IPV4",
+	2: "IPV6",
+}
+
+var AddressFamily_value = map[string]int32{
+	"UNKNOWN_FAMILY": 0,
+	"IPV4":           1,
+	"IPV6":           2,
+}
+
+func (AddressFamily) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8571034d60397816, []int{0}
+}
+
 type NetworkLocation struct {
 	Addresses []string  `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	Labels    *LabelSet `protobuf:"bytes,2,opt,name=labels,proto3" json:"labels,omitempty"`
 	Name      string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// The address families of the addresses, so that peers can tell which
+	// locations they are able to reach.
+	Families []AddressFamily `protobuf:"varint,4,rep,packed,name=families,proto3,enum=pb.AddressFamily" json:"families,omitempty"`
 }
 
 func (m *NetworkLocation) Reset()      { *m = NetworkLocation{} }
@@ -83,29 +111,48 @@ func (m *NetworkLocation) GetName() string {
 	return ""
 }
 
+func (m *NetworkLocation) GetFamilies() []AddressFamily {
+	if m != nil {
+		return m.Families
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("pb.AddressFamily", AddressFamily_name, AddressFamily_value)
 	proto.RegisterType((*NetworkLocation)(nil), "pb.NetworkLocation")
 }
 
 func init() { proto.RegisterFile("network.proto", fileDescriptor_8571034d60397816) }
 
 var fileDescriptor_8571034d60397816 = []byte{
-	// 196 bytes of a gzipped FileDescriptorProto
+	// 270 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4b, 0x2d, 0x29,
 	0xcf, 0x2f, 0xca, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2a, 0x48, 0x92, 0xe2, 0xce,
-	0x49, 0x4c, 0x4a, 0xcd, 0x81, 0x08, 0x28, 0x65, 0x72, 0xf1, 0xfb, 0x41, 0x54, 0xf8, 0xe4, 0x27,
-	0x27, 0x96, 0x64, 0xe6, 0xe7, 0x09, 0xc9, 0x70, 0x71, 0x26, 0xa6, 0xa4, 0x14, 0xa5, 0x16, 0x17,
-	0xa7, 0x16, 0x4b, 0x30, 0x2a, 0x30, 0x6b, 0x70, 0x06, 0x21, 0x04, 0x84, 0x54, 0xb8, 0xd8, 0xc0,
-	0xfa, 0x8b, 0x25, 0x98, 0x14, 0x18, 0x35, 0xb8, 0x8d, 0x78, 0xf4, 0x0a, 0x92, 0xf4, 0x7c, 0x40,
-	0x22, 0xc1, 0xa9, 0x25, 0x41, 0x50, 0x39, 0x21, 0x21, 0x2e, 0x96, 0xbc, 0xc4, 0xdc, 0x54, 0x09,
-	0x66, 0x05, 0x46, 0x0d, 0xce, 0x20, 0x30, 0xdb, 0xc9, 0xe4, 0xc2, 0x43, 0x39, 0x86, 0x1b, 0x0f,
-	0xe5, 0x18, 0x3e, 0x3c, 0x94, 0x63, 0x6c, 0x78, 0x24, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3, 0x89,
-	0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0xf8, 0xe2, 0x91, 0x1c, 0xc3,
-	0x87, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c,
-	0x43, 0x12, 0x1b, 0xd8, 0x9d, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x48, 0x28, 0xee, 0x11,
-	0xc9, 0x00, 0x00, 0x00,
+	0x49, 0x4c, 0x4a, 0xcd, 0x81, 0x08, 0x28, 0xcd, 0x60, 0xe4, 0xe2, 0xf7, 0x83, 0x28, 0xf1, 0xc9,
+	0x4f, 0x4e, 0x2c, 0xc9, 0xcc, 0xcf, 0x13, 0x92, 0xe1, 0xe2, 0x4c, 0x4c, 0x49, 0x29, 0x4a, 0x2d,
+	0x2e, 0x4e, 0x2d, 0x96, 0x60, 0x54, 0x60, 0xd6, 0xe0, 0x0c, 0x42, 0x08, 0x08, 0xa9, 0x70, 0xb1,
+	0x81, 0x0d, 0x28, 0x96, 0x60, 0x52, 0x60, 0xd4, 0xe0, 0x36, 0xe2, 0xd1, 0x2b, 0x48, 0xd2, 0xf3,
+	0x01, 0x89, 0x04, 0xa7, 0x96, 0x04, 0x41, 0xe5, 0x84, 0x84, 0xb8, 0x58, 0xf2, 0x12, 0x73, 0x53,
+	0x25, 0x98, 0x15, 0x18, 0x35, 0x38, 0x83, 0xc0, 0x6c, 0x21, 0x5d, 0x2e, 0x8e, 0xb4, 0xc4, 0xdc,
+	0xcc, 0x9c, 0xcc, 0xd4, 0x62, 0x09, 0x16, 0x05, 0x66, 0x0d, 0x3e, 0x23, 0x41, 0x90, 0x5e, 0x47,
+	0x88, 0xd1, 0x6e, 0x20, 0xa9, 0xca, 0x20, 0xb8, 0x12, 0x2d, 0x73, 0x2e, 0x5e, 0x14, 0x29, 0x21,
+	0x21, 0x2e, 0xbe, 0x50, 0x3f, 0x6f, 0x3f, 0xff, 0x70, 0xbf, 0x78, 0x37, 0x47, 0x5f, 0x4f, 0x9f,
+	0x48, 0x01, 0x06, 0x21, 0x0e, 0x2e, 0x16, 0xcf, 0x80, 0x30, 0x13, 0x01, 0x46, 0x28, 0xcb, 0x4c,
+	0x80, 0xc9, 0xc9, 0xe4, 0xc2, 0x43, 0x39, 0x86, 0x1b, 0x0f, 0xe5, 0x18, 0x3e, 0x3c, 0x94, 0x63,
+	0x6c, 0x78, 0x24, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9,
+	0x31, 0x3e, 0x78, 0x24, 0xc7, 0xf8, 0xe2, 0x91, 0x1c, 0xc3, 0x87, 0x47, 0x72, 0x8c, 0x13, 0x1e,
+	0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x12, 0x1b, 0x38, 0x40, 0x8c,
+	0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0x67, 0x3a, 0xff, 0x5f, 0x32, 0x01, 0x00, 0x00,
 }
 
+func (x AddressFamily) String() string {
+	s, ok := AddressFamily_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
 func (this *NetworkLocation) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -139,19 +186,28 @@ func (this *NetworkLocation) Equal(that interface{}) bool {
 	if this.Name != that1.Name {
 		return false
 	}
+	if len(this.Families) != len(that1.Families) {
+		return false
+	}
+	for i := range this.Families {
+		if this.Families[i] != that1.Families[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *NetworkLocation) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.NetworkLocation{")
 	s = append(s, "Addresses: "+fmt.Sprintf("%#v", this.Addresses)+",\n")
 	if this.Labels != nil {
 		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
 	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Families: "+fmt.Sprintf("%#v", this.Families)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -183,6 +239,25 @@ func (m *NetworkLocation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Families) > 0 {
+		dAtA3 := make([]byte, len(m.Families)*10)
+		var j3 int
+		for _, num1 := range m.Families {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA3[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA3[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA3[:j3])
+		i = encodeVarintNetwork(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -245,6 +320,13 @@ func (m *NetworkLocation) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovNetwork(uint64(l))
 	}
+	if len(m.Families) > 0 {
+		l = 0
+		for _, e := range m.Families {
+			l += sovNetwork(uint64(e))
+		}
+		n += 1 + sovNetwork(uint64(l)) + l
+	}
 	return n
 }
 
@@ -262,6 +344,7 @@ func (this *NetworkLocation) String() string {
 		`Addresses:` + fmt.Sprintf("%v", this.Addresses) + `,`,
 		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Families:` + fmt.Sprintf("%v", this.Families) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -403,6 +486,71 @@ func (m *NetworkLocation) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v AddressFamily
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowNetwork
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= AddressFamily(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Families = append(m.Families, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowNetwork
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthNetwork
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthNetwork
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v AddressFamily
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowNetwork
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= AddressFamily(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Families = append(m.Families, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Families", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNetwork(dAtA[iNdEx:])
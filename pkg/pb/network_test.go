@@ -0,0 +1,55 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkLocation(t *testing.T) {
+	t.Run("knows the families of its addresses", func(t *testing.T) {
+		loc := &NetworkLocation{
+			Addresses: []string{"2001:db8::1", "[2001:db8::2]:443", "hub.example.com"},
+		}
+
+		loc.SetFamilies()
+
+		assert.Equal(t, []AddressFamily{IPV6}, loc.Families)
+		assert.True(t, loc.HasFamily(IPV6))
+		assert.False(t, loc.HasFamily(IPV4))
+
+		loc.Addresses = append(loc.Addresses, "192.0.2.1:443")
+		loc.SetFamilies()
+
+		assert.Equal(t, []AddressFamily{IPV4, IPV6}, loc.Families)
+	})
+
+	t.Run("assumes any family when they aren't known", func(t *testing.T) {
+		loc := &NetworkLocation{Addresses: []string{"hub.example.com"}}
+
+		loc.SetFamilies()
+
+		assert.True(t, loc.HasFamily(IPV4))
+		assert.True(t, loc.HasFamily(IPV6))
+	})
+
+	t.Run("marshals the families", func(t *testing.T) {
+		loc := &NetworkLocation{
+			Addresses: []string{"192.0.2.1", "2001:db8::1"},
+			Name:      "hub",
+		}
+
+		loc.SetFamilies()
+
+		data, err := loc.Marshal()
+		require.NoError(t, err)
+
+		var out NetworkLocation
+
+		require.NoError(t, out.Unmarshal(data))
+
+		assert.True(t, loc.Equal(&out))
+		assert.Equal(t, []AddressFamily{IPV4, IPV6}, out.Families)
+	})
+}
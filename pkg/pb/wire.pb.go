@@ -56,7 +56,7 @@ var Request_Type_value = map[string]int32{
 }
 
 func (Request_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{9, 0}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{10, 0}
 }
 
 type Labels struct {
@@ -111,6 +111,10 @@ type ServiceInfo struct {
 	// These labels are used to identify this specific service instance
 	Labels   *LabelSet `protobuf:"bytes,3,opt,name=labels,proto3" json:"labels,omitempty"`
 	Metadata []*KVPair `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	// The codec to compress connections to this service with, overriding the
+	// codec of the session. Set to "none" for payloads that are already
+	// compressed. Only used with the stream-compression feature.
+	Compression string `protobuf:"bytes,5,opt,name=compression,proto3" json:"compression,omitempty"`
 }
 
 func (m *ServiceInfo) Reset()      { *m = ServiceInfo{} }
@@ -173,6 +177,13 @@ func (m *ServiceInfo) GetMetadata() []*KVPair {
 	return nil
 }
 
+func (m *ServiceInfo) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 type Preamble struct {
 	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	Token     string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
@@ -180,6 +191,13 @@ type Preamble struct {
 	Labels      []string       `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
 	Services    []*ServiceInfo `protobuf:"bytes,4,rep,name=services,proto3" json:"services,omitempty"`
 	Compression string         `protobuf:"bytes,5,opt,name=compression,proto3" json:"compression,omitempty"`
+	// The version of the protocol the agent speaks and the optional features
+	// it supports. Agents that predate versioning send neither.
+	ProtocolVersion int32    `protobuf:"varint,6,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Features        []string `protobuf:"bytes,7,rep,name=features,proto3" json:"features,omitempty"`
+	// The compression codecs the agent supports, most preferred first. Hubs
+	// that don't know about them use compression instead.
+	CompressionCodecs []string `protobuf:"bytes,8,rep,name=compression_codecs,json=compressionCodecs,proto3" json:"compression_codecs,omitempty"`
 }
 
 func (m *Preamble) Reset()      { *m = Preamble{} }
@@ -249,16 +267,95 @@ func (m *Preamble) GetCompression() string {
 	return ""
 }
 
+func (m *Preamble) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Preamble) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *Preamble) GetCompressionCodecs() []string {
+	if m != nil {
+		return m.CompressionCodecs
+	}
+	return nil
+}
+
+// Sent by an agent on a new stream to change the services it's advertising
+// without reconnecting. The hub replies with a Response.
+type ServicesUpdate struct {
+	Added   []*ServiceInfo `protobuf:"bytes,1,rep,name=added,proto3" json:"added,omitempty"`
+	Removed []*ULID        `protobuf:"bytes,2,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (m *ServicesUpdate) Reset()      { *m = ServicesUpdate{} }
+func (*ServicesUpdate) ProtoMessage() {}
+func (*ServicesUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{3}
+}
+func (m *ServicesUpdate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServicesUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServicesUpdate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ServicesUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServicesUpdate.Merge(m, src)
+}
+func (m *ServicesUpdate) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServicesUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServicesUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServicesUpdate proto.InternalMessageInfo
+
+func (m *ServicesUpdate) GetAdded() []*ServiceInfo {
+	if m != nil {
+		return m.Added
+	}
+	return nil
+}
+
+func (m *ServicesUpdate) GetRemoved() []*ULID {
+	if m != nil {
+		return m.Removed
+	}
+	return nil
+}
+
 type Confirmation struct {
-	Time        *Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
-	Status      string     `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Compression string     `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"`
+	Time   *Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Status string     `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// The codec chosen for the session.
+	Compression string `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"`
+	// The version of the protocol the hub speaks and the features that both
+	// the hub and the agent support, which are the ones used on the session.
+	ProtocolVersion int32    `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Features        []string `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
 }
 
 func (m *Confirmation) Reset()      { *m = Confirmation{} }
 func (*Confirmation) ProtoMessage() {}
 func (*Confirmation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{3}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{4}
 }
 func (m *Confirmation) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -308,6 +405,20 @@ func (m *Confirmation) GetCompression() string {
 	return ""
 }
 
+func (m *Confirmation) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Confirmation) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 type Header struct {
 	Name  string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value []string `protobuf:"bytes,2,rep,name=value,proto3" json:"value,omitempty"`
@@ -316,7 +427,7 @@ type Header struct {
 func (m *Header) Reset()      { *m = Header{} }
 func (*Header) ProtoMessage() {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{4}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{5}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -367,7 +478,7 @@ type Auth struct {
 func (m *Auth) Reset()      { *m = Auth{} }
 func (*Auth) ProtoMessage() {}
 func (*Auth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{5}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{6}
 }
 func (m *Auth) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -416,12 +527,19 @@ type ConnectRequest struct {
 	PivotAccount *Account  `protobuf:"bytes,3,opt,name=pivot_account,json=pivotAccount,proto3" json:"pivot_account,omitempty"`
 	ProtocolId   string    `protobuf:"bytes,4,opt,name=protocol_id,json=protocolId,proto3" json:"protocol_id,omitempty"`
 	SourceAddr   []byte    `protobuf:"bytes,5,opt,name=source_addr,json=sourceAddr,proto3" json:"source_addr,omitempty"`
+	// The agent a hub is forwarding the connection for. Only honored when
+	// the request is made with a hub token.
+	SourceAccount *Account `protobuf:"bytes,6,opt,name=source_account,json=sourceAccount,proto3" json:"source_account,omitempty"`
+	SourceAgent   *ULID    `protobuf:"bytes,7,opt,name=source_agent,json=sourceAgent,proto3" json:"source_agent,omitempty"`
+	// Connect to this specific service instance, as returned by a services
+	// query, rather than any service matching target.
+	ServiceId *ULID `protobuf:"bytes,8,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
 }
 
 func (m *ConnectRequest) Reset()      { *m = ConnectRequest{} }
 func (*ConnectRequest) ProtoMessage() {}
 func (*ConnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{6}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{7}
 }
 func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -485,6 +603,27 @@ func (m *ConnectRequest) GetSourceAddr() []byte {
 	return nil
 }
 
+func (m *ConnectRequest) GetSourceAccount() *Account {
+	if m != nil {
+		return m.SourceAccount
+	}
+	return nil
+}
+
+func (m *ConnectRequest) GetSourceAgent() *ULID {
+	if m != nil {
+		return m.SourceAgent
+	}
+	return nil
+}
+
+func (m *ConnectRequest) GetServiceId() *ULID {
+	if m != nil {
+		return m.ServiceId
+	}
+	return nil
+}
+
 type ConnectAck struct {
 	ServiceId *ULID `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
 }
@@ -492,7 +631,7 @@ type ConnectAck struct {
 func (m *ConnectAck) Reset()      { *m = ConnectAck{} }
 func (*ConnectAck) ProtoMessage() {}
 func (*ConnectAck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{7}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{8}
 }
 func (m *ConnectAck) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -531,12 +670,16 @@ func (m *ConnectAck) GetServiceId() *ULID {
 type SessionIdentification struct {
 	ServiceId  *ULID  `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
 	ProtocolId string `protobuf:"bytes,2,opt,name=protocol_id,json=protocolId,proto3" json:"protocol_id,omitempty"`
+	// The agent that opened the connection, when it came from an agent
+	// rather than the web frontend.
+	SourceAccount *Account `protobuf:"bytes,3,opt,name=source_account,json=sourceAccount,proto3" json:"source_account,omitempty"`
+	SourceAgent   *ULID    `protobuf:"bytes,4,opt,name=source_agent,json=sourceAgent,proto3" json:"source_agent,omitempty"`
 }
 
 func (m *SessionIdentification) Reset()      { *m = SessionIdentification{} }
 func (*SessionIdentification) ProtoMessage() {}
 func (*SessionIdentification) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{8}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{9}
 }
 func (m *SessionIdentification) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -579,6 +722,20 @@ func (m *SessionIdentification) GetProtocolId() string {
 	return ""
 }
 
+func (m *SessionIdentification) GetSourceAccount() *Account {
+	if m != nil {
+		return m.SourceAccount
+	}
+	return nil
+}
+
+func (m *SessionIdentification) GetSourceAgent() *ULID {
+	if m != nil {
+		return m.SourceAgent
+	}
+	return nil
+}
+
 type Request struct {
 	Type          Request_Type `protobuf:"varint,1,opt,name=type,proto3,enum=pb.Request_Type" json:"type,omitempty"`
 	Method        string       `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
@@ -592,12 +749,16 @@ type Request struct {
 	AgentId       []byte       `protobuf:"bytes,10,opt,name=agentId,proto3" json:"agentId,omitempty"`
 	TargetService string       `protobuf:"bytes,11,opt,name=target_service,json=targetService,proto3" json:"target_service,omitempty"`
 	PivotAccount  *Account     `protobuf:"bytes,12,opt,name=pivot_account,json=pivotAccount,proto3" json:"pivot_account,omitempty"`
+	Scheme        string       `protobuf:"bytes,13,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Set when the sender will send a Trailers message after the body, and
+	// is able to accept one after the body of the response.
+	Trailers bool `protobuf:"varint,14,opt,name=trailers,proto3" json:"trailers,omitempty"`
 }
 
 func (m *Request) Reset()      { *m = Request{} }
 func (*Request) ProtoMessage() {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{9}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{10}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -710,16 +871,34 @@ func (m *Request) GetPivotAccount() *Account {
 	return nil
 }
 
+func (m *Request) GetScheme() string {
+	if m != nil {
+		return m.Scheme
+	}
+	return ""
+}
+
+func (m *Request) GetTrailers() bool {
+	if m != nil {
+		return m.Trailers
+	}
+	return false
+}
+
 type Response struct {
-	Error   string    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Error string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The HTTP status of the response. When a hub rejects a ConnectRequest,
+	// it classifies the error using the equivalent HTTP status.
 	Code    int32     `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
 	Headers []*Header `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	// Set when a Trailers message will be sent after the body.
+	Trailers bool `protobuf:"varint,4,opt,name=trailers,proto3" json:"trailers,omitempty"`
 }
 
 func (m *Response) Reset()      { *m = Response{} }
 func (*Response) ProtoMessage() {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f2dcdddcdf68d8e0, []int{10}
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{11}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -769,138 +948,515 @@ func (m *Response) GetHeaders() []*Header {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("pb.Request_Type", Request_Type_name, Request_Type_value)
-	proto.RegisterType((*Labels)(nil), "pb.Labels")
-	proto.RegisterType((*ServiceInfo)(nil), "pb.ServiceInfo")
-	proto.RegisterType((*Preamble)(nil), "pb.Preamble")
-	proto.RegisterType((*Confirmation)(nil), "pb.Confirmation")
-	proto.RegisterType((*Header)(nil), "pb.Header")
-	proto.RegisterType((*Auth)(nil), "pb.Auth")
-	proto.RegisterType((*ConnectRequest)(nil), "pb.ConnectRequest")
-	proto.RegisterType((*ConnectAck)(nil), "pb.ConnectAck")
-	proto.RegisterType((*SessionIdentification)(nil), "pb.SessionIdentification")
-	proto.RegisterType((*Request)(nil), "pb.Request")
-	proto.RegisterType((*Response)(nil), "pb.Response")
+func (m *Response) GetTrailers() bool {
+	if m != nil {
+		return m.Trailers
+	}
+	return false
 }
 
-func init() { proto.RegisterFile("wire.proto", fileDescriptor_f2dcdddcdf68d8e0) }
-
-var fileDescriptor_f2dcdddcdf68d8e0 = []byte{
-	// 836 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcd, 0x6e, 0x1b, 0x37,
-	0x10, 0xd6, 0x5a, 0x6b, 0x69, 0x35, 0x92, 0x1c, 0x95, 0x68, 0x83, 0x85, 0xd1, 0x6e, 0xd5, 0x45,
-	0xda, 0x1a, 0x28, 0x60, 0x14, 0xee, 0xcf, 0x5d, 0x51, 0x8c, 0x46, 0x48, 0xea, 0x08, 0xb4, 0xd2,
-	0x02, 0xbd, 0x08, 0xd4, 0x2e, 0x6d, 0x2d, 0xac, 0x5d, 0x6e, 0x48, 0xae, 0x03, 0xdf, 0xfa, 0x08,
-	0x3d, 0xf6, 0x09, 0x8a, 0x3e, 0x45, 0xcf, 0x39, 0xfa, 0x98, 0x63, 0x2d, 0x5f, 0x7a, 0xcc, 0x23,
-	0x14, 0x43, 0x72, 0x6d, 0x43, 0x69, 0x91, 0xdc, 0xe6, 0x9b, 0x21, 0x87, 0xdf, 0xcc, 0xf7, 0x11,
-	0xe0, 0x65, 0x26, 0xf9, 0x7e, 0x29, 0x85, 0x16, 0x64, 0xab, 0x5c, 0xec, 0xde, 0xd3, 0x59, 0xce,
-	0x95, 0x66, 0x79, 0x69, 0x93, 0xbb, 0xc1, 0xd9, 0xb9, 0x8b, 0xa0, 0x5a, 0x65, 0xa9, 0x8b, 0xfb,
-	0x2c, 0x49, 0x44, 0x55, 0x68, 0x07, 0xbb, 0x2b, 0xb6, 0xe0, 0x2b, 0x0b, 0xe2, 0x08, 0x5a, 0x4f,
-	0x11, 0x2a, 0xf2, 0x21, 0x6c, 0x9b, 0x42, 0xe8, 0x0d, 0x9b, 0x7b, 0x1d, 0x6a, 0x41, 0xfc, 0xbb,
-	0x07, 0xdd, 0x63, 0x2e, 0xcf, 0xb3, 0x84, 0x4f, 0x8a, 0x13, 0x41, 0xbe, 0x04, 0x50, 0x16, 0xce,
-	0xb3, 0x34, 0xf4, 0x86, 0xde, 0x5e, 0xf7, 0x20, 0xd8, 0x2f, 0x17, 0xfb, 0xcf, 0x9f, 0x4e, 0x1e,
-	0xd1, 0x8e, 0xab, 0x4d, 0x52, 0x42, 0xc0, 0xd7, 0x17, 0x25, 0x0f, 0xb7, 0x86, 0xde, 0x5e, 0x87,
-	0x9a, 0x98, 0x3c, 0x80, 0x96, 0xe9, 0xaa, 0xc2, 0xa6, 0xb9, 0xd8, 0xc3, 0x8b, 0xe6, 0xf9, 0x63,
-	0xae, 0xa9, 0xab, 0x91, 0x2f, 0x20, 0xc8, 0xb9, 0x66, 0x29, 0xd3, 0x2c, 0xf4, 0x87, 0xcd, 0xbd,
-	0xee, 0x01, 0xe0, 0xb9, 0x27, 0x3f, 0x4d, 0x59, 0x26, 0xe9, 0x4d, 0x2d, 0xfe, 0xc3, 0x83, 0x60,
-	0x2a, 0x39, 0xcb, 0x17, 0x2b, 0x4e, 0x3e, 0x41, 0x5e, 0x4a, 0x65, 0xa2, 0xa8, 0x79, 0x75, 0x90,
-	0x8d, 0xc9, 0x4c, 0x52, 0x1c, 0x4e, 0x8b, 0x33, 0x5e, 0x38, 0x3a, 0x16, 0x90, 0xfb, 0x77, 0xf8,
-	0xe0, 0xcc, 0x35, 0x83, 0xaf, 0x20, 0x70, 0x83, 0x28, 0xc7, 0xe0, 0x1e, 0x32, 0xb8, 0xb3, 0x07,
-	0x7a, 0x73, 0x80, 0x0c, 0xa1, 0x9b, 0x88, 0xbc, 0x94, 0xf6, 0xad, 0x70, 0xdb, 0x3c, 0x70, 0x37,
-	0x15, 0x9f, 0x41, 0x6f, 0x2c, 0x8a, 0x93, 0x4c, 0xe6, 0x4c, 0x67, 0xa2, 0x20, 0x9f, 0x81, 0x8f,
-	0xc2, 0xb9, 0xed, 0xf5, 0xb1, 0xf5, 0xac, 0x16, 0x92, 0x9a, 0x12, 0x32, 0x53, 0x9a, 0xe9, 0x4a,
-	0x39, 0xc2, 0x0e, 0x6d, 0x3e, 0xd6, 0x7c, 0xfb, 0xb1, 0x03, 0x68, 0x3d, 0xe6, 0x2c, 0xe5, 0x12,
-	0x15, 0x28, 0x98, 0x7b, 0xa6, 0x43, 0x4d, 0x8c, 0x7b, 0x38, 0x67, 0xab, 0x0a, 0x65, 0x31, 0x22,
-	0x1b, 0x10, 0x7f, 0x0f, 0xfe, 0xa8, 0xd2, 0x4b, 0xbc, 0x51, 0x29, 0x2e, 0xeb, 0x1b, 0x18, 0x93,
-	0x5d, 0x08, 0x4a, 0xa6, 0xd4, 0x4b, 0x21, 0x53, 0xc7, 0xe5, 0x06, 0xc7, 0x7f, 0x79, 0xb0, 0x33,
-	0x16, 0x45, 0xc1, 0x13, 0x4d, 0xf9, 0x8b, 0x8a, 0x2b, 0x8d, 0x12, 0x6b, 0x26, 0x4f, 0xb9, 0x76,
-	0xd3, 0x6d, 0x48, 0x6c, 0x6b, 0xff, 0x69, 0x8e, 0xaf, 0xa1, 0x5f, 0x66, 0xe7, 0x42, 0xcf, 0x9d,
-	0x5b, 0x9d, 0x47, 0xba, 0xd8, 0x60, 0x64, 0x53, 0xb4, 0x67, 0x4e, 0x38, 0x44, 0x3e, 0x85, 0xae,
-	0x31, 0x71, 0x22, 0x56, 0x28, 0xba, 0x6f, 0x9a, 0x41, 0x9d, 0x9a, 0xa4, 0x78, 0x40, 0x89, 0x4a,
-	0x26, 0x7c, 0xce, 0xd2, 0x54, 0x1a, 0x69, 0x7a, 0x14, 0x6c, 0x6a, 0x94, 0xa6, 0x32, 0xfe, 0x0e,
-	0xc0, 0xf1, 0x1f, 0x25, 0x67, 0xef, 0xed, 0xed, 0x98, 0xc1, 0x47, 0xc7, 0xb5, 0xb5, 0x78, 0xa1,
-	0xb3, 0x93, 0x2c, 0xb1, 0xca, 0xbe, 0xf7, 0xef, 0xd8, 0xa0, 0xbe, 0xb5, 0x49, 0x3d, 0x7e, 0xd5,
-	0x84, 0xf6, 0xed, 0x4e, 0xed, 0xb6, 0xb0, 0xdf, 0xce, 0xc1, 0x00, 0xfb, 0xb9, 0xd2, 0xfe, 0xec,
-	0xa2, 0xe4, 0x6e, 0x7f, 0xf7, 0xa1, 0x95, 0x73, 0xbd, 0x14, 0x75, 0x37, 0x87, 0x70, 0xd7, 0x25,
-	0xd3, 0x4b, 0xe7, 0x15, 0x13, 0xa3, 0x0d, 0x5e, 0x54, 0x5c, 0x5e, 0xb8, 0x9d, 0x59, 0x80, 0x52,
-	0x9f, 0x48, 0x76, 0x9a, 0xf3, 0x42, 0x3b, 0x1b, 0xdf, 0x60, 0xf2, 0x31, 0xf8, 0xac, 0xd2, 0xcb,
-	0xb0, 0x75, 0x3b, 0x13, 0x5a, 0x86, 0x9a, 0x2c, 0x79, 0x00, 0xed, 0xa5, 0x31, 0x9d, 0x0a, 0xdb,
-	0xb7, 0x3f, 0xd6, 0xfa, 0x90, 0xd6, 0x25, 0x1c, 0x5a, 0xf2, 0x5c, 0x68, 0x27, 0x47, 0x60, 0x87,
-	0xb6, 0x29, 0x94, 0x03, 0xa9, 0x2e, 0x85, 0xd2, 0x61, 0xc7, 0x52, 0xc5, 0x98, 0x84, 0xd0, 0x66,
-	0xa7, 0xbc, 0xd0, 0x93, 0x34, 0x04, 0xa3, 0x5f, 0x0d, 0xc9, 0xe7, 0xb0, 0x63, 0xed, 0x34, 0x77,
-	0x7b, 0x0d, 0xbb, 0xe6, 0x5e, 0xdf, 0x66, 0xdd, 0x6f, 0x7d, 0xdb, 0x57, 0xbd, 0x77, 0xf8, 0x2a,
-	0xfe, 0x11, 0x7c, 0xdc, 0x2b, 0x09, 0xc0, 0x7f, 0x3c, 0x9b, 0x4d, 0x07, 0x0d, 0xd2, 0x87, 0xce,
-	0xcf, 0x87, 0x0f, 0x8f, 0x9f, 0x8d, 0x9f, 0x1c, 0xce, 0x06, 0x1e, 0x69, 0x43, 0x73, 0x36, 0x9e,
-	0x0e, 0xb6, 0x30, 0x78, 0xfe, 0x68, 0x3a, 0x68, 0x62, 0x40, 0xa7, 0xe3, 0x81, 0x4f, 0x3e, 0x80,
-	0xfe, 0xe8, 0x87, 0xc3, 0xa3, 0xd9, 0x7c, 0xfc, 0xec, 0xe8, 0xe8, 0x70, 0x3c, 0x1b, 0x6c, 0xc7,
-	0xbf, 0x40, 0x40, 0xb9, 0x2a, 0x45, 0xa1, 0xcc, 0xff, 0xe3, 0x52, 0x8a, 0xfa, 0x8b, 0x59, 0x80,
-	0x73, 0x27, 0x22, 0xb5, 0xdf, 0x61, 0x9b, 0x9a, 0xf8, 0xee, 0x4a, 0x9b, 0xff, 0xbb, 0xd2, 0x87,
-	0xdf, 0x5e, 0x5e, 0x45, 0x8d, 0xd7, 0x57, 0x51, 0xe3, 0xcd, 0x55, 0xe4, 0xfd, 0xba, 0x8e, 0xbc,
-	0x3f, 0xd7, 0x91, 0xf7, 0x6a, 0x1d, 0x79, 0x97, 0xeb, 0xc8, 0xfb, 0x7b, 0x1d, 0x79, 0xff, 0xac,
-	0xa3, 0xc6, 0x9b, 0x75, 0xe4, 0xfd, 0x76, 0x1d, 0x35, 0x2e, 0xaf, 0xa3, 0xc6, 0xeb, 0xeb, 0xa8,
-	0xb1, 0x68, 0x19, 0xa3, 0x7d, 0xf3, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x19, 0xca, 0xf1, 0xa9,
-	0x50, 0x06, 0x00, 0x00,
+type Trailers struct {
+	Headers []*Header `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
 }
 
-func (x Request_Type) String() string {
-	s, ok := Request_Type_name[int32(x)]
-	if ok {
-		return s
+func (m *Trailers) Reset()      { *m = Trailers{} }
+func (*Trailers) ProtoMessage() {}
+func (*Trailers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{12}
+}
+func (m *Trailers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Trailers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Trailers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return strconv.Itoa(int(x))
 }
-func (this *Labels) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *Trailers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trailers.Merge(m, src)
+}
+func (m *Trailers) XXX_Size() int {
+	return m.Size()
+}
+func (m *Trailers) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trailers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Trailers proto.InternalMessageInfo
+
+func (m *Trailers) GetHeaders() []*Header {
+	if m != nil {
+		return m.Headers
 	}
+	return nil
+}
 
-	that1, ok := that.(*Labels)
-	if !ok {
-		that2, ok := that.(Labels)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// Sent as an RPC to /query/peers to find the services visible to the
+// account of the agent.
+type ServicesQuery struct {
+	// Only services matching these labels are returned. When empty, all the
+	// services are returned.
+	Labels *LabelSet `protobuf:"bytes,1,opt,name=labels,proto3" json:"labels,omitempty"`
+	// Keep the RPC open, sending a new response whenever the services change.
+	Watch bool `protobuf:"varint,2,opt,name=watch,proto3" json:"watch,omitempty"`
+}
+
+func (m *ServicesQuery) Reset()      { *m = ServicesQuery{} }
+func (*ServicesQuery) ProtoMessage() {}
+func (*ServicesQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{13}
+}
+func (m *ServicesQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServicesQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServicesQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *ServicesQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServicesQuery.Merge(m, src)
+}
+func (m *ServicesQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServicesQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServicesQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServicesQuery proto.InternalMessageInfo
+
+func (m *ServicesQuery) GetLabels() *LabelSet {
+	if m != nil {
+		return m.Labels
 	}
-	if len(this.Label) != len(that1.Label) {
-		return false
+	return nil
+}
+
+func (m *ServicesQuery) GetWatch() bool {
+	if m != nil {
+		return m.Watch
 	}
-	for i := range this.Label {
-		if this.Label[i] != that1.Label[i] {
-			return false
+	return false
+}
+
+type ServicesQueryResponse struct {
+	Services []*PeerService `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (m *ServicesQueryResponse) Reset()      { *m = ServicesQueryResponse{} }
+func (*ServicesQueryResponse) ProtoMessage() {}
+func (*ServicesQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{14}
+}
+func (m *ServicesQueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServicesQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServicesQueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ServiceInfo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *ServicesQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServicesQueryResponse.Merge(m, src)
+}
+func (m *ServicesQueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServicesQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServicesQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServicesQueryResponse proto.InternalMessageInfo
+
+func (m *ServicesQueryResponse) GetServices() []*PeerService {
+	if m != nil {
+		return m.Services
 	}
+	return nil
+}
 
-	that1, ok := that.(*ServiceInfo)
-	if !ok {
-		that2, ok := that.(ServiceInfo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type PeerService struct {
+	Id       *ULID     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hub      *ULID     `protobuf:"bytes,2,opt,name=hub,proto3" json:"hub,omitempty"`
+	Type     string    `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Labels   *LabelSet `protobuf:"bytes,4,opt,name=labels,proto3" json:"labels,omitempty"`
+	Metadata []*KVPair `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *PeerService) Reset()      { *m = PeerService{} }
+func (*PeerService) ProtoMessage() {}
+func (*PeerService) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{15}
+}
+func (m *PeerService) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerService) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerService.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
+	}
+}
+func (m *PeerService) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerService.Merge(m, src)
+}
+func (m *PeerService) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerService) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerService.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerService proto.InternalMessageInfo
+
+func (m *PeerService) GetId() *ULID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *PeerService) GetHub() *ULID {
+	if m != nil {
+		return m.Hub
+	}
+	return nil
+}
+
+func (m *PeerService) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PeerService) GetLabels() *LabelSet {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *PeerService) GetMetadata() []*KVPair {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// Starts an RPC on a stream.
+type RPCRequest struct {
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// How long the caller will wait for the RPC to finish, in nanoseconds.
+	// The RPC has no deadline if zero.
+	Timeout int64 `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (m *RPCRequest) Reset()      { *m = RPCRequest{} }
+func (*RPCRequest) ProtoMessage() {}
+func (*RPCRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{16}
+}
+func (m *RPCRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RPCRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RPCRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RPCRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RPCRequest.Merge(m, src)
+}
+func (m *RPCRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RPCRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RPCRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RPCRequest proto.InternalMessageInfo
+
+func (m *RPCRequest) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *RPCRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *RPCRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+// Sent by the server to end an RPC.
+type RPCStatus struct {
+	// The result of the RPC, as the equivalent HTTP status. 200 indicates
+	// success.
+	Code    int32     `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Details []*KVPair `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+}
+
+func (m *RPCStatus) Reset()      { *m = RPCStatus{} }
+func (*RPCStatus) ProtoMessage() {}
+func (*RPCStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{17}
+}
+func (m *RPCStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RPCStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RPCStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RPCStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RPCStatus.Merge(m, src)
+}
+func (m *RPCStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *RPCStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RPCStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RPCStatus proto.InternalMessageInfo
+
+func (m *RPCStatus) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *RPCStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *RPCStatus) GetDetails() []*KVPair {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("pb.Request_Type", Request_Type_name, Request_Type_value)
+	proto.RegisterType((*Labels)(nil), "pb.Labels")
+	proto.RegisterType((*ServiceInfo)(nil), "pb.ServiceInfo")
+	proto.RegisterType((*Preamble)(nil), "pb.Preamble")
+	proto.RegisterType((*ServicesUpdate)(nil), "pb.ServicesUpdate")
+	proto.RegisterType((*Confirmation)(nil), "pb.Confirmation")
+	proto.RegisterType((*Header)(nil), "pb.Header")
+	proto.RegisterType((*Auth)(nil), "pb.Auth")
+	proto.RegisterType((*ConnectRequest)(nil), "pb.ConnectRequest")
+	proto.RegisterType((*ConnectAck)(nil), "pb.ConnectAck")
+	proto.RegisterType((*SessionIdentification)(nil), "pb.SessionIdentification")
+	proto.RegisterType((*Request)(nil), "pb.Request")
+	proto.RegisterType((*Response)(nil), "pb.Response")
+	proto.RegisterType((*Trailers)(nil), "pb.Trailers")
+	proto.RegisterType((*ServicesQuery)(nil), "pb.ServicesQuery")
+	proto.RegisterType((*ServicesQueryResponse)(nil), "pb.ServicesQueryResponse")
+	proto.RegisterType((*PeerService)(nil), "pb.PeerService")
+	proto.RegisterType((*RPCRequest)(nil), "pb.RPCRequest")
+	proto.RegisterType((*RPCStatus)(nil), "pb.RPCStatus")
+}
+
+func init() { proto.RegisterFile("wire.proto", fileDescriptor_f2dcdddcdf68d8e0) }
+
+var fileDescriptor_f2dcdddcdf68d8e0 = []byte{
+	// 1186 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x3b, 0x6f, 0x1b, 0x47,
+	0x10, 0xe6, 0xf2, 0x8e, 0xe4, 0x71, 0xf8, 0x30, 0xbd, 0xb0, 0x8d, 0x83, 0x90, 0x5c, 0x98, 0x83,
+	0x9d, 0x30, 0x30, 0x22, 0x18, 0xca, 0xa3, 0xa7, 0x69, 0x21, 0x26, 0xec, 0xc8, 0xcc, 0x8a, 0x72,
+	0x8a, 0x14, 0xc4, 0xf2, 0x6e, 0x25, 0x1e, 0x44, 0xf2, 0xce, 0x77, 0x7b, 0x14, 0xd4, 0xe5, 0x27,
+	0xe4, 0x07, 0xa4, 0x0d, 0x90, 0x3a, 0x65, 0xca, 0x54, 0x2e, 0x55, 0xba, 0x8c, 0xa8, 0x26, 0xa5,
+	0x7f, 0x40, 0x8a, 0x60, 0x1f, 0x47, 0x9d, 0x28, 0xc9, 0x52, 0xba, 0xf9, 0x66, 0xf6, 0x31, 0xfb,
+	0xed, 0x37, 0xb3, 0x0b, 0x70, 0x14, 0xc4, 0x6c, 0x33, 0x8a, 0x43, 0x1e, 0xe2, 0x62, 0x34, 0xde,
+	0xb8, 0xc3, 0x83, 0x19, 0x4b, 0x38, 0x9d, 0x45, 0xca, 0xb9, 0x61, 0x1d, 0x2e, 0xb4, 0x05, 0xe9,
+	0x34, 0xf0, 0xb5, 0xdd, 0xa0, 0x9e, 0x17, 0xa6, 0x73, 0xae, 0x61, 0x6d, 0x4a, 0xc7, 0x6c, 0xaa,
+	0x80, 0xeb, 0x40, 0xf9, 0xa5, 0x80, 0x09, 0xbe, 0x07, 0x25, 0x19, 0xb0, 0x51, 0xdb, 0xe8, 0x54,
+	0x89, 0x02, 0xee, 0x9f, 0x08, 0x6a, 0xbb, 0x2c, 0x5e, 0x04, 0x1e, 0xeb, 0xcf, 0xf7, 0x43, 0xfc,
+	0x39, 0x40, 0xa2, 0xe0, 0x28, 0xf0, 0x6d, 0xd4, 0x46, 0x9d, 0xda, 0x96, 0xb5, 0x19, 0x8d, 0x37,
+	0xf7, 0x5e, 0xf6, 0x9f, 0x91, 0xaa, 0x8e, 0xf5, 0x7d, 0x8c, 0xc1, 0xe4, 0xc7, 0x11, 0xb3, 0x8b,
+	0x6d, 0xd4, 0xa9, 0x12, 0x69, 0xe3, 0x87, 0x50, 0x96, 0xab, 0x26, 0xb6, 0x21, 0x27, 0xd6, 0xc5,
+	0x44, 0xb9, 0xfd, 0x2e, 0xe3, 0x44, 0xc7, 0xf0, 0x67, 0x60, 0xcd, 0x18, 0xa7, 0x3e, 0xe5, 0xd4,
+	0x36, 0xdb, 0x46, 0xa7, 0xb6, 0x05, 0x62, 0xdc, 0x8b, 0xd7, 0x03, 0x1a, 0xc4, 0x64, 0x15, 0xc3,
+	0x6d, 0xa8, 0x79, 0xe1, 0x2c, 0x8a, 0x59, 0x92, 0x04, 0xe1, 0xdc, 0x2e, 0xc9, 0x8d, 0xf2, 0x2e,
+	0xf7, 0xd7, 0x22, 0x58, 0x83, 0x98, 0xd1, 0xd9, 0x78, 0xca, 0xf0, 0xc7, 0x22, 0x73, 0xe9, 0xcf,
+	0x32, 0xaf, 0x92, 0xaa, 0xf6, 0xf4, 0x7d, 0x71, 0x7c, 0x1e, 0x1e, 0xb2, 0xb9, 0x4e, 0x58, 0x01,
+	0xfc, 0x20, 0x97, 0xb1, 0x60, 0x25, 0xcb, 0xf1, 0x31, 0x58, 0xfa, 0xa8, 0x89, 0xce, 0xf1, 0x8e,
+	0xc8, 0x31, 0xc7, 0x14, 0x59, 0x0d, 0xb8, 0x39, 0x51, 0xfc, 0x05, 0xb4, 0xe4, 0x75, 0x78, 0xe1,
+	0x74, 0xb4, 0x60, 0xb1, 0x1c, 0x56, 0x6e, 0xa3, 0x4e, 0x89, 0xdc, 0xc9, 0xfc, 0xaf, 0x95, 0x1b,
+	0x6f, 0x80, 0xb5, 0xcf, 0x28, 0x4f, 0x63, 0x96, 0xd8, 0x15, 0x99, 0xd3, 0x0a, 0xe3, 0x2f, 0x01,
+	0xe7, 0x56, 0x1d, 0x79, 0xa1, 0xcf, 0xbc, 0xc4, 0xb6, 0xe4, 0xa8, 0xbb, 0xb9, 0x48, 0x4f, 0x06,
+	0xdc, 0x9f, 0xa0, 0xa9, 0x13, 0x4e, 0xf6, 0x22, 0x9f, 0x72, 0x86, 0x1f, 0x41, 0x89, 0xfa, 0x3e,
+	0xf3, 0x6d, 0x74, 0xf5, 0x99, 0x54, 0x14, 0xbb, 0x50, 0x89, 0xd9, 0x2c, 0x5c, 0x30, 0xdf, 0x2e,
+	0xb6, 0x8d, 0x0b, 0x0a, 0xc8, 0x02, 0xee, 0x1f, 0x08, 0xea, 0xbd, 0x70, 0xbe, 0x1f, 0xc4, 0x33,
+	0xca, 0x45, 0xe2, 0x9f, 0x82, 0x29, 0xe4, 0xaa, 0x35, 0xd3, 0x10, 0x33, 0x86, 0x99, 0x7c, 0x89,
+	0x0c, 0x09, 0xb6, 0x13, 0x4e, 0x79, 0x9a, 0xe8, 0x4b, 0xd0, 0x68, 0x9d, 0x40, 0xe3, 0x76, 0x04,
+	0x9a, 0x37, 0x13, 0x58, 0xba, 0x48, 0xa0, 0xbb, 0x05, 0xe5, 0xe7, 0x8c, 0xfa, 0x2c, 0x16, 0xf2,
+	0x9d, 0x53, 0x9d, 0x6d, 0x95, 0x48, 0x5b, 0x48, 0x64, 0x41, 0xa7, 0x29, 0x93, 0x87, 0xae, 0x12,
+	0x05, 0xdc, 0x6f, 0xc1, 0xec, 0xa6, 0x7c, 0x22, 0x66, 0xa4, 0x09, 0x8b, 0xb3, 0x19, 0xc2, 0x16,
+	0x7b, 0x45, 0x34, 0x49, 0x8e, 0xc2, 0xd8, 0xd7, 0x47, 0x5a, 0x61, 0xf7, 0x6d, 0x11, 0x9a, 0xbd,
+	0x70, 0x3e, 0x67, 0x1e, 0x27, 0xec, 0x4d, 0xca, 0x12, 0x2e, 0xea, 0x83, 0xd3, 0xf8, 0x80, 0x71,
+	0x1b, 0x5d, 0x55, 0x1f, 0x2a, 0x76, 0x65, 0x65, 0x3d, 0x81, 0x46, 0x14, 0x2c, 0x42, 0x3e, 0xd2,
+	0xa5, 0xae, 0x0b, 0xac, 0x26, 0x16, 0xe8, 0x2a, 0x17, 0xa9, 0xcb, 0x11, 0x1a, 0xe1, 0x4f, 0xa0,
+	0xb6, 0x62, 0x2c, 0xf0, 0x25, 0x59, 0x55, 0x02, 0x99, 0xab, 0xef, 0x8b, 0x01, 0x49, 0x98, 0xc6,
+	0x1e, 0x1b, 0x51, 0xdf, 0x8f, 0xa5, 0x6a, 0xeb, 0x04, 0x94, 0xab, 0xeb, 0xfb, 0x31, 0xde, 0x82,
+	0x66, 0x36, 0x40, 0x6f, 0x5a, 0xbe, 0xbc, 0x69, 0x43, 0x4f, 0xd0, 0xbb, 0x3e, 0x86, 0x7a, 0x36,
+	0xe7, 0x80, 0xcd, 0xb9, 0x5d, 0x59, 0x6b, 0x20, 0x7a, 0xcb, 0xae, 0x08, 0xae, 0xf5, 0x1a, 0xeb,
+	0xda, 0x5e, 0xe3, 0x7e, 0x03, 0xa0, 0x99, 0xec, 0x7a, 0x87, 0xb7, 0x6e, 0x51, 0xee, 0x5f, 0x08,
+	0xee, 0xef, 0x66, 0x0d, 0x80, 0xcd, 0x79, 0xb0, 0x1f, 0x78, 0x4a, 0xab, 0xb7, 0xee, 0x72, 0x6b,
+	0x2c, 0x16, 0x2f, 0xb1, 0x78, 0x99, 0x24, 0xe3, 0x7f, 0x93, 0x64, 0x7e, 0x80, 0x24, 0xf7, 0x5f,
+	0x03, 0x2a, 0xe7, 0xfa, 0x51, 0xca, 0x10, 0x09, 0x37, 0xb7, 0x5a, 0x62, 0x82, 0x0e, 0x6d, 0x0e,
+	0x8f, 0x23, 0xa6, 0xb5, 0xf2, 0x00, 0xca, 0x33, 0xc6, 0x27, 0x61, 0x96, 0xae, 0x46, 0x42, 0x57,
+	0x11, 0xe5, 0x13, 0x5d, 0x5e, 0xd2, 0x16, 0x92, 0x7f, 0x93, 0xb2, 0xf8, 0x58, 0xeb, 0x43, 0x01,
+	0x59, 0x42, 0x31, 0x3d, 0x98, 0x89, 0xe4, 0x54, 0x37, 0x5b, 0x61, 0xfc, 0x11, 0x98, 0x34, 0xe5,
+	0x13, 0xbb, 0x7c, 0x9e, 0xb4, 0x28, 0x0f, 0x22, 0xbd, 0xf8, 0x21, 0x54, 0x26, 0xb2, 0xc0, 0x54,
+	0xf3, 0xd2, 0xad, 0x5d, 0xd5, 0x1c, 0xc9, 0x42, 0x82, 0x55, 0xd1, 0x46, 0xb8, 0x96, 0x9e, 0xa5,
+	0x58, 0x55, 0x2e, 0x29, 0x3d, 0x0c, 0xe6, 0x24, 0x4c, 0xb8, 0x5d, 0x55, 0xa9, 0x0a, 0x1b, 0xdb,
+	0x50, 0x91, 0x74, 0xf5, 0x7d, 0x1b, 0xa4, 0x56, 0x33, 0x88, 0x1f, 0x41, 0x53, 0x95, 0xce, 0x48,
+	0x5f, 0x9c, 0x5d, 0x93, 0xf3, 0x1a, 0xca, 0xab, 0x1b, 0xdc, 0xe5, 0x1a, 0xaa, 0xdf, 0x54, 0x43,
+	0xa2, 0x5f, 0x79, 0x13, 0x36, 0x63, 0x76, 0x43, 0xf7, 0x2b, 0x89, 0x04, 0x3f, 0x3c, 0xa6, 0xc1,
+	0x54, 0x1c, 0xb3, 0xd9, 0x46, 0x1d, 0x8b, 0xac, 0xb0, 0xfb, 0x3d, 0x98, 0xe2, 0x2e, 0xb0, 0x05,
+	0xe6, 0xf3, 0xe1, 0x70, 0xd0, 0x2a, 0xe0, 0x06, 0x54, 0x7f, 0xdc, 0x7e, 0xba, 0xfb, 0xaa, 0xf7,
+	0x62, 0x7b, 0xd8, 0x42, 0xb8, 0x02, 0xc6, 0xb0, 0x37, 0x68, 0x15, 0x85, 0xb1, 0xf7, 0x6c, 0xd0,
+	0x32, 0x84, 0x41, 0x06, 0xbd, 0x96, 0x89, 0xef, 0x42, 0xa3, 0xfb, 0xdd, 0xf6, 0xce, 0x70, 0xd4,
+	0x7b, 0xb5, 0xb3, 0xb3, 0xdd, 0x1b, 0xb6, 0x4a, 0xee, 0x02, 0x2c, 0xc2, 0x92, 0x28, 0x9c, 0x27,
+	0xb2, 0x3f, 0xb1, 0x38, 0x0e, 0xb3, 0x16, 0xa4, 0x80, 0xe0, 0x4a, 0x3c, 0x04, 0xf2, 0xb2, 0x4b,
+	0x44, 0xda, 0xf9, 0x6b, 0x30, 0xae, 0xbf, 0x86, 0xfc, 0x31, 0xcc, 0xb5, 0x63, 0x3c, 0x01, 0x6b,
+	0xa8, 0xed, 0xfc, 0x6a, 0xe8, 0xda, 0xd5, 0xdc, 0x17, 0xd0, 0xc8, 0x5e, 0x9b, 0x1f, 0xa4, 0x8a,
+	0xce, 0x7f, 0x03, 0xe8, 0x03, 0xbf, 0x81, 0x7b, 0x50, 0x3a, 0xa2, 0xdc, 0x9b, 0xc8, 0xfc, 0x2d,
+	0xa2, 0x80, 0xfb, 0x0c, 0xee, 0x5f, 0x58, 0x6c, 0xc5, 0x41, 0xfe, 0x61, 0xce, 0x3d, 0x62, 0x03,
+	0xc6, 0x62, 0x3d, 0xe1, 0xfc, 0x61, 0x76, 0x7f, 0x43, 0x50, 0xcb, 0x45, 0xb0, 0x0d, 0xc5, 0x2b,
+	0xca, 0xbd, 0x18, 0xf8, 0x78, 0x03, 0x8c, 0x49, 0x3a, 0xb6, 0x8b, 0x6b, 0x21, 0xe1, 0x5c, 0xf5,
+	0x63, 0xe3, 0xca, 0x9f, 0x8e, 0x79, 0xcb, 0x9f, 0x4e, 0xe9, 0xfa, 0x9f, 0x8e, 0xbb, 0x03, 0x40,
+	0x06, 0xbd, 0xac, 0xca, 0x33, 0xf1, 0xa3, 0x9c, 0xf8, 0xb3, 0xda, 0x2d, 0xe6, 0x6a, 0xd7, 0x86,
+	0x8a, 0x78, 0x55, 0xc3, 0x54, 0xf5, 0x1c, 0x83, 0x64, 0xd0, 0x1d, 0x41, 0x95, 0x0c, 0x7a, 0xbb,
+	0xea, 0x71, 0xcd, 0xf4, 0x81, 0x72, 0xfa, 0xb0, 0xa1, 0x32, 0x63, 0x49, 0x42, 0x0f, 0xb2, 0x57,
+	0x26, 0x83, 0xe2, 0xae, 0x7d, 0xc6, 0x69, 0x30, 0xbd, 0xa0, 0x1c, 0x9d, 0x71, 0x16, 0x7a, 0xfa,
+	0xf5, 0xc9, 0xa9, 0x53, 0x78, 0x77, 0xea, 0x14, 0xde, 0x9f, 0x3a, 0xe8, 0xe7, 0xa5, 0x83, 0x7e,
+	0x5f, 0x3a, 0xe8, 0xed, 0xd2, 0x41, 0x27, 0x4b, 0x07, 0xfd, 0xbd, 0x74, 0xd0, 0x3f, 0x4b, 0xa7,
+	0xf0, 0x7e, 0xe9, 0xa0, 0x5f, 0xce, 0x9c, 0xc2, 0xc9, 0x99, 0x53, 0x78, 0x77, 0xe6, 0x14, 0xc6,
+	0x65, 0xd9, 0x37, 0xbf, 0xfa, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x85, 0xe1, 0x47, 0xe7, 0x0a,
+	0x00, 0x00,
+}
+
+func (x Request_Type) String() string {
+	s, ok := Request_Type_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *Labels) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Labels)
+	if !ok {
+		that2, ok := that.(Labels)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Label) != len(that1.Label) {
+		return false
+	}
+	for i := range this.Label {
+		if this.Label[i] != that1.Label[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *ServiceInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ServiceInfo)
+	if !ok {
+		that2, ok := that.(ServiceInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ServiceId.Equal(that1.ServiceId) {
+		return false
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.ServiceId.Equal(that1.ServiceId) {
-		return false
-	}
 	if this.Type != that1.Type {
 		return false
 	}
@@ -915,6 +1471,9 @@ func (this *ServiceInfo) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.Compression != that1.Compression {
+		return false
+	}
 	return true
 }
 func (this *Preamble) Equal(that interface{}) bool {
@@ -961,6 +1520,62 @@ func (this *Preamble) Equal(that interface{}) bool {
 	if this.Compression != that1.Compression {
 		return false
 	}
+	if this.ProtocolVersion != that1.ProtocolVersion {
+		return false
+	}
+	if len(this.Features) != len(that1.Features) {
+		return false
+	}
+	for i := range this.Features {
+		if this.Features[i] != that1.Features[i] {
+			return false
+		}
+	}
+	if len(this.CompressionCodecs) != len(that1.CompressionCodecs) {
+		return false
+	}
+	for i := range this.CompressionCodecs {
+		if this.CompressionCodecs[i] != that1.CompressionCodecs[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *ServicesUpdate) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ServicesUpdate)
+	if !ok {
+		that2, ok := that.(ServicesUpdate)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Added) != len(that1.Added) {
+		return false
+	}
+	for i := range this.Added {
+		if !this.Added[i].Equal(that1.Added[i]) {
+			return false
+		}
+	}
+	if len(this.Removed) != len(that1.Removed) {
+		return false
+	}
+	for i := range this.Removed {
+		if !this.Removed[i].Equal(that1.Removed[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *Confirmation) Equal(that interface{}) bool {
@@ -991,6 +1606,17 @@ func (this *Confirmation) Equal(that interface{}) bool {
 	if this.Compression != that1.Compression {
 		return false
 	}
+	if this.ProtocolVersion != that1.ProtocolVersion {
+		return false
+	}
+	if len(this.Features) != len(that1.Features) {
+		return false
+	}
+	for i := range this.Features {
+		if this.Features[i] != that1.Features[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *Header) Equal(that interface{}) bool {
@@ -1086,6 +1712,15 @@ func (this *ConnectRequest) Equal(that interface{}) bool {
 	if !bytes.Equal(this.SourceAddr, that1.SourceAddr) {
 		return false
 	}
+	if !this.SourceAccount.Equal(that1.SourceAccount) {
+		return false
+	}
+	if !this.SourceAgent.Equal(that1.SourceAgent) {
+		return false
+	}
+	if !this.ServiceId.Equal(that1.ServiceId) {
+		return false
+	}
 	return true
 }
 func (this *ConnectAck) Equal(that interface{}) bool {
@@ -1137,6 +1772,12 @@ func (this *SessionIdentification) Equal(that interface{}) bool {
 	if this.ProtocolId != that1.ProtocolId {
 		return false
 	}
+	if !this.SourceAccount.Equal(that1.SourceAccount) {
+		return false
+	}
+	if !this.SourceAgent.Equal(that1.SourceAgent) {
+		return false
+	}
 	return true
 }
 func (this *Request) Equal(that interface{}) bool {
@@ -1199,6 +1840,12 @@ func (this *Request) Equal(that interface{}) bool {
 	if !this.PivotAccount.Equal(that1.PivotAccount) {
 		return false
 	}
+	if this.Scheme != that1.Scheme {
+		return false
+	}
+	if this.Trailers != that1.Trailers {
+		return false
+	}
 	return true
 }
 func (this *Response) Equal(that interface{}) bool {
@@ -1234,9 +1881,203 @@ func (this *Response) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.Trailers != that1.Trailers {
+		return false
+	}
 	return true
 }
-func (this *Labels) GoString() string {
+func (this *Trailers) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Trailers)
+	if !ok {
+		that2, ok := that.(Trailers)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *ServicesQuery) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ServicesQuery)
+	if !ok {
+		that2, ok := that.(ServicesQuery)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if this.Watch != that1.Watch {
+		return false
+	}
+	return true
+}
+func (this *ServicesQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ServicesQueryResponse)
+	if !ok {
+		that2, ok := that.(ServicesQueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Services) != len(that1.Services) {
+		return false
+	}
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *PeerService) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PeerService)
+	if !ok {
+		that2, ok := that.(PeerService)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Id.Equal(that1.Id) {
+		return false
+	}
+	if !this.Hub.Equal(that1.Hub) {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
+	}
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *RPCRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RPCRequest)
+	if !ok {
+		that2, ok := that.(RPCRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Host != that1.Host {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.Timeout != that1.Timeout {
+		return false
+	}
+	return true
+}
+func (this *RPCStatus) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RPCStatus)
+	if !ok {
+		that2, ok := that.(RPCStatus)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if this.Message != that1.Message {
+		return false
+	}
+	if len(this.Details) != len(that1.Details) {
+		return false
+	}
+	for i := range this.Details {
+		if !this.Details[i].Equal(that1.Details[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Labels) GoString() string {
 	if this == nil {
 		return "nil"
 	}
@@ -1250,7 +2091,7 @@ func (this *ServiceInfo) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
+	s := make([]string, 0, 9)
 	s = append(s, "&pb.ServiceInfo{")
 	if this.ServiceId != nil {
 		s = append(s, "ServiceId: "+fmt.Sprintf("%#v", this.ServiceId)+",\n")
@@ -1262,6 +2103,7 @@ func (this *ServiceInfo) GoString() string {
 	if this.Metadata != nil {
 		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
 	}
+	s = append(s, "Compression: "+fmt.Sprintf("%#v", this.Compression)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1269,7 +2111,7 @@ func (this *Preamble) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 12)
 	s = append(s, "&pb.Preamble{")
 	s = append(s, "SessionId: "+fmt.Sprintf("%#v", this.SessionId)+",\n")
 	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
@@ -1278,6 +2120,24 @@ func (this *Preamble) GoString() string {
 		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
 	}
 	s = append(s, "Compression: "+fmt.Sprintf("%#v", this.Compression)+",\n")
+	s = append(s, "ProtocolVersion: "+fmt.Sprintf("%#v", this.ProtocolVersion)+",\n")
+	s = append(s, "Features: "+fmt.Sprintf("%#v", this.Features)+",\n")
+	s = append(s, "CompressionCodecs: "+fmt.Sprintf("%#v", this.CompressionCodecs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ServicesUpdate) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ServicesUpdate{")
+	if this.Added != nil {
+		s = append(s, "Added: "+fmt.Sprintf("%#v", this.Added)+",\n")
+	}
+	if this.Removed != nil {
+		s = append(s, "Removed: "+fmt.Sprintf("%#v", this.Removed)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1285,13 +2145,15 @@ func (this *Confirmation) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 9)
 	s = append(s, "&pb.Confirmation{")
 	if this.Time != nil {
 		s = append(s, "Time: "+fmt.Sprintf("%#v", this.Time)+",\n")
 	}
 	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
 	s = append(s, "Compression: "+fmt.Sprintf("%#v", this.Compression)+",\n")
+	s = append(s, "ProtocolVersion: "+fmt.Sprintf("%#v", this.ProtocolVersion)+",\n")
+	s = append(s, "Features: "+fmt.Sprintf("%#v", this.Features)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1321,7 +2183,7 @@ func (this *ConnectRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 12)
 	s = append(s, "&pb.ConnectRequest{")
 	if this.Target != nil {
 		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
@@ -1332,6 +2194,15 @@ func (this *ConnectRequest) GoString() string {
 	}
 	s = append(s, "ProtocolId: "+fmt.Sprintf("%#v", this.ProtocolId)+",\n")
 	s = append(s, "SourceAddr: "+fmt.Sprintf("%#v", this.SourceAddr)+",\n")
+	if this.SourceAccount != nil {
+		s = append(s, "SourceAccount: "+fmt.Sprintf("%#v", this.SourceAccount)+",\n")
+	}
+	if this.SourceAgent != nil {
+		s = append(s, "SourceAgent: "+fmt.Sprintf("%#v", this.SourceAgent)+",\n")
+	}
+	if this.ServiceId != nil {
+		s = append(s, "ServiceId: "+fmt.Sprintf("%#v", this.ServiceId)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1351,12 +2222,18 @@ func (this *SessionIdentification) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.SessionIdentification{")
 	if this.ServiceId != nil {
 		s = append(s, "ServiceId: "+fmt.Sprintf("%#v", this.ServiceId)+",\n")
 	}
 	s = append(s, "ProtocolId: "+fmt.Sprintf("%#v", this.ProtocolId)+",\n")
+	if this.SourceAccount != nil {
+		s = append(s, "SourceAccount: "+fmt.Sprintf("%#v", this.SourceAccount)+",\n")
+	}
+	if this.SourceAgent != nil {
+		s = append(s, "SourceAgent: "+fmt.Sprintf("%#v", this.SourceAgent)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1364,7 +2241,7 @@ func (this *Request) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 16)
+	s := make([]string, 0, 18)
 	s = append(s, "&pb.Request{")
 	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
 	s = append(s, "Method: "+fmt.Sprintf("%#v", this.Method)+",\n")
@@ -1384,6 +2261,8 @@ func (this *Request) GoString() string {
 	if this.PivotAccount != nil {
 		s = append(s, "PivotAccount: "+fmt.Sprintf("%#v", this.PivotAccount)+",\n")
 	}
+	s = append(s, "Scheme: "+fmt.Sprintf("%#v", this.Scheme)+",\n")
+	s = append(s, "Trailers: "+fmt.Sprintf("%#v", this.Trailers)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1391,64 +2270,150 @@ func (this *Response) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.Response{")
 	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
 	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
 	if this.Headers != nil {
 		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
 	}
+	s = append(s, "Trailers: "+fmt.Sprintf("%#v", this.Trailers)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func valueToGoStringWire(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+func (this *Trailers) GoString() string {
+	if this == nil {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func (m *Labels) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.Trailers{")
+	if this.Headers != nil {
+		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Labels) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *Labels) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Label) > 0 {
-		for iNdEx := len(m.Label) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Label[iNdEx])
-			copy(dAtA[i:], m.Label[iNdEx])
-			i = encodeVarintWire(dAtA, i, uint64(len(m.Label[iNdEx])))
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *ServicesQuery) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ServicesQuery{")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	}
+	s = append(s, "Watch: "+fmt.Sprintf("%#v", this.Watch)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ServiceInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ServicesQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ServicesQueryResponse{")
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PeerService) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.PeerService{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	}
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	}
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	}
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RPCRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.RPCRequest{")
+	s = append(s, "Host: "+fmt.Sprintf("%#v", this.Host)+",\n")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RPCStatus) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.RPCStatus{")
+	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	if this.Details != nil {
+		s = append(s, "Details: "+fmt.Sprintf("%#v", this.Details)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringWire(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *Labels) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Labels) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Labels) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Label) > 0 {
+		for iNdEx := len(m.Label) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Label[iNdEx])
+			copy(dAtA[i:], m.Label[iNdEx])
+			i = encodeVarintWire(dAtA, i, uint64(len(m.Label[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServiceInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
 func (m *ServiceInfo) MarshalTo(dAtA []byte) (int, error) {
@@ -1461,6 +2426,13 @@ func (m *ServiceInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Compression) > 0 {
+		i -= len(m.Compression)
+		copy(dAtA[i:], m.Compression)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Compression)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Metadata) > 0 {
 		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1529,6 +2501,29 @@ func (m *Preamble) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CompressionCodecs) > 0 {
+		for iNdEx := len(m.CompressionCodecs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CompressionCodecs[iNdEx])
+			copy(dAtA[i:], m.CompressionCodecs[iNdEx])
+			i = encodeVarintWire(dAtA, i, uint64(len(m.CompressionCodecs[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintWire(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.ProtocolVersion != 0 {
+		i = encodeVarintWire(dAtA, i, uint64(m.ProtocolVersion))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Compression) > 0 {
 		i -= len(m.Compression)
 		copy(dAtA[i:], m.Compression)
@@ -1576,6 +2571,57 @@ func (m *Preamble) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ServicesUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServicesUpdate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServicesUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Removed) > 0 {
+		for iNdEx := len(m.Removed) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Removed[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Added) > 0 {
+		for iNdEx := len(m.Added) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Added[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Confirmation) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1596,6 +2642,20 @@ func (m *Confirmation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintWire(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.ProtocolVersion != 0 {
+		i = encodeVarintWire(dAtA, i, uint64(m.ProtocolVersion))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Compression) > 0 {
 		i -= len(m.Compression)
 		copy(dAtA[i:], m.Compression)
@@ -1721,6 +2781,42 @@ func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ServiceId != nil {
+		{
+			size, err := m.ServiceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.SourceAgent != nil {
+		{
+			size, err := m.SourceAgent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.SourceAccount != nil {
+		{
+			size, err := m.SourceAccount.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.SourceAddr) > 0 {
 		i -= len(m.SourceAddr)
 		copy(dAtA[i:], m.SourceAddr)
@@ -1824,6 +2920,30 @@ func (m *SessionIdentification) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SourceAgent != nil {
+		{
+			size, err := m.SourceAgent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.SourceAccount != nil {
+		{
+			size, err := m.SourceAccount.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.ProtocolId) > 0 {
 		i -= len(m.ProtocolId)
 		copy(dAtA[i:], m.ProtocolId)
@@ -1866,6 +2986,23 @@ func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Trailers {
+		i--
+		if m.Trailers {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if len(m.Scheme) > 0 {
+		i -= len(m.Scheme)
+		copy(dAtA[i:], m.Scheme)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Scheme)))
+		i--
+		dAtA[i] = 0x6a
+	}
 	if m.PivotAccount != nil {
 		{
 			size, err := m.PivotAccount.MarshalToSizedBuffer(dAtA[:i])
@@ -1988,6 +3125,16 @@ func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Trailers {
+		i--
+		if m.Trailers {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Headers) > 0 {
 		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2017,35 +3164,325 @@ func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintWire(dAtA []byte, offset int, v uint64) int {
-	offset -= sovWire(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Trailers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Labels) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *Trailers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Trailers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Label) > 0 {
-		for _, s := range m.Label {
-			l = len(s)
-			n += 1 + l + sovWire(uint64(l))
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ServiceInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ServicesQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServicesQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServicesQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Watch {
+		i--
+		if m.Watch {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServicesQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServicesQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServicesQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerService) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerService) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerService) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RPCRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RPCRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RPCRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timeout != 0 {
+		i = encodeVarintWire(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Host) > 0 {
+		i -= len(m.Host)
+		copy(dAtA[i:], m.Host)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Host)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RPCStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RPCStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RPCStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Details) > 0 {
+		for iNdEx := len(m.Details) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Details[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Code != 0 {
+		i = encodeVarintWire(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWire(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWire(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Labels) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Label) > 0 {
+		for _, s := range m.Label {
+			l = len(s)
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServiceInfo) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -2067,6 +3504,10 @@ func (m *ServiceInfo) Size() (n int) {
 			n += 1 + l + sovWire(uint64(l))
 		}
 	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
 	return n
 }
 
@@ -2100,10 +3541,46 @@ func (m *Preamble) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWire(uint64(l))
 	}
-	return n
-}
-
-func (m *Confirmation) Size() (n int) {
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovWire(uint64(m.ProtocolVersion))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	if len(m.CompressionCodecs) > 0 {
+		for _, s := range m.CompressionCodecs {
+			l = len(s)
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServicesUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Added) > 0 {
+		for _, e := range m.Added {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	if len(m.Removed) > 0 {
+		for _, e := range m.Removed {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Confirmation) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -2121,6 +3598,15 @@ func (m *Confirmation) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWire(uint64(l))
 	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovWire(uint64(m.ProtocolVersion))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2186,6 +3672,18 @@ func (m *ConnectRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWire(uint64(l))
 	}
+	if m.SourceAccount != nil {
+		l = m.SourceAccount.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.SourceAgent != nil {
+		l = m.SourceAgent.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.ServiceId != nil {
+		l = m.ServiceId.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
 	return n
 }
 
@@ -2216,6 +3714,14 @@ func (m *SessionIdentification) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWire(uint64(l))
 	}
+	if m.SourceAccount != nil {
+		l = m.SourceAccount.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.SourceAgent != nil {
+		l = m.SourceAgent.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
 	return n
 }
 
@@ -2274,6 +3780,13 @@ func (m *Request) Size() (n int) {
 		l = m.PivotAccount.Size()
 		n += 1 + l + sovWire(uint64(l))
 	}
+	l = len(m.Scheme)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.Trailers {
+		n += 2
+	}
 	return n
 }
 
@@ -2296,183 +3809,1925 @@ func (m *Response) Size() (n int) {
 			n += 1 + l + sovWire(uint64(l))
 		}
 	}
-	return n
-}
+	if m.Trailers {
+		n += 2
+	}
+	return n
+}
+
+func (m *Trailers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServicesQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.Watch {
+		n += 2
+	}
+	return n
+}
+
+func (m *ServicesQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PeerService) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RPCRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovWire(uint64(m.Timeout))
+	}
+	return n
+}
+
+func (m *RPCStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovWire(uint64(m.Code))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if len(m.Details) > 0 {
+		for _, e := range m.Details {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovWire(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWire(x uint64) (n int) {
+	return sovWire(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Labels) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Labels{`,
+		`Label:` + fmt.Sprintf("%v", this.Label) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&ServiceInfo{`,
+		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Preamble) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*ServiceInfo{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceInfo", "ServiceInfo", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&Preamble{`,
+		`SessionId:` + fmt.Sprintf("%v", this.SessionId) + `,`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
+		`Features:` + fmt.Sprintf("%v", this.Features) + `,`,
+		`CompressionCodecs:` + fmt.Sprintf("%v", this.CompressionCodecs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServicesUpdate) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAdded := "[]*ServiceInfo{"
+	for _, f := range this.Added {
+		repeatedStringForAdded += strings.Replace(f.String(), "ServiceInfo", "ServiceInfo", 1) + ","
+	}
+	repeatedStringForAdded += "}"
+	repeatedStringForRemoved := "[]*ULID{"
+	for _, f := range this.Removed {
+		repeatedStringForRemoved += strings.Replace(fmt.Sprintf("%v", f), "ULID", "ULID", 1) + ","
+	}
+	repeatedStringForRemoved += "}"
+	s := strings.Join([]string{`&ServicesUpdate{`,
+		`Added:` + repeatedStringForAdded + `,`,
+		`Removed:` + repeatedStringForRemoved + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Confirmation) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Confirmation{`,
+		`Time:` + strings.Replace(fmt.Sprintf("%v", this.Time), "Timestamp", "Timestamp", 1) + `,`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
+		`Features:` + fmt.Sprintf("%v", this.Features) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Header) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Header{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Auth) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Auth{`,
+		`User:` + fmt.Sprintf("%v", this.User) + `,`,
+		`Password:` + fmt.Sprintf("%v", this.Password) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectRequest{`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`PivotAccount:` + strings.Replace(fmt.Sprintf("%v", this.PivotAccount), "Account", "Account", 1) + `,`,
+		`ProtocolId:` + fmt.Sprintf("%v", this.ProtocolId) + `,`,
+		`SourceAddr:` + fmt.Sprintf("%v", this.SourceAddr) + `,`,
+		`SourceAccount:` + strings.Replace(fmt.Sprintf("%v", this.SourceAccount), "Account", "Account", 1) + `,`,
+		`SourceAgent:` + strings.Replace(fmt.Sprintf("%v", this.SourceAgent), "ULID", "ULID", 1) + `,`,
+		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectAck) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectAck{`,
+		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionIdentification) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionIdentification{`,
+		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
+		`ProtocolId:` + fmt.Sprintf("%v", this.ProtocolId) + `,`,
+		`SourceAccount:` + strings.Replace(fmt.Sprintf("%v", this.SourceAccount), "Account", "Account", 1) + `,`,
+		`SourceAgent:` + strings.Replace(fmt.Sprintf("%v", this.SourceAgent), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Request) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*Header{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "Header", "Header", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&Request{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Method:` + fmt.Sprintf("%v", this.Method) + `,`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
+		`Fragment:` + fmt.Sprintf("%v", this.Fragment) + `,`,
+		`Auth:` + strings.Replace(this.Auth.String(), "Auth", "Auth", 1) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`RemoteAddr:` + fmt.Sprintf("%v", this.RemoteAddr) + `,`,
+		`Host:` + fmt.Sprintf("%v", this.Host) + `,`,
+		`AgentId:` + fmt.Sprintf("%v", this.AgentId) + `,`,
+		`TargetService:` + fmt.Sprintf("%v", this.TargetService) + `,`,
+		`PivotAccount:` + strings.Replace(fmt.Sprintf("%v", this.PivotAccount), "Account", "Account", 1) + `,`,
+		`Scheme:` + fmt.Sprintf("%v", this.Scheme) + `,`,
+		`Trailers:` + fmt.Sprintf("%v", this.Trailers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Response) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*Header{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "Header", "Header", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&Response{`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`Trailers:` + fmt.Sprintf("%v", this.Trailers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Trailers) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*Header{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "Header", "Header", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&Trailers{`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServicesQuery) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServicesQuery{`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Watch:` + fmt.Sprintf("%v", this.Watch) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServicesQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*PeerService{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "PeerService", "PeerService", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&ServicesQueryResponse{`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PeerService) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&PeerService{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RPCRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RPCRequest{`,
+		`Host:` + fmt.Sprintf("%v", this.Host) + `,`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RPCStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForDetails := "[]*KVPair{"
+	for _, f := range this.Details {
+		repeatedStringForDetails += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForDetails += "}"
+	s := strings.Join([]string{`&RPCStatus{`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`Details:` + repeatedStringForDetails + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringWire(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Labels) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Labels: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Labels: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = append(m.Label, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ServiceId == nil {
+				m.ServiceId = &ULID{}
+			}
+			if err := m.ServiceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Preamble) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Preamble: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Preamble: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, &ServiceInfo{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressionCodecs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressionCodecs = append(m.CompressionCodecs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServicesUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServicesUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServicesUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Added", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Added = append(m.Added, &ServiceInfo{})
+			if err := m.Added[len(m.Added)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Removed = append(m.Removed, &ULID{})
+			if err := m.Removed[len(m.Removed)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Confirmation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Confirmation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Confirmation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &Timestamp{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Header) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Header: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Auth) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Auth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Auth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PivotAccount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PivotAccount == nil {
+				m.PivotAccount = &Account{}
+			}
+			if err := m.PivotAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProtocolId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAddr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceAddr = append(m.SourceAddr[:0], dAtA[iNdEx:postIndex]...)
+			if m.SourceAddr == nil {
+				m.SourceAddr = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAccount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SourceAccount == nil {
+				m.SourceAccount = &Account{}
+			}
+			if err := m.SourceAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAgent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SourceAgent == nil {
+				m.SourceAgent = &ULID{}
+			}
+			if err := m.SourceAgent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ServiceId == nil {
+				m.ServiceId = &ULID{}
+			}
+			if err := m.ServiceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovWire(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozWire(x uint64) (n int) {
-	return sovWire(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *Labels) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&Labels{`,
-		`Label:` + fmt.Sprintf("%v", this.Label) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ServiceInfo) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMetadata := "[]*KVPair{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
-	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&ServiceInfo{`,
-		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Preamble) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForServices := "[]*ServiceInfo{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "ServiceInfo", "ServiceInfo", 1) + ","
-	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&Preamble{`,
-		`SessionId:` + fmt.Sprintf("%v", this.SessionId) + `,`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
-		`Services:` + repeatedStringForServices + `,`,
-		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Confirmation) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&Confirmation{`,
-		`Time:` + strings.Replace(fmt.Sprintf("%v", this.Time), "Timestamp", "Timestamp", 1) + `,`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Header) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&Header{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Auth) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&Auth{`,
-		`User:` + fmt.Sprintf("%v", this.User) + `,`,
-		`Password:` + fmt.Sprintf("%v", this.Password) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ConnectRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ConnectRequest{`,
-		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`PivotAccount:` + strings.Replace(fmt.Sprintf("%v", this.PivotAccount), "Account", "Account", 1) + `,`,
-		`ProtocolId:` + fmt.Sprintf("%v", this.ProtocolId) + `,`,
-		`SourceAddr:` + fmt.Sprintf("%v", this.SourceAddr) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ConnectAck) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ConnectAck{`,
-		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *SessionIdentification) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&SessionIdentification{`,
-		`ServiceId:` + strings.Replace(fmt.Sprintf("%v", this.ServiceId), "ULID", "ULID", 1) + `,`,
-		`ProtocolId:` + fmt.Sprintf("%v", this.ProtocolId) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Request) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForHeaders := "[]*Header{"
-	for _, f := range this.Headers {
-		repeatedStringForHeaders += strings.Replace(f.String(), "Header", "Header", 1) + ","
-	}
-	repeatedStringForHeaders += "}"
-	s := strings.Join([]string{`&Request{`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Method:` + fmt.Sprintf("%v", this.Method) + `,`,
-		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
-		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
-		`Fragment:` + fmt.Sprintf("%v", this.Fragment) + `,`,
-		`Auth:` + strings.Replace(this.Auth.String(), "Auth", "Auth", 1) + `,`,
-		`Headers:` + repeatedStringForHeaders + `,`,
-		`RemoteAddr:` + fmt.Sprintf("%v", this.RemoteAddr) + `,`,
-		`Host:` + fmt.Sprintf("%v", this.Host) + `,`,
-		`AgentId:` + fmt.Sprintf("%v", this.AgentId) + `,`,
-		`TargetService:` + fmt.Sprintf("%v", this.TargetService) + `,`,
-		`PivotAccount:` + strings.Replace(fmt.Sprintf("%v", this.PivotAccount), "Account", "Account", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Response) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForHeaders := "[]*Header{"
-	for _, f := range this.Headers {
-		repeatedStringForHeaders += strings.Replace(f.String(), "Header", "Header", 1) + ","
-	}
-	repeatedStringForHeaders += "}"
-	s := strings.Join([]string{`&Response{`,
-		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
-		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
-		`Headers:` + repeatedStringForHeaders + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringWire(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *Labels) Unmarshal(dAtA []byte) error {
+func (m *ConnectAck) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2495,17 +5750,17 @@ func (m *Labels) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Labels: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectAck: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Labels: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectAck: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -2515,23 +5770,27 @@ func (m *Labels) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Label = append(m.Label, string(dAtA[iNdEx:postIndex]))
+			if m.ServiceId == nil {
+				m.ServiceId = &ULID{}
+			}
+			if err := m.ServiceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2557,7 +5816,7 @@ func (m *Labels) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
+func (m *SessionIdentification) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2580,10 +5839,10 @@ func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionIdentification: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionIdentification: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2624,7 +5883,7 @@ func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2652,11 +5911,11 @@ func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.ProtocolId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAccount", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2683,16 +5942,16 @@ func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.SourceAccount == nil {
+				m.SourceAccount = &Account{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.SourceAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAgent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2719,8 +5978,10 @@ func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata, &KVPair{})
-			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.SourceAgent == nil {
+				m.SourceAgent = &ULID{}
+			}
+			if err := m.SourceAgent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2748,7 +6009,7 @@ func (m *ServiceInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Preamble) Unmarshal(dAtA []byte) error {
+func (m *Request) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2771,17 +6032,17 @@ func (m *Preamble) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Preamble: wiretype end group for non-group")
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Preamble: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SessionId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var stringLen uint64
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -2791,27 +6052,14 @@ func (m *Preamble) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Type |= Request_Type(b&0x7F) << shift
 				if b < 0x80 {
 					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+				}
 			}
-			m.SessionId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2839,11 +6087,11 @@ func (m *Preamble) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Method = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2871,13 +6119,13 @@ func (m *Preamble) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -2887,29 +6135,27 @@ func (m *Preamble) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Services = append(m.Services, &ServiceInfo{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Query = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fragment", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2937,64 +6183,47 @@ func (m *Preamble) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Compression = string(dAtA[iNdEx:postIndex])
+			m.Fragment = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipWire(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Auth", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthWire
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Confirmation) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowWire
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Auth == nil {
+				m.Auth = &Auth{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Auth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Confirmation: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Confirmation: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3021,16 +6250,14 @@ func (m *Confirmation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Time == nil {
-				m.Time = &Timestamp{}
-			}
-			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, &Header{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoteAddr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3058,11 +6285,11 @@ func (m *Confirmation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = string(dAtA[iNdEx:postIndex])
+			m.RemoteAddr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3090,66 +6317,13 @@ func (m *Confirmation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Compression = string(dAtA[iNdEx:postIndex])
+			m.Host = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipWire(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthWire
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthWire
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Header) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowWire
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Header: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentId", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3159,27 +6333,29 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.AgentId = append(m.AgentId[:0], dAtA[iNdEx:postIndex]...)
+			if m.AgentId == nil {
+				m.AgentId = []byte{}
+			}
 			iNdEx = postIndex
-		case 2:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetService", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3206,67 +6382,14 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipWire(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthWire
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthWire
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Auth) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowWire
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Auth: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Auth: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			}
+			m.TargetService = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PivotAccount", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3276,27 +6399,31 @@ func (m *Auth) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.User = string(dAtA[iNdEx:postIndex])
+			if m.PivotAccount == nil {
+				m.PivotAccount = &Account{}
+			}
+			if err := m.PivotAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheme", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3324,8 +6451,28 @@ func (m *Auth) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Password = string(dAtA[iNdEx:postIndex])
+			m.Scheme = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trailers", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Trailers = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWire(dAtA[iNdEx:])
@@ -3350,7 +6497,7 @@ func (m *Auth) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3373,17 +6520,17 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3393,33 +6540,29 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Target == nil {
-				m.Target = &LabelSet{}
-			}
-			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var stringLen uint64
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3429,27 +6572,14 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Code |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Type = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PivotAccount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3476,50 +6606,16 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.PivotAccount == nil {
-				m.PivotAccount = &Account{}
-			}
-			if err := m.PivotAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, &Header{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowWire
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ProtocolId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SourceAddr", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trailers", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3529,26 +6625,12 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SourceAddr = append(m.SourceAddr[:0], dAtA[iNdEx:postIndex]...)
-			if m.SourceAddr == nil {
-				m.SourceAddr = []byte{}
-			}
-			iNdEx = postIndex
+			m.Trailers = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWire(dAtA[iNdEx:])
@@ -3573,7 +6655,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConnectAck) Unmarshal(dAtA []byte) error {
+func (m *Trailers) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3596,15 +6678,15 @@ func (m *ConnectAck) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectAck: wiretype end group for non-group")
+			return fmt.Errorf("proto: Trailers: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectAck: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Trailers: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3631,10 +6713,8 @@ func (m *ConnectAck) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ServiceId == nil {
-				m.ServiceId = &ULID{}
-			}
-			if err := m.ServiceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, &Header{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3662,7 +6742,7 @@ func (m *ConnectAck) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SessionIdentification) Unmarshal(dAtA []byte) error {
+func (m *ServicesQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3685,15 +6765,15 @@ func (m *SessionIdentification) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SessionIdentification: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServicesQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SessionIdentification: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServicesQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3720,18 +6800,18 @@ func (m *SessionIdentification) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ServiceId == nil {
-				m.ServiceId = &ULID{}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
 			}
-			if err := m.ServiceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Watch", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3741,24 +6821,12 @@ func (m *SessionIdentification) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ProtocolId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Watch = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWire(dAtA[iNdEx:])
@@ -3783,7 +6851,7 @@ func (m *SessionIdentification) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *ServicesQueryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3806,36 +6874,17 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServicesQueryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServicesQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowWire
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= Request_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3845,29 +6894,84 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, &PeerService{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerService) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Method = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerService: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerService: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3877,29 +6981,33 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -3909,27 +7017,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthWire
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Query = string(dAtA[iNdEx:postIndex])
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fragment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3957,11 +7069,11 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fragment = string(dAtA[iNdEx:postIndex])
+			m.Type = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Auth", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3988,16 +7100,16 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Auth == nil {
-				m.Auth = &Auth{}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
 			}
-			if err := m.Auth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4024,44 +7136,65 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Headers = append(m.Headers, &Header{})
-			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoteAddr", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowWire
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthWire
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthWire
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RemoteAddr = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RPCRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RPCRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RPCRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
 			}
@@ -4093,43 +7226,9 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Host = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentId", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowWire
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AgentId = append(m.AgentId[:0], dAtA[iNdEx:postIndex]...)
-			if m.AgentId == nil {
-				m.AgentId = []byte{}
-			}
-			iNdEx = postIndex
-		case 11:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetService", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4157,13 +7256,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TargetService = string(dAtA[iNdEx:postIndex])
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PivotAccount", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			var msglen int
+			m.Timeout = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -4173,28 +7272,11 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Timeout |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.PivotAccount == nil {
-				m.PivotAccount = &Account{}
-			}
-			if err := m.PivotAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWire(dAtA[iNdEx:])
@@ -4219,7 +7301,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RPCStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4242,17 +7324,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RPCStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RPCStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var stringLen uint64
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -4262,29 +7344,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Code |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWire
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWire
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Error = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			m.Code = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWire
@@ -4294,14 +7363,27 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Details", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4328,8 +7410,8 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Headers = append(m.Headers, &Header{})
-			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Details = append(m.Details, &KVPair{})
+			if err := m.Details[len(m.Details)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -0,0 +1,301 @@
+// Package proxyproto implements the receiving side of the PROXY protocol
+// (v1 and v2), as sent by load balancers to convey the address of the
+// client they are proxying for.
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidHeader = errors.New("invalid proxy protocol header")
+)
+
+var (
+	sigV1 = []byte("PROXY ")
+	sigV2 = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+// The longest v1 header allowed by the spec, including the CRLF.
+const maxV1Length = 107
+
+// DefaultHeaderTimeout is how long a connection has to send it's header
+// before it's considered broken.
+const DefaultHeaderTimeout = 10 * time.Second
+
+// Listener wraps a net.Listener, returning connections that parse
+// a PROXY protocol header if one is present. Connections that do not start
+// with a header are passed through untouched, so the listener should
+// only be used when it's reachable solely through a load balancer that
+// sends the header, otherwise clients are able to spoof their address.
+type Listener struct {
+	net.Listener
+
+	// How long to wait for the header. Defaults to DefaultHeaderTimeout.
+	HeaderTimeout time.Duration
+}
+
+// NewListener returns a Listener wrapping l.
+func NewListener(l net.Listener) *Listener {
+	return &Listener{
+		Listener:      l,
+		HeaderTimeout: DefaultHeaderTimeout,
+	}
+}
+
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := l.HeaderTimeout
+	if timeout == 0 {
+		timeout = DefaultHeaderTimeout
+	}
+
+	return &Conn{
+		Conn:    conn,
+		br:      bufio.NewReader(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Conn is a connection that may have begun with a PROXY protocol header.
+// The header is read lazily, the first time the connection is read from
+// or it's addresses are requested, so that Accept is never blocked
+// waiting on a slow client.
+type Conn struct {
+	net.Conn
+
+	br      *bufio.Reader
+	timeout time.Duration
+
+	once     sync.Once
+	src, dst net.Addr
+	err      error
+}
+
+func (c *Conn) init() {
+	c.once.Do(func() {
+		c.Conn.SetReadDeadline(time.Now().Add(c.timeout))
+		c.src, c.dst, c.err = ReadHeader(c.br)
+		c.Conn.SetReadDeadline(time.Time{})
+	})
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	c.init()
+
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	return c.br.Read(b)
+}
+
+// RemoteAddr returns the address of the client as reported by the header,
+// or the address of the peer if there was no header.
+func (c *Conn) RemoteAddr() net.Addr {
+	c.init()
+
+	if c.src != nil {
+		return c.src
+	}
+
+	return c.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the address the client connected to as reported by
+// the header, or the local address if there was no header.
+func (c *Conn) LocalAddr() net.Addr {
+	c.init()
+
+	if c.dst != nil {
+		return c.dst
+	}
+
+	return c.Conn.LocalAddr()
+}
+
+// ReadHeader reads a PROXY protocol header from br and returns the source
+// and destination addresses it contains. If br does not begin with
+// a header, nothing is consumed and nil addresses are returned. Nil addresses
+// are also returned for headers that don't carry addresses, such as
+// v1 UNKNOWN and v2 LOCAL.
+func ReadHeader(br *bufio.Reader) (net.Addr, net.Addr, error) {
+	first, err := br.Peek(1)
+	if err != nil {
+		// An empty connection can't have a header, let the caller see the
+		// EOF on their own read.
+		if err == io.EOF {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	switch first[0] {
+	case sigV1[0]:
+		sig, err := br.Peek(len(sigV1))
+		if err != nil || !bytes.Equal(sig, sigV1) {
+			return nil, nil, nil
+		}
+
+		return readV1(br)
+	case sigV2[0]:
+		sig, err := br.Peek(len(sigV2))
+		if err != nil || !bytes.Equal(sig, sigV2) {
+			return nil, nil, nil
+		}
+
+		return readV2(br)
+	default:
+		return nil, nil, nil
+	}
+}
+
+func readV1(br *bufio.Reader) (net.Addr, net.Addr, error) {
+	var line []byte
+
+	for len(line) < maxV1Length {
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line = append(line, b)
+
+		if b == '\n' {
+			break
+		}
+	}
+
+	str := string(line)
+
+	if !strings.HasSuffix(str, "\r\n") {
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "v1 header not terminated")
+	}
+
+	parts := strings.Split(strings.TrimSuffix(str, "\r\n"), " ")
+
+	if len(parts) < 2 {
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "v1 header missing protocol")
+	}
+
+	switch parts[1] {
+	case "UNKNOWN":
+		return nil, nil, nil
+	case "TCP4", "TCP6":
+		// ok
+	default:
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "unknown v1 protocol: %s", parts[1])
+	}
+
+	if len(parts) != 6 {
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "v1 header has wrong number of fields")
+	}
+
+	src, err := parseV1Addr(parts[2], parts[4])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dst, err := parseV1Addr(parts[3], parts[5])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return src, dst, nil
+}
+
+func parseV1Addr(host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, errors.Wrapf(ErrInvalidHeader, "invalid v1 address: %s", host)
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidHeader, "invalid v1 port: %s", port)
+	}
+
+	return &net.TCPAddr{IP: ip, Port: int(p)}, nil
+}
+
+const (
+	v2CmdLocal = 0x0
+	v2CmdProxy = 0x1
+
+	v2FamInet  = 0x1
+	v2FamInet6 = 0x2
+)
+
+func readV2(br *bufio.Reader) (net.Addr, net.Addr, error) {
+	var hdr [16]byte
+
+	_, err := io.ReadFull(br, hdr[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if hdr[12]>>4 != 0x2 {
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "unsupported v2 version: %d", hdr[12]>>4)
+	}
+
+	cmd := hdr[12] & 0xf
+	fam := hdr[13] >> 4
+
+	body := make([]byte, binary.BigEndian.Uint16(hdr[14:]))
+
+	_, err = io.ReadFull(br, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch cmd {
+	case v2CmdLocal:
+		return nil, nil, nil
+	case v2CmdProxy:
+		// ok
+	default:
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "unknown v2 command: %d", cmd)
+	}
+
+	var sz int
+
+	switch fam {
+	case v2FamInet:
+		sz = net.IPv4len
+	case v2FamInet6:
+		sz = net.IPv6len
+	default:
+		// Unix sockets and unspecified families carry nothing useful to us.
+		return nil, nil, nil
+	}
+
+	if len(body) < 2*sz+4 {
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "v2 address block too short")
+	}
+
+	src := &net.TCPAddr{
+		IP:   net.IP(body[:sz]),
+		Port: int(binary.BigEndian.Uint16(body[2*sz:])),
+	}
+
+	dst := &net.TCPAddr{
+		IP:   net.IP(body[sz : 2*sz]),
+		Port: int(binary.BigEndian.Uint16(body[2*sz+2:])),
+	}
+
+	return src, dst, nil
+}
@@ -0,0 +1,141 @@
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyProto(t *testing.T) {
+	t.Run("parses a v1 header", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("PROXY TCP4 10.0.0.1 10.0.0.2 5555 443\r\nGET / HTTP/1.1\r\n"))
+
+		src, dst, err := ReadHeader(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "10.0.0.1:5555", src.String())
+		assert.Equal(t, "10.0.0.2:443", dst.String())
+
+		rest, err := ioutil.ReadAll(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "GET / HTTP/1.1\r\n", string(rest))
+	})
+
+	t.Run("parses a v1 ipv6 header", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("PROXY TCP6 2001:db8::1 2001:db8::2 5555 443\r\n"))
+
+		src, _, err := ReadHeader(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[2001:db8::1]:5555", src.String())
+	})
+
+	t.Run("ignores v1 unknown headers", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("PROXY UNKNOWN\r\nhello"))
+
+		src, dst, err := ReadHeader(br)
+		require.NoError(t, err)
+
+		assert.Nil(t, src)
+		assert.Nil(t, dst)
+
+		rest, err := ioutil.ReadAll(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", string(rest))
+	})
+
+	t.Run("rejects malformed v1 headers", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("PROXY TCP4 nope 10.0.0.2 5555 443\r\n"))
+
+		_, _, err := ReadHeader(br)
+		assert.Error(t, err)
+
+		br = bufio.NewReader(strings.NewReader("PROXY TCP4 10.0.0.1 10.0.0.2 5555 443" + strings.Repeat(" ", 100)))
+
+		_, _, err = ReadHeader(br)
+		assert.Error(t, err)
+	})
+
+	t.Run("parses a v2 header", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		buf.Write(sigV2)
+		buf.WriteByte(0x21)
+		buf.WriteByte(0x11)
+		binary.Write(&buf, binary.BigEndian, uint16(12))
+		buf.Write(net.ParseIP("10.0.0.1").To4())
+		buf.Write(net.ParseIP("10.0.0.2").To4())
+		binary.Write(&buf, binary.BigEndian, uint16(5555))
+		binary.Write(&buf, binary.BigEndian, uint16(443))
+		buf.WriteString("hello")
+
+		br := bufio.NewReader(&buf)
+
+		src, dst, err := ReadHeader(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "10.0.0.1:5555", src.String())
+		assert.Equal(t, "10.0.0.2:443", dst.String())
+
+		rest, err := ioutil.ReadAll(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", string(rest))
+	})
+
+	t.Run("passes through connections without a header", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("POST / HTTP/1.1\r\n"))
+
+		src, dst, err := ReadHeader(br)
+		require.NoError(t, err)
+
+		assert.Nil(t, src)
+		assert.Nil(t, dst)
+
+		rest, err := ioutil.ReadAll(br)
+		require.NoError(t, err)
+
+		assert.Equal(t, "POST / HTTP/1.1\r\n", string(rest))
+	})
+
+	t.Run("reports the client address from the listener", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		pl := NewListener(l)
+
+		go func() {
+			c, err := net.Dial("tcp", l.Addr().String())
+			if err != nil {
+				return
+			}
+
+			defer c.Close()
+
+			c.Write([]byte("PROXY TCP4 192.0.2.1 10.0.0.2 5555 443\r\nhello"))
+		}()
+
+		conn, err := pl.Accept()
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		assert.Equal(t, "192.0.2.1:5555", conn.RemoteAddr().String())
+
+		data, err := ioutil.ReadAll(conn)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", string(data))
+	})
+}
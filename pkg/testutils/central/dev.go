@@ -8,10 +8,6 @@ import (
 	"os"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/horizon/internal/testsql"
 	"github.com/hashicorp/horizon/pkg/control"
 	"github.com/hashicorp/horizon/pkg/grpc/lz4"
@@ -39,27 +35,14 @@ type DevSetup struct {
 	Account        *pb.Account
 	MgmtCtx        context.Context
 	ClientListener net.Listener
-	AwsSession     *session.Session
-	S3Bucket       string
+	ObjectStore    control.ObjectStore
 	HubServToken   string
 }
 
 func Dev(t testing.T, f func(setup *DevSetup)) {
 	vc := testutils.SetupVault()
 
-	sess := session.New(aws.NewConfig().
-		WithEndpoint("http://localhost:4566").
-		WithRegion("us-east-1").
-		WithCredentials(credentials.NewStaticCredentials("hzn", "hzn", "hzn")).
-		WithS3ForcePathStyle(true),
-	)
-
-	bucket := "hzntest-" + pb.NewULID().SpecString()
-	s3.New(sess).CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucket),
-	})
-
-	defer testutils.DeleteBucket(s3.New(sess), bucket)
+	store := control.NewMemObjectStore()
 
 	db := testsql.TestPostgresDB(t, "hzn_test")
 	defer db.Close()
@@ -70,8 +53,7 @@ func Dev(t testing.T, f func(setup *DevSetup)) {
 		VaultPath:         pb.NewULID().SpecString(),
 		KeyId:             "k1",
 		RegisterToken:     "aabbcc",
-		AwsSession:        sess,
-		Bucket:            bucket,
+		ObjectStore:       store,
 		DisablePrometheus: true,
 	})
 	require.NoError(t, err)
@@ -180,13 +162,12 @@ func Dev(t testing.T, f func(setup *DevSetup)) {
 	defer os.RemoveAll(tmpdir)
 
 	client, err := control.NewClient(ctx, control.ClientConfig{
-		Id:       id,
-		Token:    ctr.Token,
-		Version:  "test",
-		Client:   gClient,
-		Session:  sess,
-		S3Bucket: bucket,
-		WorkDir:  tmpdir,
+		Id:          id,
+		Token:       ctr.Token,
+		Version:     "test",
+		Client:      gClient,
+		ObjectStore: store,
+		WorkDir:     tmpdir,
 	})
 
 	require.NoError(t, err)
@@ -218,8 +199,7 @@ func Dev(t testing.T, f func(setup *DevSetup)) {
 		},
 		MgmtCtx:        metadata.NewIncomingContext(top, md2),
 		ClientListener: ln,
-		AwsSession:     sess,
-		S3Bucket:       bucket,
+		ObjectStore:    store,
 		HubServToken:   hubServToken.Token,
 	})
 }
@@ -245,13 +225,12 @@ func (s *DevSetup) NewControlClient(t testing.T, f func(c *control.Client, li ne
 	defer os.RemoveAll(tmpdir)
 
 	client, err := control.NewClient(s.Top, control.ClientConfig{
-		Id:       id,
-		Token:    s.HubToken,
-		Version:  "test",
-		Client:   gClient,
-		Session:  s.AwsSession,
-		S3Bucket: s.S3Bucket,
-		WorkDir:  tmpdir,
+		Id:          id,
+		Token:       s.HubToken,
+		Version:     "test",
+		Client:      gClient,
+		ObjectStore: s.ObjectStore,
+		WorkDir:     tmpdir,
 	})
 
 	require.NoError(t, err)
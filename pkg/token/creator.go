@@ -160,21 +160,7 @@ func (c *TokenCreator) EncodeED25519WithVault(vc *api.Client, path, keyId string
 		return "", err
 	}
 
-	secret, err := vc.Logical().Write(filepath.Join("/transit/sign", path), map[string]interface{}{
-		"input":                base64.StdEncoding.EncodeToString(data),
-		"marshaling_algorithm": "jws",
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	ct, ok := secret.Data["signature"].(string)
-	if !ok {
-		return "", fmt.Errorf("vault response missing ciphertext")
-	}
-
-	sig, err := base64.RawURLEncoding.DecodeString(ct[9:])
+	sig, err := SignWithVault(vc, path, data)
 	if err != nil {
 		return "", err
 	}
@@ -201,3 +187,23 @@ func (c *TokenCreator) EncodeED25519WithVault(vc *api.Client, path, keyId string
 
 	return Armor(buf.Bytes()), nil
 }
+
+// SignWithVault signs data with the ed25519 key at path in vault's transit
+// engine, returning the raw signature.
+func SignWithVault(vc *api.Client, path string, data []byte) ([]byte, error) {
+	secret, err := vc.Logical().Write(filepath.Join("/transit/sign", path), map[string]interface{}{
+		"input":                base64.StdEncoding.EncodeToString(data),
+		"marshaling_algorithm": "jws",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ct, ok := secret.Data["signature"].(string)
+	if !ok {
+		return nil, fmt.Errorf("vault response missing ciphertext")
+	}
+
+	return base64.RawURLEncoding.DecodeString(ct[9:])
+}
@@ -1,9 +1,13 @@
 package web_test
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -19,6 +23,8 @@ import (
 	"github.com/hashicorp/horizon/pkg/web"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 type fakeHTTPService struct {
@@ -62,6 +68,56 @@ func (f *fakeHTTPService) HandleRequest(ctx context.Context, L hclog.Logger, sct
 }
 
 func TestWeb(t *testing.T) {
+	release := make(chan struct{})
+
+	upstream := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stream":
+			fmt.Fprintf(w, "first\n")
+			w.(http.Flusher).Flush()
+
+			<-release
+
+			fmt.Fprintf(w, "second\n")
+		case "/trailers":
+			w.Header().Set("Trailer", "X-Response-Sum")
+
+			data, _ := ioutil.ReadAll(r.Body)
+
+			fmt.Fprintf(w, "got: %s", string(data))
+
+			w.Header().Set("X-Response-Sum", "resp-"+r.Trailer.Get("X-Request-Sum"))
+		case "/grpc":
+			w.Header().Set("Trailer", "Grpc-Status")
+			w.Header().Set("Content-Type", "application/grpc")
+
+			data, _ := ioutil.ReadAll(r.Body)
+
+			fmt.Fprintf(w, "%s over HTTP/%d", string(data), r.ProtoMajor)
+
+			w.Header().Set("Grpc-Status", "0")
+		case "/echo":
+			w.Header().Set("Content-Type", "application/grpc")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+
+			// Each line is answered before the next is read.
+			br := bufio.NewReader(r.Body)
+
+			for {
+				line, err := br.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				fmt.Fprintf(w, "echo: %s", line)
+				w.(http.Flusher).Flush()
+			}
+		}
+	}), &http2.Server{}))
+
+	defer upstream.Close()
+
 	central.Dev(t, func(setup *central.DevSetup) {
 		L := hclog.L()
 		hub, err := hub.NewHub(L, setup.ControlClient, setup.HubServToken)
@@ -88,6 +144,13 @@ func TestWeb(t *testing.T) {
 		})
 		require.NoError(t, err)
 
+		_, err = a.AddService(&agent.Service{
+			Type:    "http",
+			Labels:  pb.ParseLabelSet("env=test2"),
+			Handler: agent.HTTPHandler(upstream.URL),
+		})
+		require.NoError(t, err)
+
 		err = a.Start(ctx, discovery.HubConfigs(discovery.HubConfig{
 			Addr:     setup.HubAddr,
 			Insecure: true,
@@ -109,6 +172,17 @@ func TestWeb(t *testing.T) {
 
 		require.NoError(t, err)
 
+		streamName := "stream.localdomain"
+
+		_, err = setup.ControlServer.AddLabelLink(setup.MgmtCtx,
+			&pb.AddLabelLinkRequest{
+				Labels:  pb.ParseLabelSet(":hostname=" + streamName),
+				Account: setup.Account,
+				Target:  pb.ParseLabelSet("env=test2"),
+			})
+
+		require.NoError(t, err)
+
 		time.Sleep(time.Second)
 
 		require.NoError(t, setup.ControlClient.ForceLabelLinkUpdate(ctx, L))
@@ -149,5 +223,180 @@ func TestWeb(t *testing.T) {
 			expected := "this is from the fake service: this is a request"
 			assert.Equal(t, expected, w.Body.String())
 		})
+
+		t.Run("streams the response before it is complete", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			fs := httptest.NewServer(f)
+			defer fs.Close()
+
+			req, err := http.NewRequest("GET", fs.URL+"/stream", nil)
+			require.NoError(t, err)
+
+			req.Host = streamName
+
+			client := &http.Client{Timeout: 10 * time.Second}
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			br := bufio.NewReader(resp.Body)
+
+			line, err := br.ReadString('\n')
+			require.NoError(t, err)
+
+			assert.Equal(t, "first\n", line)
+
+			close(release)
+
+			line, err = br.ReadString('\n')
+			require.NoError(t, err)
+
+			assert.Equal(t, "second\n", line)
+		})
+
+		t.Run("passes trailers in both directions", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			fs := httptest.NewServer(f)
+			defer fs.Close()
+
+			req, err := http.NewRequest("POST", fs.URL+"/trailers", strings.NewReader("some data"))
+			require.NoError(t, err)
+
+			req.Host = streamName
+
+			// Trailers are only sent on chunked requests.
+			req.ContentLength = -1
+			req.Trailer = http.Header{
+				"X-Request-Sum": []string{"abcd"},
+			}
+
+			client := &http.Client{Timeout: 10 * time.Second}
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, "got: some data", string(data))
+			assert.Equal(t, "resp-abcd", resp.Trailer.Get("X-Response-Sum"))
+		})
+
+		t.Run("proxies grpc calls over h2c", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			fs := httptest.NewServer(h2c.NewHandler(f, &http2.Server{}))
+			defer fs.Close()
+
+			req, err := http.NewRequest("POST", fs.URL+"/grpc", strings.NewReader("call"))
+			require.NoError(t, err)
+
+			req.Host = streamName
+			req.Header.Set("Content-Type", "application/grpc")
+			req.Header.Set("Te", "trailers")
+
+			client := &http.Client{
+				Timeout: 10 * time.Second,
+				Transport: &http2.Transport{
+					AllowHTTP: true,
+					DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+						return net.Dial(network, addr)
+					},
+				},
+			}
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, 2, resp.ProtoMajor)
+
+			data, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, "call over HTTP/2", string(data))
+			assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+		})
+
+		t.Run("streams the request and response at the same time over HTTP/2", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			fs := httptest.NewServer(h2c.NewHandler(f, &http2.Server{}))
+			defer fs.Close()
+
+			pr, pw := io.Pipe()
+
+			req, err := http.NewRequest("POST", fs.URL+"/echo", pr)
+			require.NoError(t, err)
+
+			req.Host = streamName
+			req.Header.Set("Content-Type", "application/grpc")
+
+			client := &http.Client{
+				Timeout: 10 * time.Second,
+				Transport: &http2.Transport{
+					AllowHTTP: true,
+					DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+						return net.Dial(network, addr)
+					},
+				},
+			}
+
+			// The response begins before any of the request body is sent.
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			_, err = pw.Write([]byte("one\n"))
+			require.NoError(t, err)
+
+			br := bufio.NewReader(resp.Body)
+
+			line, err := br.ReadString('\n')
+			require.NoError(t, err)
+
+			assert.Equal(t, "echo: one\n", line)
+
+			// The request body is still open, so this only arrives if it's
+			// being streamed.
+			_, err = pw.Write([]byte("two\n"))
+			require.NoError(t, err)
+
+			line, err = br.ReadString('\n')
+			require.NoError(t, err)
+
+			assert.Equal(t, "echo: two\n", line)
+
+			pw.Close()
+		})
+
+		t.Run("reports errors to grpc clients as a grpc status", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "http://nope.localdomain/grpc", strings.NewReader("call"))
+			require.NoError(t, err)
+
+			req.Header.Set("Content-Type", "application/grpc")
+
+			w := httptest.NewRecorder()
+
+			f.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "12", w.Header().Get("Grpc-Status"))
+		})
 	})
 }
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,6 +22,7 @@ import (
 	"github.com/hashicorp/horizon/pkg/wire"
 	servertiming "github.com/mitchellh/go-server-timing"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -35,6 +38,10 @@ var (
 	// the that rate per their account limits (which, at the time of writing
 	// is 5 per second for guests)
 	RequestBurst = 20
+
+	// How often an account that keeps hitting one of its limits is
+	// reported to control, which sends a webhook for each report.
+	RateLimitReportInterval = time.Minute
 )
 
 type HostnameChecker interface {
@@ -52,12 +59,55 @@ type Connector interface {
 }
 
 type ratesPerAccount struct {
+	account *pb.Account
+
 	bandwidth *rate.Limiter
 	requests  *rate.Limiter
 
 	clampValue int
 
 	warn *int64
+
+	// When each limit was last reported, in unix nanoseconds, so that an
+	// account sitting at a limit is reported once per
+	// RateLimitReportInterval rather than on every request.
+	requestsReported  *int64
+	bandwidthReported *int64
+}
+
+// The limits reported to control when they're hit.
+const (
+	LimitHTTPRequests = "http-requests"
+	LimitBandwidth    = "bandwidth"
+)
+
+// reportRateLimit tells control that an account hit one of its limits,
+// unless it was already reported within RateLimitReportInterval. last is
+// the time the limit was last reported.
+func (f *Frontend) reportRateLimit(account *pb.Account, limit string, last *int64) {
+	now := time.Now()
+
+	prev := atomic.LoadInt64(last)
+	if prev != 0 && now.Sub(time.Unix(0, prev)) < RateLimitReportInterval {
+		return
+	}
+
+	// Another request reported it first.
+	if !atomic.CompareAndSwapInt64(last, prev, now.UnixNano()) {
+		return
+	}
+
+	rec := &pb.FlowRecord{
+		RateLimit: &pb.FlowRecord_RateLimit{
+			HubId:      f.client.Id(),
+			Account:    account,
+			Limit:      limit,
+			OccurredAt: pb.NewTimestamp(now),
+		},
+	}
+
+	// Don't hold up the request if the activity stream is backed up.
+	go f.client.SendFlow(rec)
 }
 
 type Frontend struct {
@@ -154,12 +204,12 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if err != nil || target == nil {
 		if deploySpecific {
 			f.L.Error("unable to resolve label link", "error", err, "http-host", req.Host, "lookup-host", host, "deploy-id", deployId)
-			renderError(w, fmt.Sprintf(
+			renderError(w, req, fmt.Sprintf(
 				"no registered application for host: %s (deploy-id: %s)", host, deployId),
 				http.StatusNotFound)
 		} else {
 			f.L.Error("unable to resolve label link", "error", err, "hostname", req.Host)
-			renderError(w, fmt.Sprintf(
+			renderError(w, req, fmt.Sprintf(
 				"no registered application for host: %s", req.Host),
 				http.StatusNotFound)
 		}
@@ -198,10 +248,14 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 
 		rates = &ratesPerAccount{
+			account:    account,
 			bandwidth:  rate.NewLimiter(bwLimit, int(limits.Bandwidth/10)),
 			requests:   rate.NewLimiter(reqLimit, RequestBurst),
 			clampValue: int(limits.Bandwidth / 10),
 			warn:       new(int64),
+
+			requestsReported:  new(int64),
+			bandwidthReported: new(int64),
 		}
 
 		f.rates.Add(account.SpecString(), rates)
@@ -221,9 +275,18 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 		f.L.Info("request limit hit", "target", target.SpecString(), "account", account.SpecString())
 
+		f.reportRateLimit(account, LimitHTTPRequests, rates.requestsReported)
+
 		w.Header().Add("X-Horizon-Endpoint", f.endpointId)
 		w.Header().Add("X-Horizon-Warn", "per request limit exceeded")
 
+		if isGRPC(req) {
+			renderGRPCError(w, fmt.Sprintf(
+				"request exceeded configured limits on this account, retry in %s", delay),
+				http.StatusTooManyRequests)
+			return
+		}
+
 		data, err := httpassets.Asset("error_limit.html")
 		if err != nil {
 			http.Error(w, fmt.Sprintf(
@@ -266,7 +329,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	calc, err := f.client.LookupService(ctx, account, target)
 	if err != nil {
 		f.L.Error("error resolving labels to services", "error", err, "labels", target)
-		renderError(w,
+		renderError(w, req,
 			"service lookup failed: "+err.Error(),
 			http.StatusInternalServerError)
 		return
@@ -277,7 +340,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			"account", account,
 			"target", target,
 		)
-		renderError(w,
+		renderError(w, req,
 			"no deployments for service",
 			http.StatusNotFound)
 		return
@@ -306,7 +369,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	if wctx == nil {
 		f.L.Error("no viable service found", "labels", target, "candidates", len(services))
-		renderError(w,
+		renderError(w, req,
 			"unable to find viable endpoint",
 			http.StatusInternalServerError)
 		return
@@ -314,6 +377,11 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	bt := th.NewMetric("request").Start()
 
+	// Don't return while the request body is still being sent, as net/http
+	// only allows it to be read while the handler is running.
+	var bodyWg sync.WaitGroup
+	defer bodyWg.Wait()
+
 	defer wctx.Close()
 
 	var wreq pb.Request
@@ -322,6 +390,11 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	wreq.Path = req.URL.EscapedPath()
 	wreq.Query = req.URL.RawQuery
 	wreq.Fragment = req.URL.Fragment
+	wreq.RemoteAddr = req.RemoteAddr
+	wreq.Scheme = "http"
+	if req.TLS != nil {
+		wreq.Scheme = "https"
+	}
 	if user, pass, ok := req.BasicAuth(); ok {
 		wreq.Auth = &pb.Auth{
 			User:     user,
@@ -336,18 +409,47 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		})
 	}
 
+	// net/http consumes the Trailer header, so we pass the declared trailers
+	// along so the agent is able to declare them to the service.
+	if len(req.Trailer) > 0 {
+		decl := &pb.Header{Name: "Trailer"}
+
+		for k := range req.Trailer {
+			decl.Value = append(decl.Value, k)
+		}
+
+		wreq.Headers = append(wreq.Headers, decl)
+	}
+
+	wreq.Trailers = true
+
 	err = wctx.WriteMarshal(1, &wreq)
 	if err != nil {
 		f.L.Error("error connecting to service", "error", err, "labels", target)
-		renderError(w,
+		renderError(w, req,
 			"error connecting to service: "+err.Error(),
 			http.StatusInternalServerError)
 		return
 	}
 
-	adapter := wctx.Writer()
-	io.Copy(adapter, req.Body)
-	adapter.Close()
+	// On HTTP/2 the request body is streamed to the service while we wait
+	// on the response so that the service is able to respond before the
+	// request body is complete, such as for bidirectional streaming. HTTP/1
+	// discards the unread body once the response begins, so there the body
+	// is sent first.
+	if req.ProtoMajor >= 2 {
+		bodyWg.Add(1)
+
+		go func() {
+			defer bodyWg.Done()
+			f.sendRequestBody(wctx, req, reqId)
+		}()
+	} else {
+		f.sendRequestBody(wctx, req, reqId)
+	}
+
+	// Unblocks sending the body if we return before it's complete.
+	defer req.Body.Close()
 
 	bt.Stop()
 
@@ -357,7 +459,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	tag, err := wctx.ReadMarshal(&wresp)
 	if err != nil || tag != 1 {
-		renderError(w,
+		renderError(w, req,
 			"error reading response: "+err.Error(),
 			http.StatusInternalServerError)
 		return
@@ -387,11 +489,109 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	w.WriteHeader(int(wresp.Code))
 
-	f.L.Trace("copying request body", "id", reqId)
-	io.Copy(w, &ratedReader{f: f, r: wctx.Reader(), acc: rates})
+	fw := newFlushWriter(w)
+
+	// Get the headers to the client right away, so that streaming responses
+	// are able to begin before any of the body is available.
+	fw.Flush()
+
+	f.L.Trace("copying response body", "id", reqId)
+
+	_, err = io.Copy(fw, &ratedReader{f: f, r: wctx.Reader(), acc: rates})
+	if err != nil {
+		f.L.Debug("error copying response body", "id", reqId, "error", err)
+		return
+	}
+
+	if !wresp.Trailers {
+		return
+	}
+
+	var trailers pb.Trailers
+
+	_, err = wctx.ReadMarshal(&trailers)
+	if err != nil {
+		f.L.Debug("error reading response trailers", "id", reqId, "error", err)
+		return
+	}
+
+	for _, h := range trailers.Headers {
+		for _, v := range h.Value {
+			hdr.Add(http.TrailerPrefix+h.Name, v)
+		}
+	}
+}
+
+// sendRequestBody copies the body of req to the service, followed by any
+// trailers.
+func (f *Frontend) sendRequestBody(wctx wire.Context, req *http.Request, reqId *pb.ULID) {
+	_, err := io.Copy(wctx.Writer(), req.Body)
+	if err != nil {
+		// We don't close the write side here so that the service
+		// doesn't see a truncated body as complete.
+		f.L.Debug("error copying request body", "id", reqId, "error", err)
+		return
+	}
+
+	err = wctx.CloseWrite()
+	if err != nil {
+		f.L.Debug("error closing request body", "id", reqId, "error", err)
+		return
+	}
+
+	var trailers pb.Trailers
+
+	for k, v := range req.Trailer {
+		trailers.Headers = append(trailers.Headers, &pb.Header{
+			Name:  k,
+			Value: v,
+		})
+	}
+
+	err = wctx.WriteMarshal(1, &trailers)
+	if err != nil {
+		f.L.Debug("error sending request trailers", "id", reqId, "error", err)
+	}
 }
 
-func renderError(w http.ResponseWriter, fallback string, code int) {
+// flushWriter flushes each write through to the client so that streamed
+// responses, such as server-sent events, are delivered as they're produced.
+type flushWriter struct {
+	w io.Writer
+	f http.Flusher
+}
+
+func newFlushWriter(w http.ResponseWriter) *flushWriter {
+	fw := &flushWriter{w: w}
+
+	if f, ok := w.(http.Flusher); ok {
+		fw.f = f
+	}
+
+	return fw
+}
+
+func (fw *flushWriter) Write(b []byte) (int, error) {
+	n, err := fw.w.Write(b)
+
+	fw.Flush()
+
+	return n, err
+}
+
+func (fw *flushWriter) Flush() {
+	if fw.f != nil {
+		fw.f.Flush()
+	}
+}
+
+func renderError(w http.ResponseWriter, req *http.Request, fallback string, code int) {
+	// gRPC clients expect errors as a gRPC status rather than an HTTP one.
+	if isGRPC(req) {
+		renderGRPCError(w, fallback, code)
+		return
+	}
+
 	data, err := httpassets.Asset("error.html")
 	if err != nil {
 		http.Error(w, fallback, code)
@@ -402,6 +602,37 @@ func renderError(w http.ResponseWriter, fallback string, code int) {
 	fmt.Fprintf(w, string(data))
 }
 
+// isGRPC indicates if req is a gRPC call. gRPC-Web is excluded because it's
+// usable by clients that don't support trailers.
+func isGRPC(req *http.Request) bool {
+	ct := req.Header.Get("Content-Type")
+
+	return ct == "application/grpc" ||
+		strings.HasPrefix(ct, "application/grpc+") ||
+		strings.HasPrefix(ct, "application/grpc;")
+}
+
+// renderGRPCError writes a trailers-only gRPC response. The errors the
+// frontend generates are failures to reach the service, so they're reported
+// as unavailable, except for unknown hosts and accounts over their limits.
+func renderGRPCError(w http.ResponseWriter, msg string, code int) {
+	status := codes.Unavailable
+
+	switch code {
+	case http.StatusNotFound:
+		status = codes.Unimplemented
+	case http.StatusTooManyRequests:
+		status = codes.ResourceExhausted
+	}
+
+	hdr := w.Header()
+	hdr.Set("Content-Type", "application/grpc")
+	hdr.Set("Grpc-Status", strconv.Itoa(int(status)))
+	hdr.Set("Grpc-Message", url.PathEscape(msg))
+
+	w.WriteHeader(http.StatusOK)
+}
+
 type ratedReader struct {
 	f   *Frontend
 	r   io.Reader
@@ -434,6 +665,7 @@ func (r *ratedReader) Read(b []byte) (int, error) {
 
 	if atomic.CompareAndSwapInt64(r.acc.warn, 0, 1) {
 		r.f.L.Debug("introducing delay to manage bandwidth usage", "delay", res.Delay())
+		r.f.reportRateLimit(r.acc.account, LimitBandwidth, r.acc.bandwidthReported)
 	}
 
 	time.Sleep(res.Delay())
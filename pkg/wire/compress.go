@@ -0,0 +1,299 @@
+package wire
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+	"github.com/pkg/errors"
+)
+
+// Codecs that can compress the streams between agents and hubs.
+const (
+	CompressionNone = "none"
+	CompressionLZ4  = "lz4"
+	CompressionZstd = "zstd"
+)
+
+var ErrUnknownCodec = errors.New("unknown compression codec")
+
+// Compression is a codec and the level it compresses at. A Level of 0 uses
+// the default level of the codec. The level only affects the data sent, so
+// each side of a stream can use a different one.
+type Compression struct {
+	Codec string
+	Level int
+}
+
+// DefaultCompression are the codecs offered to peers when none are
+// configured, most preferred first.
+var DefaultCompression = []Compression{
+	{Codec: CompressionZstd},
+	{Codec: CompressionLZ4},
+}
+
+// The id of each codec in the header written to streams when
+// FeatureStreamCompression is in use.
+var codecIds = map[string]byte{
+	CompressionNone: 0,
+	CompressionLZ4:  1,
+	CompressionZstd: 2,
+}
+
+// ParseCompression parses a list of codecs, most preferred first, each
+// optionally followed by a level, such as "zstd:3,lz4".
+func ParseCompression(str string) ([]Compression, error) {
+	var cs []Compression
+
+	for _, part := range strings.Split(str, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var c Compression
+
+		idx := strings.IndexByte(part, ':')
+		if idx == -1 {
+			c.Codec = part
+		} else {
+			level, err := strconv.Atoi(part[idx+1:])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid level for codec: %s", part)
+			}
+
+			c.Codec = part[:idx]
+			c.Level = level
+		}
+
+		if !KnownCodec(c.Codec) {
+			return nil, errors.Wrapf(ErrUnknownCodec, "codec: %s", c.Codec)
+		}
+
+		cs = append(cs, c)
+	}
+
+	return cs, nil
+}
+
+// KnownCodec returns true if codec can be used on a stream.
+func KnownCodec(codec string) bool {
+	_, ok := codecIds[codec]
+	return ok
+}
+
+// CodecNames returns the names of the codecs in cs, in the same order.
+func CodecNames(cs []Compression) []string {
+	var names []string
+
+	for _, c := range cs {
+		names = append(names, c.Codec)
+	}
+
+	return names
+}
+
+// NegotiateCompression returns the first of the codecs preferred by a peer
+// that is also in local, at the level configured in local. If there is no
+// such codec, streams are not compressed.
+func NegotiateCompression(local []Compression, preferred []string) Compression {
+	for _, name := range preferred {
+		for _, c := range local {
+			if c.Codec == name {
+				return c
+			}
+		}
+	}
+
+	return Compression{Codec: CompressionNone}
+}
+
+// WithCodec returns the compression to use for codec. The level of c is
+// kept if it's for the same codec.
+func (c Compression) WithCodec(codec string) Compression {
+	if codec == c.Codec {
+		return c
+	}
+
+	return Compression{Codec: codec}
+}
+
+// Enabled returns true if c compresses data.
+func (c Compression) Enabled() bool {
+	return c.Codec != "" && c.Codec != CompressionNone
+}
+
+// CompressedStream reads and writes a stream through a codec.
+type CompressedStream struct {
+	io.Reader
+	io.Writer
+
+	stream  io.Closer
+	flush   flusher
+	release []func()
+}
+
+// Flush writes any data buffered by the codec to the stream, so that the
+// peer can read it. FramingWriter calls it at the end of each frame.
+func (s *CompressedStream) Flush() error {
+	if s.flush == nil {
+		return nil
+	}
+
+	return s.flush.Flush()
+}
+
+// Close releases the resources held by the codec and closes the stream.
+func (s *CompressedStream) Close() error {
+	for _, f := range s.release {
+		f()
+	}
+
+	s.release = nil
+
+	return s.stream.Close()
+}
+
+// Wrap returns stream compressed with c.
+func (c Compression) Wrap(stream io.ReadWriteCloser) (*CompressedStream, error) {
+	cs := &CompressedStream{
+		Reader: stream,
+		Writer: stream,
+		stream: stream,
+	}
+
+	switch c.Codec {
+	case "", CompressionNone:
+		// ok
+	case CompressionLZ4:
+		w := lz4.NewWriter(stream)
+		w.CompressionLevel = c.Level
+
+		cs.Reader = lz4.NewReader(stream)
+		cs.Writer = w
+		cs.flush = w
+	case CompressionZstd:
+		enc, err := getZstdEncoder(c.Level, stream)
+		if err != nil {
+			return nil, err
+		}
+
+		dec, err := zstd.NewReader(stream,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderLowmem(true),
+		)
+		if err != nil {
+			putZstdEncoder(c.Level, enc)
+			return nil, err
+		}
+
+		cs.Reader = dec
+		cs.Writer = enc
+		cs.flush = enc
+		cs.release = []func(){
+			func() {
+				// Ends the frame so the peer sees a clean EOF.
+				enc.Close()
+				putZstdEncoder(c.Level, enc)
+			},
+			dec.Close,
+		}
+	default:
+		return nil, errors.Wrapf(ErrUnknownCodec, "codec: %s", c.Codec)
+	}
+
+	return cs, nil
+}
+
+// OpenCompressedStream prepares a stream opened to a peer to be compressed
+// with c. If header is set, which should be the case when the peers agreed
+// on FeatureStreamCompression, the codec is first written to the stream so
+// that the peer uses it rather than the codec of the session.
+func OpenCompressedStream(stream io.ReadWriteCloser, c Compression, header bool) (*CompressedStream, error) {
+	if header {
+		codec := c.Codec
+		if codec == "" {
+			codec = CompressionNone
+		}
+
+		id, ok := codecIds[codec]
+		if !ok {
+			return nil, errors.Wrapf(ErrUnknownCodec, "codec: %s", c.Codec)
+		}
+
+		_, err := stream.Write([]byte{id})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Wrap(stream)
+}
+
+// AcceptCompressedStream prepares a stream opened by a peer. If header is
+// set, the codec is read from the stream. Otherwise session, the codec of
+// the session, is used.
+func AcceptCompressedStream(stream io.ReadWriteCloser, session Compression, header bool) (*CompressedStream, error) {
+	if !header {
+		return session.Wrap(stream)
+	}
+
+	var buf [1]byte
+
+	_, err := io.ReadFull(stream, buf[:])
+	if err != nil {
+		return nil, err
+	}
+
+	for codec, id := range codecIds {
+		if id == buf[0] {
+			return session.WithCodec(codec).Wrap(stream)
+		}
+	}
+
+	return nil, errors.Wrapf(ErrUnknownCodec, "codec id: %d", buf[0])
+}
+
+// Creating zstd encoders is expensive, so they are reused across streams.
+var (
+	zstdMu       sync.Mutex
+	zstdEncoders = make(map[int]*sync.Pool)
+)
+
+func zstdPool(level int) *sync.Pool {
+	zstdMu.Lock()
+	defer zstdMu.Unlock()
+
+	pool, ok := zstdEncoders[level]
+	if !ok {
+		pool = &sync.Pool{}
+		zstdEncoders[level] = pool
+	}
+
+	return pool
+}
+
+func getZstdEncoder(level int, w io.Writer) (*zstd.Encoder, error) {
+	if enc, ok := zstdPool(level).Get().(*zstd.Encoder); ok {
+		enc.Reset(w)
+		return enc, nil
+	}
+
+	opts := []zstd.EOption{
+		zstd.WithEncoderConcurrency(1),
+	}
+
+	if level != 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+
+	return zstd.NewWriter(w, opts...)
+}
+
+func putZstdEncoder(level int, enc *zstd.Encoder) {
+	enc.Reset(nil)
+	zstdPool(level).Put(enc)
+}
@@ -0,0 +1,276 @@
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/yamux"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func streamPair(t testing.TB) (*yamux.Stream, *yamux.Stream) {
+	cc, sc := net.Pipe()
+
+	client, err := yamux.Client(cc, nil)
+	require.NoError(t, err)
+
+	server, err := yamux.Server(sc, nil)
+	require.NoError(t, err)
+
+	opened, err := client.OpenStream()
+	require.NoError(t, err)
+
+	accepted, err := server.AcceptStream()
+	require.NoError(t, err)
+
+	return opened, accepted
+}
+
+func TestCompression(t *testing.T) {
+	t.Run("picks the codec the peer prefers most", func(t *testing.T) {
+		local := []Compression{
+			{Codec: CompressionLZ4, Level: 4},
+			{Codec: CompressionZstd, Level: 3},
+		}
+
+		c := NegotiateCompression(local, []string{"brotli", CompressionZstd, CompressionLZ4})
+		assert.Equal(t, Compression{Codec: CompressionZstd, Level: 3}, c)
+
+		c = NegotiateCompression(local, []string{"brotli"})
+		assert.Equal(t, CompressionNone, c.Codec)
+		assert.False(t, c.Enabled())
+	})
+
+	t.Run("parses codecs with levels", func(t *testing.T) {
+		cs, err := ParseCompression("zstd:3, lz4,none")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Compression{
+			{Codec: CompressionZstd, Level: 3},
+			{Codec: CompressionLZ4},
+			{Codec: CompressionNone},
+		}, cs)
+
+		_, err = ParseCompression("brotli")
+		assert.Equal(t, ErrUnknownCodec, errors.Cause(err))
+
+		_, err = ParseCompression("zstd:fast")
+		assert.Error(t, err)
+	})
+
+	for _, codec := range []string{CompressionNone, CompressionLZ4, CompressionZstd} {
+		t.Run(fmt.Sprintf("exchanges messages using %s", codec), func(t *testing.T) {
+			opened, accepted := streamPair(t)
+
+			c := Compression{Codec: codec}
+
+			ocs, err := OpenCompressedStream(opened, c, false)
+			require.NoError(t, err)
+
+			defer ocs.Close()
+
+			acs, err := AcceptCompressedStream(accepted, c, false)
+			require.NoError(t, err)
+
+			defer acs.Close()
+
+			fw, err := NewFramingWriter(ocs)
+			require.NoError(t, err)
+
+			mb := MarshalBytes("hello hzn!")
+			_, err = fw.WriteMarshal(30, &mb)
+			require.NoError(t, err)
+
+			fr, err := NewFramingReader(acs)
+			require.NoError(t, err)
+
+			var out MarshalBytes
+
+			tag, _, err := fr.ReadMarshal(&out)
+			require.NoError(t, err)
+
+			assert.Equal(t, byte(30), tag)
+			assert.Equal(t, "hello hzn!", string(out))
+		})
+	}
+
+	for _, codec := range []string{CompressionLZ4, CompressionZstd} {
+		t.Run(fmt.Sprintf("flushes each frame using %s", codec), func(t *testing.T) {
+			// A pipe has no buffering, so a frame left in the encoder is
+			// never read.
+			cc, sc := net.Pipe()
+
+			c := Compression{Codec: codec}
+
+			ocs, err := c.Wrap(cc)
+			require.NoError(t, err)
+
+			defer ocs.Close()
+
+			acs, err := c.Wrap(sc)
+			require.NoError(t, err)
+
+			defer acs.Close()
+
+			fw, err := NewFramingWriter(ocs)
+			require.NoError(t, err)
+
+			fr, err := NewFramingReader(acs)
+			require.NoError(t, err)
+
+			msgs := []string{"one", "two", "three", "four"}
+
+			errs := make(chan error, 1)
+
+			go func() {
+				for _, msg := range msgs {
+					mb := MarshalBytes(msg)
+
+					_, err := fw.WriteMarshal(30, &mb)
+					if err != nil {
+						errs <- err
+						return
+					}
+				}
+
+				errs <- nil
+			}()
+
+			for _, msg := range msgs {
+				var out MarshalBytes
+
+				tag, _, err := fr.ReadMarshal(&out)
+				require.NoError(t, err)
+
+				assert.Equal(t, byte(30), tag)
+				assert.Equal(t, msg, string(out))
+			}
+
+			require.NoError(t, <-errs)
+		})
+	}
+
+	t.Run("uses the codec sent at the start of the stream", func(t *testing.T) {
+		opened, accepted := streamPair(t)
+
+		ocs, err := OpenCompressedStream(opened, Compression{Codec: CompressionNone}, true)
+		require.NoError(t, err)
+
+		defer ocs.Close()
+
+		// The session would otherwise compress with lz4.
+		acs, err := AcceptCompressedStream(accepted, Compression{Codec: CompressionLZ4}, true)
+		require.NoError(t, err)
+
+		defer acs.Close()
+
+		_, err = ocs.Write([]byte("plain"))
+		require.NoError(t, err)
+
+		buf := make([]byte, 5)
+
+		_, err = io.ReadFull(acs, buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, "plain", string(buf))
+	})
+
+	t.Run("rejects unknown codecs", func(t *testing.T) {
+		opened, accepted := streamPair(t)
+
+		_, err := OpenCompressedStream(opened, Compression{Codec: "brotli"}, true)
+		assert.Equal(t, ErrUnknownCodec, errors.Cause(err))
+
+		_, err = opened.Write([]byte{99})
+		require.NoError(t, err)
+
+		_, err = AcceptCompressedStream(accepted, Compression{Codec: CompressionLZ4}, true)
+		assert.Equal(t, ErrUnknownCodec, errors.Cause(err))
+	})
+}
+
+// discardStream is a stream that counts and discards the bytes written to
+// it.
+type discardStream struct {
+	n int64
+}
+
+func (d *discardStream) Write(b []byte) (int, error) {
+	d.n += int64(len(b))
+	return len(b), nil
+}
+
+func (d *discardStream) Read(b []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (d *discardStream) Close() error {
+	return nil
+}
+
+// BenchmarkCompression compares the CPU used by each codec with the bytes
+// it saves, reported as the ratio of the compressed size to the original.
+// Random data stands in for payloads that are already compressed.
+func BenchmarkCompression(b *testing.B) {
+	text := bytes.Repeat([]byte(`{"service":"api","labels":["env=prod","app=web"],"status":200}`+"\n"), 1024)
+
+	random := make([]byte, len(text))
+	rand.New(rand.NewSource(0)).Read(random)
+
+	payloads := []struct {
+		name string
+		data []byte
+	}{
+		{"text", text},
+		{"random", random},
+	}
+
+	codecs := []Compression{
+		{Codec: CompressionNone},
+		{Codec: CompressionLZ4},
+		{Codec: CompressionLZ4, Level: 9},
+		{Codec: CompressionZstd, Level: 1},
+		{Codec: CompressionZstd},
+		{Codec: CompressionZstd, Level: 11},
+	}
+
+	for _, payload := range payloads {
+		for _, c := range codecs {
+			name := fmt.Sprintf("%s/%s-%d", payload.name, c.Codec, c.Level)
+
+			b.Run(name, func(b *testing.B) {
+				var out discardStream
+
+				cs, err := c.Wrap(&out)
+				require.NoError(b, err)
+
+				fw, err := NewFramingWriter(cs)
+				require.NoError(b, err)
+
+				mb := MarshalBytes(payload.data)
+
+				b.SetBytes(int64(len(payload.data)))
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					_, err = fw.WriteMarshal(30, &mb)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				b.StopTimer()
+
+				cs.Close()
+
+				b.ReportMetric(float64(out.n)/float64(int64(len(payload.data))*int64(b.N)), "ratio")
+			})
+		}
+	}
+}
@@ -18,9 +18,16 @@ type Context interface {
 	// Forwards any data between the 2 contexts
 	BridgeTo(other Context) error
 
-	// Returns a writer that will send traffic as framed messages
+	// Returns a writer that will send traffic as framed messages. Closing
+	// the writer is the same as calling CloseWrite.
 	Writer() io.WriteCloser
 
+	// Signals to the remote side that no more data will be sent via Writer,
+	// which the remote side sees as EOF from Reader. Data can still be read
+	// and structured messages can still be written with WriteMarshal, allowing
+	// for messages such as trailers to be sent after the data.
+	CloseWrite() error
+
 	// Returns a reader that recieves traffic as framed messages
 	Reader() io.Reader
 
@@ -42,6 +49,8 @@ type ctx struct {
 	messages *int64
 	bytes    *int64
 
+	writeClosed int32
+
 	closers []func() error
 }
 
@@ -130,7 +139,36 @@ func (c *ctx) WriteMarshal(tag byte, v Marshaller) error {
 }
 
 func (c *ctx) Writer() io.WriteCloser {
-	return c.fw.WriteAdapter()
+	return &ctxWriter{WriteAdapter: c.fw.WriteAdapter(), c: c}
+}
+
+type ctxWriter struct {
+	*WriteAdapter
+	c *ctx
+}
+
+func (w *ctxWriter) Write(b []byte) (int, error) {
+	if atomic.LoadInt32(&w.c.writeClosed) == 1 {
+		return 0, ErrWriteClosed
+	}
+
+	return w.WriteAdapter.Write(b)
+}
+
+func (w *ctxWriter) Close() error {
+	return w.c.CloseWrite()
+}
+
+var ErrWriteClosed = errors.New("write side of context closed")
+
+func (c *ctx) CloseWrite() error {
+	// Only the first close sends the EOF, so that the remote side doesn't
+	// see extra EOFs where it expects structured messages.
+	if !atomic.CompareAndSwapInt32(&c.writeClosed, 0, 1) {
+		return nil
+	}
+
+	return c.fw.WriteFrame(adaptTagEOF, 0)
 }
 
 func (c *ctx) Reader() io.Reader {
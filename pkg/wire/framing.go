@@ -102,6 +102,25 @@ type Unmarshaller interface {
 
 var ErrRemoteError = errors.New("remote error detected")
 
+// RemoteError is returned by ReadMarshal when the remote side sent an error
+// Response. It matches ErrRemoteError with errors.Is.
+type RemoteError struct {
+	Code    int32
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return ErrRemoteError.Error() + ": " + e.Message
+}
+
+func (e *RemoteError) Cause() error {
+	return ErrRemoteError
+}
+
+func (e *RemoteError) Unwrap() error {
+	return ErrRemoteError
+}
+
 func (f *FramingReader) ReadMarshal(v Unmarshaller) (byte, int, error) {
 	tag, sz, err := f.Next()
 	if err != nil {
@@ -123,7 +142,7 @@ func (f *FramingReader) ReadMarshal(v Unmarshaller) (byte, int, error) {
 	if tag == 255 {
 		var resp pb.Response
 		resp.Unmarshal(buf[:sz])
-		return 0, 0, errors.Wrapf(ErrRemoteError, resp.Error)
+		return 0, 0, &RemoteError{Code: resp.Code, Message: resp.Error}
 	}
 
 	err = v.Unmarshal(buf[:sz])
@@ -185,12 +204,24 @@ func (f *FramingWriter) WriteFrame(tag byte, size int) error {
 	f.bw.Write(b)
 
 	if size == 0 {
-		f.bw.Flush()
-		if f.flush != nil {
-			f.flush.Flush()
-		}
-	} else {
-		f.writeLeft = size
+		return f.flushFrame()
+	}
+
+	f.writeLeft = size
+
+	return nil
+}
+
+// flushFrame sends the buffered frame on to the underlying writer, and
+// flushes that too if it buffers.
+func (f *FramingWriter) flushFrame() error {
+	err := f.bw.Flush()
+	if err != nil {
+		return err
+	}
+
+	if f.flush != nil {
+		return f.flush.Flush()
 	}
 
 	return nil
@@ -215,10 +246,7 @@ func (f *FramingWriter) Write(b []byte) (int, error) {
 	f.writeLeft -= n
 
 	if f.writeLeft == 0 {
-		err = f.bw.Flush()
-		if f.flush != nil {
-			f.flush.Flush()
-		}
+		err = f.flushFrame()
 	}
 
 	return n, err
@@ -248,6 +276,11 @@ func (f *FramingWriter) WriteMarshal(tag byte, v Marshaller) (int, error) {
 		return 0, err
 	}
 
+	// Empty messages are just the frame, which WriteFrame has sent.
+	if sz == 0 {
+		return 0, nil
+	}
+
 	return f.Write(buf[:sz])
 }
 
@@ -2,6 +2,7 @@ package wire
 
 import (
 	bytes "bytes"
+	"errors"
 	"io"
 	"io/ioutil"
 	"testing"
@@ -48,4 +49,58 @@ func TestFraming(t *testing.T) {
 
 		assert.Equal(t, byte(30), tag)
 	})
+	t.Run("handles empty messages", func(t *testing.T) {
+		var out bytes.Buffer
+
+		fw, err := NewFramingWriter(&out)
+		require.NoError(t, err)
+
+		_, err = fw.WriteMarshal(1, &pb.ServicesQuery{})
+		require.NoError(t, err)
+
+		var sid pb.SessionIdentification
+		sid.ProtocolId = "blah"
+
+		_, err = fw.WriteMarshal(2, &sid)
+		require.NoError(t, err)
+
+		fr, err := NewFramingReader(&out)
+		require.NoError(t, err)
+
+		var q pb.ServicesQuery
+
+		tag, _, err := fr.ReadMarshal(&q)
+		require.NoError(t, err)
+
+		assert.Equal(t, byte(1), tag)
+
+		var sid2 pb.SessionIdentification
+
+		tag, _, err = fr.ReadMarshal(&sid2)
+		require.NoError(t, err)
+
+		assert.Equal(t, byte(2), tag)
+		assert.Equal(t, "blah", sid2.ProtocolId)
+	})
+	t.Run("returns errors flushing the writer", func(t *testing.T) {
+		fw, err := NewFramingWriter(&failFlusher{})
+		require.NoError(t, err)
+
+		_, err = fw.WriteMarshal(1, &pb.ServicesQuery{})
+		assert.Equal(t, errFlush, err)
+
+		mb := MarshalBytes("hello hzn!")
+		_, err = fw.WriteMarshal(2, &mb)
+		assert.Equal(t, errFlush, err)
+	})
+}
+
+var errFlush = errors.New("flush failed")
+
+type failFlusher struct {
+	bytes.Buffer
+}
+
+func (f *failFlusher) Flush() error {
+	return errFlush
 }
@@ -2,105 +2,314 @@ package wire
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/pb"
-	"github.com/hashicorp/yamux"
 	"github.com/pkg/errors"
 )
 
+// An RPC runs over its own stream. The client starts it by sending an
+// RPCRequest tagged with RPCRequestTag, followed by any number of messages
+// and then a marker that it has finished sending. The server replies with
+// any number of messages and ends the RPC by sending an RPCStatus.
+
+// RPCRequestTag is the tag of the RPCRequest that starts an RPC on a stream.
+const RPCRequestTag = 3
+
+const (
+	rpcTag          = 20
+	rpcCloseSendTag = 21
+	rpcStatusTag    = 22
+)
+
+var ErrUnknownMethod = errors.New("unknown method requested")
+
+// RPCError is the error returned to a client for an RPC that failed. A
+// handler can return one to control the status the client sees.
+type RPCError struct {
+	// The equivalent HTTP status of the error.
+	Code    int32
+	Message string
+	Details []*pb.KVPair
+}
+
+// NewRPCError returns an RPCError with the given code and message.
+func NewRPCError(code int, format string, args ...interface{}) *RPCError {
+	return &RPCError{
+		Code:    int32(code),
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error (%d): %s", e.Code, e.Message)
+}
+
+// rpcStatus converts the result of a handler into the status sent to the
+// client.
+func rpcStatus(err error) *pb.RPCStatus {
+	if err == nil {
+		return &pb.RPCStatus{Code: http.StatusOK}
+	}
+
+	var re *RPCError
+	if errors.As(err, &re) {
+		return &pb.RPCStatus{
+			Code:    re.Code,
+			Message: re.Message,
+			Details: re.Details,
+		}
+	}
+
+	code := http.StatusInternalServerError
+
+	switch errors.Cause(err) {
+	case ErrUnknownMethod:
+		code = http.StatusNotFound
+	case context.DeadlineExceeded:
+		code = http.StatusGatewayTimeout
+	}
+
+	return &pb.RPCStatus{
+		Code:    int32(code),
+		Message: err.Error(),
+	}
+}
+
 type RPCClient struct {
-	stream *yamux.Stream
+	r      io.Reader
+	w      io.Writer
+	closer io.Closer
 }
 
-const rpcTag = 20
+// NewRPCClient returns a client that makes an RPC over a stream, reading
+// from r and writing to w. The stream carries a single RPC and closer,
+// which can be nil, is used to close it.
+func NewRPCClient(r io.Reader, w io.Writer, closer io.Closer) *RPCClient {
+	return &RPCClient{
+		r:      r,
+		w:      w,
+		closer: closer,
+	}
+}
 
-func (r *RPCClient) Begin(host, path string, req Marshaller) (RPCContext, error) {
-	var wreq pb.Request
-	wreq.Type = pb.RPC
-	wreq.Path = path
-	wreq.Host = host
+// Close closes the stream used by the client.
+func (r *RPCClient) Close() error {
+	if r.closer == nil {
+		return nil
+	}
 
-	fw, err := NewFramingWriter(r.stream)
-	if err != nil {
-		return nil, err
+	return r.closer.Close()
+}
+
+// Stream starts the RPC for the method at host and path, returning an
+// RPCStream to exchange messages with the server. The deadline of ctx is
+// sent to the server, and the stream is closed if ctx is done before the
+// RPC ends.
+func (r *RPCClient) Stream(ctx context.Context, host, path string) (*RPCStream, error) {
+	req := pb.RPCRequest{
+		Host: host,
+		Path: path,
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Timeout = int64(time.Until(deadline))
+		if req.Timeout <= 0 {
+			return nil, context.DeadlineExceeded
+		}
 	}
 
-	_, err = fw.WriteMarshal(1, &wreq)
+	fw, err := NewFramingWriter(r.w)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = fw.WriteMarshal(rpcTag, req)
+	_, err = fw.WriteMarshal(RPCRequestTag, &req)
 	if err != nil {
 		return nil, err
 	}
 
-	fr, err := NewFramingReader(r.stream)
+	fr, err := NewFramingReader(r.r)
 	if err != nil {
 		return nil, err
 	}
 
-	return &rpcCtx{
-		Context: &ctx{
-			fr: fr,
-			fw: fw,
-		},
-	}, nil
+	s := &RPCStream{
+		ctx:    ctx,
+		client: r,
+		fr:     fr,
+		fw:     fw,
+		done:   make(chan struct{}),
+	}
+
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				r.Close()
+			case <-s.done:
+			}
+		}()
+	}
+
+	return s, nil
 }
 
+// Call makes an RPC that sends req and receives a single message into resp.
 func (r *RPCClient) Call(host, path string, req Marshaller, resp Unmarshaller) error {
-	var wreq pb.Request
-	wreq.Type = pb.RPC
-	wreq.Path = "/query/peers"
-	wreq.Host = "agents.edge"
+	return r.CallContext(context.Background(), host, path, req, resp)
+}
 
-	fw, err := NewFramingWriter(r.stream)
+// CallContext is like Call, using ctx for the deadline of the RPC.
+func (r *RPCClient) CallContext(ctx context.Context, host, path string, req Marshaller, resp Unmarshaller) error {
+	s, err := r.Stream(ctx, host, path)
 	if err != nil {
 		return err
 	}
 
-	defer fw.Recycle()
+	defer s.finish()
 
-	_, err = fw.WriteMarshal(1, &wreq)
+	err = s.Send(req)
 	if err != nil {
 		return err
 	}
 
-	_, err = fw.WriteMarshal(rpcTag, req)
+	err = s.CloseSend()
 	if err != nil {
 		return err
 	}
 
-	fr, err := NewFramingReader(r.stream)
+	err = s.Recv(resp)
 	if err != nil {
+		if err == io.EOF {
+			return errors.Wrapf(ErrProtocolError, "rpc ended without a response")
+		}
+
 		return err
 	}
 
-	defer fr.Recycle()
+	var extra MarshalBytes
 
-	tag, _, err := fr.ReadMarshal(resp)
-	if err != nil {
+	err = s.Recv(&extra)
+	switch err {
+	case io.EOF:
+		return nil
+	case nil:
+		return errors.Wrapf(ErrProtocolError, "rpc sent more than one response")
+	default:
 		return err
 	}
+}
 
-	if tag != rpcTag {
+// RPCStream is the client side of an RPC in progress.
+type RPCStream struct {
+	ctx    context.Context
+	client *RPCClient
+	fr     *FramingReader
+	fw     *FramingWriter
+
+	status *pb.RPCStatus
+
+	doneOnce sync.Once
+	done     chan struct{}
+}
+
+// Send sends a message to the server.
+func (s *RPCStream) Send(v Marshaller) error {
+	_, err := s.fw.WriteMarshal(rpcTag, v)
+	return s.checkErr(err)
+}
+
+// CloseSend tells the server that no more messages will be sent.
+func (s *RPCStream) CloseSend() error {
+	var empty MarshalBytes
+
+	_, err := s.fw.WriteMarshal(rpcCloseSendTag, &empty)
+	return s.checkErr(err)
+}
+
+// Recv reads the next message from the server into v. Once the server has
+// ended the RPC, it returns io.EOF if the RPC succeeded or an *RPCError if
+// it failed.
+func (s *RPCStream) Recv(v Unmarshaller) error {
+	if s.status != nil {
+		return s.statusErr()
+	}
+
+	var raw MarshalBytes
+
+	tag, _, err := s.fr.ReadMarshal(&raw)
+	if err != nil {
+		return s.checkErr(err)
+	}
+
+	switch tag {
+	case rpcTag:
+		return v.Unmarshal(raw)
+	case rpcStatusTag:
+		var status pb.RPCStatus
+
+		err = status.Unmarshal(raw)
+		if err != nil {
+			return err
+		}
+
+		s.status = &status
+		s.finish()
+
+		return s.statusErr()
+	default:
 		return errors.Wrapf(ErrProtocolError, "wrong tag recieved: %d", tag)
 	}
+}
 
-	return nil
+// Close abandons the RPC, closing the stream it runs over.
+func (s *RPCStream) Close() error {
+	s.finish()
+	return s.client.Close()
 }
 
-func NewRPCClient(stream *yamux.Stream) *RPCClient {
-	return &RPCClient{
-		stream: stream,
+func (s *RPCStream) statusErr() error {
+	if s.status.Code == http.StatusOK {
+		return io.EOF
 	}
+
+	return &RPCError{
+		Code:    s.status.Code,
+		Message: s.status.Message,
+		Details: s.status.Details,
+	}
+}
+
+// checkErr reports errors caused by the stream being closed because the
+// context is done as the context's error.
+func (s *RPCStream) checkErr(err error) error {
+	if err != nil && s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+
+	return err
+}
+
+func (s *RPCStream) finish() {
+	s.doneOnce.Do(func() {
+		close(s.done)
+	})
 }
 
 type RPCContext interface {
 	Context
+
+	// ReadRequest reads the next message sent by the client. It returns
+	// io.EOF once the client has finished sending.
 	ReadRequest(v Unmarshaller) error
+
+	// WriteResponse sends a message to the client.
 	WriteResponse(v Marshaller) error
 }
 
@@ -108,12 +317,27 @@ type RPCHandler interface {
 	HandleRPC(ctx context.Context, wctx RPCContext) error
 }
 
+// RPCHandlerFunc adapts a function to an RPCHandler.
+type RPCHandlerFunc func(ctx context.Context, wctx RPCContext) error
+
+func (f RPCHandlerFunc) HandleRPC(ctx context.Context, wctx RPCContext) error {
+	return f(ctx, wctx)
+}
+
 type RPCServer struct {
 	mu      sync.RWMutex
 	methods map[string]RPCHandler
 }
 
-func (r *RPCServer) AddMethod(name string, h RPCHandler) {
+// AddMethod registers h to handle RPCs to path on any host.
+func (r *RPCServer) AddMethod(path string, h RPCHandler) {
+	r.AddHostMethod("", path, h)
+}
+
+// AddHostMethod registers h to handle RPCs to path on host. Methods
+// registered for a specific host take precedence over those added with
+// AddMethod.
+func (r *RPCServer) AddHostMethod(host, path string, h RPCHandler) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -121,10 +345,20 @@ func (r *RPCServer) AddMethod(name string, h RPCHandler) {
 		r.methods = make(map[string]RPCHandler)
 	}
 
-	r.methods[name] = h
+	r.methods[host+path] = h
 }
 
-var ErrUnknownMethod = errors.New("unknown method requested")
+func (r *RPCServer) lookup(host, path string) (RPCHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if h, ok := r.methods[host+path]; ok {
+		return h, true
+	}
+
+	h, ok := r.methods[path]
+	return h, ok
+}
 
 type ReadMarshaler interface {
 	ReadMarshal(Unmarshaller) (byte, int, error)
@@ -136,33 +370,62 @@ type WriteMarshaler interface {
 
 type rpcCtx struct {
 	Context
+
+	closeSend bool
 }
 
 func (r *rpcCtx) ReadRequest(v Unmarshaller) error {
-	tag, err := r.Context.ReadMarshal(v)
+	if r.closeSend {
+		return io.EOF
+	}
+
+	var raw MarshalBytes
+
+	tag, err := r.Context.ReadMarshal(&raw)
 	if err != nil {
 		return err
 	}
 
-	if tag != rpcTag {
+	switch tag {
+	case rpcTag:
+		return v.Unmarshal(raw)
+	case rpcCloseSendTag:
+		r.closeSend = true
+		return io.EOF
+	default:
 		return errors.Wrapf(ErrProtocolError, "incorrect tag: %d (expected %d)", tag, rpcTag)
 	}
-
-	return nil
 }
 
 func (r *rpcCtx) WriteResponse(v Marshaller) error {
 	return r.Context.WriteMarshal(rpcTag, v)
 }
 
-func (r *RPCServer) HandleRequest(ctx context.Context, L hclog.Logger, wctx Context, wreq *pb.Request) error {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// HandleRequest runs the handler for the RPC started by req, which the
+// caller has read from wctx, and sends the status of the RPC once the
+// handler returns. The error from the handler is returned so the caller
+// can log it.
+func (r *RPCServer) HandleRequest(ctx context.Context, L hclog.Logger, wctx Context, req *pb.RPCRequest) error {
+	var err error
+
+	handler, ok := r.lookup(req.Host, req.Path)
+	if ok {
+		if req.Timeout > 0 {
+			var cancel func()
+
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(req.Timeout))
+			defer cancel()
+		}
+
+		err = handler.HandleRPC(ctx, &rpcCtx{Context: wctx})
+	} else {
+		err = errors.Wrapf(ErrUnknownMethod, "no handler for method: %s%s", req.Host, req.Path)
+	}
 
-	handler, ok := r.methods[wreq.Path]
-	if !ok {
-		return errors.Wrapf(ErrUnknownMethod, "no handler for method: %s", wreq.Path)
+	serr := wctx.WriteMarshal(rpcStatusTag, rpcStatus(err))
+	if serr != nil {
+		L.Debug("error sending rpc status", "path", req.Path, "error", serr)
 	}
 
-	return handler.HandleRPC(ctx, &rpcCtx{wctx})
+	return err
 }
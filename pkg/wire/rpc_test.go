@@ -0,0 +1,237 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/yamux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveRPC(t *testing.T, srv *RPCServer) *RPCClient {
+	cc, sc := net.Pipe()
+
+	client, err := yamux.Client(cc, nil)
+	require.NoError(t, err)
+
+	server, err := yamux.Server(sc, nil)
+	require.NoError(t, err)
+
+	go func() {
+		defer server.Close()
+
+		stream, err := server.AcceptStream()
+		if err != nil {
+			return
+		}
+
+		defer stream.Close()
+
+		fr, err := NewFramingReader(stream)
+		require.NoError(t, err)
+
+		fw, err := NewFramingWriter(stream)
+		require.NoError(t, err)
+
+		var req pb.RPCRequest
+
+		tag, _, err := fr.ReadMarshal(&req)
+		if err != nil || tag != RPCRequestTag {
+			return
+		}
+
+		srv.HandleRequest(context.Background(), hclog.L(), NewContext(nil, fr, fw), &req)
+	}()
+
+	stream, err := client.OpenStream()
+	require.NoError(t, err)
+
+	return NewRPCClient(stream, stream, client)
+}
+
+func TestRPC(t *testing.T) {
+	var srv RPCServer
+
+	srv.AddMethod("/echo", RPCHandlerFunc(func(ctx context.Context, wctx RPCContext) error {
+		for {
+			var mb MarshalBytes
+
+			err := wctx.ReadRequest(&mb)
+			if err == io.EOF {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			err = wctx.WriteResponse(&mb)
+			if err != nil {
+				return err
+			}
+		}
+	}))
+
+	srv.AddHostMethod("other", "/echo", RPCHandlerFunc(func(ctx context.Context, wctx RPCContext) error {
+		var mb MarshalBytes
+
+		err := wctx.ReadRequest(&mb)
+		if err != nil {
+			return err
+		}
+
+		out := MarshalBytes("other")
+		return wctx.WriteResponse(&out)
+	}))
+
+	srv.AddMethod("/deadline", RPCHandlerFunc(func(ctx context.Context, wctx RPCContext) error {
+		var mb MarshalBytes
+
+		err := wctx.ReadRequest(&mb)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := ctx.Deadline(); !ok {
+			return NewRPCError(http.StatusBadRequest, "no deadline")
+		}
+
+		<-ctx.Done()
+
+		return ctx.Err()
+	}))
+
+	srv.AddMethod("/fail", RPCHandlerFunc(func(ctx context.Context, wctx RPCContext) error {
+		return &RPCError{
+			Code:    http.StatusConflict,
+			Message: "already exists",
+			Details: []*pb.KVPair{{Key: "name", Value: "test"}},
+		}
+	}))
+
+	t.Run("makes unary calls", func(t *testing.T) {
+		client := serveRPC(t, &srv)
+		defer client.Close()
+
+		req := MarshalBytes("hello")
+
+		var resp MarshalBytes
+
+		err := client.Call("hub", "/echo", &req, &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", string(resp))
+	})
+
+	t.Run("routes by host and path", func(t *testing.T) {
+		client := serveRPC(t, &srv)
+		defer client.Close()
+
+		req := MarshalBytes("hello")
+
+		var resp MarshalBytes
+
+		err := client.Call("other", "/echo", &req, &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "other", string(resp))
+	})
+
+	t.Run("returns a status for unknown methods", func(t *testing.T) {
+		client := serveRPC(t, &srv)
+		defer client.Close()
+
+		req := MarshalBytes("hello")
+
+		var resp MarshalBytes
+
+		err := client.Call("hub", "/nope", &req, &resp)
+		require.Error(t, err)
+
+		var re *RPCError
+		require.True(t, errors.As(err, &re))
+
+		assert.Equal(t, int32(http.StatusNotFound), re.Code)
+	})
+
+	t.Run("returns errors with details", func(t *testing.T) {
+		client := serveRPC(t, &srv)
+		defer client.Close()
+
+		req := MarshalBytes("hello")
+
+		var resp MarshalBytes
+
+		err := client.Call("hub", "/fail", &req, &resp)
+		require.Error(t, err)
+
+		var re *RPCError
+		require.True(t, errors.As(err, &re))
+
+		assert.Equal(t, int32(http.StatusConflict), re.Code)
+		assert.Equal(t, "already exists", re.Message)
+
+		require.Equal(t, 1, len(re.Details))
+		assert.Equal(t, "name", re.Details[0].Key)
+	})
+
+	t.Run("propagates the deadline", func(t *testing.T) {
+		client := serveRPC(t, &srv)
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		req := MarshalBytes("hello")
+
+		var resp MarshalBytes
+
+		err := client.CallContext(ctx, "hub", "/deadline", &req, &resp)
+		require.Error(t, err)
+
+		var re *RPCError
+		if errors.As(err, &re) {
+			assert.Equal(t, int32(http.StatusGatewayTimeout), re.Code)
+		} else {
+			assert.Equal(t, context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("streams messages both ways", func(t *testing.T) {
+		client := serveRPC(t, &srv)
+		defer client.Close()
+
+		stream, err := client.Stream(context.Background(), "hub", "/echo")
+		require.NoError(t, err)
+
+		for _, msg := range []string{"a", "b", "c"} {
+			req := MarshalBytes(msg)
+
+			err = stream.Send(&req)
+			require.NoError(t, err)
+
+			var resp MarshalBytes
+
+			err = stream.Recv(&resp)
+			require.NoError(t, err)
+
+			assert.Equal(t, msg, string(resp))
+		}
+
+		err = stream.CloseSend()
+		require.NoError(t, err)
+
+		var resp MarshalBytes
+
+		err = stream.Recv(&resp)
+		assert.Equal(t, io.EOF, err)
+	})
+}
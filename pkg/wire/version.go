@@ -0,0 +1,90 @@
+package wire
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// ProtocolVersion is the version of the protocol spoken between agents and
+// hubs, exchanged in the Preamble and Confirmation. Peers that predate
+// versioning don't send a version and are treated as version 1.
+const ProtocolVersion = 2
+
+// MinProtocolVersion is the oldest version of the protocol that is still
+// supported.
+const MinProtocolVersion = 1
+
+// Optional features of the protocol. A feature is only used on a session
+// if both sides advertise it in the handshake.
+const (
+	// Agents can change their services with a ServicesUpdate.
+	FeatureServicesUpdate = "services-update"
+
+	// Agents and hubs can make RPCs to each other.
+	FeatureRPC = "rpc"
+
+	// Each stream starts with the codec it's compressed with, allowing the
+	// codec to be chosen per stream.
+	FeatureStreamCompression = "stream-compression"
+)
+
+// Features are the features supported by this implementation.
+var Features = []string{
+	FeatureServicesUpdate,
+	FeatureRPC,
+	FeatureStreamCompression,
+}
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+	ErrUnsupportedFeature = errors.New("feature not supported by peer")
+)
+
+// PeerVersion returns the protocol version of a peer that sent version in
+// its handshake.
+func PeerVersion(version int32) int32 {
+	if version == 0 {
+		return 1
+	}
+
+	return version
+}
+
+// FeatureSet is the set of features agreed on for a session.
+type FeatureSet map[string]struct{}
+
+// NegotiateFeatures returns the features present in both local and remote.
+func NegotiateFeatures(local, remote []string) FeatureSet {
+	fs := make(FeatureSet)
+
+	for _, l := range local {
+		for _, r := range remote {
+			if l == r {
+				fs[l] = struct{}{}
+				break
+			}
+		}
+	}
+
+	return fs
+}
+
+// Has returns true if the set contains the named feature.
+func (fs FeatureSet) Has(name string) bool {
+	_, ok := fs[name]
+	return ok
+}
+
+// List returns the features in the set, sorted by name.
+func (fs FeatureSet) List() []string {
+	var out []string
+
+	for name := range fs {
+		out = append(out, name)
+	}
+
+	sort.Strings(out)
+
+	return out
+}
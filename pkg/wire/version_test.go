@@ -0,0 +1,31 @@
+package wire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	t.Run("treats peers without a version as version 1", func(t *testing.T) {
+		assert.Equal(t, int32(1), PeerVersion(0))
+		assert.Equal(t, int32(2), PeerVersion(2))
+	})
+
+	t.Run("agrees on the features both sides support", func(t *testing.T) {
+		fs := NegotiateFeatures(Features, []string{FeatureRPC, "future"})
+
+		assert.True(t, fs.Has(FeatureRPC))
+		assert.False(t, fs.Has(FeatureServicesUpdate))
+		assert.False(t, fs.Has("future"))
+
+		assert.Equal(t, []string{FeatureRPC}, fs.List())
+	})
+
+	t.Run("agrees on no features with old peers", func(t *testing.T) {
+		fs := NegotiateFeatures(Features, nil)
+
+		assert.Equal(t, 0, len(fs.List()))
+		assert.False(t, fs.Has(FeatureRPC))
+	})
+}
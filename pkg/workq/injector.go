@@ -14,6 +14,11 @@ type Injector struct {
 	db *gorm.DB
 }
 
+// NewInjector returns an Injector that adds jobs to the queues in db.
+func NewInjector(db *gorm.DB) *Injector {
+	return &Injector{db: db}
+}
+
 func (i *Injector) Inject(job *Job) error {
 	if job.Id == nil {
 		job.Id = pb.NewULID().Bytes()